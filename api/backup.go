@@ -80,9 +80,12 @@ func newBackupTarget(input BackupTarget) (*longhorn.BackupTargetSpec, error) {
 	}
 
 	return &longhorn.BackupTargetSpec{
-		BackupTargetURL:  input.BackupTargetURL,
-		CredentialSecret: input.CredentialSecret,
-		PollInterval:     metav1.Duration{Duration: time.Duration(pollInterval) * time.Second}}, nil
+		BackupTargetURL:       input.BackupTargetURL,
+		CredentialSecret:      input.CredentialSecret,
+		PollInterval:          metav1.Duration{Duration: time.Duration(pollInterval) * time.Second},
+		Zone:                  input.Zone,
+		Region:                input.Region,
+		ReplicationTargetName: input.ReplicationTargetName}, nil
 }
 
 func (s *Server) BackupTargetUpdate(rw http.ResponseWriter, req *http.Request) error {
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/rancher/go-rancher/api"
+	"github.com/rancher/go-rancher/client"
+
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+func (s *Server) CloneScheduleList(rw http.ResponseWriter, req *http.Request) (err error) {
+	apiContext := api.GetApiContext(req)
+
+	list, err := s.cloneScheduleList(apiContext)
+	if err != nil {
+		return err
+	}
+	apiContext.Write(list)
+	return nil
+}
+
+func (s *Server) cloneScheduleList(apiContext *api.ApiContext) (*client.GenericCollection, error) {
+	list, err := s.m.ListCloneSchedulesSorted()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list clone schedules")
+	}
+	return toCloneScheduleCollection(list), nil
+}
+
+func (s *Server) CloneScheduleGet(rw http.ResponseWriter, req *http.Request) error {
+	apiContext := api.GetApiContext(req)
+
+	id := mux.Vars(req)["name"]
+
+	cloneSchedule, err := s.m.GetCloneSchedule(id)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get clone schedule '%s'", id)
+	}
+	apiContext.Write(toCloneScheduleResource(cloneSchedule))
+	return nil
+}
+
+func (s *Server) CloneScheduleCreate(rw http.ResponseWriter, req *http.Request) error {
+	var input CloneSchedule
+	apiContext := api.GetApiContext(req)
+
+	if err := apiContext.Read(&input); err != nil {
+		return err
+	}
+
+	obj, err := s.m.CreateCloneSchedule(input.Name, &longhorn.CloneScheduleSpec{
+		SourceVolume: input.SourceVolume,
+		TargetVolume: input.TargetVolume,
+		SourceType:   longhorn.CloneScheduleSourceType(input.SourceType),
+		Cron:         input.Cron,
+		Suspend:      input.Suspend,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create clone schedule %v", input.Name)
+	}
+	apiContext.Write(toCloneScheduleResource(obj))
+	return nil
+}
+
+func (s *Server) CloneScheduleUpdate(rw http.ResponseWriter, req *http.Request) error {
+	var input CloneSchedule
+
+	apiContext := api.GetApiContext(req)
+	if err := apiContext.Read(&input); err != nil {
+		return err
+	}
+
+	name := mux.Vars(req)["name"]
+
+	obj, err := util.RetryOnConflictCause(func() (interface{}, error) {
+		return s.m.UpdateCloneSchedule(name, &longhorn.CloneScheduleSpec{
+			SourceType: longhorn.CloneScheduleSourceType(input.SourceType),
+			Cron:       input.Cron,
+			Suspend:    input.Suspend,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	cloneSchedule, ok := obj.(*longhorn.CloneSchedule)
+	if !ok {
+		return fmt.Errorf("failed to convert %v to clone schedule object", name)
+	}
+
+	apiContext.Write(toCloneScheduleResource(cloneSchedule))
+	return nil
+}
+
+func (s *Server) CloneScheduleDelete(rw http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["name"]
+	if err := s.m.DeleteCloneSchedule(id); err != nil {
+		return errors.Wrapf(err, "failed to delete clone schedule %v", id)
+	}
+
+	return nil
+}
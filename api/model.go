@@ -29,39 +29,40 @@ type Empty struct {
 type Volume struct {
 	client.Resource
 
-	Name                        string                                 `json:"name"`
-	Size                        string                                 `json:"size"`
-	Frontend                    longhorn.VolumeFrontend                `json:"frontend"`
-	DisableFrontend             bool                                   `json:"disableFrontend"`
-	FromBackup                  string                                 `json:"fromBackup"`
-	RestoreVolumeRecurringJob   longhorn.RestoreVolumeRecurringJobType `json:"restoreVolumeRecurringJob"`
-	DataSource                  longhorn.VolumeDataSource              `json:"dataSource"`
-	DataLocality                longhorn.DataLocality                  `json:"dataLocality"`
-	StaleReplicaTimeout         int                                    `json:"staleReplicaTimeout"`
-	State                       longhorn.VolumeState                   `json:"state"`
-	Robustness                  longhorn.VolumeRobustness              `json:"robustness"`
-	Image                       string                                 `json:"image"`
-	CurrentImage                string                                 `json:"currentImage"`
-	BackingImage                string                                 `json:"backingImage"`
-	Created                     string                                 `json:"created"`
-	LastBackup                  string                                 `json:"lastBackup"`
-	LastBackupAt                string                                 `json:"lastBackupAt"`
-	LastAttachedBy              string                                 `json:"lastAttachedBy"`
-	Standby                     bool                                   `json:"standby"`
-	RestoreRequired             bool                                   `json:"restoreRequired"`
-	RestoreInitiated            bool                                   `json:"restoreInitiated"`
-	RevisionCounterDisabled     bool                                   `json:"revisionCounterDisabled"`
-	SnapshotDataIntegrity       longhorn.SnapshotDataIntegrity         `json:"snapshotDataIntegrity"`
-	UnmapMarkSnapChainRemoved   longhorn.UnmapMarkSnapChainRemoved     `json:"unmapMarkSnapChainRemoved"`
-	BackupCompressionMethod     longhorn.BackupCompressionMethod       `json:"backupCompressionMethod"`
-	ReplicaSoftAntiAffinity     longhorn.ReplicaSoftAntiAffinity       `json:"replicaSoftAntiAffinity"`
-	ReplicaZoneSoftAntiAffinity longhorn.ReplicaZoneSoftAntiAffinity   `json:"replicaZoneSoftAntiAffinity"`
-	ReplicaDiskSoftAntiAffinity longhorn.ReplicaDiskSoftAntiAffinity   `json:"replicaDiskSoftAntiAffinity"`
-	DataEngine                  longhorn.DataEngineType                `json:"dataEngine"`
-	SnapshotMaxCount            int                                    `json:"snapshotMaxCount"`
-	SnapshotMaxSize             string                                 `json:"snapshotMaxSize"`
-	FreezeFilesystemForSnapshot longhorn.FreezeFilesystemForSnapshot   `json:"freezeFilesystemForSnapshot"`
-	BackupTargetName            string                                 `json:"backupTargetName"`
+	Name                         string                                 `json:"name"`
+	Size                         string                                 `json:"size"`
+	Frontend                     longhorn.VolumeFrontend                `json:"frontend"`
+	DisableFrontend              bool                                   `json:"disableFrontend"`
+	FromBackup                   string                                 `json:"fromBackup"`
+	RestoreVolumeRecurringJob    longhorn.RestoreVolumeRecurringJobType `json:"restoreVolumeRecurringJob"`
+	DataSource                   longhorn.VolumeDataSource              `json:"dataSource"`
+	DataLocality                 longhorn.DataLocality                  `json:"dataLocality"`
+	StaleReplicaTimeout          int                                    `json:"staleReplicaTimeout"`
+	State                        longhorn.VolumeState                   `json:"state"`
+	Robustness                   longhorn.VolumeRobustness              `json:"robustness"`
+	Image                        string                                 `json:"image"`
+	CurrentImage                 string                                 `json:"currentImage"`
+	BackingImage                 string                                 `json:"backingImage"`
+	Created                      string                                 `json:"created"`
+	LastBackup                   string                                 `json:"lastBackup"`
+	LastBackupAt                 string                                 `json:"lastBackupAt"`
+	LastAttachedBy               string                                 `json:"lastAttachedBy"`
+	Standby                      bool                                   `json:"standby"`
+	RestoreRequired              bool                                   `json:"restoreRequired"`
+	RestoreInitiated             bool                                   `json:"restoreInitiated"`
+	RevisionCounterDisabled      bool                                   `json:"revisionCounterDisabled"`
+	SnapshotDataIntegrity        longhorn.SnapshotDataIntegrity         `json:"snapshotDataIntegrity"`
+	SnapshotDataIntegrityCronJob string                                 `json:"snapshotDataIntegrityCronJob"`
+	UnmapMarkSnapChainRemoved    longhorn.UnmapMarkSnapChainRemoved     `json:"unmapMarkSnapChainRemoved"`
+	BackupCompressionMethod      longhorn.BackupCompressionMethod       `json:"backupCompressionMethod"`
+	ReplicaSoftAntiAffinity      longhorn.ReplicaSoftAntiAffinity       `json:"replicaSoftAntiAffinity"`
+	ReplicaZoneSoftAntiAffinity  longhorn.ReplicaZoneSoftAntiAffinity   `json:"replicaZoneSoftAntiAffinity"`
+	ReplicaDiskSoftAntiAffinity  longhorn.ReplicaDiskSoftAntiAffinity   `json:"replicaDiskSoftAntiAffinity"`
+	DataEngine                   longhorn.DataEngineType                `json:"dataEngine"`
+	SnapshotMaxCount             int                                    `json:"snapshotMaxCount"`
+	SnapshotMaxSize              string                                 `json:"snapshotMaxSize"`
+	FreezeFilesystemForSnapshot  longhorn.FreezeFilesystemForSnapshot   `json:"freezeFilesystemForSnapshot"`
+	BackupTargetName             string                                 `json:"backupTargetName"`
 
 	DiskSelector         []string                      `json:"diskSelector"`
 	NodeSelector         []string                      `json:"nodeSelector"`
@@ -84,6 +85,11 @@ type Volume struct {
 
 	Encrypted bool `json:"encrypted"`
 
+	TrashedAt string `json:"trashedAt"`
+
+	ReplicaSelectorRelocation bool     `json:"replicaSelectorRelocation"`
+	SelectorViolatingReplicas []string `json:"selectorViolatingReplicas"`
+
 	Replicas         []Replica        `json:"replicas"`
 	Controllers      []Controller     `json:"controllers"`
 	BackupStatus     []BackupStatus   `json:"backupStatus"`
@@ -176,6 +182,9 @@ type Backup struct {
 	NewlyUploadedDataSize  string               `json:"newlyUploadDataSize"`
 	ReUploadedDataSize     string               `json:"reUploadedDataSize"`
 	BackupTargetName       string               `json:"backupTargetName"`
+	ReplicationTargetName  string               `json:"replicationTargetName"`
+	ReplicationBackupName  string               `json:"replicationBackupName"`
+	ReplicationState       string               `json:"replicationState"`
 }
 
 type BackupBackingImage struct {
@@ -241,6 +250,7 @@ type Replica struct {
 type Attachment struct {
 	AttachmentID   string            `json:"attachmentID"`
 	AttachmentType string            `json:"attachmentType"`
+	AttacherID     string            `json:"attacherID"`
 	NodeID         string            `json:"nodeID"`
 	Parameters     map[string]string `json:"parameters"`
 	// Indicate whether this attachment ticket has been satisfied
@@ -285,6 +295,27 @@ type BackingImage struct {
 	DeletionTimestamp string `json:"deletionTimestamp"`
 }
 
+type UpgradeFreezeReport struct {
+	client.Resource
+
+	Enabled                            bool     `json:"enabled"`
+	PendingEngineImageUpgrades         []string `json:"pendingEngineImageUpgrades"`
+	PendingLiveEngineUpgrades          []string `json:"pendingLiveEngineUpgrades"`
+	PendingInstanceManagerReplacements []string `json:"pendingInstanceManagerReplacements"`
+}
+
+type EngineUpgradePreCheck struct {
+	client.Resource
+
+	Upgradeable bool                           `json:"upgradeable"`
+	Failures    []EngineUpgradePreCheckFailure `json:"failures"`
+}
+
+type EngineUpgradePreCheckFailure struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
 type BackingImageCleanupInput struct {
 	Disks []string `json:"disks"`
 }
@@ -304,6 +335,7 @@ type AttachInput struct {
 	DisableFrontend bool   `json:"disableFrontend"`
 	AttachedBy      string `json:"attachedBy"`
 	AttacherType    string `json:"attacherType"`
+	AttacherID      string `json:"attacherID"`
 	AttachmentID    string `json:"attachmentID"`
 }
 
@@ -314,9 +346,10 @@ type DetachInput struct {
 }
 
 type SnapshotInput struct {
-	Name       string            `json:"name"`
-	Labels     map[string]string `json:"labels"`
-	BackupMode string            `json:"backupMode"`
+	Name         string            `json:"name"`
+	Labels       map[string]string `json:"labels"`
+	BackupMode   string            `json:"backupMode"`
+	TakeSnapshot bool              `json:"takeSnapshot"`
 }
 
 type SnapshotCRInput struct {
@@ -360,6 +393,10 @@ type UpdateSnapshotDataIntegrityInput struct {
 	SnapshotDataIntegrity string `json:"snapshotDataIntegrity"`
 }
 
+type UpdateSnapshotDataIntegrityCronJobInput struct {
+	SnapshotDataIntegrityCronJob string `json:"snapshotDataIntegrityCronJob"`
+}
+
 type UpdateSnapshotMaxCountInput struct {
 	SnapshotMaxCount int `json:"snapshotMaxCount"`
 }
@@ -400,6 +437,10 @@ type UpdateFreezeFilesystemForSnapshotInput struct {
 	FreezeFilesystemForSnapshot string `json:"freezeFilesystemForSnapshot"`
 }
 
+type UpdateReplicaSelectorRelocationInput struct {
+	ReplicaSelectorRelocation bool `json:"replicaSelectorRelocation"`
+}
+
 type UpdateBackupTargetInput struct {
 	BackupTargetName string `json:"backupTargetName"`
 }
@@ -454,6 +495,7 @@ type DiskStatus struct {
 	ScheduledReplica      map[string]int64              `json:"scheduledReplica"`
 	ScheduledBackingImage map[string]int64              `json:"scheduledBackingImage"`
 	DiskUUID              string                        `json:"diskUUID"`
+	EncryptedDevicePath   string                        `json:"encryptedDevicePath"`
 }
 
 type DiskInfo struct {
@@ -583,12 +625,116 @@ type InstanceManager struct {
 	Instances map[string]longhorn.InstanceProcess `json:"instances"`
 }
 
+// InstanceManagerDetail is the process inventory of an instance manager and the resource usage of its pod.
+// The usage is not broken down per process, since the instance manager does not report it.
+type InstanceManagerDetail struct {
+	client.Resource
+	Name         string                        `json:"name"`
+	NodeID       string                        `json:"nodeID"`
+	ManagerType  string                        `json:"managerType"`
+	DataEngine   string                        `json:"dataEngine"`
+	CurrentState longhorn.InstanceManagerState `json:"currentState"`
+	Image        string                        `json:"image"`
+
+	// The uptime of the instance manager pod. The instance manager does not report when each process started.
+	PodStartTime  string `json:"podStartTime"`
+	UptimeSeconds int64  `json:"uptimeSeconds"`
+	// The CPU millicores and memory bytes used by the whole instance manager pod, if the metrics are available
+	PodUsageAvailable bool  `json:"podUsageAvailable"`
+	PodCPUUsage       int64 `json:"podCPUUsage"`
+	PodMemoryUsage    int64 `json:"podMemoryUsage"`
+
+	Processes []InstanceManagerProcess `json:"processes"`
+}
+
+type InstanceManagerProcess struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	VolumeName string `json:"volumeName"`
+	DataEngine string `json:"dataEngine"`
+	State      string `json:"state"`
+	ErrorMsg   string `json:"errorMsg"`
+	PortStart  int32  `json:"portStart"`
+	PortEnd    int32  `json:"portEnd"`
+}
+
+type RebuildQueueEntry struct {
+	client.Resource
+
+	Position         int    `json:"position"`
+	VolumeName       string `json:"volumeName"`
+	State            string `json:"state"`
+	HealthyReplicas  int    `json:"healthyReplicas"`
+	NumberOfReplicas int    `json:"numberOfReplicas"`
+	Priority         int    `json:"priority"`
+	Size             string `json:"size"`
+}
+
 type RecurringJob struct {
 	client.Resource
 	longhorn.RecurringJobSpec
 	longhorn.RecurringJobStatus
 }
 
+type CloneSchedule struct {
+	client.Resource
+	Name              string `json:"name"`
+	SourceVolume      string `json:"sourceVolume"`
+	TargetVolume      string `json:"targetVolume"`
+	SourceType        string `json:"sourceType"`
+	Cron              string `json:"cron"`
+	Suspend           bool   `json:"suspend"`
+	State             string `json:"state"`
+	LastScheduleTime  string `json:"lastScheduleTime"`
+	LastRefreshTime   string `json:"lastRefreshTime"`
+	LastRefreshSource string `json:"lastRefreshSource"`
+	Message           string `json:"message"`
+}
+
+type RestoreTest struct {
+	client.Resource
+	Name             string   `json:"name"`
+	BackupTargetName string   `json:"backupTargetName"`
+	VolumeName       string   `json:"volumeName"`
+	SelectionPolicy  string   `json:"selectionPolicy"`
+	Cron             string   `json:"cron"`
+	Suspend          bool     `json:"suspend"`
+	Image            string   `json:"image"`
+	Command          []string `json:"command"`
+	FSType           string   `json:"fsType"`
+	TimeoutSeconds   int64    `json:"timeoutSeconds"`
+	State            string   `json:"state"`
+	LastScheduleTime string   `json:"lastScheduleTime"`
+	CurrentBackup    string   `json:"currentBackup"`
+	LastTestTime     string   `json:"lastTestTime"`
+	LastTestedBackup string   `json:"lastTestedBackup"`
+	LastResult       string   `json:"lastResult"`
+	Message          string   `json:"message"`
+	PassedCount      int64    `json:"passedCount"`
+	FailedCount      int64    `json:"failedCount"`
+}
+
+type ReplicaCountPolicy struct {
+	client.Resource
+	Name                       string                       `json:"name"`
+	Selector                   map[string]string            `json:"selector"`
+	NumberOfReplicas           int                          `json:"numberOfReplicas"`
+	MinNumberOfReplicas        int                          `json:"minNumberOfReplicas"`
+	Priority                   int                          `json:"priority"`
+	StabilizationWindowSeconds int64                        `json:"stabilizationWindowSeconds"`
+	DryRun                     bool                         `json:"dryRun"`
+	Decisions                  []ReplicaCountPolicyDecision `json:"decisions"`
+}
+
+type ReplicaCountPolicyDecision struct {
+	VolumeName              string `json:"volumeName"`
+	CurrentNumberOfReplicas int    `json:"currentNumberOfReplicas"`
+	DesiredNumberOfReplicas int    `json:"desiredNumberOfReplicas"`
+	Reason                  string `json:"reason"`
+	PendingSince            string `json:"pendingSince"`
+	LastAppliedTime         string `json:"lastAppliedTime"`
+}
+
 type Orphan struct {
 	client.Resource
 	Name string `json:"name"`
@@ -660,6 +806,7 @@ func NewSchema() *client.Schemas {
 	schemas.AddType("UpdateDataLocalityInput", UpdateDataLocalityInput{})
 	schemas.AddType("UpdateAccessModeInput", UpdateAccessModeInput{})
 	schemas.AddType("UpdateSnapshotDataIntegrityInput", UpdateSnapshotDataIntegrityInput{})
+	schemas.AddType("UpdateSnapshotDataIntegrityCronJobInput", UpdateSnapshotDataIntegrityCronJobInput{})
 	schemas.AddType("UpdateSnapshotMaxCountInput", UpdateSnapshotMaxCountInput{})
 	schemas.AddType("UpdateSnapshotMaxSizeInput", UpdateSnapshotMaxSizeInput{})
 	schemas.AddType("UpdateBackupCompressionInput", UpdateBackupCompressionMethodInput{})
@@ -668,6 +815,7 @@ func NewSchema() *client.Schemas {
 	schemas.AddType("UpdateReplicaZoneSoftAntiAffinityInput", UpdateReplicaZoneSoftAntiAffinityInput{})
 	schemas.AddType("UpdateReplicaDiskSoftAntiAffinityInput", UpdateReplicaDiskSoftAntiAffinityInput{})
 	schemas.AddType("UpdateFreezeFilesystemForSnapshotInput", UpdateFreezeFilesystemForSnapshotInput{})
+	schemas.AddType("UpdateReplicaSelectorRelocationInput", UpdateReplicaSelectorRelocationInput{})
 	schemas.AddType("UpdateBackupTargetInput", UpdateBackupTargetInput{})
 	schemas.AddType("UpdateOfflineRebuildingInput", UpdateOfflineRebuildingInput{})
 	schemas.AddType("workloadStatus", longhorn.WorkloadStatus{})
@@ -692,9 +840,14 @@ func NewSchema() *client.Schemas {
 	schemas.AddType("supportBundleInitateInput", SupportBundleInitateInput{})
 
 	schemas.AddType("tag", Tag{})
+	schemas.AddType("upgradeFreezeReport", UpgradeFreezeReport{})
+	schemas.AddType("engineUpgradePreCheck", EngineUpgradePreCheck{})
+	schemas.AddType("engineUpgradePreCheckFailure", EngineUpgradePreCheckFailure{})
 
 	schemas.AddType("instanceManager", InstanceManager{})
 	schemas.AddType("instanceProcess", longhorn.InstanceProcess{})
+	schemas.AddType("instanceManagerDetail", InstanceManagerDetail{})
+	schemas.AddType("instanceManagerProcess", InstanceManagerProcess{})
 
 	schemas.AddType("backingImageDiskFileStatus", longhorn.BackingImageDiskFileStatus{})
 	schemas.AddType("backingImageCleanupInput", BackingImageCleanupInput{})
@@ -711,12 +864,17 @@ func NewSchema() *client.Schemas {
 	backupBackingImageSchema(schemas.AddType("backupBackingImage", BackupBackingImage{}))
 	settingSchema(schemas.AddType("setting", Setting{}))
 	recurringJobSchema(schemas.AddType("recurringJob", RecurringJob{}))
+	cloneScheduleSchema(schemas.AddType("cloneSchedule", CloneSchedule{}))
+	restoreTestSchema(schemas.AddType("restoreTest", RestoreTest{}))
+	replicaCountPolicySchema(schemas.AddType("replicaCountPolicy", ReplicaCountPolicy{}))
+	schemas.AddType("replicaCountPolicyDecision", ReplicaCountPolicyDecision{})
 	engineImageSchema(schemas.AddType("engineImage", EngineImage{}))
 	backingImageSchema(schemas.AddType("backingImage", BackingImage{}))
 	nodeSchema(schemas.AddType("node", Node{}))
 	diskSchema(schemas.AddType("diskUpdateInput", DiskUpdateInput{}))
 	diskInfoSchema(schemas.AddType("diskInfo", DiskInfo{}))
 	kubernetesStatusSchema(schemas.AddType("kubernetesStatus", longhorn.KubernetesStatus{}))
+	rebuildQueueEntrySchema(schemas.AddType("rebuildQueueEntry", RebuildQueueEntry{}))
 	backupTargetListOutputSchema(schemas.AddType("backupTargetListOutput", BackupTargetListOutput{}))
 	backupVolumeListOutputSchema(schemas.AddType("backupVolumeListOutput", BackupVolumeListOutput{}))
 	backupListOutputSchema(schemas.AddType("backupListOutput", BackupListOutput{}))
@@ -840,6 +998,108 @@ func backupBackingImageSchema(backupBackingImage *client.Schema) {
 	}
 }
 
+func cloneScheduleSchema(cloneSchedule *client.Schema) {
+	cloneSchedule.CollectionMethods = []string{"GET", "POST"}
+	cloneSchedule.ResourceMethods = []string{"GET", "PUT", "DELETE"}
+
+	name := cloneSchedule.ResourceFields["name"]
+	name.Required = true
+	name.Unique = true
+	name.Create = true
+	cloneSchedule.ResourceFields["name"] = name
+
+	sourceVolume := cloneSchedule.ResourceFields["sourceVolume"]
+	sourceVolume.Required = true
+	sourceVolume.Create = true
+	cloneSchedule.ResourceFields["sourceVolume"] = sourceVolume
+
+	targetVolume := cloneSchedule.ResourceFields["targetVolume"]
+	targetVolume.Required = true
+	targetVolume.Create = true
+	cloneSchedule.ResourceFields["targetVolume"] = targetVolume
+
+	cron := cloneSchedule.ResourceFields["cron"]
+	cron.Required = true
+	cron.Create = true
+	cron.Update = true
+	cloneSchedule.ResourceFields["cron"] = cron
+
+	sourceType := cloneSchedule.ResourceFields["sourceType"]
+	sourceType.Create = true
+	sourceType.Update = true
+	cloneSchedule.ResourceFields["sourceType"] = sourceType
+
+	suspend := cloneSchedule.ResourceFields["suspend"]
+	suspend.Create = true
+	suspend.Update = true
+	cloneSchedule.ResourceFields["suspend"] = suspend
+}
+
+func restoreTestSchema(restoreTest *client.Schema) {
+	restoreTest.CollectionMethods = []string{"GET", "POST"}
+	restoreTest.ResourceMethods = []string{"GET", "PUT", "DELETE"}
+
+	name := restoreTest.ResourceFields["name"]
+	name.Required = true
+	name.Unique = true
+	name.Create = true
+	restoreTest.ResourceFields["name"] = name
+
+	backupTargetName := restoreTest.ResourceFields["backupTargetName"]
+	backupTargetName.Required = true
+	backupTargetName.Create = true
+	restoreTest.ResourceFields["backupTargetName"] = backupTargetName
+
+	cron := restoreTest.ResourceFields["cron"]
+	cron.Required = true
+	cron.Create = true
+	cron.Update = true
+	restoreTest.ResourceFields["cron"] = cron
+
+	image := restoreTest.ResourceFields["image"]
+	image.Required = true
+	image.Create = true
+	image.Update = true
+	restoreTest.ResourceFields["image"] = image
+
+	for _, fieldName := range []string{"volumeName", "selectionPolicy", "suspend", "command", "fsType", "timeoutSeconds"} {
+		field := restoreTest.ResourceFields[fieldName]
+		field.Create = true
+		field.Update = true
+		restoreTest.ResourceFields[fieldName] = field
+	}
+}
+
+func replicaCountPolicySchema(policy *client.Schema) {
+	policy.CollectionMethods = []string{"GET", "POST"}
+	policy.ResourceMethods = []string{"GET", "PUT", "DELETE"}
+
+	name := policy.ResourceFields["name"]
+	name.Required = true
+	name.Unique = true
+	name.Create = true
+	policy.ResourceFields["name"] = name
+
+	selector := policy.ResourceFields["selector"]
+	selector.Required = true
+	selector.Create = true
+	selector.Update = true
+	policy.ResourceFields["selector"] = selector
+
+	numberOfReplicas := policy.ResourceFields["numberOfReplicas"]
+	numberOfReplicas.Required = true
+	numberOfReplicas.Create = true
+	numberOfReplicas.Update = true
+	policy.ResourceFields["numberOfReplicas"] = numberOfReplicas
+
+	for _, fieldName := range []string{"minNumberOfReplicas", "priority", "stabilizationWindowSeconds", "dryRun"} {
+		field := policy.ResourceFields[fieldName]
+		field.Create = true
+		field.Update = true
+		policy.ResourceFields[fieldName] = field
+	}
+}
+
 func recurringJobSchema(job *client.Schema) {
 	job.CollectionMethods = []string{"GET", "POST"}
 	job.ResourceMethods = []string{"GET", "PUT", "DELETE"}
@@ -915,6 +1175,13 @@ func backupTargetSchema(backupTarget *client.Schema) {
 	backupTargetPollInterval.Default = "300"
 	backupTarget.ResourceFields["pollInterval"] = backupTargetPollInterval
 
+	for _, field := range []string{"zone", "region", "replicationTargetName"} {
+		placement := backupTarget.ResourceFields[field]
+		placement.Create = true
+		placement.Default = ""
+		backupTarget.ResourceFields[field] = placement
+	}
+
 	backupTarget.ResourceActions = map[string]client.Action{
 		"backupTargetSync": {
 			Input:  "syncBackupResource",
@@ -927,6 +1194,13 @@ func backupTargetSchema(backupTarget *client.Schema) {
 	}
 }
 
+func rebuildQueueEntrySchema(rebuildQueueEntry *client.Schema) {
+	// The entries are only listed as a whole at /v1/rebuildqueue
+	rebuildQueueEntry.PluralName = "rebuildQueue"
+	rebuildQueueEntry.CollectionMethods = []string{"GET"}
+	rebuildQueueEntry.ResourceMethods = []string{}
+}
+
 func backupVolumeSchema(backupVolume *client.Schema) {
 	backupVolume.CollectionMethods = []string{"GET"}
 	backupVolume.ResourceMethods = []string{"GET", "PUT", "DELETE"}
@@ -1000,6 +1274,10 @@ func volumeSchema(volume *client.Schema) {
 		"cancelExpansion": {
 			Output: "volume",
 		},
+		"restoreFromRecycleBin": {
+			Output: "volume",
+		},
+		"purge": {},
 		"offlineReplicaRebuilding": {
 			Input:  "UpdateOfflineRebuildingInput",
 			Output: "volume",
@@ -1085,6 +1363,10 @@ func volumeSchema(volume *client.Schema) {
 			Input: "UpdateSnapshotDataIntegrityInput",
 		},
 
+		"updateSnapshotDataIntegrityCronJob": {
+			Input: "UpdateSnapshotDataIntegrityCronJobInput",
+		},
+
 		"updateSnapshotMaxCount": {
 			Input: "UpdateSnapshotMaxCountInput",
 		},
@@ -1117,6 +1399,10 @@ func volumeSchema(volume *client.Schema) {
 			Input: "UpdateFreezeFilesystemForSnapshotInput",
 		},
 
+		"updateReplicaSelectorRelocation": {
+			Input: "UpdateReplicaSelectorRelocationInput",
+		},
+
 		"updateBackupTargetName": {
 			Input: "UpdateBackupTargetInput",
 		},
@@ -1141,6 +1427,10 @@ func volumeSchema(volume *client.Schema) {
 		"engineUpgrade": {
 			Input: "engineUpgradeInput",
 		},
+
+		"engineUpgradePreCheck": {
+			Output: "engineUpgradePreCheck",
+		},
 	}
 	volume.ResourceFields["controllers"] = client.Field{
 		Type:     "array[controller]",
@@ -1186,6 +1476,10 @@ func volumeSchema(volume *client.Schema) {
 	volumeSnapshotDataIntegrity.Default = longhorn.SnapshotDataIntegrityIgnored
 	volume.ResourceFields["snapshotDataIntegrity"] = volumeSnapshotDataIntegrity
 
+	volumeSnapshotDataIntegrityCronJob := volume.ResourceFields["snapshotDataIntegrityCronJob"]
+	volumeSnapshotDataIntegrityCronJob.Create = true
+	volume.ResourceFields["snapshotDataIntegrityCronJob"] = volumeSnapshotDataIntegrityCronJob
+
 	volumeBackupCompressionMethod := volume.ResourceFields["backupCompressionMethod"]
 	volumeBackupCompressionMethod.Create = true
 	volumeBackupCompressionMethod.Default = longhorn.BackupCompressionMethodLz4
@@ -1268,6 +1562,10 @@ func volumeSchema(volume *client.Schema) {
 	nodeSelector.Create = true
 	volume.ResourceFields["nodeSelector"] = nodeSelector
 
+	replicaSelectorRelocation := volume.ResourceFields["replicaSelectorRelocation"]
+	replicaSelectorRelocation.Create = true
+	volume.ResourceFields["replicaSelectorRelocation"] = replicaSelectorRelocation
+
 	kubernetesStatus := volume.ResourceFields["kubernetesStatus"]
 	kubernetesStatus.Type = "kubernetesStatus"
 	volume.ResourceFields["kubernetesStatus"] = kubernetesStatus
@@ -1411,6 +1709,26 @@ func toSettingCollection(settings []*longhorn.Setting) *client.GenericCollection
 	return &client.GenericCollection{Data: data, Collection: client.Collection{ResourceType: "setting"}}
 }
 
+// isAttachedForMaintenanceOnly checks if the volume is attached for maintenance attachment tickets only,
+// in which case the volume is presented as detached.
+func isAttachedForMaintenanceOnly(v *longhorn.Volume, va *longhorn.VolumeAttachment) bool {
+	if v.Status.State == longhorn.VolumeStateDetached {
+		return false
+	}
+
+	hasMaintenanceTicket := false
+	for _, ticket := range va.Spec.AttachmentTickets {
+		if ticket == nil {
+			continue
+		}
+		if !longhorn.IsMaintenanceAttachmentTicket(ticket) {
+			return false
+		}
+		hasMaintenanceTicket = true
+	}
+	return hasMaintenanceTicket
+}
+
 func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhorn.Replica, backups []*longhorn.Backup, lhVolumeAttachment *longhorn.VolumeAttachment, apiContext *api.ApiContext) *Volume {
 	var ve *longhorn.Engine
 	controllers := []Controller{}
@@ -1551,12 +1869,18 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 		})
 	}
 
+	volumeState := v.Status.State
 	if lhVolumeAttachment != nil {
+		if isAttachedForMaintenanceOnly(v, lhVolumeAttachment) {
+			volumeState = longhorn.VolumeStateDetached
+		}
 		for k, v := range lhVolumeAttachment.Spec.AttachmentTickets {
-			if v != nil {
+			// Maintenance attachment tickets attach the volume in the background only
+			if v != nil && !longhorn.IsMaintenanceAttachmentTicket(v) {
 				volumeAttachment.Attachments[k] = Attachment{
 					AttachmentID:   v.ID,
 					AttachmentType: string(v.Type),
+					AttacherID:     v.AttacherID,
 					NodeID:         v.NodeID,
 					Parameters:     v.Parameters,
 				}
@@ -1603,32 +1927,33 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 			Actions: map[string]string{},
 			Links:   map[string]string{},
 		},
-		Name:                        v.Name,
-		Size:                        strconv.FormatInt(v.Spec.Size, 10),
-		Frontend:                    v.Spec.Frontend,
-		DisableFrontend:             v.Spec.DisableFrontend,
-		LastAttachedBy:              v.Spec.LastAttachedBy,
-		FromBackup:                  v.Spec.FromBackup,
-		DataSource:                  v.Spec.DataSource,
-		NumberOfReplicas:            v.Spec.NumberOfReplicas,
-		ReplicaAutoBalance:          v.Spec.ReplicaAutoBalance,
-		DataLocality:                v.Spec.DataLocality,
-		SnapshotDataIntegrity:       v.Spec.SnapshotDataIntegrity,
-		SnapshotMaxCount:            v.Spec.SnapshotMaxCount,
-		SnapshotMaxSize:             strconv.FormatInt(v.Spec.SnapshotMaxSize, 10),
-		BackupCompressionMethod:     v.Spec.BackupCompressionMethod,
-		StaleReplicaTimeout:         v.Spec.StaleReplicaTimeout,
-		Created:                     v.CreationTimestamp.String(),
-		Image:                       v.Spec.Image,
-		BackingImage:                v.Spec.BackingImage,
-		Standby:                     v.Spec.Standby,
-		DiskSelector:                v.Spec.DiskSelector,
-		NodeSelector:                v.Spec.NodeSelector,
-		RestoreVolumeRecurringJob:   v.Spec.RestoreVolumeRecurringJob,
-		FreezeFilesystemForSnapshot: v.Spec.FreezeFilesystemForSnapshot,
-		BackupTargetName:            v.Spec.BackupTargetName,
-
-		State:                       v.Status.State,
+		Name:                         v.Name,
+		Size:                         strconv.FormatInt(v.Spec.Size, 10),
+		Frontend:                     v.Spec.Frontend,
+		DisableFrontend:              v.Spec.DisableFrontend,
+		LastAttachedBy:               v.Spec.LastAttachedBy,
+		FromBackup:                   v.Spec.FromBackup,
+		DataSource:                   v.Spec.DataSource,
+		NumberOfReplicas:             v.Spec.NumberOfReplicas,
+		ReplicaAutoBalance:           v.Spec.ReplicaAutoBalance,
+		DataLocality:                 v.Spec.DataLocality,
+		SnapshotDataIntegrity:        v.Spec.SnapshotDataIntegrity,
+		SnapshotDataIntegrityCronJob: v.Spec.SnapshotDataIntegrityCronJob,
+		SnapshotMaxCount:             v.Spec.SnapshotMaxCount,
+		SnapshotMaxSize:              strconv.FormatInt(v.Spec.SnapshotMaxSize, 10),
+		BackupCompressionMethod:      v.Spec.BackupCompressionMethod,
+		StaleReplicaTimeout:          v.Spec.StaleReplicaTimeout,
+		Created:                      v.CreationTimestamp.String(),
+		Image:                        v.Spec.Image,
+		BackingImage:                 v.Spec.BackingImage,
+		Standby:                      v.Spec.Standby,
+		DiskSelector:                 v.Spec.DiskSelector,
+		NodeSelector:                 v.Spec.NodeSelector,
+		RestoreVolumeRecurringJob:    v.Spec.RestoreVolumeRecurringJob,
+		FreezeFilesystemForSnapshot:  v.Spec.FreezeFilesystemForSnapshot,
+		BackupTargetName:             v.Spec.BackupTargetName,
+
+		State:                       volumeState,
 		Robustness:                  v.Status.Robustness,
 		CurrentImage:                v.Status.CurrentImage,
 		LastBackup:                  v.Status.LastBackup,
@@ -1652,6 +1977,11 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 
 		Encrypted: v.Spec.Encrypted,
 
+		TrashedAt: v.Spec.TrashedAt,
+
+		ReplicaSelectorRelocation: v.Spec.ReplicaSelectorRelocation,
+		SelectorViolatingReplicas: v.Status.SelectorViolatingReplicas,
+
 		Conditions:       sliceToMap(v.Status.Conditions),
 		KubernetesStatus: v.Status.KubernetesStatus,
 		CloneStatus:      v.Status.CloneStatus,
@@ -1672,7 +2002,13 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 		"detach": {},
 	}
 
-	if v.Status.Robustness == longhorn.VolumeRobustnessFaulted {
+	if v.Spec.TrashedAt != "" {
+		// a volume in the recycle bin can only be restored or purged
+		actions = map[string]struct{}{
+			"restoreFromRecycleBin": {},
+			"purge":                 {},
+		}
+	} else if v.Status.Robustness == longhorn.VolumeRobustnessFaulted {
 		actions["salvage"] = struct{}{}
 	} else {
 
@@ -1690,6 +2026,7 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 			actions["offlineReplicaRebuilding"] = struct{}{}
 			actions["replicaRemove"] = struct{}{}
 			actions["engineUpgrade"] = struct{}{}
+			actions["engineUpgradePreCheck"] = struct{}{}
 			actions["pvCreate"] = struct{}{}
 			actions["pvcCreate"] = struct{}{}
 			actions["updateDataLocality"] = struct{}{}
@@ -1697,6 +2034,7 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 			actions["updateReplicaAutoBalance"] = struct{}{}
 			actions["updateUnmapMarkSnapChainRemoved"] = struct{}{}
 			actions["updateSnapshotDataIntegrity"] = struct{}{}
+			actions["updateSnapshotDataIntegrityCronJob"] = struct{}{}
 			actions["updateSnapshotMaxCount"] = struct{}{}
 			actions["updateSnapshotMaxSize"] = struct{}{}
 			actions["updateBackupCompressionMethod"] = struct{}{}
@@ -1704,6 +2042,7 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 			actions["updateReplicaZoneSoftAntiAffinity"] = struct{}{}
 			actions["updateReplicaDiskSoftAntiAffinity"] = struct{}{}
 			actions["updateFreezeFilesystemForSnapshot"] = struct{}{}
+			actions["updateReplicaSelectorRelocation"] = struct{}{}
 			actions["updateBackupTargetName"] = struct{}{}
 			actions["recurringJobAdd"] = struct{}{}
 			actions["recurringJobDelete"] = struct{}{}
@@ -1725,11 +2064,13 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 			actions["snapshotRevert"] = struct{}{}
 			actions["replicaRemove"] = struct{}{}
 			actions["engineUpgrade"] = struct{}{}
+			actions["engineUpgradePreCheck"] = struct{}{}
 			actions["updateReplicaCount"] = struct{}{}
 			actions["updateDataLocality"] = struct{}{}
 			actions["updateReplicaAutoBalance"] = struct{}{}
 			actions["updateUnmapMarkSnapChainRemoved"] = struct{}{}
 			actions["updateSnapshotDataIntegrity"] = struct{}{}
+			actions["updateSnapshotDataIntegrityCronJob"] = struct{}{}
 			actions["updateSnapshotMaxCount"] = struct{}{}
 			actions["updateSnapshotMaxSize"] = struct{}{}
 			actions["updateBackupCompressionMethod"] = struct{}{}
@@ -1737,6 +2078,7 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 			actions["updateReplicaZoneSoftAntiAffinity"] = struct{}{}
 			actions["updateReplicaDiskSoftAntiAffinity"] = struct{}{}
 			actions["updateFreezeFilesystemForSnapshot"] = struct{}{}
+			actions["updateReplicaSelectorRelocation"] = struct{}{}
 			actions["updateBackupTargetName"] = struct{}{}
 			actions["pvCreate"] = struct{}{}
 			actions["pvcCreate"] = struct{}{}
@@ -1869,6 +2211,10 @@ func toBackupTargetResource(bt *longhorn.BackupTarget, apiContext *api.ApiContex
 			PollInterval:     bt.Spec.PollInterval.Duration.String(),
 			Available:        bt.Status.Available,
 			Message:          types.GetCondition(bt.Status.Conditions, longhorn.BackupTargetConditionTypeUnavailable).Message,
+
+			Zone:                  bt.Spec.Zone,
+			Region:                bt.Spec.Region,
+			ReplicationTargetName: bt.Spec.ReplicationTargetName,
 		},
 	}
 	res.Actions = map[string]string{
@@ -2023,6 +2369,9 @@ func toBackupResource(b *longhorn.Backup) *Backup {
 		NewlyUploadedDataSize:  b.Status.NewlyUploadedDataSize,
 		ReUploadedDataSize:     b.Status.ReUploadedDataSize,
 		BackupTargetName:       backupTargetName,
+		ReplicationTargetName:  b.Status.ReplicationTargetName,
+		ReplicationBackupName:  b.Status.ReplicationBackupName,
+		ReplicationState:       string(b.Status.ReplicationState),
 	}
 	// Set the volume name from backup CR's label if it's empty.
 	// This field is empty probably because the backup state is not Ready
@@ -2067,6 +2416,45 @@ func toEngineImageCollection(eis []*longhorn.EngineImage, defaultImage string) *
 	return &client.GenericCollection{Data: data, Collection: client.Collection{ResourceType: "engineImage"}}
 }
 
+func toUpgradeFreezeReportResource(report *manager.UpgradeFreezeReport) *UpgradeFreezeReport {
+	return &UpgradeFreezeReport{
+		Resource: client.Resource{
+			Id:    string(types.SettingNameUpgradeFreeze),
+			Type:  "upgradeFreezeReport",
+			Links: map[string]string{},
+		},
+		Enabled:                            report.Enabled,
+		PendingEngineImageUpgrades:         report.PendingEngineImageUpgrades,
+		PendingLiveEngineUpgrades:          report.PendingLiveEngineUpgrades,
+		PendingInstanceManagerReplacements: report.PendingInstanceManagerReplacements,
+	}
+}
+
+func toRebuildQueueCollection(queue []*datastore.RebuildQueueEntry) *client.GenericCollection {
+	data := []interface{}{}
+	for i, entry := range queue {
+		state := "waiting"
+		if entry.Rebuilding {
+			state = "rebuilding"
+		}
+		data = append(data, &RebuildQueueEntry{
+			Resource: client.Resource{
+				Id:    entry.VolumeName,
+				Type:  "rebuildQueueEntry",
+				Links: map[string]string{},
+			},
+			Position:         i + 1,
+			VolumeName:       entry.VolumeName,
+			State:            state,
+			HealthyReplicas:  entry.HealthyReplicas,
+			NumberOfReplicas: entry.NumberOfReplicas,
+			Priority:         entry.Priority,
+			Size:             strconv.FormatInt(entry.Size, 10),
+		})
+	}
+	return &client.GenericCollection{Data: data, Collection: client.Collection{ResourceType: "rebuildQueueEntry"}}
+}
+
 func toBackingImageResource(bi *longhorn.BackingImage, apiContext *api.ApiContext) *BackingImage {
 	deletionTimestamp := ""
 	if bi.DeletionTimestamp != nil {
@@ -2179,6 +2567,7 @@ func toNodeResource(node *longhorn.Node, address string, apiContext *api.ApiCont
 				ScheduledReplica:      node.Status.DiskStatus[name].ScheduledReplica,
 				ScheduledBackingImage: node.Status.DiskStatus[name].ScheduledBackingImage,
 				DiskUUID:              node.Status.DiskStatus[name].DiskUUID,
+				EncryptedDevicePath:   node.Status.DiskStatus[name].EncryptedDevicePath,
 			}
 		}
 		disks[name] = di
@@ -2360,6 +2749,51 @@ func toInstanceManagerCollection(instanceManagers map[string]*longhorn.InstanceM
 	return &client.GenericCollection{Data: data, Collection: client.Collection{ResourceType: "instanceManager"}}
 }
 
+func toInstanceManagerDetailResource(detail *manager.InstanceManagerDetail) *InstanceManagerDetail {
+	im := detail.InstanceManager
+	res := &InstanceManagerDetail{
+		Resource: client.Resource{
+			Id:   im.Name,
+			Type: "instanceManagerDetail",
+		},
+		Name:              im.Name,
+		NodeID:            im.Spec.NodeID,
+		ManagerType:       string(im.Spec.Type),
+		DataEngine:        string(im.Spec.DataEngine),
+		CurrentState:      im.Status.CurrentState,
+		Image:             im.Spec.Image,
+		PodUsageAvailable: detail.PodUsageAvailable,
+		PodCPUUsage:       detail.PodCPUUsage,
+		PodMemoryUsage:    detail.PodMemoryUsage,
+		Processes:         []InstanceManagerProcess{},
+	}
+	if detail.PodStartTime != nil {
+		res.PodStartTime = detail.PodStartTime.UTC().Format(time.RFC3339)
+		res.UptimeSeconds = int64(time.Since(detail.PodStartTime.Time).Seconds())
+	}
+	for _, p := range detail.Processes {
+		res.Processes = append(res.Processes, InstanceManagerProcess{
+			Name:       p.Name,
+			Type:       string(p.Type),
+			VolumeName: p.VolumeName,
+			DataEngine: string(p.DataEngine),
+			State:      string(p.State),
+			ErrorMsg:   p.ErrorMsg,
+			PortStart:  p.PortStart,
+			PortEnd:    p.PortEnd,
+		})
+	}
+	return res
+}
+
+func toInstanceManagerDetailCollection(details []*manager.InstanceManagerDetail) *client.GenericCollection {
+	var data []interface{}
+	for _, detail := range details {
+		data = append(data, toInstanceManagerDetailResource(detail))
+	}
+	return &client.GenericCollection{Data: data, Collection: client.Collection{ResourceType: "instanceManagerDetail"}}
+}
+
 func toRecurringJobResource(recurringJob *longhorn.RecurringJob, apiContext *api.ApiContext) *RecurringJob {
 	return &RecurringJob{
 		Resource: client.Resource{
@@ -2390,6 +2824,106 @@ func toRecurringJobCollection(jobs []*longhorn.RecurringJob, apiContext *api.Api
 	return &client.GenericCollection{Data: data, Collection: client.Collection{ResourceType: "recurringJob"}}
 }
 
+func toCloneScheduleResource(cloneSchedule *longhorn.CloneSchedule) *CloneSchedule {
+	return &CloneSchedule{
+		Resource: client.Resource{
+			Id:   cloneSchedule.Name,
+			Type: "cloneSchedule",
+		},
+		Name:              cloneSchedule.Name,
+		SourceVolume:      cloneSchedule.Spec.SourceVolume,
+		TargetVolume:      cloneSchedule.Spec.TargetVolume,
+		SourceType:        string(cloneSchedule.Spec.SourceType),
+		Cron:              cloneSchedule.Spec.Cron,
+		Suspend:           cloneSchedule.Spec.Suspend,
+		State:             string(cloneSchedule.Status.State),
+		LastScheduleTime:  cloneSchedule.Status.LastScheduleTime,
+		LastRefreshTime:   cloneSchedule.Status.LastRefreshTime,
+		LastRefreshSource: cloneSchedule.Status.LastRefreshSource,
+		Message:           cloneSchedule.Status.Message,
+	}
+}
+
+func toCloneScheduleCollection(cloneSchedules []*longhorn.CloneSchedule) *client.GenericCollection {
+	data := []interface{}{}
+	for _, cloneSchedule := range cloneSchedules {
+		data = append(data, toCloneScheduleResource(cloneSchedule))
+	}
+	return &client.GenericCollection{Data: data, Collection: client.Collection{ResourceType: "cloneSchedule"}}
+}
+
+func toRestoreTestResource(restoreTest *longhorn.RestoreTest) *RestoreTest {
+	return &RestoreTest{
+		Resource: client.Resource{
+			Id:   restoreTest.Name,
+			Type: "restoreTest",
+		},
+		Name:             restoreTest.Name,
+		BackupTargetName: restoreTest.Spec.BackupTargetName,
+		VolumeName:       restoreTest.Spec.VolumeName,
+		SelectionPolicy:  string(restoreTest.Spec.SelectionPolicy),
+		Cron:             restoreTest.Spec.Cron,
+		Suspend:          restoreTest.Spec.Suspend,
+		Image:            restoreTest.Spec.Image,
+		Command:          restoreTest.Spec.Command,
+		FSType:           restoreTest.Spec.FSType,
+		TimeoutSeconds:   restoreTest.Spec.TimeoutSeconds,
+		State:            string(restoreTest.Status.State),
+		LastScheduleTime: restoreTest.Status.LastScheduleTime,
+		CurrentBackup:    restoreTest.Status.CurrentBackup,
+		LastTestTime:     restoreTest.Status.LastTestTime,
+		LastTestedBackup: restoreTest.Status.LastTestedBackup,
+		LastResult:       string(restoreTest.Status.LastResult),
+		Message:          restoreTest.Status.Message,
+		PassedCount:      restoreTest.Status.PassedCount,
+		FailedCount:      restoreTest.Status.FailedCount,
+	}
+}
+
+func toRestoreTestCollection(restoreTests []*longhorn.RestoreTest) *client.GenericCollection {
+	data := []interface{}{}
+	for _, restoreTest := range restoreTests {
+		data = append(data, toRestoreTestResource(restoreTest))
+	}
+	return &client.GenericCollection{Data: data, Collection: client.Collection{ResourceType: "restoreTest"}}
+}
+
+func toReplicaCountPolicyResource(policy *longhorn.ReplicaCountPolicy) *ReplicaCountPolicy {
+	res := &ReplicaCountPolicy{
+		Resource: client.Resource{
+			Id:   policy.Name,
+			Type: "replicaCountPolicy",
+		},
+		Name:                       policy.Name,
+		Selector:                   policy.Spec.Selector,
+		NumberOfReplicas:           policy.Spec.NumberOfReplicas,
+		MinNumberOfReplicas:        policy.Spec.MinNumberOfReplicas,
+		Priority:                   policy.Spec.Priority,
+		StabilizationWindowSeconds: policy.Spec.StabilizationWindowSeconds,
+		DryRun:                     policy.Spec.DryRun,
+		Decisions:                  []ReplicaCountPolicyDecision{},
+	}
+	for _, decision := range policy.Status.Decisions {
+		res.Decisions = append(res.Decisions, ReplicaCountPolicyDecision{
+			VolumeName:              decision.VolumeName,
+			CurrentNumberOfReplicas: decision.CurrentNumberOfReplicas,
+			DesiredNumberOfReplicas: decision.DesiredNumberOfReplicas,
+			Reason:                  decision.Reason,
+			PendingSince:            decision.PendingSince,
+			LastAppliedTime:         decision.LastAppliedTime,
+		})
+	}
+	return res
+}
+
+func toReplicaCountPolicyCollection(policies []*longhorn.ReplicaCountPolicy) *client.GenericCollection {
+	var data []interface{}
+	for _, policy := range policies {
+		data = append(data, toReplicaCountPolicyResource(policy))
+	}
+	return &client.GenericCollection{Data: data, Collection: client.Collection{ResourceType: "replicaCountPolicy"}}
+}
+
 func toOrphanResource(orphan *longhorn.Orphan) *Orphan {
 	return &Orphan{
 		Resource: client.Resource{
@@ -2421,3 +2955,22 @@ func sliceToMap(conditions []longhorn.Condition) map[string]longhorn.Condition {
 	}
 	return converted
 }
+
+func toEngineUpgradePreCheckResource(volumeName string, failures []datastore.EngineUpgradePreCheckFailure) *EngineUpgradePreCheck {
+	res := &EngineUpgradePreCheck{
+		Resource: client.Resource{
+			Id:    volumeName,
+			Type:  "engineUpgradePreCheck",
+			Links: map[string]string{},
+		},
+		Upgradeable: len(failures) == 0,
+		Failures:    []EngineUpgradePreCheckFailure{},
+	}
+	for _, failure := range failures {
+		res.Failures = append(res.Failures, EngineUpgradePreCheckFailure{
+			Reason:  failure.Reason,
+			Message: failure.Message,
+		})
+	}
+	return res
+}
@@ -54,6 +54,20 @@ func (s *Server) NodeGet(rw http.ResponseWriter, req *http.Request) error {
 	return nil
 }
 
+// NodeInstanceManagerList lists the instance managers on the node with their processes and the resource usage of
+// their pods. There is no resource usage per process.
+func (s *Server) NodeInstanceManagerList(rw http.ResponseWriter, req *http.Request) error {
+	apiContext := api.GetApiContext(req)
+	id := mux.Vars(req)["name"]
+
+	details, err := s.m.GetInstanceManagerDetailsByNode(id)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get instance manager details of node %v", id)
+	}
+	apiContext.Write(toInstanceManagerDetailCollection(details))
+	return nil
+}
+
 func (s *Server) NodeUpdate(rw http.ResponseWriter, req *http.Request) error {
 	var n Node
 	apiContext := api.GetApiContext(req)
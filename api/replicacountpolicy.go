@@ -0,0 +1,112 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/rancher/go-rancher/api"
+	"github.com/rancher/go-rancher/client"
+
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+func (s *Server) ReplicaCountPolicyList(rw http.ResponseWriter, req *http.Request) (err error) {
+	apiContext := api.GetApiContext(req)
+
+	list, err := s.replicaCountPolicyList(apiContext)
+	if err != nil {
+		return err
+	}
+	apiContext.Write(list)
+	return nil
+}
+
+func (s *Server) replicaCountPolicyList(apiContext *api.ApiContext) (*client.GenericCollection, error) {
+	list, err := s.m.ListReplicaCountPoliciesSorted()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list replica count policies")
+	}
+	return toReplicaCountPolicyCollection(list), nil
+}
+
+func (s *Server) ReplicaCountPolicyGet(rw http.ResponseWriter, req *http.Request) error {
+	apiContext := api.GetApiContext(req)
+
+	id := mux.Vars(req)["name"]
+
+	policy, err := s.m.GetReplicaCountPolicy(id)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get replica count policy '%s'", id)
+	}
+	apiContext.Write(toReplicaCountPolicyResource(policy))
+	return nil
+}
+
+func (s *Server) ReplicaCountPolicyCreate(rw http.ResponseWriter, req *http.Request) error {
+	var input ReplicaCountPolicy
+	apiContext := api.GetApiContext(req)
+
+	if err := apiContext.Read(&input); err != nil {
+		return err
+	}
+
+	obj, err := s.m.CreateReplicaCountPolicy(input.Name, &longhorn.ReplicaCountPolicySpec{
+		Selector:                   input.Selector,
+		NumberOfReplicas:           input.NumberOfReplicas,
+		MinNumberOfReplicas:        input.MinNumberOfReplicas,
+		Priority:                   input.Priority,
+		StabilizationWindowSeconds: input.StabilizationWindowSeconds,
+		DryRun:                     input.DryRun,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create replica count policy %v", input.Name)
+	}
+	apiContext.Write(toReplicaCountPolicyResource(obj))
+	return nil
+}
+
+func (s *Server) ReplicaCountPolicyUpdate(rw http.ResponseWriter, req *http.Request) error {
+	var input ReplicaCountPolicy
+
+	apiContext := api.GetApiContext(req)
+	if err := apiContext.Read(&input); err != nil {
+		return err
+	}
+
+	name := mux.Vars(req)["name"]
+
+	obj, err := util.RetryOnConflictCause(func() (interface{}, error) {
+		return s.m.UpdateReplicaCountPolicy(name, &longhorn.ReplicaCountPolicySpec{
+			Selector:                   input.Selector,
+			NumberOfReplicas:           input.NumberOfReplicas,
+			MinNumberOfReplicas:        input.MinNumberOfReplicas,
+			Priority:                   input.Priority,
+			StabilizationWindowSeconds: input.StabilizationWindowSeconds,
+			DryRun:                     input.DryRun,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	policy, ok := obj.(*longhorn.ReplicaCountPolicy)
+	if !ok {
+		return fmt.Errorf("failed to convert %v to replica count policy object", name)
+	}
+
+	apiContext.Write(toReplicaCountPolicyResource(policy))
+	return nil
+}
+
+func (s *Server) ReplicaCountPolicyDelete(rw http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["name"]
+	if err := s.m.DeleteReplicaCountPolicy(id); err != nil {
+		return errors.Wrapf(err, "failed to delete replica count policy %v", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/rancher/go-rancher/api"
+	"github.com/rancher/go-rancher/client"
+
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+func (s *Server) RestoreTestList(rw http.ResponseWriter, req *http.Request) (err error) {
+	apiContext := api.GetApiContext(req)
+
+	list, err := s.restoreTestList(apiContext)
+	if err != nil {
+		return err
+	}
+	apiContext.Write(list)
+	return nil
+}
+
+func (s *Server) restoreTestList(apiContext *api.ApiContext) (*client.GenericCollection, error) {
+	list, err := s.m.ListRestoreTestsSorted()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list restore tests")
+	}
+	return toRestoreTestCollection(list), nil
+}
+
+func (s *Server) RestoreTestGet(rw http.ResponseWriter, req *http.Request) error {
+	apiContext := api.GetApiContext(req)
+
+	id := mux.Vars(req)["name"]
+
+	restoreTest, err := s.m.GetRestoreTest(id)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get restore test '%s'", id)
+	}
+	apiContext.Write(toRestoreTestResource(restoreTest))
+	return nil
+}
+
+func (s *Server) RestoreTestCreate(rw http.ResponseWriter, req *http.Request) error {
+	var input RestoreTest
+	apiContext := api.GetApiContext(req)
+
+	if err := apiContext.Read(&input); err != nil {
+		return err
+	}
+
+	obj, err := s.m.CreateRestoreTest(input.Name, &longhorn.RestoreTestSpec{
+		BackupTargetName: input.BackupTargetName,
+		VolumeName:       input.VolumeName,
+		SelectionPolicy:  longhorn.RestoreTestSelectionPolicy(input.SelectionPolicy),
+		Cron:             input.Cron,
+		Suspend:          input.Suspend,
+		Image:            input.Image,
+		Command:          input.Command,
+		FSType:           input.FSType,
+		TimeoutSeconds:   input.TimeoutSeconds,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create restore test %v", input.Name)
+	}
+	apiContext.Write(toRestoreTestResource(obj))
+	return nil
+}
+
+func (s *Server) RestoreTestUpdate(rw http.ResponseWriter, req *http.Request) error {
+	var input RestoreTest
+
+	apiContext := api.GetApiContext(req)
+	if err := apiContext.Read(&input); err != nil {
+		return err
+	}
+
+	name := mux.Vars(req)["name"]
+
+	obj, err := util.RetryOnConflictCause(func() (interface{}, error) {
+		return s.m.UpdateRestoreTest(name, &longhorn.RestoreTestSpec{
+			VolumeName:      input.VolumeName,
+			SelectionPolicy: longhorn.RestoreTestSelectionPolicy(input.SelectionPolicy),
+			Cron:            input.Cron,
+			Suspend:         input.Suspend,
+			Image:           input.Image,
+			Command:         input.Command,
+			FSType:          input.FSType,
+			TimeoutSeconds:  input.TimeoutSeconds,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	restoreTest, ok := obj.(*longhorn.RestoreTest)
+	if !ok {
+		return fmt.Errorf("failed to convert %v to restore test object", name)
+	}
+
+	apiContext.Write(toRestoreTestResource(restoreTest))
+	return nil
+}
+
+func (s *Server) RestoreTestDelete(rw http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["name"]
+	if err := s.m.DeleteRestoreTest(id); err != nil {
+		return errors.Wrapf(err, "failed to delete restore test %v", id)
+	}
+
+	return nil
+}
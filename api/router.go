@@ -84,16 +84,21 @@ func NewRouter(s *Server) *mux.Router {
 		"expand":                            s.VolumeExpand,
 		"cancelExpansion":                   s.VolumeCancelExpansion,
 		"offlineReplicaRebuilding":          s.VolumeOfflineRebuilding,
-
-		"updateReplicaCount":                s.VolumeUpdateReplicaCount,
-		"updateReplicaAutoBalance":          s.VolumeUpdateReplicaAutoBalance,
-		"updateSnapshotDataIntegrity":       s.VolumeUpdateSnapshotDataIntegrity,
-		"updateBackupCompressionMethod":     s.VolumeUpdateBackupCompressionMethod,
-		"updateFreezeFilesystemForSnapshot": s.VolumeUpdateFreezeFilesystemForSnapshot,
-		"updateBackupTargetName":            s.VolumeUpdateBackupTargetName,
-		"replicaRemove":                     s.ReplicaRemove,
-
-		"engineUpgrade": s.EngineUpgrade,
+		"restoreFromRecycleBin":             s.VolumeRestoreFromRecycleBin,
+		"purge":                             s.VolumePurge,
+
+		"updateReplicaCount":                 s.VolumeUpdateReplicaCount,
+		"updateReplicaAutoBalance":           s.VolumeUpdateReplicaAutoBalance,
+		"updateSnapshotDataIntegrity":        s.VolumeUpdateSnapshotDataIntegrity,
+		"updateSnapshotDataIntegrityCronJob": s.VolumeUpdateSnapshotDataIntegrityCronJob,
+		"updateBackupCompressionMethod":      s.VolumeUpdateBackupCompressionMethod,
+		"updateFreezeFilesystemForSnapshot":  s.VolumeUpdateFreezeFilesystemForSnapshot,
+		"updateReplicaSelectorRelocation":    s.VolumeUpdateReplicaSelectorRelocation,
+		"updateBackupTargetName":             s.VolumeUpdateBackupTargetName,
+		"replicaRemove":                      s.ReplicaRemove,
+
+		"engineUpgrade":         s.EngineUpgrade,
+		"engineUpgradePreCheck": s.EngineUpgradePreCheck,
 
 		"trimFilesystem": s.fwd.Handler(s.fwd.HandleProxyRequestByNodeID, s.fwd.GetHTTPAddressByNodeID(OwnerIDFromVolume(s.m)), s.VolumeFilesystemTrim),
 
@@ -153,6 +158,7 @@ func NewRouter(s *Server) *mux.Router {
 	r.Methods("GET").Path("/v1/nodes/{name}").Handler(f(schemas, s.NodeGet))
 	r.Methods("PUT").Path("/v1/nodes/{name}").Handler(f(schemas, s.NodeUpdate))
 	r.Methods("DELETE").Path("/v1/nodes/{name}").Handler(f(schemas, s.NodeDelete))
+	r.Methods("GET").Path("/v1/nodes/{name}/instanceManagers").Handler(f(schemas, s.NodeInstanceManagerList))
 	nodeActions := map[string]func(http.ResponseWriter, *http.Request) error{
 		"diskUpdate": s.DiskUpdate,
 	}
@@ -167,6 +173,10 @@ func NewRouter(s *Server) *mux.Router {
 
 	r.Methods("Get").Path("/v1/events").Handler(f(schemas, s.EventList))
 
+	r.Methods("GET").Path("/v1/upgradefreeze").Handler(f(schemas, s.UpgradeFreezeReportGet))
+
+	r.Methods("GET").Path("/v1/rebuildqueue").Handler(f(schemas, s.RebuildQueueList))
+
 	r.Methods("GET").Path("/v1/disktags").Handler(f(schemas, s.DiskTagList))
 	r.Methods("GET").Path("/v1/nodetags").Handler(f(schemas, s.NodeTagList))
 
@@ -204,6 +214,24 @@ func NewRouter(s *Server) *mux.Router {
 	r.Methods("POST").Path("/v1/recurringjobs").Handler(f(schemas, s.RecurringJobCreate))
 	r.Methods("PUT").Path("/v1/recurringjobs/{name}").Handler(f(schemas, s.RecurringJobUpdate))
 
+	r.Methods("GET").Path("/v1/cloneschedules").Handler(f(schemas, s.CloneScheduleList))
+	r.Methods("GET").Path("/v1/cloneschedules/{name}").Handler(f(schemas, s.CloneScheduleGet))
+	r.Methods("DELETE").Path("/v1/cloneschedules/{name}").Handler(f(schemas, s.CloneScheduleDelete))
+	r.Methods("POST").Path("/v1/cloneschedules").Handler(f(schemas, s.CloneScheduleCreate))
+	r.Methods("PUT").Path("/v1/cloneschedules/{name}").Handler(f(schemas, s.CloneScheduleUpdate))
+
+	r.Methods("GET").Path("/v1/restoretests").Handler(f(schemas, s.RestoreTestList))
+	r.Methods("GET").Path("/v1/restoretests/{name}").Handler(f(schemas, s.RestoreTestGet))
+	r.Methods("DELETE").Path("/v1/restoretests/{name}").Handler(f(schemas, s.RestoreTestDelete))
+	r.Methods("POST").Path("/v1/restoretests").Handler(f(schemas, s.RestoreTestCreate))
+	r.Methods("PUT").Path("/v1/restoretests/{name}").Handler(f(schemas, s.RestoreTestUpdate))
+
+	r.Methods("GET").Path("/v1/replicacountpolicies").Handler(f(schemas, s.ReplicaCountPolicyList))
+	r.Methods("GET").Path("/v1/replicacountpolicies/{name}").Handler(f(schemas, s.ReplicaCountPolicyGet))
+	r.Methods("DELETE").Path("/v1/replicacountpolicies/{name}").Handler(f(schemas, s.ReplicaCountPolicyDelete))
+	r.Methods("POST").Path("/v1/replicacountpolicies").Handler(f(schemas, s.ReplicaCountPolicyCreate))
+	r.Methods("PUT").Path("/v1/replicacountpolicies/{name}").Handler(f(schemas, s.ReplicaCountPolicyUpdate))
+
 	r.Methods("GET").Path("/v1/orphans").Handler(f(schemas, s.OrphanList))
 	r.Methods("GET").Path("/v1/orphans/{name}").Handler(f(schemas, s.OrphanGet))
 	r.Methods("DELETE").Path("/v1/orphans/{name}").Handler(f(schemas, s.OrphanDelete))
@@ -68,3 +68,14 @@ func (s *Server) SettingSet(w http.ResponseWriter, req *http.Request) error {
 	apiContext.Write(toSettingResource(si))
 	return nil
 }
+
+func (s *Server) UpgradeFreezeReportGet(w http.ResponseWriter, req *http.Request) error {
+	apiContext := api.GetApiContext(req)
+
+	report, err := s.m.GetUpgradeFreezeReport()
+	if err != nil {
+		return errors.Wrap(err, "failed to get upgrade freeze report")
+	}
+	apiContext.Write(toUpgradeFreezeReportResource(report))
+	return nil
+}
@@ -2,11 +2,13 @@ package api
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"sort"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 
 	"github.com/rancher/go-rancher/api"
 	"github.com/rancher/go-rancher/client"
@@ -14,11 +16,18 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
 
+const (
+	// authenticatedUserHeader is set by the authenticating proxies usually put in front of the UI and the API,
+	// e.g. oauth2-proxy.
+	authenticatedUserHeader = "X-Forwarded-User"
+)
+
 func (s *Server) VolumeList(rw http.ResponseWriter, req *http.Request) (err error) {
 	defer func() {
 		err = errors.Wrap(err, "failed to list volume")
@@ -26,7 +35,13 @@ func (s *Server) VolumeList(rw http.ResponseWriter, req *http.Request) (err erro
 
 	apiContext := api.GetApiContext(req)
 
-	resp, err := s.volumeList(apiContext)
+	// Volumes in the recycle bin are hidden unless they are explicitly requested
+	var resp *client.GenericCollection
+	if req.URL.Query().Get("trashed") == "true" {
+		resp, err = s.trashedVolumeList(apiContext)
+	} else {
+		resp, err = s.volumeList(apiContext)
+	}
 	if err != nil {
 		return err
 	}
@@ -37,6 +52,18 @@ func (s *Server) VolumeList(rw http.ResponseWriter, req *http.Request) (err erro
 }
 
 func (s *Server) volumeList(apiContext *api.ApiContext) (*client.GenericCollection, error) {
+	return s.filteredVolumeList(apiContext, func(v *longhorn.Volume) bool {
+		return v.Spec.TrashedAt == ""
+	})
+}
+
+func (s *Server) trashedVolumeList(apiContext *api.ApiContext) (*client.GenericCollection, error) {
+	return s.filteredVolumeList(apiContext, func(v *longhorn.Volume) bool {
+		return v.Spec.TrashedAt != ""
+	})
+}
+
+func (s *Server) filteredVolumeList(apiContext *api.ApiContext, filter func(v *longhorn.Volume) bool) (*client.GenericCollection, error) {
 	resp := &client.GenericCollection{}
 
 	volumes, err := s.m.ListSorted()
@@ -45,6 +72,9 @@ func (s *Server) volumeList(apiContext *api.ApiContext) (*client.GenericCollecti
 	}
 
 	for _, v := range volumes {
+		if !filter(v) {
+			continue
+		}
 		controllers, err := s.m.GetEnginesSorted(v.Name)
 		if err != nil {
 			return nil, err
@@ -72,6 +102,17 @@ func (s *Server) volumeList(apiContext *api.ApiContext) (*client.GenericCollecti
 	return resp, nil
 }
 
+func (s *Server) RebuildQueueList(rw http.ResponseWriter, req *http.Request) error {
+	apiContext := api.GetApiContext(req)
+
+	queue, err := s.m.ListRebuildQueue()
+	if err != nil {
+		return errors.Wrap(err, "failed to list rebuild queue")
+	}
+	apiContext.Write(toRebuildQueueCollection(queue))
+	return nil
+}
+
 func (s *Server) VolumeGet(rw http.ResponseWriter, req *http.Request) error {
 	id := mux.Vars(req)["name"]
 	return s.responseWithVolume(rw, req, id, nil)
@@ -173,35 +214,37 @@ func (s *Server) VolumeCreate(rw http.ResponseWriter, req *http.Request) error {
 	}
 
 	v, err := s.m.Create(volume.Name, &longhorn.VolumeSpec{
-		Size:                        size,
-		AccessMode:                  volume.AccessMode,
-		Migratable:                  volume.Migratable,
-		Encrypted:                   volume.Encrypted,
-		Frontend:                    volume.Frontend,
-		FromBackup:                  volume.FromBackup,
-		RestoreVolumeRecurringJob:   volume.RestoreVolumeRecurringJob,
-		DataSource:                  volume.DataSource,
-		NumberOfReplicas:            volume.NumberOfReplicas,
-		ReplicaAutoBalance:          volume.ReplicaAutoBalance,
-		DataLocality:                volume.DataLocality,
-		StaleReplicaTimeout:         volume.StaleReplicaTimeout,
-		BackingImage:                volume.BackingImage,
-		Standby:                     volume.Standby,
-		RevisionCounterDisabled:     volume.RevisionCounterDisabled,
-		DiskSelector:                volume.DiskSelector,
-		NodeSelector:                volume.NodeSelector,
-		SnapshotDataIntegrity:       volume.SnapshotDataIntegrity,
-		SnapshotMaxCount:            volume.SnapshotMaxCount,
-		SnapshotMaxSize:             snapshotMaxSize,
-		BackupCompressionMethod:     volume.BackupCompressionMethod,
-		UnmapMarkSnapChainRemoved:   volume.UnmapMarkSnapChainRemoved,
-		ReplicaSoftAntiAffinity:     volume.ReplicaSoftAntiAffinity,
-		ReplicaZoneSoftAntiAffinity: volume.ReplicaZoneSoftAntiAffinity,
-		ReplicaDiskSoftAntiAffinity: volume.ReplicaDiskSoftAntiAffinity,
-		DataEngine:                  volume.DataEngine,
-		FreezeFilesystemForSnapshot: volume.FreezeFilesystemForSnapshot,
-		BackupTargetName:            volume.BackupTargetName,
-		OfflineRebuilding:           volume.OfflineRebuilding,
+		Size:                         size,
+		AccessMode:                   volume.AccessMode,
+		Migratable:                   volume.Migratable,
+		Encrypted:                    volume.Encrypted,
+		Frontend:                     volume.Frontend,
+		FromBackup:                   volume.FromBackup,
+		RestoreVolumeRecurringJob:    volume.RestoreVolumeRecurringJob,
+		DataSource:                   volume.DataSource,
+		NumberOfReplicas:             volume.NumberOfReplicas,
+		ReplicaAutoBalance:           volume.ReplicaAutoBalance,
+		DataLocality:                 volume.DataLocality,
+		StaleReplicaTimeout:          volume.StaleReplicaTimeout,
+		BackingImage:                 volume.BackingImage,
+		Standby:                      volume.Standby,
+		RevisionCounterDisabled:      volume.RevisionCounterDisabled,
+		DiskSelector:                 volume.DiskSelector,
+		NodeSelector:                 volume.NodeSelector,
+		SnapshotDataIntegrity:        volume.SnapshotDataIntegrity,
+		SnapshotDataIntegrityCronJob: volume.SnapshotDataIntegrityCronJob,
+		SnapshotMaxCount:             volume.SnapshotMaxCount,
+		SnapshotMaxSize:              snapshotMaxSize,
+		BackupCompressionMethod:      volume.BackupCompressionMethod,
+		UnmapMarkSnapChainRemoved:    volume.UnmapMarkSnapChainRemoved,
+		ReplicaSoftAntiAffinity:      volume.ReplicaSoftAntiAffinity,
+		ReplicaZoneSoftAntiAffinity:  volume.ReplicaZoneSoftAntiAffinity,
+		ReplicaDiskSoftAntiAffinity:  volume.ReplicaDiskSoftAntiAffinity,
+		DataEngine:                   volume.DataEngine,
+		FreezeFilesystemForSnapshot:  volume.FreezeFilesystemForSnapshot,
+		BackupTargetName:             volume.BackupTargetName,
+		OfflineRebuilding:            volume.OfflineRebuilding,
+		ReplicaSelectorRelocation:    volume.ReplicaSelectorRelocation,
 	}, volume.RecurringJobSelector)
 	if err != nil {
 		return errors.Wrap(err, "failed to create volume")
@@ -212,7 +255,9 @@ func (s *Server) VolumeCreate(rw http.ResponseWriter, req *http.Request) error {
 func (s *Server) VolumeDelete(rw http.ResponseWriter, req *http.Request) error {
 	id := mux.Vars(req)["name"]
 
-	if err := s.m.Delete(id); err != nil {
+	if _, err := util.RetryOnConflictCause(func() (interface{}, error) {
+		return nil, s.m.Delete(id)
+	}); err != nil {
 		return errors.Wrap(err, "failed to delete volume")
 	}
 
@@ -229,7 +274,7 @@ func (s *Server) VolumeAttach(rw http.ResponseWriter, req *http.Request) error {
 	id := mux.Vars(req)["name"]
 
 	obj, err := util.RetryOnConflictCause(func() (interface{}, error) {
-		return s.m.Attach(id, input.HostID, input.DisableFrontend, input.AttachedBy, input.AttacherType, input.AttachmentID)
+		return s.m.Attach(id, input.HostID, input.DisableFrontend, input.AttachedBy, input.AttacherType, s.getAttacherID(input, req), input.AttachmentID)
 	})
 	if err != nil {
 		return err
@@ -242,6 +287,59 @@ func (s *Server) VolumeAttach(rw http.ResponseWriter, req *http.Request) error {
 	return s.responseWithVolume(rw, req, "", v)
 }
 
+// getAttacherID returns the identity of the requester of an attachment. If the
+// requester does not identify itself, for example the attachment requested by
+// the UI, the user authenticated by the proxy in front of the API is used, but
+// only if the request comes from a proxy listed in the setting
+// api-trusted-proxies. Otherwise it is left empty.
+func (s *Server) getAttacherID(input AttachInput, req *http.Request) string {
+	if input.AttacherID != "" {
+		return input.AttacherID
+	}
+	if input.AttachedBy != "" {
+		return input.AttachedBy
+	}
+
+	user := req.Header.Get(authenticatedUserHeader)
+	if user == "" {
+		return ""
+	}
+
+	trustedProxiesSetting, err := s.m.GetSettingValueExisted(types.SettingNameAPITrustedProxies)
+	if err != nil {
+		logrus.WithError(err).Warnf("Failed to get setting %v, ignoring the %v header", types.SettingNameAPITrustedProxies, authenticatedUserHeader)
+		return ""
+	}
+	trustedProxies, err := types.UnmarshalTrustedProxies(trustedProxiesSetting)
+	if err != nil {
+		logrus.WithError(err).Warnf("Failed to parse setting %v, ignoring the %v header", types.SettingNameAPITrustedProxies, authenticatedUserHeader)
+		return ""
+	}
+	if !isRequestFromTrustedProxy(req, trustedProxies) {
+		return ""
+	}
+	return user
+}
+
+// isRequestFromTrustedProxy returns true if the remote address of the request
+// is in one of the trusted proxy networks.
+func isRequestFromTrustedProxy(req *http.Request, trustedProxies []*net.IPNet) bool {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, trustedProxy := range trustedProxies {
+		if trustedProxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Server) VolumeDetach(rw http.ResponseWriter, req *http.Request) error {
 	var input DetachInput
 
@@ -398,6 +496,29 @@ func (s *Server) VolumeUpdateSnapshotDataIntegrity(rw http.ResponseWriter, req *
 	return s.responseWithVolume(rw, req, "", v)
 }
 
+func (s *Server) VolumeUpdateSnapshotDataIntegrityCronJob(rw http.ResponseWriter, req *http.Request) error {
+	var input UpdateSnapshotDataIntegrityCronJobInput
+	id := mux.Vars(req)["name"]
+
+	apiContext := api.GetApiContext(req)
+	if err := apiContext.Read(&input); err != nil {
+		return errors.Wrap(err, "failed to read snapshotDataIntegrityCronJob")
+	}
+
+	obj, err := util.RetryOnConflictCause(func() (interface{}, error) {
+		return s.m.UpdateSnapshotDataIntegrityCronJob(id, input.SnapshotDataIntegrityCronJob)
+	})
+	if err != nil {
+		return err
+	}
+	v, ok := obj.(*longhorn.Volume)
+	if !ok {
+		return fmt.Errorf("failed to convert to volume %v object", id)
+	}
+
+	return s.responseWithVolume(rw, req, "", v)
+}
+
 func (s *Server) VolumeUpdateBackupCompressionMethod(rw http.ResponseWriter, req *http.Request) error {
 	var input UpdateBackupCompressionMethodInput
 	id := mux.Vars(req)["name"]
@@ -654,6 +775,33 @@ func (s *Server) VolumeCancelExpansion(rw http.ResponseWriter, req *http.Request
 	return s.responseWithVolume(rw, req, "", v)
 }
 
+func (s *Server) VolumeRestoreFromRecycleBin(rw http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["name"]
+
+	obj, err := util.RetryOnConflictCause(func() (interface{}, error) {
+		return s.m.RestoreFromRecycleBin(id)
+	})
+	if err != nil {
+		return err
+	}
+	v, ok := obj.(*longhorn.Volume)
+	if !ok {
+		return fmt.Errorf("failed to convert to volume %v object", id)
+	}
+
+	return s.responseWithVolume(rw, req, "", v)
+}
+
+func (s *Server) VolumePurge(rw http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["name"]
+
+	if err := s.m.Purge(id); err != nil {
+		return errors.Wrap(err, "failed to purge volume")
+	}
+
+	return nil
+}
+
 func (s *Server) VolumeOfflineRebuilding(rw http.ResponseWriter, req *http.Request) error {
 	var input UpdateOfflineRebuildingInput
 
@@ -790,6 +938,17 @@ func (s *Server) EngineUpgrade(rw http.ResponseWriter, req *http.Request) error
 	return s.responseWithVolume(rw, req, id, v)
 }
 
+func (s *Server) EngineUpgradePreCheck(rw http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["name"]
+
+	failures, err := s.m.CheckEngineUpgrade(id)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check engine upgrade for volume %v", id)
+	}
+	api.GetApiContext(req).Write(toEngineUpgradePreCheckResource(id, failures))
+	return nil
+}
+
 func (s *Server) VolumeUpdateSnapshotMaxCount(rw http.ResponseWriter, req *http.Request) error {
 	var input UpdateSnapshotMaxCount
 	id := mux.Vars(req)["name"]
@@ -861,6 +1020,28 @@ func (s *Server) VolumeUpdateFreezeFilesystemForSnapshot(rw http.ResponseWriter,
 	return s.responseWithVolume(rw, req, "", v)
 }
 
+func (s *Server) VolumeUpdateReplicaSelectorRelocation(rw http.ResponseWriter, req *http.Request) error {
+	var input UpdateReplicaSelectorRelocationInput
+	id := mux.Vars(req)["name"]
+
+	apiContext := api.GetApiContext(req)
+	if err := apiContext.Read(&input); err != nil {
+		return errors.Wrap(err, "failed to read ReplicaSelectorRelocation input")
+	}
+
+	obj, err := util.RetryOnConflictCause(func() (interface{}, error) {
+		return s.m.UpdateReplicaSelectorRelocation(id, input.ReplicaSelectorRelocation)
+	})
+	if err != nil {
+		return err
+	}
+	v, ok := obj.(*longhorn.Volume)
+	if !ok {
+		return fmt.Errorf("failed to convert to volume %v object", id)
+	}
+	return s.responseWithVolume(rw, req, "", v)
+}
+
 func (s *Server) VolumeUpdateBackupTargetName(rw http.ResponseWriter, req *http.Request) error {
 	var input UpdateBackupTargetInput
 	id := mux.Vars(req)["name"]
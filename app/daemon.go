@@ -10,6 +10,7 @@ import (
 
 	"github.com/gorilla/handlers"
 	"github.com/pkg/errors"
+	"github.com/rancher/dynamiclistener"
 	"github.com/rancher/wrangler/v3/pkg/signals"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
@@ -18,6 +19,7 @@ import (
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientset "k8s.io/client-go/kubernetes"
 
@@ -39,8 +41,10 @@ import (
 	"github.com/longhorn/longhorn-manager/util/client"
 	"github.com/longhorn/longhorn-manager/webhook"
 
+	apputil "github.com/longhorn/longhorn-manager/app/util"
 	metricscollector "github.com/longhorn/longhorn-manager/metrics_collector"
 	recoverybackend "github.com/longhorn/longhorn-manager/recovery_backend"
+	webhookserver "github.com/longhorn/longhorn-manager/webhook/server"
 )
 
 const (
@@ -53,6 +57,8 @@ const (
 	FlagServiceAccount            = "service-account"
 	FlagKubeConfig                = "kube-config"
 	FlagUpgradeVersionCheck       = "upgrade-version-check"
+	FlagTLSSANs                   = "tls-sans"
+	FlagAPITLSPort                = "api-tls-port"
 )
 
 const (
@@ -103,6 +109,14 @@ func DaemonCmd() cli.Command {
 				Name:  FlagUpgradeVersionCheck,
 				Usage: "Enforce version checking for upgrades. If disabled, there will be no requirement for the necessary upgrade source version",
 			},
+			cli.StringSliceFlag{
+				Name:  FlagTLSSANs,
+				Usage: "Specify the additional DNS names, such as external ones, of the self-signed certificates of the webhook and API servers (optional)",
+			},
+			cli.IntFlag{
+				Name:  FlagAPITLSPort,
+				Usage: "Specify the port to serve the API over HTTPS, using the user-provided certificate in secret " + types.StaticCertName + " or a self-signed one. Disabled if 0 (optional)",
+			},
 		},
 		Action: func(c *cli.Context) {
 			if err := startManager(c); err != nil {
@@ -122,7 +136,8 @@ func DaemonCmd() cli.Command {
 // - ctx: The context used to manage the webhook servers lifecycle.
 // - kubeconfigPath: The path to the kubeconfig file.
 // - currentNodeID: The ID of the current node attempting to acquire the leadership.
-func startWebhooksByLeaderElection(ctx context.Context, kubeconfigPath, currentNodeID string) error {
+// - extraSANs: The additional DNS names of the webhook server certificates.
+func startWebhooksByLeaderElection(ctx context.Context, kubeconfigPath, currentNodeID string, extraSANs []string) error {
 	config, err := rest.InClusterConfig()
 	if err != nil {
 		return errors.Wrap(err, "failed to get client config")
@@ -165,7 +180,7 @@ func startWebhooksByLeaderElection(ctx context.Context, kubeconfigPath, currentN
 			if err != nil {
 				return err
 			}
-			if err := webhook.StartWebhook(ctx, types.WebhookTypeConversion, clientsWithoutDatastore); err != nil {
+			if err := webhook.StartWebhook(ctx, types.WebhookTypeConversion, clientsWithoutDatastore, extraSANs); err != nil {
 				return err
 			}
 
@@ -192,7 +207,7 @@ func startWebhooksByLeaderElection(ctx context.Context, kubeconfigPath, currentN
 			return err
 		}
 
-		if err := webhook.StartWebhook(ctx, types.WebhookTypeAdmission, clients); err != nil {
+		if err := webhook.StartWebhook(ctx, types.WebhookTypeAdmission, clients, extraSANs); err != nil {
 			return err
 		}
 
@@ -292,7 +307,7 @@ func startManager(c *cli.Context) error {
 
 	logger := logrus.StandardLogger().WithField("node", currentNodeID)
 
-	err = startWebhooksByLeaderElection(ctx, kubeconfigPath, currentNodeID)
+	err = startWebhooksByLeaderElection(ctx, kubeconfigPath, currentNodeID, c.StringSlice(FlagTLSSANs))
 	if err != nil {
 		return err
 	}
@@ -323,7 +338,13 @@ func startManager(c *cli.Context) error {
 		return err
 	}
 
-	m := manager.NewVolumeManager(currentNodeID, clients.Datastore, proxyConnCounter)
+	eventBroadcaster, err := apputil.CreateEventBroadcaster(clients.RESTConfig)
+	if err != nil {
+		return err
+	}
+	eventRecorder := eventBroadcaster.NewRecorder(clients.Scheme, corev1.EventSource{Component: "longhorn-volume-manager"})
+
+	m := manager.NewVolumeManager(currentNodeID, clients.Datastore, proxyConnCounter, clients.MetricsClient, eventRecorder)
 
 	metricscollector.InitMetricsCollectorSystem(logger, currentNodeID, clients.Datastore, kubeconfigPath, proxyConnCounter)
 
@@ -367,6 +388,13 @@ func startManager(c *cli.Context) error {
 		}
 	}()
 
+	if apiTLSPort := c.Int(FlagAPITLSPort); apiTLSPort != 0 {
+		if err := startAPITLSServer(ctx, clients, apiTLSPort, router, c.StringSlice(FlagTLSSANs)); err != nil {
+			return err
+		}
+		logger.Infof("Listening on port %v over HTTPS", apiTLSPort)
+	}
+
 	go func() {
 		debugAddress := "127.0.0.1:6060"
 		debugHandler := http.DefaultServeMux
@@ -380,6 +408,23 @@ func startManager(c *cli.Context) error {
 	return nil
 }
 
+// startAPITLSServer serves the API over HTTPS, using the user-provided certificate in the static TLS secret
+// if it exists, otherwise a self-signed certificate for the API service and the extra SANs.
+func startAPITLSServer(ctx context.Context, clients *client.Clients, port int, handler http.Handler, extraSANs []string) error {
+	sans := append([]string{
+		types.APIServiceName,
+		fmt.Sprintf("%s.%s.svc", types.APIServiceName, clients.Namespace),
+	}, extraSANs...)
+
+	if err := webhookserver.ListenAndServeTLS(ctx, port, handler, clients.Core.Secret(), clients.Namespace, types.APICertName,
+		sans, dynamiclistener.OnlyAllow(types.APIServiceName)); err != nil {
+		return errors.Wrap(err, "failed to start API server over HTTPS")
+	}
+
+	// Start the secret controller watching the certificate secrets
+	return clients.Start(ctx)
+}
+
 func environmentCheck() error {
 	// Here we only check if the necessary tool the iscsiadm is installed when Longhorn starts up.
 	// Others tools and settings like kernel versions, multipathd, nfs client, etc. are checked in the node controller (every 30 sec).
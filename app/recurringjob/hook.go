@@ -0,0 +1,61 @@
+package recurringjob
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/constant"
+	"github.com/longhorn/longhorn-manager/util/backuphook"
+
+	longhornclient "github.com/longhorn/longhorn-manager/client"
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+// getBackupHooks returns the backup hooks of the running pods using the volume.
+func (job *VolumeJob) getBackupHooks(volume *longhornclient.Volume) ([]*backuphook.Hook, error) {
+	kubeStatus := longhorn.KubernetesStatus{
+		Namespace:    volume.KubernetesStatus.Namespace,
+		LastPodRefAt: volume.KubernetesStatus.LastPodRefAt,
+	}
+	for _, workload := range volume.KubernetesStatus.WorkloadsStatus {
+		kubeStatus.WorkloadsStatus = append(kubeStatus.WorkloadsStatus, longhorn.WorkloadStatus{
+			PodName:   workload.PodName,
+			PodStatus: workload.PodStatus,
+		})
+	}
+	return backuphook.GetHooks(kubeStatus, job.getPod)
+}
+
+func (job *VolumeJob) getPod(namespace, name string) (*corev1.Pod, error) {
+	pod, err := job.kubeClient.CoreV1().Pods(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return pod, nil
+}
+
+// runPreBackupHooks runs the pre-backup commands and returns the hooks whose post-backup commands should run
+// once the snapshot is taken.
+func (job *VolumeJob) runPreBackupHooks(hooks []*backuphook.Hook) ([]*backuphook.Hook, error) {
+	return backuphook.RunPreHooks(job.kubeConfig, job.kubeClient, job.logger, hooks, job.recordBackupHookFailure)
+}
+
+// runPostBackupHooks runs the post-backup commands of all the hooks.
+func (job *VolumeJob) runPostBackupHooks(hooks []*backuphook.Hook) {
+	backuphook.RunPostHooks(job.kubeConfig, job.kubeClient, job.logger, hooks, job.recordBackupHookFailure)
+}
+
+func (job *VolumeJob) recordBackupHookFailure(err error) {
+	job.logger.WithError(err).Warn("Failed to run backup hook")
+	if eventErr := job.eventCreate(corev1.EventTypeWarning, constant.EventReasonFailedBackupHook, errors.Wrapf(err, "volume %v", job.volumeName).Error()); eventErr != nil {
+		job.logger.WithError(eventErr).Warn("failed to create an event log")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
 	corev1 "k8s.io/api/core/v1"
@@ -51,6 +52,10 @@ func NewJob(name string, logger *logrus.Logger, managerURL string, recurringJob
 	if err != nil {
 		return nil, err
 	}
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get k8s client")
+	}
 
 	parameters := map[string]string{}
 	if recurringJob.Spec.Parameters != nil {
@@ -61,6 +66,9 @@ func NewJob(name string, logger *logrus.Logger, managerURL string, recurringJob
 		api:      apiClient,
 		lhClient: lhClient,
 
+		kubeClient: kubeClient,
+		kubeConfig: config,
+
 		eventRecorder: eventBroadcaster.NewRecorder(scheme, corev1.EventSource{Component: "longhorn-recurring-job"}),
 		logger:        logger,
 
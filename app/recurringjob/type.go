@@ -5,6 +5,8 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 
 	longhornclient "github.com/longhorn/longhorn-manager/client"
@@ -17,6 +19,9 @@ type Job struct {
 	api      *longhornclient.RancherClient // Rancher client used to interact with the Longhorn API.
 	lhClient *lhclientset.Clientset        // Kubernetes clientset for Longhorn resources.
 
+	kubeClient kubernetes.Interface // Kubernetes clientset used to run the backup hooks in the workload pods.
+	kubeConfig *rest.Config         // Kubernetes client config used to run the backup hooks in the workload pods.
+
 	eventRecorder record.EventRecorder // Used to record events related to the job.
 	logger        *logrus.Logger       // Log messages related to the job.
 
@@ -443,7 +443,7 @@ func (job *VolumeJob) doRecurringBackup() (err error) {
 		return errors.Wrapf(err, "could not get volume %v", job.volumeName)
 	}
 
-	if err := job.doSnapshot(); err != nil {
+	if err := job.doBackupSnapshot(volume); err != nil {
 		return err
 	}
 
@@ -543,6 +543,23 @@ func (job *VolumeJob) doRecurringBackup() (err error) {
 	return nil
 }
 
+// doBackupSnapshot takes the snapshot feeding the backup between the pre-backup and post-backup hooks
+// of the pods using the volume.
+func (job *VolumeJob) doBackupSnapshot(volume *longhornclient.Volume) error {
+	hooks, err := job.getBackupHooks(volume)
+	if err != nil {
+		return err
+	}
+
+	executed, err := job.runPreBackupHooks(hooks)
+	defer job.runPostBackupHooks(executed)
+	if err != nil {
+		return err
+	}
+
+	return job.doSnapshot()
+}
+
 func (job *VolumeJob) getBackupVolume(backupTargetName string) (*longhornclient.BackupVolume, error) {
 	list, err := job.api.BackupVolume.List(&longhornclient.ListOpts{})
 	if err != nil {
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gorilla/websocket"
@@ -14,6 +15,7 @@ type RancherBaseClientImpl struct {
 
 type RancherBaseClient interface {
 	Websocket(string, map[string][]string) (*websocket.Conn, *http.Response, error)
+	Watch(context.Context, string) <-chan WatchEvent
 	List(string, *ListOpts, interface{}) error
 	Post(string, interface{}, interface{}) error
 	GetLink(Resource, string, interface{}) error
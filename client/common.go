@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -22,9 +23,10 @@ const (
 )
 
 var (
-	debug             = false
-	dialer            = &websocket.Dialer{}
-	privateFieldRegex = regexp.MustCompile("^[[:lower:]]")
+	debug                = false
+	defaultRetryInterval = time.Second
+	dialer               = &websocket.Dialer{}
+	privateFieldRegex    = regexp.MustCompile("^[[:lower:]]")
 )
 
 type ClientOpts struct {
@@ -32,6 +34,13 @@ type ClientOpts struct {
 	AccessKey string
 	SecretKey string
 	Timeout   time.Duration
+
+	// Retries is the number of times a request is retried when the server cannot be reached or is
+	// temporarily unavailable. POST requests, which are not idempotent, are only retried if they
+	// have not been sent yet or the server rejected them. Requests are not retried by default.
+	Retries int
+	// RetryInterval is the wait before the first retry, which doubles for every following retry.
+	RetryInterval time.Duration
 }
 
 type ApiError struct {
@@ -241,16 +250,85 @@ func (rancherClient *RancherBaseClientImpl) newHttpClient() *http.Client {
 	return &http.Client{Timeout: rancherClient.Opts.Timeout}
 }
 
-func (rancherClient *RancherBaseClientImpl) doDelete(url string) error {
+// doRequest sends the request and retries it up to Opts.Retries times if the server cannot be reached
+// or responds that it is temporarily unavailable, see isRetryable. The response of the last attempt
+// is returned.
+func (rancherClient *RancherBaseClientImpl) doRequest(method string, url string, body []byte) (*http.Response, error) {
 	client := rancherClient.newHttpClient()
-	req, err := http.NewRequest("DELETE", url, nil)
+	interval := rancherClient.Opts.RetryInterval
+	if interval == 0 {
+		interval = defaultRetryInterval
+	}
+
+	for attempt := 0; ; attempt++ {
+		var input io.Reader
+		if body != nil {
+			input = bytes.NewReader(body)
+		}
+		req, err := http.NewRequest(method, url, input)
+		if err != nil {
+			return nil, err
+		}
+
+		rancherClient.setupRequest(req)
+		if method == "POST" || method == "PUT" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := client.Do(req)
+		if attempt >= rancherClient.Opts.Retries || !isRetryable(method, resp, err) {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			if closeErr := resp.Body.Close(); closeErr != nil && debug {
+				fmt.Printf("Warning: failed to close retried response body: %v\n", closeErr)
+			}
+		}
+		if debug {
+			fmt.Printf("Retrying %s %s in %v\n", method, url, interval)
+		}
+		time.Sleep(interval)
+		interval *= 2
+	}
+}
+
+// isRetryable returns whether the request can be sent again. A request that may have reached the
+// server is only retried if the method is idempotent, otherwise an action could be run twice.
+func isRetryable(method string, resp *http.Response, err error) bool {
 	if err != nil {
-		return err
+		return isIdempotent(method) || isDialError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		// The server refused to handle the request.
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		// The request may have been forwarded to the server before the proxy gave up.
+		return isIdempotent(method)
 	}
+	return false
+}
 
-	rancherClient.setupRequest(req)
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
 
-	resp, err := client.Do(req)
+// isDialError returns whether the request failed before the connection to the server was
+// established, so the server cannot have received it.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func (rancherClient *RancherBaseClientImpl) doDelete(url string) error {
+	resp, err := rancherClient.doRequest("DELETE", url, nil)
 	if err != nil {
 		return err
 	}
@@ -273,7 +351,7 @@ func (rancherClient *RancherBaseClientImpl) doDelete(url string) error {
 
 func (rancherClient *RancherBaseClientImpl) Websocket(url string, headers map[string][]string) (*websocket.Conn, *http.Response, error) {
 	httpHeaders := http.Header{}
-	for k, v := range httpHeaders {
+	for k, v := range headers {
 		httpHeaders[k] = v
 	}
 
@@ -298,15 +376,7 @@ func (rancherClient *RancherBaseClientImpl) doGet(url string, opts *ListOpts, re
 		fmt.Println("GET " + url)
 	}
 
-	client := rancherClient.newHttpClient()
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return err
-	}
-
-	rancherClient.setupRequest(req)
-
-	resp, err := client.Do(req)
+	resp, err := rancherClient.doRequest("GET", url, nil)
 	if err != nil {
 		return err
 	}
@@ -387,16 +457,7 @@ func (rancherClient *RancherBaseClientImpl) doModify(method string, url string,
 		fmt.Println("Request => " + string(bodyContent))
 	}
 
-	client := rancherClient.newHttpClient()
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(bodyContent))
-	if err != nil {
-		return err
-	}
-
-	rancherClient.setupRequest(req)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(req)
+	resp, err := rancherClient.doRequest(method, url, bodyContent)
 	if err != nil {
 		return err
 	}
@@ -572,30 +633,20 @@ func (rancherClient *RancherBaseClientImpl) doAction(schemaType string, action s
 		return errors.New("Unknown schema type [" + schemaType + "]")
 	}
 
-	var input io.Reader
+	var bodyContent []byte
 
 	if inputObject != nil {
-		bodyContent, err := json.Marshal(inputObject)
+		var err error
+		bodyContent, err = json.Marshal(inputObject)
 		if err != nil {
 			return err
 		}
 		if debug {
 			fmt.Println("Request => " + string(bodyContent))
 		}
-		input = bytes.NewBuffer(bodyContent)
 	}
 
-	client := rancherClient.newHttpClient()
-	req, err := http.NewRequest("POST", actionUrl, input)
-	if err != nil {
-		return err
-	}
-
-	rancherClient.setupRequest(req)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Content-Length", "0")
-
-	resp, err := client.Do(req)
+	resp, err := rancherClient.doRequest("POST", actionUrl, bodyContent)
 	if err != nil {
 		return err
 	}
@@ -615,6 +666,10 @@ func (rancherClient *RancherBaseClientImpl) doAction(schemaType string, action s
 		return err
 	}
 
+	if len(byteContent) == 0 {
+		return nil
+	}
+
 	if debug {
 		fmt.Println("Response <= " + string(byteContent))
 	}
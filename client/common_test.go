@@ -0,0 +1,135 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryable(t *testing.T) {
+	assert := assert.New(t)
+
+	dialErr := &url.Error{Op: "Post", URL: "http://longhorn-backend:9500", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	readErr := &url.Error{Op: "Post", URL: "http://longhorn-backend:9500", Err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}}
+
+	tests := map[string]struct {
+		method     string
+		statusCode int
+		err        error
+		expected   bool
+	}{
+		"getWithDialError":        {method: http.MethodGet, err: dialErr, expected: true},
+		"getWithReadError":        {method: http.MethodGet, err: readErr, expected: true},
+		"postWithDialError":       {method: http.MethodPost, err: dialErr, expected: true},
+		"postWithReadError":       {method: http.MethodPost, err: readErr, expected: false},
+		"postWithTimeout":         {method: http.MethodPost, err: errors.New("context deadline exceeded"), expected: false},
+		"deleteWithReadError":     {method: http.MethodDelete, err: readErr, expected: true},
+		"getOK":                   {method: http.MethodGet, statusCode: http.StatusOK, expected: false},
+		"getNotFound":             {method: http.MethodGet, statusCode: http.StatusNotFound, expected: false},
+		"getServiceUnavailable":   {method: http.MethodGet, statusCode: http.StatusServiceUnavailable, expected: true},
+		"getBadGateway":           {method: http.MethodGet, statusCode: http.StatusBadGateway, expected: true},
+		"postTooManyRequests":     {method: http.MethodPost, statusCode: http.StatusTooManyRequests, expected: true},
+		"postServiceUnavailable":  {method: http.MethodPost, statusCode: http.StatusServiceUnavailable, expected: true},
+		"postBadGateway":          {method: http.MethodPost, statusCode: http.StatusBadGateway, expected: false},
+		"postGatewayTimeout":      {method: http.MethodPost, statusCode: http.StatusGatewayTimeout, expected: false},
+		"putGatewayTimeout":       {method: http.MethodPut, statusCode: http.StatusGatewayTimeout, expected: true},
+		"postInternalServerError": {method: http.MethodPost, statusCode: http.StatusInternalServerError, expected: false},
+		"getInternalServerError":  {method: http.MethodGet, statusCode: http.StatusInternalServerError, expected: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var resp *http.Response
+			if tt.err == nil {
+				resp = &http.Response{StatusCode: tt.statusCode}
+			}
+			assert.Equal(tt.expected, isRetryable(tt.method, resp, tt.err))
+		})
+	}
+}
+
+func TestDoRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[string]struct {
+		method             string
+		retries            int
+		statusCodes        []int
+		expectedAttempts   int32
+		expectedStatusCode int
+	}{
+		"notRetriedByDefault": {
+			method:             http.MethodGet,
+			statusCodes:        []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedAttempts:   1,
+			expectedStatusCode: http.StatusServiceUnavailable,
+		},
+		"retriedUntilSucceeded": {
+			method:             http.MethodGet,
+			retries:            3,
+			statusCodes:        []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectedAttempts:   3,
+			expectedStatusCode: http.StatusOK,
+		},
+		"retriedUpToRetries": {
+			method:             http.MethodPut,
+			retries:            2,
+			statusCodes:        []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			expectedAttempts:   3,
+			expectedStatusCode: http.StatusServiceUnavailable,
+		},
+		"postRetriedWhenRejected": {
+			method:             http.MethodPost,
+			retries:            3,
+			statusCodes:        []int{http.StatusTooManyRequests, http.StatusCreated},
+			expectedAttempts:   2,
+			expectedStatusCode: http.StatusCreated,
+		},
+		"postNotRetriedWhenMaybeHandled": {
+			method:             http.MethodPost,
+			retries:            3,
+			statusCodes:        []int{http.StatusGatewayTimeout, http.StatusCreated},
+			expectedAttempts:   1,
+			expectedStatusCode: http.StatusGatewayTimeout,
+		},
+		"clientErrorNotRetried": {
+			method:             http.MethodGet,
+			retries:            3,
+			statusCodes:        []int{http.StatusNotFound, http.StatusOK},
+			expectedAttempts:   1,
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+				assert.Equal(tt.method, r.Method)
+				w.WriteHeader(tt.statusCodes[attempt-1])
+			}))
+			defer server.Close()
+
+			rancherClient := &RancherBaseClientImpl{
+				Opts: &ClientOpts{
+					Url:           server.URL,
+					Retries:       tt.retries,
+					RetryInterval: time.Millisecond,
+				},
+			}
+			resp, err := rancherClient.doRequest(tt.method, server.URL, []byte("{}"))
+			assert.NoError(err)
+			defer resp.Body.Close()
+			assert.Equal(tt.expectedStatusCode, resp.StatusCode)
+			assert.Equal(tt.expectedAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
@@ -9,6 +9,8 @@ type AttachInput struct {
 
 	AttachedBy string `json:"attachedBy,omitempty" yaml:"attached_by,omitempty"`
 
+	AttacherID string `json:"attacherID,omitempty" yaml:"attacher_id,omitempty"`
+
 	AttacherType string `json:"attacherType,omitempty" yaml:"attacher_type,omitempty"`
 
 	AttachmentID string `json:"attachmentID,omitempty" yaml:"attachment_id,omitempty"`
@@ -7,6 +7,8 @@ const (
 type Attachment struct {
 	Resource `yaml:"-"`
 
+	AttacherID string `json:"attacherID,omitempty" yaml:"attacher_id,omitempty"`
+
 	AttachmentID string `json:"attachmentID,omitempty" yaml:"attachment_id,omitempty"`
 
 	AttachmentType string `json:"attachmentType,omitempty" yaml:"attachment_type,omitempty"`
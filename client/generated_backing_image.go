@@ -58,6 +58,8 @@ type BackingImageOperations interface {
 	ActionBackingImageCleanup(*BackingImage, *BackingImageCleanupInput) (*BackingImage, error)
 
 	ActionUpdateMinNumberOfCopies(*BackingImage, *UpdateMinNumberOfCopiesInput) (*BackingImage, error)
+
+	ActionBackupBackingImageCreate(*BackingImage, *BackupBackingImage) (*BackingImage, error)
 }
 
 func newBackingImageClient(rancherClient *RancherClient) *BackingImageClient {
@@ -127,3 +129,12 @@ func (c *BackingImageClient) ActionUpdateMinNumberOfCopies(resource *BackingImag
 
 	return resp, err
 }
+
+func (c *BackingImageClient) ActionBackupBackingImageCreate(resource *BackingImage, input *BackupBackingImage) (*BackingImage, error) {
+
+	resp := &BackingImage{}
+
+	err := c.rancherClient.doAction(BACKING_IMAGE_TYPE, "backupBackingImageCreate", &resource.Resource, input, resp)
+
+	return resp, err
+}
@@ -23,6 +23,12 @@ type Backup struct {
 
 	Progress int64 `json:"progress,omitempty" yaml:"progress,omitempty"`
 
+	ReplicationBackupName string `json:"replicationBackupName,omitempty" yaml:"replication_backup_name,omitempty"`
+
+	ReplicationState string `json:"replicationState,omitempty" yaml:"replication_state,omitempty"`
+
+	ReplicationTargetName string `json:"replicationTargetName,omitempty" yaml:"replication_target_name,omitempty"`
+
 	Size string `json:"size,omitempty" yaml:"size,omitempty"`
 
 	SnapshotCreated string `json:"snapshotCreated,omitempty" yaml:"snapshot_created,omitempty"`
@@ -48,6 +48,8 @@ type BackupBackingImageOperations interface {
 	Update(existing *BackupBackingImage, updates interface{}) (*BackupBackingImage, error)
 	ById(id string) (*BackupBackingImage, error)
 	Delete(container *BackupBackingImage) error
+
+	ActionBackupBackingImageRestore(*BackupBackingImage, *BackingImageRestoreInput) (*BackupBackingImage, error)
 }
 
 func newBackupBackingImageClient(rancherClient *RancherClient) *BackupBackingImageClient {
@@ -99,3 +101,12 @@ func (c *BackupBackingImageClient) ById(id string) (*BackupBackingImage, error)
 func (c *BackupBackingImageClient) Delete(container *BackupBackingImage) error {
 	return c.rancherClient.doResourceDelete(BACKUP_BACKING_IMAGE_TYPE, &container.Resource)
 }
+
+func (c *BackupBackingImageClient) ActionBackupBackingImageRestore(resource *BackupBackingImage, input *BackingImageRestoreInput) (*BackupBackingImage, error) {
+
+	resp := &BackupBackingImage{}
+
+	err := c.rancherClient.doAction(BACKUP_BACKING_IMAGE_TYPE, "backupBackingImageRestore", &resource.Resource, input, resp)
+
+	return resp, err
+}
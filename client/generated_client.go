@@ -3,81 +3,100 @@ package client
 type RancherClient struct {
 	RancherBaseClient
 
-	ApiVersion                             ApiVersionOperations
-	Error                                  ErrorOperations
-	AttachInput                            AttachInputOperations
-	DetachInput                            DetachInputOperations
-	SnapshotInput                          SnapshotInputOperations
-	SnapshotCRInput                        SnapshotCRInputOperations
-	BackupTarget                           BackupTargetOperations
-	Backup                                 BackupOperations
-	BackupInput                            BackupInputOperations
-	BackupStatus                           BackupStatusOperations
-	Orphan                                 OrphanOperations
-	RestoreStatus                          RestoreStatusOperations
-	PurgeStatus                            PurgeStatusOperations
-	RebuildStatus                          RebuildStatusOperations
-	ReplicaRemoveInput                     ReplicaRemoveInputOperations
-	SalvageInput                           SalvageInputOperations
-	ActivateInput                          ActivateInputOperations
-	ExpandInput                            ExpandInputOperations
-	EngineUpgradeInput                     EngineUpgradeInputOperations
-	Replica                                ReplicaOperations
-	Controller                             ControllerOperations
-	DiskUpdate                             DiskUpdateOperations
-	UpdateReplicaCountInput                UpdateReplicaCountInputOperations
-	UpdateReplicaAutoBalanceInput          UpdateReplicaAutoBalanceInputOperations
-	UpdateDataLocalityInput                UpdateDataLocalityInputOperations
-	UpdateAccessModeInput                  UpdateAccessModeInputOperations
-	UpdateSnapshotDataIntegrityInput       UpdateSnapshotDataIntegrityInputOperations
-	UpdateSnapshotMaxCountInput            UpdateSnapshotMaxCountInputOperations
-	UpdateSnapshotMaxSizeInput             UpdateSnapshotMaxSizeInputOperations
-	UpdateBackupCompressionInput           UpdateBackupCompressionInputOperations
-	UpdateUnmapMarkSnapChainRemovedInput   UpdateUnmapMarkSnapChainRemovedInputOperations
-	UpdateReplicaSoftAntiAffinityInput     UpdateReplicaSoftAntiAffinityInputOperations
-	UpdateReplicaZoneSoftAntiAffinityInput UpdateReplicaZoneSoftAntiAffinityInputOperations
-	UpdateReplicaDiskSoftAntiAffinityInput UpdateReplicaDiskSoftAntiAffinityInputOperations
-	UpdateFreezeFSForSnapshotInput         UpdateFreezeFSForSnapshotInputOperations
-	WorkloadStatus                         WorkloadStatusOperations
-	CloneStatus                            CloneStatusOperations
-	Empty                                  EmptyOperations
-	VolumeRecurringJob                     VolumeRecurringJobOperations
-	VolumeRecurringJobInput                VolumeRecurringJobInputOperations
-	PVCreateInput                          PVCreateInputOperations
-	PVCCreateInput                         PVCCreateInputOperations
-	SettingDefinition                      SettingDefinitionOperations
-	VolumeCondition                        VolumeConditionOperations
-	NodeCondition                          NodeConditionOperations
-	DiskCondition                          DiskConditionOperations
-	LonghornCondition                      LonghornConditionOperations
-	SupportBundle                          SupportBundleOperations
-	SupportBundleInitateInput              SupportBundleInitateInputOperations
-	Tag                                    TagOperations
-	InstanceManager                        InstanceManagerOperations
-	BackingImageDiskFileStatus             BackingImageDiskFileStatusOperations
-	BackingImageCleanupInput               BackingImageCleanupInputOperations
-	BackingImageRestoreInput               BackingImageRestoreInputOperations
-	UpdateMinNumberOfCopiesInput           UpdateMinNumberOfCopiesInputOperations
-	Attachment                             AttachmentOperations
-	VolumeAttachment                       VolumeAttachmentOperations
-	Volume                                 VolumeOperations
-	Snapshot                               SnapshotOperations
-	SnapshotCR                             SnapshotCROperations
-	BackupVolume                           BackupVolumeOperations
-	BackupBackingImage                     BackupBackingImageOperations
-	Setting                                SettingOperations
-	RecurringJob                           RecurringJobOperations
-	EngineImage                            EngineImageOperations
-	BackingImage                           BackingImageOperations
-	Node                                   NodeOperations
-	DiskUpdateInput                        DiskUpdateInputOperations
-	DiskInfo                               DiskInfoOperations
-	KubernetesStatus                       KubernetesStatusOperations
-	BackupListOutput                       BackupListOutputOperations
-	SnapshotListOutput                     SnapshotListOutputOperations
-	SystemBackup                           SystemBackupOperations
-	SystemRestore                          SystemRestoreOperations
-	SnapshotCRListOutput                   SnapshotCRListOutputOperations
+	ApiVersion                              ApiVersionOperations
+	Error                                   ErrorOperations
+	AttachInput                             AttachInputOperations
+	DetachInput                             DetachInputOperations
+	SnapshotInput                           SnapshotInputOperations
+	SnapshotCRInput                         SnapshotCRInputOperations
+	BackupTarget                            BackupTargetOperations
+	Backup                                  BackupOperations
+	BackupInput                             BackupInputOperations
+	BackupStatus                            BackupStatusOperations
+	Orphan                                  OrphanOperations
+	RestoreStatus                           RestoreStatusOperations
+	PurgeStatus                             PurgeStatusOperations
+	RebuildStatus                           RebuildStatusOperations
+	ReplicaRemoveInput                      ReplicaRemoveInputOperations
+	SalvageInput                            SalvageInputOperations
+	ActivateInput                           ActivateInputOperations
+	ExpandInput                             ExpandInputOperations
+	EngineUpgradeInput                      EngineUpgradeInputOperations
+	Replica                                 ReplicaOperations
+	Controller                              ControllerOperations
+	DiskUpdate                              DiskUpdateOperations
+	UpdateReplicaCountInput                 UpdateReplicaCountInputOperations
+	UpdateReplicaAutoBalanceInput           UpdateReplicaAutoBalanceInputOperations
+	UpdateDataLocalityInput                 UpdateDataLocalityInputOperations
+	UpdateAccessModeInput                   UpdateAccessModeInputOperations
+	UpdateSnapshotDataIntegrityInput        UpdateSnapshotDataIntegrityInputOperations
+	UpdateSnapshotDataIntegrityCronJobInput UpdateSnapshotDataIntegrityCronJobInputOperations
+	UpdateSnapshotMaxCountInput             UpdateSnapshotMaxCountInputOperations
+	UpdateSnapshotMaxSizeInput              UpdateSnapshotMaxSizeInputOperations
+	UpdateBackupCompressionInput            UpdateBackupCompressionInputOperations
+	UpdateUnmapMarkSnapChainRemovedInput    UpdateUnmapMarkSnapChainRemovedInputOperations
+	UpdateReplicaSoftAntiAffinityInput      UpdateReplicaSoftAntiAffinityInputOperations
+	UpdateReplicaZoneSoftAntiAffinityInput  UpdateReplicaZoneSoftAntiAffinityInputOperations
+	UpdateReplicaDiskSoftAntiAffinityInput  UpdateReplicaDiskSoftAntiAffinityInputOperations
+	UpdateFreezeFSForSnapshotInput          UpdateFreezeFSForSnapshotInputOperations
+	UpdateReplicaSelectorRelocationInput    UpdateReplicaSelectorRelocationInputOperations
+	UpdateBackupTargetInput                 UpdateBackupTargetInputOperations
+	UpdateOfflineRebuildingInput            UpdateOfflineRebuildingInputOperations
+	WorkloadStatus                          WorkloadStatusOperations
+	CloneStatus                             CloneStatusOperations
+	Empty                                   EmptyOperations
+	VolumeRecurringJob                      VolumeRecurringJobOperations
+	VolumeRecurringJobInput                 VolumeRecurringJobInputOperations
+	PVCreateInput                           PVCreateInputOperations
+	PVCCreateInput                          PVCCreateInputOperations
+	SettingDefinition                       SettingDefinitionOperations
+	VolumeCondition                         VolumeConditionOperations
+	NodeCondition                           NodeConditionOperations
+	DiskCondition                           DiskConditionOperations
+	LonghornCondition                       LonghornConditionOperations
+	SupportBundle                           SupportBundleOperations
+	SupportBundleInitateInput               SupportBundleInitateInputOperations
+	Tag                                     TagOperations
+	InstanceManager                         InstanceManagerOperations
+	InstanceManagerDetail                   InstanceManagerDetailOperations
+	InstanceManagerProcess                  InstanceManagerProcessOperations
+	BackingImageDiskFileStatus              BackingImageDiskFileStatusOperations
+	BackingImageCleanupInput                BackingImageCleanupInputOperations
+	BackingImageRestoreInput                BackingImageRestoreInputOperations
+	UpgradeFreezeReport                     UpgradeFreezeReportOperations
+	EngineUpgradePreCheck                   EngineUpgradePreCheckOperations
+	EngineUpgradePreCheckFailure            EngineUpgradePreCheckFailureOperations
+	Event                                   EventOperations
+	InstanceProcess                         InstanceProcessOperations
+	BackupTargetListOutput                  BackupTargetListOutputOperations
+	BackupVolumeListOutput                  BackupVolumeListOutputOperations
+	SyncBackupResource                      SyncBackupResourceOperations
+	RebuildQueueEntry                       RebuildQueueEntryOperations
+	UpdateMinNumberOfCopiesInput            UpdateMinNumberOfCopiesInputOperations
+	Attachment                              AttachmentOperations
+	VolumeAttachment                        VolumeAttachmentOperations
+	Volume                                  VolumeOperations
+	Snapshot                                SnapshotOperations
+	SnapshotCR                              SnapshotCROperations
+	BackupVolume                            BackupVolumeOperations
+	BackupBackingImage                      BackupBackingImageOperations
+	Setting                                 SettingOperations
+	RecurringJob                            RecurringJobOperations
+	CloneSchedule                           CloneScheduleOperations
+	RestoreTest                             RestoreTestOperations
+	ReplicaCountPolicy                      ReplicaCountPolicyOperations
+	ReplicaCountPolicyDecision              ReplicaCountPolicyDecisionOperations
+	EngineImage                             EngineImageOperations
+	BackingImage                            BackingImageOperations
+	Node                                    NodeOperations
+	DiskUpdateInput                         DiskUpdateInputOperations
+	DiskInfo                                DiskInfoOperations
+	KubernetesStatus                        KubernetesStatusOperations
+	BackupListOutput                        BackupListOutputOperations
+	SnapshotListOutput                      SnapshotListOutputOperations
+	SystemBackup                            SystemBackupOperations
+	SystemRestore                           SystemRestoreOperations
+	SnapshotCRListOutput                    SnapshotCRListOutputOperations
 }
 
 func constructClient(rancherBaseClient *RancherBaseClientImpl) *RancherClient {
@@ -112,6 +131,7 @@ func constructClient(rancherBaseClient *RancherBaseClientImpl) *RancherClient {
 	client.UpdateDataLocalityInput = newUpdateDataLocalityInputClient(client)
 	client.UpdateAccessModeInput = newUpdateAccessModeInputClient(client)
 	client.UpdateSnapshotDataIntegrityInput = newUpdateSnapshotDataIntegrityInputClient(client)
+	client.UpdateSnapshotDataIntegrityCronJobInput = newUpdateSnapshotDataIntegrityCronJobInputClient(client)
 	client.UpdateSnapshotMaxCountInput = newUpdateSnapshotMaxCountInputClient(client)
 	client.UpdateSnapshotMaxSizeInput = newUpdateSnapshotMaxSizeInputClient(client)
 	client.UpdateBackupCompressionInput = newUpdateBackupCompressionInputClient(client)
@@ -120,6 +140,9 @@ func constructClient(rancherBaseClient *RancherBaseClientImpl) *RancherClient {
 	client.UpdateReplicaZoneSoftAntiAffinityInput = newUpdateReplicaZoneSoftAntiAffinityInputClient(client)
 	client.UpdateReplicaDiskSoftAntiAffinityInput = newUpdateReplicaDiskSoftAntiAffinityInputClient(client)
 	client.UpdateFreezeFSForSnapshotInput = newUpdateFreezeFSForSnapshotInputClient(client)
+	client.UpdateReplicaSelectorRelocationInput = newUpdateReplicaSelectorRelocationInputClient(client)
+	client.UpdateBackupTargetInput = newUpdateBackupTargetInputClient(client)
+	client.UpdateOfflineRebuildingInput = newUpdateOfflineRebuildingInputClient(client)
 	client.WorkloadStatus = newWorkloadStatusClient(client)
 	client.CloneStatus = newCloneStatusClient(client)
 	client.Empty = newEmptyClient(client)
@@ -136,10 +159,21 @@ func constructClient(rancherBaseClient *RancherBaseClientImpl) *RancherClient {
 	client.SupportBundleInitateInput = newSupportBundleInitateInputClient(client)
 	client.Tag = newTagClient(client)
 	client.InstanceManager = newInstanceManagerClient(client)
+	client.InstanceManagerDetail = newInstanceManagerDetailClient(client)
+	client.InstanceManagerProcess = newInstanceManagerProcessClient(client)
 	client.BackingImageDiskFileStatus = newBackingImageDiskFileStatusClient(client)
 	client.BackingImageCleanupInput = newBackingImageCleanupInputClient(client)
 	client.UpdateMinNumberOfCopiesInput = newUpdateMinNumberOfCopiesInputClient(client)
 	client.BackingImageRestoreInput = newBackingImageRestoreInputClient(client)
+	client.UpgradeFreezeReport = newUpgradeFreezeReportClient(client)
+	client.EngineUpgradePreCheck = newEngineUpgradePreCheckClient(client)
+	client.EngineUpgradePreCheckFailure = newEngineUpgradePreCheckFailureClient(client)
+	client.Event = newEventClient(client)
+	client.InstanceProcess = newInstanceProcessClient(client)
+	client.BackupTargetListOutput = newBackupTargetListOutputClient(client)
+	client.BackupVolumeListOutput = newBackupVolumeListOutputClient(client)
+	client.SyncBackupResource = newSyncBackupResourceClient(client)
+	client.RebuildQueueEntry = newRebuildQueueEntryClient(client)
 	client.Attachment = newAttachmentClient(client)
 	client.VolumeAttachment = newVolumeAttachmentClient(client)
 	client.Volume = newVolumeClient(client)
@@ -149,6 +183,10 @@ func constructClient(rancherBaseClient *RancherBaseClientImpl) *RancherClient {
 	client.BackupBackingImage = newBackupBackingImageClient(client)
 	client.Setting = newSettingClient(client)
 	client.RecurringJob = newRecurringJobClient(client)
+	client.CloneSchedule = newCloneScheduleClient(client)
+	client.RestoreTest = newRestoreTestClient(client)
+	client.ReplicaCountPolicy = newReplicaCountPolicyClient(client)
+	client.ReplicaCountPolicyDecision = newReplicaCountPolicyDecisionClient(client)
 	client.EngineImage = newEngineImageClient(client)
 	client.BackingImage = newBackingImageClient(client)
 	client.Node = newNodeClient(client)
@@ -0,0 +1,99 @@
+package client
+
+const (
+	CLONE_SCHEDULE_TYPE = "cloneSchedule"
+)
+
+type CloneSchedule struct {
+	Resource `yaml:"-"`
+
+	Cron string `json:"cron,omitempty" yaml:"cron,omitempty"`
+
+	LastRefreshSource string `json:"lastRefreshSource,omitempty" yaml:"last_refresh_source,omitempty"`
+
+	LastRefreshTime string `json:"lastRefreshTime,omitempty" yaml:"last_refresh_time,omitempty"`
+
+	LastScheduleTime string `json:"lastScheduleTime,omitempty" yaml:"last_schedule_time,omitempty"`
+
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	SourceType string `json:"sourceType,omitempty" yaml:"source_type,omitempty"`
+
+	SourceVolume string `json:"sourceVolume,omitempty" yaml:"source_volume,omitempty"`
+
+	State string `json:"state,omitempty" yaml:"state,omitempty"`
+
+	Suspend bool `json:"suspend,omitempty" yaml:"suspend,omitempty"`
+
+	TargetVolume string `json:"targetVolume,omitempty" yaml:"target_volume,omitempty"`
+}
+
+type CloneScheduleCollection struct {
+	Collection
+	Data   []CloneSchedule `json:"data,omitempty"`
+	client *CloneScheduleClient
+}
+
+type CloneScheduleClient struct {
+	rancherClient *RancherClient
+}
+
+type CloneScheduleOperations interface {
+	List(opts *ListOpts) (*CloneScheduleCollection, error)
+	Create(opts *CloneSchedule) (*CloneSchedule, error)
+	Update(existing *CloneSchedule, updates interface{}) (*CloneSchedule, error)
+	ById(id string) (*CloneSchedule, error)
+	Delete(container *CloneSchedule) error
+}
+
+func newCloneScheduleClient(rancherClient *RancherClient) *CloneScheduleClient {
+	return &CloneScheduleClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *CloneScheduleClient) Create(container *CloneSchedule) (*CloneSchedule, error) {
+	resp := &CloneSchedule{}
+	err := c.rancherClient.doCreate(CLONE_SCHEDULE_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *CloneScheduleClient) Update(existing *CloneSchedule, updates interface{}) (*CloneSchedule, error) {
+	resp := &CloneSchedule{}
+	err := c.rancherClient.doUpdate(CLONE_SCHEDULE_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *CloneScheduleClient) List(opts *ListOpts) (*CloneScheduleCollection, error) {
+	resp := &CloneScheduleCollection{}
+	err := c.rancherClient.doList(CLONE_SCHEDULE_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *CloneScheduleCollection) Next() (*CloneScheduleCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &CloneScheduleCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *CloneScheduleClient) ById(id string) (*CloneSchedule, error) {
+	resp := &CloneSchedule{}
+	err := c.rancherClient.doById(CLONE_SCHEDULE_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *CloneScheduleClient) Delete(container *CloneSchedule) error {
+	return c.rancherClient.doResourceDelete(CLONE_SCHEDULE_TYPE, &container.Resource)
+}
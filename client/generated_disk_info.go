@@ -15,6 +15,12 @@ type DiskInfo struct {
 
 	DiskUUID string `json:"diskUUID,omitempty" yaml:"disk_uuid,omitempty"`
 
+	Encrypted bool `json:"encrypted,omitempty" yaml:"encrypted,omitempty"`
+
+	EncryptedDevicePath string `json:"encryptedDevicePath,omitempty" yaml:"encrypted_device_path,omitempty"`
+
+	EncryptionSecret string `json:"encryptionSecret,omitempty" yaml:"encryption_secret,omitempty"`
+
 	EvictionRequested bool `json:"evictionRequested,omitempty" yaml:"eviction_requested,omitempty"`
 
 	Path string `json:"path,omitempty" yaml:"path,omitempty"`
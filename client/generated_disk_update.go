@@ -11,6 +11,10 @@ type DiskUpdate struct {
 
 	DiskType string `json:"diskType,omitempty" yaml:"disk_type,omitempty"`
 
+	Encrypted bool `json:"encrypted,omitempty" yaml:"encrypted,omitempty"`
+
+	EncryptionSecret string `json:"encryptionSecret,omitempty" yaml:"encryption_secret,omitempty"`
+
 	EvictionRequested bool `json:"evictionRequested,omitempty" yaml:"eviction_requested,omitempty"`
 
 	Path string `json:"path,omitempty" yaml:"path,omitempty"`
@@ -0,0 +1,81 @@
+package client
+
+const (
+	ENGINE_UPGRADE_PRE_CHECK_TYPE = "engineUpgradePreCheck"
+)
+
+type EngineUpgradePreCheck struct {
+	Resource `yaml:"-"`
+
+	Failures []EngineUpgradePreCheckFailure `json:"failures,omitempty" yaml:"failures,omitempty"`
+
+	Upgradeable bool `json:"upgradeable,omitempty" yaml:"upgradeable,omitempty"`
+}
+
+type EngineUpgradePreCheckCollection struct {
+	Collection
+	Data   []EngineUpgradePreCheck `json:"data,omitempty"`
+	client *EngineUpgradePreCheckClient
+}
+
+type EngineUpgradePreCheckClient struct {
+	rancherClient *RancherClient
+}
+
+type EngineUpgradePreCheckOperations interface {
+	List(opts *ListOpts) (*EngineUpgradePreCheckCollection, error)
+	Create(opts *EngineUpgradePreCheck) (*EngineUpgradePreCheck, error)
+	Update(existing *EngineUpgradePreCheck, updates interface{}) (*EngineUpgradePreCheck, error)
+	ById(id string) (*EngineUpgradePreCheck, error)
+	Delete(container *EngineUpgradePreCheck) error
+}
+
+func newEngineUpgradePreCheckClient(rancherClient *RancherClient) *EngineUpgradePreCheckClient {
+	return &EngineUpgradePreCheckClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *EngineUpgradePreCheckClient) Create(container *EngineUpgradePreCheck) (*EngineUpgradePreCheck, error) {
+	resp := &EngineUpgradePreCheck{}
+	err := c.rancherClient.doCreate(ENGINE_UPGRADE_PRE_CHECK_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *EngineUpgradePreCheckClient) Update(existing *EngineUpgradePreCheck, updates interface{}) (*EngineUpgradePreCheck, error) {
+	resp := &EngineUpgradePreCheck{}
+	err := c.rancherClient.doUpdate(ENGINE_UPGRADE_PRE_CHECK_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *EngineUpgradePreCheckClient) List(opts *ListOpts) (*EngineUpgradePreCheckCollection, error) {
+	resp := &EngineUpgradePreCheckCollection{}
+	err := c.rancherClient.doList(ENGINE_UPGRADE_PRE_CHECK_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *EngineUpgradePreCheckCollection) Next() (*EngineUpgradePreCheckCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &EngineUpgradePreCheckCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *EngineUpgradePreCheckClient) ById(id string) (*EngineUpgradePreCheck, error) {
+	resp := &EngineUpgradePreCheck{}
+	err := c.rancherClient.doById(ENGINE_UPGRADE_PRE_CHECK_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *EngineUpgradePreCheckClient) Delete(container *EngineUpgradePreCheck) error {
+	return c.rancherClient.doResourceDelete(ENGINE_UPGRADE_PRE_CHECK_TYPE, &container.Resource)
+}
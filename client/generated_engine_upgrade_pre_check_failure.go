@@ -0,0 +1,81 @@
+package client
+
+const (
+	ENGINE_UPGRADE_PRE_CHECK_FAILURE_TYPE = "engineUpgradePreCheckFailure"
+)
+
+type EngineUpgradePreCheckFailure struct {
+	Resource `yaml:"-"`
+
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+type EngineUpgradePreCheckFailureCollection struct {
+	Collection
+	Data   []EngineUpgradePreCheckFailure `json:"data,omitempty"`
+	client *EngineUpgradePreCheckFailureClient
+}
+
+type EngineUpgradePreCheckFailureClient struct {
+	rancherClient *RancherClient
+}
+
+type EngineUpgradePreCheckFailureOperations interface {
+	List(opts *ListOpts) (*EngineUpgradePreCheckFailureCollection, error)
+	Create(opts *EngineUpgradePreCheckFailure) (*EngineUpgradePreCheckFailure, error)
+	Update(existing *EngineUpgradePreCheckFailure, updates interface{}) (*EngineUpgradePreCheckFailure, error)
+	ById(id string) (*EngineUpgradePreCheckFailure, error)
+	Delete(container *EngineUpgradePreCheckFailure) error
+}
+
+func newEngineUpgradePreCheckFailureClient(rancherClient *RancherClient) *EngineUpgradePreCheckFailureClient {
+	return &EngineUpgradePreCheckFailureClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *EngineUpgradePreCheckFailureClient) Create(container *EngineUpgradePreCheckFailure) (*EngineUpgradePreCheckFailure, error) {
+	resp := &EngineUpgradePreCheckFailure{}
+	err := c.rancherClient.doCreate(ENGINE_UPGRADE_PRE_CHECK_FAILURE_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *EngineUpgradePreCheckFailureClient) Update(existing *EngineUpgradePreCheckFailure, updates interface{}) (*EngineUpgradePreCheckFailure, error) {
+	resp := &EngineUpgradePreCheckFailure{}
+	err := c.rancherClient.doUpdate(ENGINE_UPGRADE_PRE_CHECK_FAILURE_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *EngineUpgradePreCheckFailureClient) List(opts *ListOpts) (*EngineUpgradePreCheckFailureCollection, error) {
+	resp := &EngineUpgradePreCheckFailureCollection{}
+	err := c.rancherClient.doList(ENGINE_UPGRADE_PRE_CHECK_FAILURE_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *EngineUpgradePreCheckFailureCollection) Next() (*EngineUpgradePreCheckFailureCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &EngineUpgradePreCheckFailureCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *EngineUpgradePreCheckFailureClient) ById(id string) (*EngineUpgradePreCheckFailure, error) {
+	resp := &EngineUpgradePreCheckFailure{}
+	err := c.rancherClient.doById(ENGINE_UPGRADE_PRE_CHECK_FAILURE_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *EngineUpgradePreCheckFailureClient) Delete(container *EngineUpgradePreCheckFailure) error {
+	return c.rancherClient.doResourceDelete(ENGINE_UPGRADE_PRE_CHECK_FAILURE_TYPE, &container.Resource)
+}
@@ -0,0 +1,81 @@
+package client
+
+const (
+	EVENT_TYPE = "event"
+)
+
+type Event struct {
+	Resource `yaml:"-"`
+
+	Event interface{} `json:"event,omitempty" yaml:"event,omitempty"`
+
+	EventType string `json:"eventType,omitempty" yaml:"event_type,omitempty"`
+}
+
+type EventCollection struct {
+	Collection
+	Data   []Event `json:"data,omitempty"`
+	client *EventClient
+}
+
+type EventClient struct {
+	rancherClient *RancherClient
+}
+
+type EventOperations interface {
+	List(opts *ListOpts) (*EventCollection, error)
+	Create(opts *Event) (*Event, error)
+	Update(existing *Event, updates interface{}) (*Event, error)
+	ById(id string) (*Event, error)
+	Delete(container *Event) error
+}
+
+func newEventClient(rancherClient *RancherClient) *EventClient {
+	return &EventClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *EventClient) Create(container *Event) (*Event, error) {
+	resp := &Event{}
+	err := c.rancherClient.doCreate(EVENT_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *EventClient) Update(existing *Event, updates interface{}) (*Event, error) {
+	resp := &Event{}
+	err := c.rancherClient.doUpdate(EVENT_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *EventClient) List(opts *ListOpts) (*EventCollection, error) {
+	resp := &EventCollection{}
+	err := c.rancherClient.doList(EVENT_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *EventCollection) Next() (*EventCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &EventCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *EventClient) ById(id string) (*Event, error) {
+	resp := &Event{}
+	err := c.rancherClient.doById(EVENT_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *EventClient) Delete(container *Event) error {
+	return c.rancherClient.doResourceDelete(EVENT_TYPE, &container.Resource)
+}
@@ -0,0 +1,101 @@
+package client
+
+const (
+	INSTANCE_MANAGER_DETAIL_TYPE = "instanceManagerDetail"
+)
+
+type InstanceManagerDetail struct {
+	Resource `yaml:"-"`
+
+	CurrentState string `json:"currentState,omitempty" yaml:"current_state,omitempty"`
+
+	DataEngine string `json:"dataEngine,omitempty" yaml:"data_engine,omitempty"`
+
+	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+
+	ManagerType string `json:"managerType,omitempty" yaml:"manager_type,omitempty"`
+
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	NodeID string `json:"nodeID,omitempty" yaml:"node_id,omitempty"`
+
+	PodCPUUsage int64 `json:"podCPUUsage,omitempty" yaml:"pod_cpuusage,omitempty"`
+
+	PodMemoryUsage int64 `json:"podMemoryUsage,omitempty" yaml:"pod_memory_usage,omitempty"`
+
+	PodStartTime string `json:"podStartTime,omitempty" yaml:"pod_start_time,omitempty"`
+
+	PodUsageAvailable bool `json:"podUsageAvailable,omitempty" yaml:"pod_usage_available,omitempty"`
+
+	Processes []InstanceManagerProcess `json:"processes,omitempty" yaml:"processes,omitempty"`
+
+	UptimeSeconds int64 `json:"uptimeSeconds,omitempty" yaml:"uptime_seconds,omitempty"`
+}
+
+type InstanceManagerDetailCollection struct {
+	Collection
+	Data   []InstanceManagerDetail `json:"data,omitempty"`
+	client *InstanceManagerDetailClient
+}
+
+type InstanceManagerDetailClient struct {
+	rancherClient *RancherClient
+}
+
+type InstanceManagerDetailOperations interface {
+	List(opts *ListOpts) (*InstanceManagerDetailCollection, error)
+	Create(opts *InstanceManagerDetail) (*InstanceManagerDetail, error)
+	Update(existing *InstanceManagerDetail, updates interface{}) (*InstanceManagerDetail, error)
+	ById(id string) (*InstanceManagerDetail, error)
+	Delete(container *InstanceManagerDetail) error
+}
+
+func newInstanceManagerDetailClient(rancherClient *RancherClient) *InstanceManagerDetailClient {
+	return &InstanceManagerDetailClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *InstanceManagerDetailClient) Create(container *InstanceManagerDetail) (*InstanceManagerDetail, error) {
+	resp := &InstanceManagerDetail{}
+	err := c.rancherClient.doCreate(INSTANCE_MANAGER_DETAIL_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *InstanceManagerDetailClient) Update(existing *InstanceManagerDetail, updates interface{}) (*InstanceManagerDetail, error) {
+	resp := &InstanceManagerDetail{}
+	err := c.rancherClient.doUpdate(INSTANCE_MANAGER_DETAIL_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *InstanceManagerDetailClient) List(opts *ListOpts) (*InstanceManagerDetailCollection, error) {
+	resp := &InstanceManagerDetailCollection{}
+	err := c.rancherClient.doList(INSTANCE_MANAGER_DETAIL_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *InstanceManagerDetailCollection) Next() (*InstanceManagerDetailCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &InstanceManagerDetailCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *InstanceManagerDetailClient) ById(id string) (*InstanceManagerDetail, error) {
+	resp := &InstanceManagerDetail{}
+	err := c.rancherClient.doById(INSTANCE_MANAGER_DETAIL_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *InstanceManagerDetailClient) Delete(container *InstanceManagerDetail) error {
+	return c.rancherClient.doResourceDelete(INSTANCE_MANAGER_DETAIL_TYPE, &container.Resource)
+}
@@ -0,0 +1,93 @@
+package client
+
+const (
+	INSTANCE_MANAGER_PROCESS_TYPE = "instanceManagerProcess"
+)
+
+type InstanceManagerProcess struct {
+	Resource `yaml:"-"`
+
+	DataEngine string `json:"dataEngine,omitempty" yaml:"data_engine,omitempty"`
+
+	ErrorMsg string `json:"errorMsg,omitempty" yaml:"error_msg,omitempty"`
+
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	PortEnd int64 `json:"portEnd,omitempty" yaml:"port_end,omitempty"`
+
+	PortStart int64 `json:"portStart,omitempty" yaml:"port_start,omitempty"`
+
+	State string `json:"state,omitempty" yaml:"state,omitempty"`
+
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+
+	VolumeName string `json:"volumeName,omitempty" yaml:"volume_name,omitempty"`
+}
+
+type InstanceManagerProcessCollection struct {
+	Collection
+	Data   []InstanceManagerProcess `json:"data,omitempty"`
+	client *InstanceManagerProcessClient
+}
+
+type InstanceManagerProcessClient struct {
+	rancherClient *RancherClient
+}
+
+type InstanceManagerProcessOperations interface {
+	List(opts *ListOpts) (*InstanceManagerProcessCollection, error)
+	Create(opts *InstanceManagerProcess) (*InstanceManagerProcess, error)
+	Update(existing *InstanceManagerProcess, updates interface{}) (*InstanceManagerProcess, error)
+	ById(id string) (*InstanceManagerProcess, error)
+	Delete(container *InstanceManagerProcess) error
+}
+
+func newInstanceManagerProcessClient(rancherClient *RancherClient) *InstanceManagerProcessClient {
+	return &InstanceManagerProcessClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *InstanceManagerProcessClient) Create(container *InstanceManagerProcess) (*InstanceManagerProcess, error) {
+	resp := &InstanceManagerProcess{}
+	err := c.rancherClient.doCreate(INSTANCE_MANAGER_PROCESS_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *InstanceManagerProcessClient) Update(existing *InstanceManagerProcess, updates interface{}) (*InstanceManagerProcess, error) {
+	resp := &InstanceManagerProcess{}
+	err := c.rancherClient.doUpdate(INSTANCE_MANAGER_PROCESS_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *InstanceManagerProcessClient) List(opts *ListOpts) (*InstanceManagerProcessCollection, error) {
+	resp := &InstanceManagerProcessCollection{}
+	err := c.rancherClient.doList(INSTANCE_MANAGER_PROCESS_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *InstanceManagerProcessCollection) Next() (*InstanceManagerProcessCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &InstanceManagerProcessCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *InstanceManagerProcessClient) ById(id string) (*InstanceManagerProcess, error) {
+	resp := &InstanceManagerProcess{}
+	err := c.rancherClient.doById(INSTANCE_MANAGER_PROCESS_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *InstanceManagerProcessClient) Delete(container *InstanceManagerProcess) error {
+	return c.rancherClient.doResourceDelete(INSTANCE_MANAGER_PROCESS_TYPE, &container.Resource)
+}
@@ -0,0 +1,91 @@
+package client
+
+const (
+	REBUILD_QUEUE_ENTRY_TYPE = "rebuildQueueEntry"
+)
+
+type RebuildQueueEntry struct {
+	Resource `yaml:"-"`
+
+	HealthyReplicas int64 `json:"healthyReplicas,omitempty" yaml:"healthy_replicas,omitempty"`
+
+	NumberOfReplicas int64 `json:"numberOfReplicas,omitempty" yaml:"number_of_replicas,omitempty"`
+
+	Position int64 `json:"position,omitempty" yaml:"position,omitempty"`
+
+	Priority int64 `json:"priority,omitempty" yaml:"priority,omitempty"`
+
+	Size string `json:"size,omitempty" yaml:"size,omitempty"`
+
+	State string `json:"state,omitempty" yaml:"state,omitempty"`
+
+	VolumeName string `json:"volumeName,omitempty" yaml:"volume_name,omitempty"`
+}
+
+type RebuildQueueEntryCollection struct {
+	Collection
+	Data   []RebuildQueueEntry `json:"data,omitempty"`
+	client *RebuildQueueEntryClient
+}
+
+type RebuildQueueEntryClient struct {
+	rancherClient *RancherClient
+}
+
+type RebuildQueueEntryOperations interface {
+	List(opts *ListOpts) (*RebuildQueueEntryCollection, error)
+	Create(opts *RebuildQueueEntry) (*RebuildQueueEntry, error)
+	Update(existing *RebuildQueueEntry, updates interface{}) (*RebuildQueueEntry, error)
+	ById(id string) (*RebuildQueueEntry, error)
+	Delete(container *RebuildQueueEntry) error
+}
+
+func newRebuildQueueEntryClient(rancherClient *RancherClient) *RebuildQueueEntryClient {
+	return &RebuildQueueEntryClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *RebuildQueueEntryClient) Create(container *RebuildQueueEntry) (*RebuildQueueEntry, error) {
+	resp := &RebuildQueueEntry{}
+	err := c.rancherClient.doCreate(REBUILD_QUEUE_ENTRY_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *RebuildQueueEntryClient) Update(existing *RebuildQueueEntry, updates interface{}) (*RebuildQueueEntry, error) {
+	resp := &RebuildQueueEntry{}
+	err := c.rancherClient.doUpdate(REBUILD_QUEUE_ENTRY_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *RebuildQueueEntryClient) List(opts *ListOpts) (*RebuildQueueEntryCollection, error) {
+	resp := &RebuildQueueEntryCollection{}
+	err := c.rancherClient.doList(REBUILD_QUEUE_ENTRY_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *RebuildQueueEntryCollection) Next() (*RebuildQueueEntryCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &RebuildQueueEntryCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *RebuildQueueEntryClient) ById(id string) (*RebuildQueueEntry, error) {
+	resp := &RebuildQueueEntry{}
+	err := c.rancherClient.doById(REBUILD_QUEUE_ENTRY_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *RebuildQueueEntryClient) Delete(container *RebuildQueueEntry) error {
+	return c.rancherClient.doResourceDelete(REBUILD_QUEUE_ENTRY_TYPE, &container.Resource)
+}
@@ -0,0 +1,93 @@
+package client
+
+const (
+	REPLICA_COUNT_POLICY_TYPE = "replicaCountPolicy"
+)
+
+type ReplicaCountPolicy struct {
+	Resource `yaml:"-"`
+
+	Decisions []ReplicaCountPolicyDecision `json:"decisions,omitempty" yaml:"decisions,omitempty"`
+
+	DryRun bool `json:"dryRun,omitempty" yaml:"dry_run,omitempty"`
+
+	MinNumberOfReplicas int64 `json:"minNumberOfReplicas,omitempty" yaml:"min_number_of_replicas,omitempty"`
+
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	NumberOfReplicas int64 `json:"numberOfReplicas,omitempty" yaml:"number_of_replicas,omitempty"`
+
+	Priority int64 `json:"priority,omitempty" yaml:"priority,omitempty"`
+
+	Selector map[string]string `json:"selector,omitempty" yaml:"selector,omitempty"`
+
+	StabilizationWindowSeconds int64 `json:"stabilizationWindowSeconds,omitempty" yaml:"stabilization_window_seconds,omitempty"`
+}
+
+type ReplicaCountPolicyCollection struct {
+	Collection
+	Data   []ReplicaCountPolicy `json:"data,omitempty"`
+	client *ReplicaCountPolicyClient
+}
+
+type ReplicaCountPolicyClient struct {
+	rancherClient *RancherClient
+}
+
+type ReplicaCountPolicyOperations interface {
+	List(opts *ListOpts) (*ReplicaCountPolicyCollection, error)
+	Create(opts *ReplicaCountPolicy) (*ReplicaCountPolicy, error)
+	Update(existing *ReplicaCountPolicy, updates interface{}) (*ReplicaCountPolicy, error)
+	ById(id string) (*ReplicaCountPolicy, error)
+	Delete(container *ReplicaCountPolicy) error
+}
+
+func newReplicaCountPolicyClient(rancherClient *RancherClient) *ReplicaCountPolicyClient {
+	return &ReplicaCountPolicyClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *ReplicaCountPolicyClient) Create(container *ReplicaCountPolicy) (*ReplicaCountPolicy, error) {
+	resp := &ReplicaCountPolicy{}
+	err := c.rancherClient.doCreate(REPLICA_COUNT_POLICY_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *ReplicaCountPolicyClient) Update(existing *ReplicaCountPolicy, updates interface{}) (*ReplicaCountPolicy, error) {
+	resp := &ReplicaCountPolicy{}
+	err := c.rancherClient.doUpdate(REPLICA_COUNT_POLICY_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *ReplicaCountPolicyClient) List(opts *ListOpts) (*ReplicaCountPolicyCollection, error) {
+	resp := &ReplicaCountPolicyCollection{}
+	err := c.rancherClient.doList(REPLICA_COUNT_POLICY_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *ReplicaCountPolicyCollection) Next() (*ReplicaCountPolicyCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &ReplicaCountPolicyCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *ReplicaCountPolicyClient) ById(id string) (*ReplicaCountPolicy, error) {
+	resp := &ReplicaCountPolicy{}
+	err := c.rancherClient.doById(REPLICA_COUNT_POLICY_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *ReplicaCountPolicyClient) Delete(container *ReplicaCountPolicy) error {
+	return c.rancherClient.doResourceDelete(REPLICA_COUNT_POLICY_TYPE, &container.Resource)
+}
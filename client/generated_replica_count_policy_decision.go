@@ -0,0 +1,89 @@
+package client
+
+const (
+	REPLICA_COUNT_POLICY_DECISION_TYPE = "replicaCountPolicyDecision"
+)
+
+type ReplicaCountPolicyDecision struct {
+	Resource `yaml:"-"`
+
+	CurrentNumberOfReplicas int64 `json:"currentNumberOfReplicas,omitempty" yaml:"current_number_of_replicas,omitempty"`
+
+	DesiredNumberOfReplicas int64 `json:"desiredNumberOfReplicas,omitempty" yaml:"desired_number_of_replicas,omitempty"`
+
+	LastAppliedTime string `json:"lastAppliedTime,omitempty" yaml:"last_applied_time,omitempty"`
+
+	PendingSince string `json:"pendingSince,omitempty" yaml:"pending_since,omitempty"`
+
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+
+	VolumeName string `json:"volumeName,omitempty" yaml:"volume_name,omitempty"`
+}
+
+type ReplicaCountPolicyDecisionCollection struct {
+	Collection
+	Data   []ReplicaCountPolicyDecision `json:"data,omitempty"`
+	client *ReplicaCountPolicyDecisionClient
+}
+
+type ReplicaCountPolicyDecisionClient struct {
+	rancherClient *RancherClient
+}
+
+type ReplicaCountPolicyDecisionOperations interface {
+	List(opts *ListOpts) (*ReplicaCountPolicyDecisionCollection, error)
+	Create(opts *ReplicaCountPolicyDecision) (*ReplicaCountPolicyDecision, error)
+	Update(existing *ReplicaCountPolicyDecision, updates interface{}) (*ReplicaCountPolicyDecision, error)
+	ById(id string) (*ReplicaCountPolicyDecision, error)
+	Delete(container *ReplicaCountPolicyDecision) error
+}
+
+func newReplicaCountPolicyDecisionClient(rancherClient *RancherClient) *ReplicaCountPolicyDecisionClient {
+	return &ReplicaCountPolicyDecisionClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *ReplicaCountPolicyDecisionClient) Create(container *ReplicaCountPolicyDecision) (*ReplicaCountPolicyDecision, error) {
+	resp := &ReplicaCountPolicyDecision{}
+	err := c.rancherClient.doCreate(REPLICA_COUNT_POLICY_DECISION_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *ReplicaCountPolicyDecisionClient) Update(existing *ReplicaCountPolicyDecision, updates interface{}) (*ReplicaCountPolicyDecision, error) {
+	resp := &ReplicaCountPolicyDecision{}
+	err := c.rancherClient.doUpdate(REPLICA_COUNT_POLICY_DECISION_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *ReplicaCountPolicyDecisionClient) List(opts *ListOpts) (*ReplicaCountPolicyDecisionCollection, error) {
+	resp := &ReplicaCountPolicyDecisionCollection{}
+	err := c.rancherClient.doList(REPLICA_COUNT_POLICY_DECISION_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *ReplicaCountPolicyDecisionCollection) Next() (*ReplicaCountPolicyDecisionCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &ReplicaCountPolicyDecisionCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *ReplicaCountPolicyDecisionClient) ById(id string) (*ReplicaCountPolicyDecision, error) {
+	resp := &ReplicaCountPolicyDecision{}
+	err := c.rancherClient.doById(REPLICA_COUNT_POLICY_DECISION_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *ReplicaCountPolicyDecisionClient) Delete(container *ReplicaCountPolicyDecision) error {
+	return c.rancherClient.doResourceDelete(REPLICA_COUNT_POLICY_DECISION_TYPE, &container.Resource)
+}
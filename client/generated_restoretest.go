@@ -0,0 +1,115 @@
+package client
+
+const (
+	RESTORE_TEST_TYPE = "restoreTest"
+)
+
+type RestoreTest struct {
+	Resource `yaml:"-"`
+
+	BackupTargetName string `json:"backupTargetName,omitempty" yaml:"backup_target_name,omitempty"`
+
+	Command []string `json:"command,omitempty" yaml:"command,omitempty"`
+
+	Cron string `json:"cron,omitempty" yaml:"cron,omitempty"`
+
+	CurrentBackup string `json:"currentBackup,omitempty" yaml:"current_backup,omitempty"`
+
+	FailedCount int64 `json:"failedCount,omitempty" yaml:"failed_count,omitempty"`
+
+	FsType string `json:"fsType,omitempty" yaml:"fs_type,omitempty"`
+
+	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+
+	LastResult string `json:"lastResult,omitempty" yaml:"last_result,omitempty"`
+
+	LastScheduleTime string `json:"lastScheduleTime,omitempty" yaml:"last_schedule_time,omitempty"`
+
+	LastTestTime string `json:"lastTestTime,omitempty" yaml:"last_test_time,omitempty"`
+
+	LastTestedBackup string `json:"lastTestedBackup,omitempty" yaml:"last_tested_backup,omitempty"`
+
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	PassedCount int64 `json:"passedCount,omitempty" yaml:"passed_count,omitempty"`
+
+	SelectionPolicy string `json:"selectionPolicy,omitempty" yaml:"selection_policy,omitempty"`
+
+	State string `json:"state,omitempty" yaml:"state,omitempty"`
+
+	Suspend bool `json:"suspend,omitempty" yaml:"suspend,omitempty"`
+
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty" yaml:"timeout_seconds,omitempty"`
+
+	VolumeName string `json:"volumeName,omitempty" yaml:"volume_name,omitempty"`
+}
+
+type RestoreTestCollection struct {
+	Collection
+	Data   []RestoreTest `json:"data,omitempty"`
+	client *RestoreTestClient
+}
+
+type RestoreTestClient struct {
+	rancherClient *RancherClient
+}
+
+type RestoreTestOperations interface {
+	List(opts *ListOpts) (*RestoreTestCollection, error)
+	Create(opts *RestoreTest) (*RestoreTest, error)
+	Update(existing *RestoreTest, updates interface{}) (*RestoreTest, error)
+	ById(id string) (*RestoreTest, error)
+	Delete(container *RestoreTest) error
+}
+
+func newRestoreTestClient(rancherClient *RancherClient) *RestoreTestClient {
+	return &RestoreTestClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *RestoreTestClient) Create(container *RestoreTest) (*RestoreTest, error) {
+	resp := &RestoreTest{}
+	err := c.rancherClient.doCreate(RESTORE_TEST_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *RestoreTestClient) Update(existing *RestoreTest, updates interface{}) (*RestoreTest, error) {
+	resp := &RestoreTest{}
+	err := c.rancherClient.doUpdate(RESTORE_TEST_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *RestoreTestClient) List(opts *ListOpts) (*RestoreTestCollection, error) {
+	resp := &RestoreTestCollection{}
+	err := c.rancherClient.doList(RESTORE_TEST_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *RestoreTestCollection) Next() (*RestoreTestCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &RestoreTestCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *RestoreTestClient) ById(id string) (*RestoreTest, error) {
+	resp := &RestoreTest{}
+	err := c.rancherClient.doById(RESTORE_TEST_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *RestoreTestClient) Delete(container *RestoreTest) error {
+	return c.rancherClient.doResourceDelete(RESTORE_TEST_TYPE, &container.Resource)
+}
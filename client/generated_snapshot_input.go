@@ -12,6 +12,8 @@ type SnapshotInput struct {
 	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
 
 	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	TakeSnapshot bool `json:"takeSnapshot,omitempty" yaml:"take_snapshot,omitempty"`
 }
 
 type SnapshotInputCollection struct {
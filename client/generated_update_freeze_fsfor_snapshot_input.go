@@ -1,7 +1,7 @@
 package client
 
 const (
-	UPDATE_FREEZE_FSFOR_SNAPSHOT_INPUT_TYPE = "UpdateFreezeFSForSnapshotInput"
+	UPDATE_FREEZE_FSFOR_SNAPSHOT_INPUT_TYPE = "UpdateFreezeFilesystemForSnapshotInput"
 )
 
 type UpdateFreezeFSForSnapshotInput struct {
@@ -0,0 +1,79 @@
+package client
+
+const (
+	UPDATE_REPLICA_SELECTOR_RELOCATION_INPUT_TYPE = "UpdateReplicaSelectorRelocationInput"
+)
+
+type UpdateReplicaSelectorRelocationInput struct {
+	Resource `yaml:"-"`
+
+	ReplicaSelectorRelocation bool `json:"replicaSelectorRelocation,omitempty" yaml:"replica_selector_relocation,omitempty"`
+}
+
+type UpdateReplicaSelectorRelocationInputCollection struct {
+	Collection
+	Data   []UpdateReplicaSelectorRelocationInput `json:"data,omitempty"`
+	client *UpdateReplicaSelectorRelocationInputClient
+}
+
+type UpdateReplicaSelectorRelocationInputClient struct {
+	rancherClient *RancherClient
+}
+
+type UpdateReplicaSelectorRelocationInputOperations interface {
+	List(opts *ListOpts) (*UpdateReplicaSelectorRelocationInputCollection, error)
+	Create(opts *UpdateReplicaSelectorRelocationInput) (*UpdateReplicaSelectorRelocationInput, error)
+	Update(existing *UpdateReplicaSelectorRelocationInput, updates interface{}) (*UpdateReplicaSelectorRelocationInput, error)
+	ById(id string) (*UpdateReplicaSelectorRelocationInput, error)
+	Delete(container *UpdateReplicaSelectorRelocationInput) error
+}
+
+func newUpdateReplicaSelectorRelocationInputClient(rancherClient *RancherClient) *UpdateReplicaSelectorRelocationInputClient {
+	return &UpdateReplicaSelectorRelocationInputClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *UpdateReplicaSelectorRelocationInputClient) Create(container *UpdateReplicaSelectorRelocationInput) (*UpdateReplicaSelectorRelocationInput, error) {
+	resp := &UpdateReplicaSelectorRelocationInput{}
+	err := c.rancherClient.doCreate(UPDATE_REPLICA_SELECTOR_RELOCATION_INPUT_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *UpdateReplicaSelectorRelocationInputClient) Update(existing *UpdateReplicaSelectorRelocationInput, updates interface{}) (*UpdateReplicaSelectorRelocationInput, error) {
+	resp := &UpdateReplicaSelectorRelocationInput{}
+	err := c.rancherClient.doUpdate(UPDATE_REPLICA_SELECTOR_RELOCATION_INPUT_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *UpdateReplicaSelectorRelocationInputClient) List(opts *ListOpts) (*UpdateReplicaSelectorRelocationInputCollection, error) {
+	resp := &UpdateReplicaSelectorRelocationInputCollection{}
+	err := c.rancherClient.doList(UPDATE_REPLICA_SELECTOR_RELOCATION_INPUT_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *UpdateReplicaSelectorRelocationInputCollection) Next() (*UpdateReplicaSelectorRelocationInputCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &UpdateReplicaSelectorRelocationInputCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *UpdateReplicaSelectorRelocationInputClient) ById(id string) (*UpdateReplicaSelectorRelocationInput, error) {
+	resp := &UpdateReplicaSelectorRelocationInput{}
+	err := c.rancherClient.doById(UPDATE_REPLICA_SELECTOR_RELOCATION_INPUT_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *UpdateReplicaSelectorRelocationInputClient) Delete(container *UpdateReplicaSelectorRelocationInput) error {
+	return c.rancherClient.doResourceDelete(UPDATE_REPLICA_SELECTOR_RELOCATION_INPUT_TYPE, &container.Resource)
+}
@@ -0,0 +1,79 @@
+package client
+
+const (
+	UPDATE_SNAPSHOT_DATA_INTEGRITY_CRON_JOB_INPUT_TYPE = "UpdateSnapshotDataIntegrityCronJobInput"
+)
+
+type UpdateSnapshotDataIntegrityCronJobInput struct {
+	Resource `yaml:"-"`
+
+	SnapshotDataIntegrityCronJob string `json:"snapshotDataIntegrityCronJob,omitempty" yaml:"snapshot_data_integrity_cron_job,omitempty"`
+}
+
+type UpdateSnapshotDataIntegrityCronJobInputCollection struct {
+	Collection
+	Data   []UpdateSnapshotDataIntegrityCronJobInput `json:"data,omitempty"`
+	client *UpdateSnapshotDataIntegrityCronJobInputClient
+}
+
+type UpdateSnapshotDataIntegrityCronJobInputClient struct {
+	rancherClient *RancherClient
+}
+
+type UpdateSnapshotDataIntegrityCronJobInputOperations interface {
+	List(opts *ListOpts) (*UpdateSnapshotDataIntegrityCronJobInputCollection, error)
+	Create(opts *UpdateSnapshotDataIntegrityCronJobInput) (*UpdateSnapshotDataIntegrityCronJobInput, error)
+	Update(existing *UpdateSnapshotDataIntegrityCronJobInput, updates interface{}) (*UpdateSnapshotDataIntegrityCronJobInput, error)
+	ById(id string) (*UpdateSnapshotDataIntegrityCronJobInput, error)
+	Delete(container *UpdateSnapshotDataIntegrityCronJobInput) error
+}
+
+func newUpdateSnapshotDataIntegrityCronJobInputClient(rancherClient *RancherClient) *UpdateSnapshotDataIntegrityCronJobInputClient {
+	return &UpdateSnapshotDataIntegrityCronJobInputClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *UpdateSnapshotDataIntegrityCronJobInputClient) Create(container *UpdateSnapshotDataIntegrityCronJobInput) (*UpdateSnapshotDataIntegrityCronJobInput, error) {
+	resp := &UpdateSnapshotDataIntegrityCronJobInput{}
+	err := c.rancherClient.doCreate(UPDATE_SNAPSHOT_DATA_INTEGRITY_CRON_JOB_INPUT_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *UpdateSnapshotDataIntegrityCronJobInputClient) Update(existing *UpdateSnapshotDataIntegrityCronJobInput, updates interface{}) (*UpdateSnapshotDataIntegrityCronJobInput, error) {
+	resp := &UpdateSnapshotDataIntegrityCronJobInput{}
+	err := c.rancherClient.doUpdate(UPDATE_SNAPSHOT_DATA_INTEGRITY_CRON_JOB_INPUT_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *UpdateSnapshotDataIntegrityCronJobInputClient) List(opts *ListOpts) (*UpdateSnapshotDataIntegrityCronJobInputCollection, error) {
+	resp := &UpdateSnapshotDataIntegrityCronJobInputCollection{}
+	err := c.rancherClient.doList(UPDATE_SNAPSHOT_DATA_INTEGRITY_CRON_JOB_INPUT_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *UpdateSnapshotDataIntegrityCronJobInputCollection) Next() (*UpdateSnapshotDataIntegrityCronJobInputCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &UpdateSnapshotDataIntegrityCronJobInputCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *UpdateSnapshotDataIntegrityCronJobInputClient) ById(id string) (*UpdateSnapshotDataIntegrityCronJobInput, error) {
+	resp := &UpdateSnapshotDataIntegrityCronJobInput{}
+	err := c.rancherClient.doById(UPDATE_SNAPSHOT_DATA_INTEGRITY_CRON_JOB_INPUT_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *UpdateSnapshotDataIntegrityCronJobInputClient) Delete(container *UpdateSnapshotDataIntegrityCronJobInput) error {
+	return c.rancherClient.doResourceDelete(UPDATE_SNAPSHOT_DATA_INTEGRITY_CRON_JOB_INPUT_TYPE, &container.Resource)
+}
@@ -0,0 +1,85 @@
+package client
+
+const (
+	UPGRADE_FREEZE_REPORT_TYPE = "upgradeFreezeReport"
+)
+
+type UpgradeFreezeReport struct {
+	Resource `yaml:"-"`
+
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+
+	PendingEngineImageUpgrades []string `json:"pendingEngineImageUpgrades,omitempty" yaml:"pending_engine_image_upgrades,omitempty"`
+
+	PendingInstanceManagerReplacements []string `json:"pendingInstanceManagerReplacements,omitempty" yaml:"pending_instance_manager_replacements,omitempty"`
+
+	PendingLiveEngineUpgrades []string `json:"pendingLiveEngineUpgrades,omitempty" yaml:"pending_live_engine_upgrades,omitempty"`
+}
+
+type UpgradeFreezeReportCollection struct {
+	Collection
+	Data   []UpgradeFreezeReport `json:"data,omitempty"`
+	client *UpgradeFreezeReportClient
+}
+
+type UpgradeFreezeReportClient struct {
+	rancherClient *RancherClient
+}
+
+type UpgradeFreezeReportOperations interface {
+	List(opts *ListOpts) (*UpgradeFreezeReportCollection, error)
+	Create(opts *UpgradeFreezeReport) (*UpgradeFreezeReport, error)
+	Update(existing *UpgradeFreezeReport, updates interface{}) (*UpgradeFreezeReport, error)
+	ById(id string) (*UpgradeFreezeReport, error)
+	Delete(container *UpgradeFreezeReport) error
+}
+
+func newUpgradeFreezeReportClient(rancherClient *RancherClient) *UpgradeFreezeReportClient {
+	return &UpgradeFreezeReportClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *UpgradeFreezeReportClient) Create(container *UpgradeFreezeReport) (*UpgradeFreezeReport, error) {
+	resp := &UpgradeFreezeReport{}
+	err := c.rancherClient.doCreate(UPGRADE_FREEZE_REPORT_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *UpgradeFreezeReportClient) Update(existing *UpgradeFreezeReport, updates interface{}) (*UpgradeFreezeReport, error) {
+	resp := &UpgradeFreezeReport{}
+	err := c.rancherClient.doUpdate(UPGRADE_FREEZE_REPORT_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *UpgradeFreezeReportClient) List(opts *ListOpts) (*UpgradeFreezeReportCollection, error) {
+	resp := &UpgradeFreezeReportCollection{}
+	err := c.rancherClient.doList(UPGRADE_FREEZE_REPORT_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *UpgradeFreezeReportCollection) Next() (*UpgradeFreezeReportCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &UpgradeFreezeReportCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *UpgradeFreezeReportClient) ById(id string) (*UpgradeFreezeReport, error) {
+	resp := &UpgradeFreezeReport{}
+	err := c.rancherClient.doById(UPGRADE_FREEZE_REPORT_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *UpgradeFreezeReportClient) Delete(container *UpgradeFreezeReport) error {
+	return c.rancherClient.doResourceDelete(UPGRADE_FREEZE_REPORT_TYPE, &container.Resource)
+}
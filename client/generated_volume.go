@@ -77,6 +77,8 @@ type Volume struct {
 
 	ReplicaDiskSoftAntiAffinity string `json:"replicaDiskSoftAntiAffinity,omitempty" yaml:"replica_disk_soft_anti_affinity,omitempty"`
 
+	ReplicaSelectorRelocation bool `json:"replicaSelectorRelocation,omitempty" yaml:"replica_selector_relocation,omitempty"`
+
 	ReplicaSoftAntiAffinity string `json:"replicaSoftAntiAffinity,omitempty" yaml:"replica_soft_anti_affinity,omitempty"`
 
 	ReplicaZoneSoftAntiAffinity string `json:"replicaZoneSoftAntiAffinity,omitempty" yaml:"replica_zone_soft_anti_affinity,omitempty"`
@@ -95,6 +97,8 @@ type Volume struct {
 
 	Robustness string `json:"robustness,omitempty" yaml:"robustness,omitempty"`
 
+	SelectorViolatingReplicas []string `json:"selectorViolatingReplicas,omitempty" yaml:"selector_violating_replicas,omitempty"`
+
 	ShareEndpoint string `json:"shareEndpoint,omitempty" yaml:"share_endpoint,omitempty"`
 
 	ShareState string `json:"shareState,omitempty" yaml:"share_state,omitempty"`
@@ -103,6 +107,8 @@ type Volume struct {
 
 	SnapshotDataIntegrity string `json:"snapshotDataIntegrity,omitempty" yaml:"snapshot_data_integrity,omitempty"`
 
+	SnapshotDataIntegrityCronJob string `json:"snapshotDataIntegrityCronJob,omitempty" yaml:"snapshot_data_integrity_cron_job,omitempty"`
+
 	SnapshotMaxCount int64 `json:"snapshotMaxCount,omitempty" yaml:"snapshot_max_count,omitempty"`
 
 	SnapshotMaxSize string `json:"snapshotMaxSize,omitempty" yaml:"snapshot_max_size,omitempty"`
@@ -113,6 +119,8 @@ type Volume struct {
 
 	State string `json:"state,omitempty" yaml:"state,omitempty"`
 
+	TrashedAt string `json:"trashedAt,omitempty" yaml:"trashed_at,omitempty"`
+
 	UnmapMarkSnapChainRemoved string `json:"unmapMarkSnapChainRemoved,omitempty" yaml:"unmap_mark_snap_chain_removed,omitempty"`
 
 	VolumeAttachment VolumeAttachment `json:"volumeAttachment,omitempty" yaml:"volume_attachment,omitempty"`
@@ -141,10 +149,16 @@ type VolumeOperations interface {
 
 	ActionCancelExpansion(*Volume) (*Volume, error)
 
-	ActionOfflineReplicaRebuilding(*Volume) (*Volume, error)
+	ActionOfflineReplicaRebuilding(*Volume, *UpdateOfflineRebuildingInput) (*Volume, error)
+
+	ActionPurge(*Volume) (*Volume, error)
+
+	ActionRestoreFromRecycleBin(*Volume) (*Volume, error)
 
 	ActionDetach(*Volume, *DetachInput) (*Volume, error)
 
+	ActionEngineUpgradePreCheck(*Volume) (*EngineUpgradePreCheck, error)
+
 	ActionExpand(*Volume, *ExpandInput) (*Volume, error)
 
 	ActionPvCreate(*Volume, *PVCreateInput) (*Volume, error)
@@ -186,6 +200,38 @@ type VolumeOperations interface {
 	ActionTrimFilesystem(*Volume) (*Volume, error)
 
 	ActionUpdateAccessMode(*Volume, *UpdateAccessModeInput) (*Volume, error)
+
+	ActionEngineUpgrade(*Volume, *EngineUpgradeInput) (*Volume, error)
+
+	ActionUpdateReplicaCount(*Volume, *UpdateReplicaCountInput) (*Volume, error)
+
+	ActionUpdateReplicaAutoBalance(*Volume, *UpdateReplicaAutoBalanceInput) (*Volume, error)
+
+	ActionUpdateDataLocality(*Volume, *UpdateDataLocalityInput) (*Volume, error)
+
+	ActionUpdateSnapshotDataIntegrity(*Volume, *UpdateSnapshotDataIntegrityInput) (*Volume, error)
+
+	ActionUpdateSnapshotDataIntegrityCronJob(*Volume, *UpdateSnapshotDataIntegrityCronJobInput) (*Volume, error)
+
+	ActionUpdateSnapshotMaxCount(*Volume, *UpdateSnapshotMaxCountInput) (*Volume, error)
+
+	ActionUpdateSnapshotMaxSize(*Volume, *UpdateSnapshotMaxSizeInput) (*Volume, error)
+
+	ActionUpdateBackupCompressionMethod(*Volume, *UpdateBackupCompressionInput) (*Volume, error)
+
+	ActionUpdateUnmapMarkSnapChainRemoved(*Volume, *UpdateUnmapMarkSnapChainRemovedInput) (*Volume, error)
+
+	ActionUpdateReplicaSoftAntiAffinity(*Volume, *UpdateReplicaSoftAntiAffinityInput) (*Volume, error)
+
+	ActionUpdateReplicaZoneSoftAntiAffinity(*Volume, *UpdateReplicaZoneSoftAntiAffinityInput) (*Volume, error)
+
+	ActionUpdateReplicaDiskSoftAntiAffinity(*Volume, *UpdateReplicaDiskSoftAntiAffinityInput) (*Volume, error)
+
+	ActionUpdateFreezeFilesystemForSnapshot(*Volume, *UpdateFreezeFSForSnapshotInput) (*Volume, error)
+
+	ActionUpdateReplicaSelectorRelocation(*Volume, *UpdateReplicaSelectorRelocationInput) (*Volume, error)
+
+	ActionUpdateBackupTargetName(*Volume, *UpdateBackupTargetInput) (*Volume, error)
 }
 
 func newVolumeClient(rancherClient *RancherClient) *VolumeClient {
@@ -265,11 +311,29 @@ func (c *VolumeClient) ActionCancelExpansion(resource *Volume) (*Volume, error)
 	return resp, err
 }
 
-func (c *VolumeClient) ActionOfflineReplicaRebuilding(resource *Volume) (*Volume, error) {
+func (c *VolumeClient) ActionPurge(resource *Volume) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "purge", &resource.Resource, nil, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionRestoreFromRecycleBin(resource *Volume) (*Volume, error) {
 
 	resp := &Volume{}
 
-	err := c.rancherClient.doAction(VOLUME_TYPE, "offlineReplicaRebuilding", &resource.Resource, nil, resp)
+	err := c.rancherClient.doAction(VOLUME_TYPE, "restoreFromRecycleBin", &resource.Resource, nil, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionOfflineReplicaRebuilding(resource *Volume, input *UpdateOfflineRebuildingInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "offlineReplicaRebuilding", &resource.Resource, input, resp)
 
 	return resp, err
 }
@@ -283,6 +347,15 @@ func (c *VolumeClient) ActionDetach(resource *Volume, input *DetachInput) (*Volu
 	return resp, err
 }
 
+func (c *VolumeClient) ActionEngineUpgradePreCheck(resource *Volume) (*EngineUpgradePreCheck, error) {
+
+	resp := &EngineUpgradePreCheck{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "engineUpgradePreCheck", &resource.Resource, nil, resp)
+
+	return resp, err
+}
+
 func (c *VolumeClient) ActionExpand(resource *Volume, input *ExpandInput) (*Volume, error) {
 
 	resp := &Volume{}
@@ -471,3 +544,147 @@ func (c *VolumeClient) ActionUpdateAccessMode(resource *Volume, input *UpdateAcc
 
 	return resp, err
 }
+
+func (c *VolumeClient) ActionEngineUpgrade(resource *Volume, input *EngineUpgradeInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "engineUpgrade", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateReplicaCount(resource *Volume, input *UpdateReplicaCountInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateReplicaCount", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateReplicaAutoBalance(resource *Volume, input *UpdateReplicaAutoBalanceInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateReplicaAutoBalance", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateDataLocality(resource *Volume, input *UpdateDataLocalityInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateDataLocality", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateSnapshotDataIntegrity(resource *Volume, input *UpdateSnapshotDataIntegrityInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateSnapshotDataIntegrity", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateSnapshotDataIntegrityCronJob(resource *Volume, input *UpdateSnapshotDataIntegrityCronJobInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateSnapshotDataIntegrityCronJob", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateSnapshotMaxCount(resource *Volume, input *UpdateSnapshotMaxCountInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateSnapshotMaxCount", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateSnapshotMaxSize(resource *Volume, input *UpdateSnapshotMaxSizeInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateSnapshotMaxSize", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateBackupCompressionMethod(resource *Volume, input *UpdateBackupCompressionInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateBackupCompressionMethod", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateUnmapMarkSnapChainRemoved(resource *Volume, input *UpdateUnmapMarkSnapChainRemovedInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateUnmapMarkSnapChainRemoved", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateReplicaSoftAntiAffinity(resource *Volume, input *UpdateReplicaSoftAntiAffinityInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateReplicaSoftAntiAffinity", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateReplicaZoneSoftAntiAffinity(resource *Volume, input *UpdateReplicaZoneSoftAntiAffinityInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateReplicaZoneSoftAntiAffinity", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateReplicaDiskSoftAntiAffinity(resource *Volume, input *UpdateReplicaDiskSoftAntiAffinityInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateReplicaDiskSoftAntiAffinity", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateFreezeFilesystemForSnapshot(resource *Volume, input *UpdateFreezeFSForSnapshotInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateFreezeFilesystemForSnapshot", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateReplicaSelectorRelocation(resource *Volume, input *UpdateReplicaSelectorRelocationInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateReplicaSelectorRelocation", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateBackupTargetName(resource *Volume, input *UpdateBackupTargetInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateBackupTargetName", &resource.Resource, input, resp)
+
+	return resp, err
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+const (
+	// The API server pings the websocket every 15 seconds, so a connection without any message for
+	// longer than this is considered broken.
+	watchReadTimeout = 45 * time.Second
+
+	maxWatchRetryInterval = 30 * time.Second
+)
+
+// WatchEvent is sent by Watch every time the collection of the watched resource changes.
+type WatchEvent struct {
+	// Data is the whole collection in JSON. Use Decode to get the typed collection.
+	Data []byte
+	// Err is set when the connection is broken. The watch reconnects by itself afterward.
+	Err error
+}
+
+// Decode unmarshals the collection of the event into the collection type of the watched resource,
+// e.g. *VolumeCollection for "volumes" or *EventCollection for "events".
+func (e *WatchEvent) Decode(collection interface{}) error {
+	if e.Err != nil {
+		return e.Err
+	}
+	return json.Unmarshal(e.Data, collection)
+}
+
+// Watch streams the collection of the resource type from the websocket API, e.g. "volumes", "nodes",
+// "settings" or "events". The current collection is sent once connected and again every time it
+// changes. A broken connection is reported by an event with Err set and then reestablished until the
+// context is done, after which the channel is closed.
+func (rancherClient *RancherBaseClientImpl) Watch(ctx context.Context, resource string) <-chan WatchEvent {
+	events := make(chan WatchEvent)
+
+	go func() {
+		defer close(events)
+
+		interval := rancherClient.Opts.RetryInterval
+		if interval == 0 {
+			interval = defaultRetryInterval
+		}
+		retryInterval := interval
+
+		for {
+			connected, err := rancherClient.watch(ctx, resource, events)
+			if ctx.Err() != nil {
+				return
+			}
+			if connected {
+				retryInterval = interval
+			}
+			if debug {
+				fmt.Printf("Reconnecting the watch of %s in %v: %v\n", resource, retryInterval, err)
+			}
+
+			select {
+			case events <- WatchEvent{Err: err}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case <-time.After(retryInterval):
+			case <-ctx.Done():
+				return
+			}
+			retryInterval *= 2
+			if retryInterval > maxWatchRetryInterval {
+				retryInterval = maxWatchRetryInterval
+			}
+		}
+	}()
+
+	return events
+}
+
+// watch sends the collections received from a single websocket connection until it is broken. It
+// returns whether the connection was established and the reason it was closed.
+func (rancherClient *RancherBaseClientImpl) watch(ctx context.Context, resource string, events chan<- WatchEvent) (bool, error) {
+	watchUrl, err := rancherClient.watchUrl(resource)
+	if err != nil {
+		return false, err
+	}
+
+	conn, resp, err := rancherClient.Websocket(watchUrl, nil)
+	if err != nil {
+		if resp != nil {
+			return false, errors.Wrapf(err, "failed to watch [%s] with status [%s]", watchUrl, resp.Status)
+		}
+		return false, errors.Wrapf(err, "failed to watch [%s]", watchUrl)
+	}
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		_ = conn.Close()
+	}()
+
+	if err := conn.SetReadDeadline(time.Now().Add(watchReadTimeout)); err != nil {
+		return true, err
+	}
+	pingHandler := conn.PingHandler()
+	conn.SetPingHandler(func(appData string) error {
+		if err := conn.SetReadDeadline(time.Now().Add(watchReadTimeout)); err != nil {
+			return err
+		}
+		return pingHandler(appData)
+	})
+
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return true, errors.Wrapf(err, "failed to read from [%s]", watchUrl)
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(watchReadTimeout)); err != nil {
+			return true, err
+		}
+		if messageType != websocket.TextMessage {
+			continue
+		}
+
+		select {
+		case events <- WatchEvent{Data: data}:
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+	}
+}
+
+func (rancherClient *RancherBaseClientImpl) watchUrl(resource string) (string, error) {
+	u, err := url.Parse(rancherClient.Opts.Url)
+	if err != nil {
+		return "", err
+	}
+
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	case "http":
+		u.Scheme = "ws"
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/ws/" + resource
+	return u.String(), nil
+}
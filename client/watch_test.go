@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchUrl(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[string]struct {
+		url      string
+		expected string
+	}{
+		"http":          {url: "http://longhorn-backend:9500/v1", expected: "ws://longhorn-backend:9500/v1/ws/volumes"},
+		"https":         {url: "https://longhorn.example.com/v1/", expected: "wss://longhorn.example.com/v1/ws/volumes"},
+		"withoutPrefix": {url: "http://longhorn-backend:9500", expected: "ws://longhorn-backend:9500/ws/volumes"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rancherClient := &RancherBaseClientImpl{Opts: &ClientOpts{Url: tt.url}}
+			watchUrl, err := rancherClient.watchUrl("volumes")
+			assert.NoError(err)
+			assert.Equal(tt.expected, watchUrl)
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	assert := assert.New(t)
+
+	// Every connection sends its number and is then closed by the server.
+	upgrader := websocket.Upgrader{}
+	connections := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/ws/volumes" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"data":[{"name":"`+<-connections+`"}]}`))
+	}))
+	defer server.Close()
+	connections <- "first"
+	connections <- "second"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rancherClient := &RancherBaseClientImpl{
+		Opts: &ClientOpts{
+			Url:           server.URL + "/v1",
+			RetryInterval: time.Millisecond,
+		},
+	}
+	events := rancherClient.Watch(ctx, "volumes")
+
+	receive := func() WatchEvent {
+		select {
+		case event, ok := <-events:
+			assert.True(ok)
+			return event
+		case <-time.After(10 * time.Second):
+			assert.FailNow("timed out waiting for the watch event")
+		}
+		return WatchEvent{}
+	}
+
+	for _, expected := range []string{"first", "second"} {
+		var collection VolumeCollection
+		event := receive()
+		assert.NoError(event.Decode(&collection))
+		if assert.Len(collection.Data, 1) {
+			assert.Equal(expected, collection.Data[0].Name)
+		}
+
+		event = receive()
+		assert.Error(event.Err)
+		assert.Error(event.Decode(&collection))
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatchReportsFailedConnection(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rancherClient := &RancherBaseClientImpl{
+		Opts: &ClientOpts{
+			Url:           server.URL + "/v1",
+			RetryInterval: time.Millisecond,
+		},
+	}
+	events := rancherClient.Watch(ctx, "volumes")
+
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-events:
+			assert.ErrorContains(event.Err, "503 Service Unavailable")
+		case <-time.After(10 * time.Second):
+			assert.FailNow("timed out waiting for the watch event")
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		for ok {
+			_, ok = <-events
+		}
+	case <-time.After(10 * time.Second):
+		assert.FailNow("watch channel not closed after the context is done")
+	}
+}
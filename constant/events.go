@@ -33,8 +33,14 @@ const (
 	EventReasonSucceededExpansion = "SucceededExpansion"
 	EventReasonCanceledExpansion  = "CanceledExpansion"
 
+	EventReasonTrashed = "Trashed"
+	EventReasonPurged  = "Purged"
+
 	EventReasonAttached = "Attached"
 	EventReasonDetached = "Detached"
+
+	EventReasonNodeIdentityChanged = "NodeIdentityChanged"
+
 	EventReasonHealthy  = "Healthy"
 	EventReasonFaulted  = "Faulted"
 	EventReasonDegraded = "Degraded"
@@ -46,6 +52,13 @@ const (
 	EventReasonEvictionCanceled      = "EvictionCanceled"
 	EventReasonEvictionFailed        = "EvictionFailed"
 
+	EventReasonRelocated = "Relocated"
+
+	EventReasonDraining     = "Draining"
+	EventReasonDrainTimeout = "DrainTimeout"
+
+	EventReasonFailedEncrypting = "FailedEncrypting"
+
 	EventReasonDetachedUnexpectedly = "DetachedUnexpectedly"
 	EventReasonRemount              = "Remount"
 	EventReasonAutoSalvaged         = "AutoSalvaged"
@@ -58,6 +71,9 @@ const (
 
 	EventReasonFailedSnapshotDataIntegrityCheck = "FailedSnapshotDataIntegrityCheck"
 
+	EventReasonFailedBackupHook        = "FailedBackupHook"
+	EventReasonBackupTargetUnavailable = "BackupTargetUnavailable"
+
 	EventReasonFailed   = "Failed"
 	EventReasonReady    = "Ready"
 	EventReasonUploaded = "Uploaded"
@@ -66,10 +82,18 @@ const (
 	EventReasonFailedUpgradePreCheck  = "FailedUpgradePreCheck"
 	EventReasonFailedUpgradePostCheck = "FailedUpgradePostCheck"
 	EventReasonPassedUpgradeCheck     = "PassedUpgradeCheck"
+	EventReasonUpgradeFrozen          = "UpgradeFrozen"
 
 	EventReasonRolloutSkippedFmt = "RolloutSkipped: %v %v"
 
 	EventReasonMigrationFailed = "MigrationFailed"
 
 	EventReasonOrphanCleanupCompleted = "OrphanCleanupCompleted"
+
+	EventReasonRefreshing = "Refreshing"
+	EventReasonRefreshed  = "Refreshed"
+
+	EventReasonTesting    = "Testing"
+	EventReasonTestPassed = "TestPassed"
+	EventReasonTestFailed = "TestFailed"
 )
@@ -616,7 +616,8 @@ func (c *BackingImageDataSourceController) handleAttachmentTicketCreation(bids *
 	}()
 
 	attachmentTicketID := longhorn.GetAttachmentTicketID(longhorn.AttacherTypeBackingImageDataSourceController, bids.Name)
-	createOrUpdateAttachmentTicket(va, attachmentTicketID, vol.Status.OwnerID, longhorn.AnyValue, longhorn.AttacherTypeBackingImageDataSourceController)
+	createOrUpdateAttachmentTicket(va, attachmentTicketID, vol.Status.OwnerID, longhorn.AnyValue, longhorn.AttacherTypeBackingImageDataSourceController,
+		getAttacherID(attacherKindBackingImageDataSource, bids.Name, nil))
 
 	return nil
 }
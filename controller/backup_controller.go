@@ -23,13 +23,18 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientset "k8s.io/client-go/kubernetes"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	restclient "k8s.io/client-go/rest"
 
 	"github.com/longhorn/backupstore"
 
+	bsutil "github.com/longhorn/backupstore/util"
+
+	"github.com/longhorn/longhorn-manager/constant"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
+	"github.com/longhorn/longhorn-manager/util/backuphook"
 
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
@@ -41,6 +46,7 @@ const (
 
 const (
 	WaitForSnapshotMessage                 = "Waiting for the snapshot %v to be ready"
+	WaitForSnapshotCreationMessage         = "Waiting for the snapshot %v to be created"
 	FailedWaitingForSnapshotMessage        = "Failed waiting for the snapshot %v to be ready"
 	WaitForEngineMessage                   = "Waiting for the engine %v to be ready"
 	FailedWaitingForEngineMessage          = "Failed waiting for the engine %v to be ready"
@@ -73,6 +79,7 @@ type BackupController struct {
 	controllerID string
 
 	kubeClient    clientset.Interface
+	kubeConfig    *restclient.Config // Used to run the backup hooks in the workload pods.
 	eventRecorder record.EventRecorder
 
 	monitors    map[string]*engineapi.BackupMonitor
@@ -98,6 +105,7 @@ func NewBackupController(
 	ds *datastore.DataStore,
 	scheme *runtime.Scheme,
 	kubeClient clientset.Interface,
+	kubeConfig *restclient.Config,
 	controllerID string,
 	namespace string,
 	proxyConnCounter util.Counter,
@@ -121,6 +129,7 @@ func NewBackupController(
 		ds: ds,
 
 		kubeClient:    kubeClient,
+		kubeConfig:    kubeConfig,
 		eventRecorder: eventBroadcaster.NewRecorder(scheme, corev1.EventSource{Component: "longhorn-backup-controller"}),
 
 		proxyConnCounter: proxyConnCounter,
@@ -134,8 +143,11 @@ func NewBackupController(
 
 	var err error
 	if _, err = ds.BackupInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    bc.enqueueBackup,
-		UpdateFunc: func(old, cur interface{}) { bc.enqueueBackup(cur) },
+		AddFunc: bc.enqueueBackup,
+		UpdateFunc: func(old, cur interface{}) {
+			bc.enqueueBackup(cur)
+			bc.enqueueReplicationSourceBackup(cur)
+		},
 		DeleteFunc: bc.enqueueBackup,
 	}); err != nil {
 		return nil, err
@@ -145,6 +157,20 @@ func NewBackupController(
 	return bc, nil
 }
 
+// enqueueReplicationSourceBackup enqueues the backup replicated by the replica backup, so that the source backup
+// can follow the replication progress.
+func (bc *BackupController) enqueueReplicationSourceBackup(obj interface{}) {
+	backup, ok := obj.(*longhorn.Backup)
+	if !ok {
+		return
+	}
+	sourceBackupName := backup.Labels[types.GetLonghornLabelKey(types.LonghornLabelBackupReplicationSource)]
+	if sourceBackupName == "" {
+		return
+	}
+	bc.queue.Add(bc.namespace + "/" + sourceBackupName)
+}
+
 func (bc *BackupController) enqueueBackup(obj interface{}) {
 	key, err := controller.KeyFunc(obj)
 	if err != nil {
@@ -405,7 +431,7 @@ func (bc *BackupController) reconcile(backupName string) (err error) {
 				log.Warnf("Failed to sync backup volume %v for backup target %v", canonicalBackupVolumeName, backupTargetName)
 				return
 			}
-			if err := bc.deleteSnapshotAfterBackupCompleted(backup); err != nil {
+			if err := bc.deleteSnapshotAfterBackupCompleted(backup, backupTarget); err != nil {
 				log.WithError(err).Warn("Failed to delete snapshot after backup completed")
 				return
 			}
@@ -480,7 +506,7 @@ func (bc *BackupController) reconcile(backupName string) (err error) {
 	// The backup config had synced
 	if !backup.Status.LastSyncedAt.IsZero() &&
 		!backup.Spec.SyncRequestedAt.After(backup.Status.LastSyncedAt.Time) {
-		return nil
+		return bc.reconcileReplication(backup, backupTarget, canonicalBackupVolumeName)
 	}
 
 	// The backup creation is complete, then the source of truth becomes the remote backup target
@@ -585,7 +611,13 @@ func (bc *BackupController) handleAttachmentTicketCreation(backup *longhorn.Back
 	}()
 
 	attachmentTicketID := longhorn.GetAttachmentTicketID(longhorn.AttacherTypeBackupController, backup.Name)
-	createOrUpdateAttachmentTicket(va, attachmentTicketID, vol.Status.OwnerID, longhorn.AnyValue, longhorn.AttacherTypeBackupController)
+	attacherID := getAttacherID(attacherKindBackup, backup.Name, backup.Spec.Labels)
+	if _, ok := va.Spec.AttachmentTickets[attachmentTicketID]; !ok && !hasWorkloadTicket(va.Spec.AttachmentTickets, longhorn.AnyValue) {
+		// No workload is using the volume. Back it up in maintenance mode so that the volume is not
+		// shown as attached and the backup gives way once a workload requests the volume.
+		createMaintenanceAttachmentTicket(va, attachmentTicketID, vol.Status.OwnerID, longhorn.AttacherTypeBackupController, attacherID)
+	}
+	createOrUpdateAttachmentTicket(va, attachmentTicketID, vol.Status.OwnerID, longhorn.AnyValue, longhorn.AttacherTypeBackupController, attacherID)
 
 	return nil
 }
@@ -848,6 +880,20 @@ func (bc *BackupController) checkMonitor(backup *longhorn.Backup, volume *longho
 		return nil, err
 	}
 
+	waitForSnapshot, err := bc.takeBackupSnapshot(backup, volume, engine, engineClientProxy)
+	if err != nil {
+		backup.Status.Error = err.Error()
+		backup.Status.State = longhorn.BackupStateError
+		backup.Status.LastSyncedAt = metav1.Time{Time: time.Now().UTC()}
+		return nil, err
+	}
+	if waitForSnapshot {
+		backup.Status.State = longhorn.BackupStatePending
+		backup.Status.Messages[MessageTypeReconcileInfo] = fmt.Sprintf(WaitForSnapshotCreationMessage, backup.Spec.SnapshotName)
+		err = fmt.Errorf("waiting for the snapshot %v to be created before enabling backup monitor", backup.Spec.SnapshotName)
+		return nil, err
+	}
+
 	snapshot, err := bc.ds.GetSnapshotRO(backup.Spec.SnapshotName)
 	if err != nil {
 		bc.creationRetryCounter.IncreaseCount(backup.Name)
@@ -911,6 +957,55 @@ func (bc *BackupController) checkMonitor(backup *longhorn.Backup, volume *longho
 	return monitor, nil
 }
 
+// takeBackupSnapshot takes the snapshot of a backup requested with the annotation BackupAnnotationTakeSnapshot,
+// between the pre-backup and post-backup hooks of the pods using the volume. It returns true until the snapshot CR
+// is created for the snapshot taken. The snapshot is taken only if the engine doesn't have it yet, so the hooks
+// never run twice for a backup.
+func (bc *BackupController) takeBackupSnapshot(backup *longhorn.Backup, volume *longhorn.Volume, engine *longhorn.Engine, engineClientProxy engineapi.EngineClientProxy) (bool, error) {
+	if _, requested := backup.Annotations[types.BackupAnnotationTakeSnapshot]; !requested {
+		return false, nil
+	}
+	if _, err := bc.ds.GetSnapshotRO(backup.Spec.SnapshotName); err == nil {
+		return false, nil
+	} else if !apierrors.IsNotFound(err) {
+		return false, errors.Wrapf(err, "failed to get snapshot %v", backup.Spec.SnapshotName)
+	}
+
+	snapshot, err := engineClientProxy.SnapshotGet(engine, backup.Spec.SnapshotName)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get snapshot %v from engine %v", backup.Spec.SnapshotName, engine.Name)
+	}
+	if snapshot != nil {
+		// Taken already, the snapshot CR is created once the engine status is synced
+		return true, nil
+	}
+
+	log := getLoggerForBackup(bc.logger, backup)
+	hooks, err := backuphook.GetHooks(volume.Status.KubernetesStatus, bc.ds.GetPodRO)
+	if err != nil {
+		return false, err
+	}
+	onFailure := func(err error) {
+		log.WithError(err).Warn("Failed to run backup hook")
+		bc.eventRecorder.Eventf(backup, corev1.EventTypeWarning, constant.EventReasonFailedBackupHook, "volume %v: %v", volume.Name, err)
+	}
+	executed, err := backuphook.RunPreHooks(bc.kubeConfig, bc.kubeClient, log, hooks, onFailure)
+	defer backuphook.RunPostHooks(bc.kubeConfig, bc.kubeClient, log, executed, onFailure)
+	if err != nil {
+		return false, err
+	}
+
+	freezeFilesystem, err := bc.ds.GetFreezeFilesystemForSnapshotSetting(engine)
+	if err != nil {
+		return false, err
+	}
+	if _, err := engineClientProxy.SnapshotCreate(engine, backup.Spec.SnapshotName, nil, freezeFilesystem); err != nil {
+		return false, errors.Wrapf(err, "failed to take snapshot %v", backup.Spec.SnapshotName)
+	}
+	log.Infof("Took snapshot %v for backup", backup.Spec.SnapshotName)
+	return true, nil
+}
+
 // syncWithMonitor syncs the backup state/progress from the replica monitor
 func (bc *BackupController) syncWithMonitor(backup *longhorn.Backup, volume *longhorn.Volume, monitor *engineapi.BackupMonitor) error {
 	if backup == nil || volume == nil || monitor == nil {
@@ -1158,12 +1253,20 @@ func (bc *BackupController) setInprogressDeletionMap(backupURL string, state lon
 	bc.inProgressDeletingMap[backupURL].ErrorMessage = errMsg
 }
 
-func (bc *BackupController) deleteSnapshotAfterBackupCompleted(backup *longhorn.Backup) error {
+func (bc *BackupController) deleteSnapshotAfterBackupCompleted(backup *longhorn.Backup, backupTarget *longhorn.BackupTarget) error {
 	// If the backup is in the final state, delete the snapshot if needed
 	if _, ok := backup.Status.Labels[types.RecurringJobLabel]; ok {
 		// leave the snapshot management for recurring jobs to the `SettingNameAutoCleanupRecurringJobBackupSnapshot` setting.
 		return nil
 	}
+	if isReplicaBackup(backup) {
+		// leave the snapshot management to the source backup, which deletes the snapshot once the replication is done.
+		return nil
+	}
+	if !isBackupReplicationInFinalState(backup) && backupTarget != nil && backupTarget.Spec.ReplicationTargetName != "" {
+		// The snapshot is still required by the replication.
+		return nil
+	}
 
 	cleanupSnap, err := bc.ds.GetSettingAsBool(types.SettingNameAutoCleanupSnapshotAfterOnDemandBackupCompleted)
 	if err != nil {
@@ -1178,3 +1281,90 @@ func (bc *BackupController) deleteSnapshotAfterBackupCompleted(backup *longhorn.
 	}
 	return nil
 }
+
+func isReplicaBackup(backup *longhorn.Backup) bool {
+	_, ok := backup.Labels[types.GetLonghornLabelKey(types.LonghornLabelBackupReplicationSource)]
+	return ok
+}
+
+func isBackupReplicationInFinalState(backup *longhorn.Backup) bool {
+	return backup.Status.ReplicationState == longhorn.BackupReplicationStateCompleted ||
+		backup.Status.ReplicationState == longhorn.BackupReplicationStateError
+}
+
+// reconcileReplication replicates the completed backup to the replication target of its backup target by backing
+// up the same snapshot to the replication target, then follows the replica backup until it is in the final state.
+// The backup is not copied within the backupstore, so the replication requires the snapshot to still exist. The
+// snapshot is kept until the replication is in the final state. If the snapshot is gone before the replication
+// starts, for example removed by the user, the replication fails.
+func (bc *BackupController) reconcileReplication(backup *longhorn.Backup, backupTarget *longhorn.BackupTarget, volumeName string) (err error) {
+	if backup.Status.State != longhorn.BackupStateCompleted || backup.Spec.SnapshotName == "" || isReplicaBackup(backup) {
+		return nil
+	}
+
+	log := getLoggerForBackup(bc.logger, backup)
+
+	existingReplicationState := backup.Status.ReplicationState
+	defer func() {
+		if err != nil || existingReplicationState == backup.Status.ReplicationState || !isBackupReplicationInFinalState(backup) {
+			return
+		}
+		if err = bc.deleteSnapshotAfterBackupCompleted(backup, backupTarget); err != nil {
+			err = errors.Wrap(err, "failed to delete snapshot after backup replication finished")
+		}
+	}()
+
+	switch backup.Status.ReplicationState {
+	case longhorn.BackupReplicationStateNone:
+		if backupTarget == nil || backupTarget.Spec.ReplicationTargetName == "" {
+			return nil
+		}
+		if _, err := bc.ds.GetSnapshotRO(backup.Spec.SnapshotName); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "failed to get snapshot %v", backup.Spec.SnapshotName)
+			}
+			log.Warnf("Failed to replicate backup to backup target %v since snapshot %v is not found", backupTarget.Spec.ReplicationTargetName, backup.Spec.SnapshotName)
+			backup.Status.ReplicationTargetName = backupTarget.Spec.ReplicationTargetName
+			backup.Status.ReplicationState = longhorn.BackupReplicationStateError
+			return nil
+		}
+		replicaBackup := &longhorn.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: bsutil.GenerateName("backup"),
+				Labels: map[string]string{
+					types.LonghornLabelBackupTarget:                                       backupTarget.Spec.ReplicationTargetName,
+					types.GetLonghornLabelKey(types.LonghornLabelBackupReplicationSource): backup.Name,
+				},
+			},
+			Spec: longhorn.BackupSpec{
+				SnapshotName: backup.Spec.SnapshotName,
+				Labels:       backup.Spec.Labels,
+				BackupMode:   backup.Spec.BackupMode,
+			},
+		}
+		if _, err := bc.ds.CreateBackup(replicaBackup, volumeName); err != nil {
+			return errors.Wrapf(err, "failed to create replica backup on backup target %v", backupTarget.Spec.ReplicationTargetName)
+		}
+		log.Infof("Replicating backup to backup target %v as backup %v", backupTarget.Spec.ReplicationTargetName, replicaBackup.Name)
+		backup.Status.ReplicationTargetName = backupTarget.Spec.ReplicationTargetName
+		backup.Status.ReplicationBackupName = replicaBackup.Name
+		backup.Status.ReplicationState = longhorn.BackupReplicationStateInProgress
+	case longhorn.BackupReplicationStateInProgress:
+		replicaBackup, err := bc.ds.GetBackupRO(backup.Status.ReplicationBackupName)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "failed to get replica backup %v", backup.Status.ReplicationBackupName)
+			}
+			log.Warnf("Replica backup %v is not found", backup.Status.ReplicationBackupName)
+			backup.Status.ReplicationState = longhorn.BackupReplicationStateError
+			return nil
+		}
+		switch replicaBackup.Status.State {
+		case longhorn.BackupStateCompleted:
+			backup.Status.ReplicationState = longhorn.BackupReplicationStateCompleted
+		case longhorn.BackupStateError, longhorn.BackupStateUnknown:
+			backup.Status.ReplicationState = longhorn.BackupReplicationStateError
+		}
+	}
+	return nil
+}
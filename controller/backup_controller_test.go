@@ -0,0 +1,267 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+
+	. "gopkg.in/check.v1"
+)
+
+const (
+	TestReplicationBackupTargetName = "replication"
+	TestReplicaBackupName           = "test-replica-backup"
+	TestBackupSnapshotName          = "test-backup-snapshot"
+)
+
+type BackupReplicationTestCase struct {
+	autoCleanupSnapshot bool
+
+	backupTarget         *longhorn.BackupTarget
+	currentBackup        *longhorn.Backup
+	currentReplicaBackup *longhorn.Backup
+	currentSnapshot      *longhorn.Snapshot
+	expectedBackup       *longhorn.Backup
+	expectReplicaBackup  bool
+	expectSnapshot       bool
+}
+
+func newTestBackupController(lhClient *lhfake.Clientset, kubeClient *fake.Clientset, extensionsClient *apiextensionsfake.Clientset, informerFactories *util.InformerFactories) (*BackupController, error) {
+	// Skip the Lister check that occurs on creation of a Backup.
+	datastore.SkipListerCheck = true
+
+	ds := datastore.NewDataStore(TestNamespace, lhClient, kubeClient, extensionsClient, informerFactories)
+
+	logger := logrus.StandardLogger()
+	bc, err := NewBackupController(logger, ds, scheme.Scheme, kubeClient, nil, TestNode1, TestNamespace, util.NewAtomicCounter())
+	if err != nil {
+		return nil, err
+	}
+
+	fakeRecorder := record.NewFakeRecorder(100)
+	bc.eventRecorder = fakeRecorder
+	for index := range bc.cacheSyncs {
+		bc.cacheSyncs[index] = alwaysReady
+	}
+
+	return bc, nil
+}
+
+func getBackupReplicationTestTemplate() *BackupReplicationTestCase {
+	tc := &BackupReplicationTestCase{
+		autoCleanupSnapshot: true,
+		backupTarget: &longhorn.BackupTarget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      TestBackupTargetName,
+				Namespace: TestNamespace,
+			},
+			Spec: longhorn.BackupTargetSpec{
+				BackupTargetURL:       TestBackupTarget,
+				ReplicationTargetName: TestReplicationBackupTargetName,
+			},
+		},
+		currentBackup: &longhorn.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      TestBackupName,
+				Namespace: TestNamespace,
+				Labels: map[string]string{
+					types.LonghornLabelBackupTarget: TestBackupTargetName,
+					types.LonghornLabelBackupVolume: TestVolumeName,
+				},
+			},
+			Spec: longhorn.BackupSpec{
+				SnapshotName: TestBackupSnapshotName,
+			},
+			Status: longhorn.BackupStatus{
+				State:            longhorn.BackupStateCompleted,
+				BackupTargetName: TestBackupTargetName,
+			},
+		},
+		currentSnapshot: newSnapshot(TestBackupSnapshotName),
+	}
+	tc.expectedBackup = tc.currentBackup.DeepCopy()
+	return tc
+}
+
+func (tc *BackupReplicationTestCase) setReplicationInProgress(replicaBackupState longhorn.BackupState) {
+	tc.currentBackup.Status.ReplicationTargetName = TestReplicationBackupTargetName
+	tc.currentBackup.Status.ReplicationBackupName = TestReplicaBackupName
+	tc.currentBackup.Status.ReplicationState = longhorn.BackupReplicationStateInProgress
+	tc.expectedBackup = tc.currentBackup.DeepCopy()
+	if replicaBackupState == "" {
+		return
+	}
+	tc.currentReplicaBackup = &longhorn.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TestReplicaBackupName,
+			Namespace: TestNamespace,
+			Labels: map[string]string{
+				types.LonghornLabelBackupTarget:                                       TestReplicationBackupTargetName,
+				types.LonghornLabelBackupVolume:                                       TestVolumeName,
+				types.GetLonghornLabelKey(types.LonghornLabelBackupReplicationSource): TestBackupName,
+			},
+		},
+		Spec: longhorn.BackupSpec{
+			SnapshotName: TestBackupSnapshotName,
+		},
+		Status: longhorn.BackupStatus{
+			State:            replicaBackupState,
+			BackupTargetName: TestReplicationBackupTargetName,
+		},
+	}
+}
+
+func generateBackupReplicationTestCases() map[string]*BackupReplicationTestCase {
+	var tc *BackupReplicationTestCase
+	testCases := map[string]*BackupReplicationTestCase{}
+
+	// backup target without replication target
+	tc = getBackupReplicationTestTemplate()
+	tc.backupTarget.Spec.ReplicationTargetName = ""
+	tc.expectSnapshot = true
+	testCases["backup target without replication target"] = tc
+
+	// start replication
+	tc = getBackupReplicationTestTemplate()
+	tc.expectedBackup.Status.ReplicationTargetName = TestReplicationBackupTargetName
+	tc.expectedBackup.Status.ReplicationState = longhorn.BackupReplicationStateInProgress
+	tc.expectReplicaBackup = true
+	tc.expectSnapshot = true
+	testCases["start replication"] = tc
+
+	// snapshot removed before replication starts
+	tc = getBackupReplicationTestTemplate()
+	tc.currentSnapshot = nil
+	tc.expectedBackup.Status.ReplicationTargetName = TestReplicationBackupTargetName
+	tc.expectedBackup.Status.ReplicationState = longhorn.BackupReplicationStateError
+	testCases["snapshot removed before replication starts"] = tc
+
+	// replication in progress
+	tc = getBackupReplicationTestTemplate()
+	tc.setReplicationInProgress(longhorn.BackupStateInProgress)
+	tc.expectReplicaBackup = true
+	tc.expectSnapshot = true
+	testCases["replication in progress"] = tc
+
+	// replication completed
+	tc = getBackupReplicationTestTemplate()
+	tc.setReplicationInProgress(longhorn.BackupStateCompleted)
+	tc.expectedBackup.Status.ReplicationState = longhorn.BackupReplicationStateCompleted
+	tc.expectReplicaBackup = true
+	testCases["replication completed"] = tc
+
+	// replication completed without snapshot cleanup
+	tc = getBackupReplicationTestTemplate()
+	tc.autoCleanupSnapshot = false
+	tc.setReplicationInProgress(longhorn.BackupStateCompleted)
+	tc.expectedBackup.Status.ReplicationState = longhorn.BackupReplicationStateCompleted
+	tc.expectReplicaBackup = true
+	tc.expectSnapshot = true
+	testCases["replication completed without snapshot cleanup"] = tc
+
+	// replication of recurring job backup completed
+	tc = getBackupReplicationTestTemplate()
+	tc.currentBackup.Status.Labels = map[string]string{types.RecurringJobLabel: "backup"}
+	tc.setReplicationInProgress(longhorn.BackupStateCompleted)
+	tc.expectedBackup.Status.ReplicationState = longhorn.BackupReplicationStateCompleted
+	tc.expectReplicaBackup = true
+	tc.expectSnapshot = true
+	testCases["replication of recurring job backup completed"] = tc
+
+	// replication failed
+	tc = getBackupReplicationTestTemplate()
+	tc.setReplicationInProgress(longhorn.BackupStateError)
+	tc.expectedBackup.Status.ReplicationState = longhorn.BackupReplicationStateError
+	tc.expectReplicaBackup = true
+	testCases["replication failed"] = tc
+
+	// replica backup removed during replication
+	tc = getBackupReplicationTestTemplate()
+	tc.setReplicationInProgress("")
+	tc.expectedBackup.Status.ReplicationState = longhorn.BackupReplicationStateError
+	testCases["replica backup removed during replication"] = tc
+
+	return testCases
+}
+
+func (s *TestSuite) TestReconcileBackupReplication(c *C) {
+	for name, tc := range generateBackupReplicationTestCases() {
+		logrus.Debugf("Testing backup replication: %v", name)
+
+		kubeClient := fake.NewSimpleClientset()
+		lhClient := lhfake.NewSimpleClientset()
+		extensionsClient := apiextensionsfake.NewSimpleClientset()
+		informerFactories := util.NewInformerFactories(TestNamespace, kubeClient, lhClient, controller.NoResyncPeriodFunc())
+
+		settingIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Settings().Informer().GetIndexer()
+		backupIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Backups().Informer().GetIndexer()
+		snapshotIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Snapshots().Informer().GetIndexer()
+
+		bc, err := newTestBackupController(lhClient, kubeClient, extensionsClient, informerFactories)
+		c.Assert(err, IsNil)
+
+		setting, err := lhClient.LonghornV1beta2().Settings(TestNamespace).Create(context.TODO(), newSetting(string(types.SettingNameAutoCleanupSnapshotAfterOnDemandBackupCompleted), strconv.FormatBool(tc.autoCleanupSnapshot)), metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		err = settingIndexer.Add(setting)
+		c.Assert(err, IsNil)
+
+		backup, err := lhClient.LonghornV1beta2().Backups(TestNamespace).Create(context.TODO(), tc.currentBackup, metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		err = backupIndexer.Add(backup)
+		c.Assert(err, IsNil)
+		if tc.currentReplicaBackup != nil {
+			replicaBackup, err := lhClient.LonghornV1beta2().Backups(TestNamespace).Create(context.TODO(), tc.currentReplicaBackup, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = backupIndexer.Add(replicaBackup)
+			c.Assert(err, IsNil)
+		}
+		if tc.currentSnapshot != nil {
+			snapshot, err := lhClient.LonghornV1beta2().Snapshots(TestNamespace).Create(context.TODO(), tc.currentSnapshot, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = snapshotIndexer.Add(snapshot)
+			c.Assert(err, IsNil)
+		}
+
+		backup = backup.DeepCopy()
+		err = bc.reconcileReplication(backup, tc.backupTarget, TestVolumeName)
+		c.Assert(err, IsNil, Commentf("test case: %v", name))
+
+		replicaBackups, err := lhClient.LonghornV1beta2().Backups(TestNamespace).List(context.TODO(), metav1.ListOptions{
+			LabelSelector: types.GetLonghornLabelKey(types.LonghornLabelBackupReplicationSource) + "=" + TestBackupName,
+		})
+		c.Assert(err, IsNil)
+		if !tc.expectReplicaBackup {
+			c.Assert(replicaBackups.Items, HasLen, 0, Commentf("test case: %v", name))
+		} else {
+			c.Assert(replicaBackups.Items, HasLen, 1, Commentf("test case: %v", name))
+			replicaBackup := replicaBackups.Items[0]
+			c.Assert(replicaBackup.Labels[types.LonghornLabelBackupTarget], Equals, TestReplicationBackupTargetName)
+			c.Assert(replicaBackup.Spec.SnapshotName, Equals, TestBackupSnapshotName)
+			tc.expectedBackup.Status.ReplicationBackupName = replicaBackup.Name
+		}
+		c.Assert(backup.Status, DeepEquals, tc.expectedBackup.Status, Commentf("test case: %v", name))
+
+		_, err = lhClient.LonghornV1beta2().Snapshots(TestNamespace).Get(context.TODO(), TestBackupSnapshotName, metav1.GetOptions{})
+		if tc.expectSnapshot {
+			c.Assert(err, IsNil, Commentf("test case: %v", name))
+		} else {
+			c.Assert(datastore.ErrorIsNotFound(err), Equals, true, Commentf("test case: %v", name))
+		}
+	}
+}
@@ -0,0 +1,750 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientset "k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	etypes "github.com/longhorn/longhorn-engine/pkg/types"
+
+	"github.com/longhorn/longhorn-manager/constant"
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+type CloneScheduleController struct {
+	*baseController
+
+	// which namespace controller is running with
+	namespace string
+	// use as the OwnerID of the controller
+	controllerID string
+
+	kubeClient    clientset.Interface
+	eventRecorder record.EventRecorder
+
+	ds *datastore.DataStore
+
+	cacheSyncs []cache.InformerSynced
+}
+
+func NewCloneScheduleController(
+	logger logrus.FieldLogger,
+	ds *datastore.DataStore,
+	scheme *runtime.Scheme,
+	kubeClient clientset.Interface,
+	controllerID string,
+	namespace string) (*CloneScheduleController, error) {
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(logrus.Infof)
+	// TODO: remove the wrapper when every clients have moved to use the clientset.
+	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{
+		Interface: v1core.New(kubeClient.CoreV1().RESTClient()).Events(""),
+	})
+
+	csc := &CloneScheduleController{
+		baseController: newBaseController("longhorn-clone-schedule", logger),
+
+		namespace:    namespace,
+		controllerID: controllerID,
+
+		ds: ds,
+
+		kubeClient:    kubeClient,
+		eventRecorder: eventBroadcaster.NewRecorder(scheme, corev1.EventSource{Component: "longhorn-clone-schedule-controller"}),
+	}
+
+	var err error
+	if _, err = ds.CloneScheduleInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    csc.enqueueCloneSchedule,
+		UpdateFunc: func(old, cur interface{}) { csc.enqueueCloneSchedule(cur) },
+		DeleteFunc: csc.enqueueCloneSchedule,
+	}); err != nil {
+		return nil, err
+	}
+	csc.cacheSyncs = append(csc.cacheSyncs, ds.CloneScheduleInformer.HasSynced)
+
+	if _, err = ds.VolumeInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    csc.enqueueForVolume,
+		UpdateFunc: func(old, cur interface{}) { csc.enqueueForVolume(cur) },
+		DeleteFunc: csc.enqueueForVolume,
+	}, 0); err != nil {
+		return nil, err
+	}
+	csc.cacheSyncs = append(csc.cacheSyncs, ds.VolumeInformer.HasSynced)
+
+	if _, err = ds.LHVolumeAttachmentInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    csc.enqueueForVolumeAttachment,
+		UpdateFunc: func(old, cur interface{}) { csc.enqueueForVolumeAttachment(cur) },
+	}, 0); err != nil {
+		return nil, err
+	}
+	csc.cacheSyncs = append(csc.cacheSyncs, ds.LHVolumeAttachmentInformer.HasSynced)
+
+	return csc, nil
+}
+
+func (csc *CloneScheduleController) enqueueCloneSchedule(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to get key for object %#v: %v", obj, err))
+		return
+	}
+
+	csc.queue.Add(key)
+}
+
+func (csc *CloneScheduleController) enqueueCloneScheduleAfter(obj interface{}, duration time.Duration) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to get key for object %#v: %v", obj, err))
+		return
+	}
+
+	csc.queue.AddAfter(key, duration)
+}
+
+func (csc *CloneScheduleController) enqueueForVolume(obj interface{}) {
+	volume, ok := obj.(*longhorn.Volume)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("received unexpected obj: %#v", obj))
+			return
+		}
+		// use the last known state, to enqueue, dependent objects
+		volume, ok = deletedState.Obj.(*longhorn.Volume)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("DeletedFinalStateUnknown contained invalid object: %#v", deletedState.Obj))
+			return
+		}
+	}
+
+	csc.enqueueCloneSchedulesForVolume(volume.Name)
+}
+
+func (csc *CloneScheduleController) enqueueForVolumeAttachment(obj interface{}) {
+	va, ok := obj.(*longhorn.VolumeAttachment)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("received unexpected obj: %#v", obj))
+		return
+	}
+
+	csc.enqueueCloneSchedulesForVolume(va.Spec.Volume)
+}
+
+func (csc *CloneScheduleController) enqueueCloneSchedulesForVolume(volumeName string) {
+	cloneSchedules, err := csc.ds.ListCloneSchedulesRO()
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list clone schedules for volume %v since %v", volumeName, err))
+		return
+	}
+
+	for _, cloneSchedule := range cloneSchedules {
+		if cloneSchedule.Spec.SourceVolume == volumeName || cloneSchedule.Spec.TargetVolume == volumeName {
+			csc.enqueueCloneSchedule(cloneSchedule)
+		}
+	}
+}
+
+func (csc *CloneScheduleController) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer csc.queue.ShutDown()
+
+	csc.logger.Info("Starting Longhorn CloneSchedule controller")
+	defer csc.logger.Info("Shut down Longhorn CloneSchedule controller")
+
+	if !cache.WaitForNamedCacheSync(csc.name, stopCh, csc.cacheSyncs...) {
+		return
+	}
+	for i := 0; i < workers; i++ {
+		go wait.Until(csc.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (csc *CloneScheduleController) worker() {
+	for csc.processNextWorkItem() {
+	}
+}
+
+func (csc *CloneScheduleController) processNextWorkItem() bool {
+	key, quit := csc.queue.Get()
+	if quit {
+		return false
+	}
+	defer csc.queue.Done(key)
+	err := csc.syncCloneSchedule(key.(string))
+	csc.handleErr(err, key)
+	return true
+}
+
+func (csc *CloneScheduleController) handleErr(err error, key interface{}) {
+	if err == nil {
+		csc.queue.Forget(key)
+		return
+	}
+
+	log := csc.logger.WithField("cloneSchedule", key)
+	if csc.queue.NumRequeues(key) < maxRetries {
+		handleReconcileErrorLogging(log, err, "Failed to sync Longhorn clone schedule")
+		csc.queue.AddRateLimited(key)
+		return
+	}
+
+	utilruntime.HandleError(err)
+	handleReconcileErrorLogging(log, err, "Dropping Longhorn clone schedule out of the queue")
+	csc.queue.Forget(key)
+}
+
+func (csc *CloneScheduleController) syncCloneSchedule(key string) (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "failed to sync clone schedule %v", key)
+	}()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	if namespace != csc.namespace {
+		return nil
+	}
+	return csc.reconcile(name)
+}
+
+func getLoggerForCloneSchedule(logger logrus.FieldLogger, cloneSchedule *longhorn.CloneSchedule) *logrus.Entry {
+	return logger.WithFields(
+		logrus.Fields{
+			"cloneSchedule": cloneSchedule.Name,
+			"sourceVolume":  cloneSchedule.Spec.SourceVolume,
+			"targetVolume":  cloneSchedule.Spec.TargetVolume,
+		},
+	)
+}
+
+func (csc *CloneScheduleController) isResponsibleFor(cloneSchedule *longhorn.CloneSchedule) bool {
+	// The source volume outlives the target volume recreated by every refresh,
+	// hence it is used to decide the preferred owner.
+	preferredOwnerID := ""
+	if sourceVolume, err := csc.ds.GetVolumeRO(cloneSchedule.Spec.SourceVolume); err == nil {
+		preferredOwnerID = sourceVolume.Status.OwnerID
+	}
+	return isControllerResponsibleFor(csc.controllerID, csc.ds, cloneSchedule.Name, preferredOwnerID, cloneSchedule.Status.OwnerID)
+}
+
+func (csc *CloneScheduleController) reconcile(name string) (err error) {
+	cloneSchedule, err := csc.ds.GetCloneSchedule(name)
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	log := getLoggerForCloneSchedule(csc.logger, cloneSchedule)
+
+	if !csc.isResponsibleFor(cloneSchedule) {
+		return nil
+	}
+
+	if cloneSchedule.Status.OwnerID != csc.controllerID {
+		cloneSchedule.Status.OwnerID = csc.controllerID
+		cloneSchedule, err = csc.ds.UpdateCloneScheduleStatus(cloneSchedule)
+		if err != nil {
+			// we don't mind others coming first
+			if datastore.ErrorIsConflict(errors.Cause(err)) {
+				return nil
+			}
+			return err
+		}
+		log.Infof("Clone schedule got new owner %v", csc.controllerID)
+	}
+
+	if !cloneSchedule.DeletionTimestamp.IsZero() {
+		if err := csc.restoreAttachmentTickets(cloneSchedule); err != nil {
+			return err
+		}
+		if len(cloneSchedule.Status.AttachmentTickets) > 0 {
+			log.Warnf("Dropping the attachment tickets taken over from target volume %v since it doesn't exist", cloneSchedule.Spec.TargetVolume)
+		}
+		return csc.ds.RemoveFinalizerForCloneSchedule(cloneSchedule)
+	}
+
+	existingCloneSchedule := cloneSchedule.DeepCopy()
+	defer func() {
+		if err != nil {
+			return
+		}
+		if reflect.DeepEqual(existingCloneSchedule.Status, cloneSchedule.Status) {
+			return
+		}
+		if _, err := csc.ds.UpdateCloneScheduleStatus(cloneSchedule); err != nil && datastore.ErrorIsConflict(errors.Cause(err)) {
+			log.WithError(err).Debugf("Requeue %v due to conflict", name)
+			csc.enqueueCloneSchedule(cloneSchedule)
+		}
+	}()
+
+	switch cloneSchedule.Status.State {
+	case longhorn.CloneScheduleStateDetaching:
+		return csc.reconcileDetaching(cloneSchedule)
+	case longhorn.CloneScheduleStateRefreshing:
+		return csc.reconcileRefreshing(cloneSchedule)
+	case longhorn.CloneScheduleStateAttaching:
+		return csc.reconcileAttaching(cloneSchedule)
+	default:
+		return csc.reconcileSchedule(cloneSchedule)
+	}
+}
+
+// reconcileSchedule starts a refresh once the next scheduled time is reached, otherwise requeues the clone schedule
+// at the next scheduled time.
+func (csc *CloneScheduleController) reconcileSchedule(cloneSchedule *longhorn.CloneSchedule) error {
+	log := getLoggerForCloneSchedule(csc.logger, cloneSchedule)
+
+	if cloneSchedule.Status.State == "" {
+		cloneSchedule.Status.State = longhorn.CloneScheduleStateIdle
+	}
+	// The tickets are left behind when a refresh failed while the target volume didn't exist
+	if err := csc.restoreAttachmentTickets(cloneSchedule); err != nil {
+		return err
+	}
+	if cloneSchedule.Spec.Suspend {
+		return nil
+	}
+
+	schedule, err := cron.ParseStandard(cloneSchedule.Spec.Cron)
+	if err != nil {
+		return errors.Wrapf(err, "invalid cron %v", cloneSchedule.Spec.Cron)
+	}
+	lastScheduleTime := cloneSchedule.CreationTimestamp.Time
+	if cloneSchedule.Status.LastScheduleTime != "" {
+		if lastScheduleTime, err = util.ParseTime(cloneSchedule.Status.LastScheduleTime); err != nil {
+			return errors.Wrapf(err, "failed to parse last schedule time %v", cloneSchedule.Status.LastScheduleTime)
+		}
+	}
+	if remaining := time.Until(schedule.Next(lastScheduleTime)); remaining > 0 {
+		csc.enqueueCloneScheduleAfter(cloneSchedule, remaining)
+		return nil
+	}
+
+	cloneSchedule.Status.LastScheduleTime = util.Now()
+
+	source, err := csc.getRefreshSource(cloneSchedule)
+	if err != nil {
+		return csc.setError(cloneSchedule, err.Error())
+	}
+	if _, err := csc.ds.GetVolumeRO(cloneSchedule.Spec.TargetVolume); err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			return err
+		}
+		return csc.setError(cloneSchedule, fmt.Sprintf("target volume %v is not found", cloneSchedule.Spec.TargetVolume))
+	}
+
+	log.Infof("Starting to refresh target volume from %v %v", cloneSchedule.Spec.SourceType, source)
+	csc.eventRecorder.Eventf(cloneSchedule, corev1.EventTypeNormal, constant.EventReasonRefreshing,
+		"Refreshing volume %v from %v %v", cloneSchedule.Spec.TargetVolume, cloneSchedule.Spec.SourceType, source)
+
+	cloneSchedule.Status.LastRefreshSource = source
+	cloneSchedule.Status.Message = ""
+	cloneSchedule.Status.State = longhorn.CloneScheduleStateDetaching
+	return csc.reconcileDetaching(cloneSchedule)
+}
+
+// getRefreshSource returns the name of the latest ready snapshot or the URL of the latest completed backup
+// of the source volume.
+func (csc *CloneScheduleController) getRefreshSource(cloneSchedule *longhorn.CloneSchedule) (string, error) {
+	if _, err := csc.ds.GetVolumeRO(cloneSchedule.Spec.SourceVolume); err != nil {
+		return "", errors.Wrapf(err, "failed to get source volume %v", cloneSchedule.Spec.SourceVolume)
+	}
+
+	if cloneSchedule.Spec.SourceType == longhorn.CloneScheduleSourceTypeBackup {
+		backup, err := csc.getLatestBackup(cloneSchedule.Spec.SourceVolume)
+		if err != nil {
+			return "", err
+		}
+		if backup == nil {
+			return "", fmt.Errorf("no completed backup of source volume %v", cloneSchedule.Spec.SourceVolume)
+		}
+		return backup.Status.URL, nil
+	}
+
+	snapshots, err := csc.ds.ListVolumeSnapshotsRO(cloneSchedule.Spec.SourceVolume)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to list snapshots of source volume %v", cloneSchedule.Spec.SourceVolume)
+	}
+	var latest *longhorn.Snapshot
+	var latestCreationTime time.Time
+	for _, snapshot := range snapshots {
+		if snapshot.Name == etypes.VolumeHeadName || !snapshot.Status.ReadyToUse || snapshot.Status.MarkRemoved || snapshot.DeletionTimestamp != nil {
+			continue
+		}
+		creationTime, err := util.ParseTime(snapshot.Status.CreationTime)
+		if err != nil {
+			continue
+		}
+		if latest == nil || creationTime.After(latestCreationTime) {
+			latest = snapshot
+			latestCreationTime = creationTime
+		}
+	}
+	if latest == nil {
+		return "", fmt.Errorf("no ready snapshot of source volume %v", cloneSchedule.Spec.SourceVolume)
+	}
+	return latest.Name, nil
+}
+
+func (csc *CloneScheduleController) getLatestBackup(volumeName string) (*longhorn.Backup, error) {
+	backups, err := csc.ds.ListBackupsWithVolumeNameRO(volumeName, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list backups of source volume %v", volumeName)
+	}
+	var latest *longhorn.Backup
+	var latestCreationTime time.Time
+	for _, backup := range backups {
+		if backup.Status.State != longhorn.BackupStateCompleted || backup.Status.URL == "" || backup.DeletionTimestamp != nil {
+			continue
+		}
+		creationTime, err := util.ParseTime(backup.Status.SnapshotCreatedAt)
+		if err != nil {
+			continue
+		}
+		if latest == nil || creationTime.After(latestCreationTime) {
+			latest = backup
+			latestCreationTime = creationTime
+		}
+	}
+	return latest, nil
+}
+
+// reconcileDetaching takes over the attachment tickets of the target volume and waits for the volume to be detached.
+// The refresh is postponed while the target volume is used by workloads, since the pods would keep the staging and bind
+// mounts of the deleted volume.
+func (csc *CloneScheduleController) reconcileDetaching(cloneSchedule *longhorn.CloneSchedule) error {
+	targetVolume, err := csc.ds.GetVolume(cloneSchedule.Spec.TargetVolume)
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			return err
+		}
+		return csc.setError(cloneSchedule, fmt.Sprintf("target volume %v is not found", cloneSchedule.Spec.TargetVolume))
+	}
+
+	va, err := csc.ds.GetLHVolumeAttachmentByVolumeName(targetVolume.Name)
+	if err != nil && !datastore.ErrorIsNotFound(err) {
+		return err
+	}
+
+	if workload := getCloneScheduleTargetWorkload(targetVolume, va); workload != "" {
+		// Hand back the tickets taken over so far, the volume stays attached for the workload anyway.
+		if err := csc.restoreAttachmentTickets(cloneSchedule); err != nil {
+			return err
+		}
+		cloneSchedule.Status.Message = fmt.Sprintf("waiting for %v using target volume %v to be scaled down", workload, targetVolume.Name)
+		return nil
+	}
+	cloneSchedule.Status.Message = ""
+
+	// Tickets added during the detachment are taken over as well, so that the volume can be detached. Workload tickets
+	// are not among them, since the refresh waits above until they are gone.
+	if va != nil && len(va.Spec.AttachmentTickets) > 0 {
+		if cloneSchedule.Status.AttachmentTickets == nil {
+			cloneSchedule.Status.AttachmentTickets = map[string]*longhorn.AttachmentTicket{}
+		}
+		for id, ticket := range va.Spec.AttachmentTickets {
+			cloneSchedule.Status.AttachmentTickets[id] = ticket.DeepCopy()
+		}
+		// Persist the tickets before removing them from the volume attachment, otherwise they may be lost.
+		updated, err := csc.ds.UpdateCloneScheduleStatus(cloneSchedule)
+		if err != nil {
+			return err
+		}
+		updated.DeepCopyInto(cloneSchedule)
+		va.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{}
+		_, err = csc.ds.UpdateLHVolumeAttachment(va)
+		return err
+	}
+
+	if targetVolume.Status.State != longhorn.VolumeStateDetached {
+		return nil
+	}
+
+	// The clone schedule label is only needed while the target volume is deleted for the refresh, the recreated volume
+	// doesn't get it.
+	labelKey := types.GetLonghornLabelKey(types.LonghornLabelCloneSchedule)
+	labels := map[string]string{}
+	for key, value := range targetVolume.Labels {
+		if key != labelKey {
+			labels[key] = value
+		}
+	}
+	cloneSchedule.Status.TargetVolumeSpec = targetVolume.Spec.DeepCopy()
+	cloneSchedule.Status.TargetVolumeLabels = labels
+	cloneSchedule.Status.State = longhorn.CloneScheduleStateRefreshing
+	return nil
+}
+
+// getCloneScheduleTargetWorkload returns a description of the workload using the target volume, which is either a
+// running workload pod or a CSI attachment ticket. An empty string is returned if there is none.
+func getCloneScheduleTargetWorkload(targetVolume *longhorn.Volume, va *longhorn.VolumeAttachment) string {
+	kubeStatus := targetVolume.Status.KubernetesStatus
+	if kubeStatus.LastPodRefAt == "" {
+		for _, workload := range kubeStatus.WorkloadsStatus {
+			if workload.PodStatus == string(corev1.PodPending) || workload.PodStatus == string(corev1.PodRunning) {
+				return fmt.Sprintf("pod %v/%v", kubeStatus.Namespace, workload.PodName)
+			}
+		}
+	}
+	if va == nil {
+		return ""
+	}
+	ids := []string{}
+	for id, ticket := range va.Spec.AttachmentTickets {
+		if ticket != nil && ticket.Type == longhorn.AttacherTypeCSIAttacher {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+	sort.Strings(ids)
+	return fmt.Sprintf("CSI attachment ticket %v", ids[0])
+}
+
+// reconcileRefreshing deletes the target volume and recreates it from the refresh source.
+// The PV and PVC of the target volume are kept and bound to the recreated volume.
+func (csc *CloneScheduleController) reconcileRefreshing(cloneSchedule *longhorn.CloneSchedule) error {
+	log := getLoggerForCloneSchedule(csc.logger, cloneSchedule)
+
+	if cloneSchedule.Status.TargetVolumeSpec == nil {
+		return csc.setError(cloneSchedule, "the spec of the target volume was not recorded before the refresh")
+	}
+
+	lastScheduleTime, err := util.ParseTime(cloneSchedule.Status.LastScheduleTime)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse last schedule time %v", cloneSchedule.Status.LastScheduleTime)
+	}
+
+	targetVolume, err := csc.ds.GetVolume(cloneSchedule.Spec.TargetVolume)
+	if err != nil && !datastore.ErrorIsNotFound(err) {
+		return err
+	}
+	if targetVolume != nil {
+		if !targetVolume.CreationTimestamp.Time.Before(lastScheduleTime) {
+			cloneSchedule.Status.State = longhorn.CloneScheduleStateAttaching
+			return nil
+		}
+		if targetVolume.DeletionTimestamp != nil {
+			return nil
+		}
+	}
+
+	// The refresh source is validated before deleting the target volume, so that a failed refresh leaves the target
+	// volume in place.
+	spec, failure, err := csc.getRefreshedTargetVolumeSpec(cloneSchedule)
+	if err != nil {
+		return err
+	}
+	if failure != "" {
+		return csc.setError(cloneSchedule, failure)
+	}
+
+	if targetVolume != nil {
+		labelKey := types.GetLonghornLabelKey(types.LonghornLabelCloneSchedule)
+		if targetVolume.Labels[labelKey] != cloneSchedule.Name {
+			if targetVolume.Labels == nil {
+				targetVolume.Labels = map[string]string{}
+			}
+			targetVolume.Labels[labelKey] = cloneSchedule.Name
+			if _, err := csc.ds.UpdateVolume(targetVolume); err != nil {
+				return err
+			}
+			return nil
+		}
+		log.Info("Deleting target volume for the refresh")
+		return csc.ds.DeleteVolume(targetVolume.Name)
+	}
+
+	labels := map[string]string{}
+	for key, value := range cloneSchedule.Status.TargetVolumeLabels {
+		labels[key] = value
+	}
+	volume := &longhorn.Volume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   cloneSchedule.Spec.TargetVolume,
+			Labels: labels,
+		},
+		Spec: *spec,
+	}
+	if _, err := csc.ds.CreateVolume(volume); err != nil {
+		return errors.Wrapf(err, "failed to recreate target volume %v", volume.Name)
+	}
+	log.Info("Recreated target volume for the refresh")
+
+	cloneSchedule.Status.State = longhorn.CloneScheduleStateAttaching
+	return nil
+}
+
+// getRefreshedTargetVolumeSpec returns the spec of the target volume recreated from the refresh source. The size of
+// the recreated volume is the larger one of the target volume and the source, so that an expanded target volume is
+// not shrunk. A failure message is returned if the refresh source is gone.
+func (csc *CloneScheduleController) getRefreshedTargetVolumeSpec(cloneSchedule *longhorn.CloneSchedule) (*longhorn.VolumeSpec, string, error) {
+	spec := cloneSchedule.Status.TargetVolumeSpec.DeepCopy()
+	spec.NodeID = ""
+	spec.MigrationNodeID = ""
+	spec.DataSource = ""
+	spec.FromBackup = ""
+	spec.Standby = false
+	spec.TrashedAt = ""
+
+	var sourceSize int64
+	if cloneSchedule.Spec.SourceType == longhorn.CloneScheduleSourceTypeBackup {
+		backup, err := csc.getBackupByURL(cloneSchedule.Spec.SourceVolume, cloneSchedule.Status.LastRefreshSource)
+		if err != nil {
+			return nil, "", err
+		}
+		if backup == nil {
+			return nil, fmt.Sprintf("backup %v is not found", cloneSchedule.Status.LastRefreshSource), nil
+		}
+		if sourceSize, err = strconv.ParseInt(backup.Status.VolumeSize, 10, 64); err != nil {
+			return nil, "", errors.Wrapf(err, "failed to parse volume size %v of backup %v", backup.Status.VolumeSize, backup.Name)
+		}
+		spec.FromBackup = backup.Status.URL
+		spec.BackupTargetName = backup.Status.BackupTargetName
+	} else {
+		sourceVolume, err := csc.ds.GetVolumeRO(cloneSchedule.Spec.SourceVolume)
+		if err != nil {
+			if !datastore.ErrorIsNotFound(err) {
+				return nil, "", err
+			}
+			return nil, fmt.Sprintf("source volume %v is not found", cloneSchedule.Spec.SourceVolume), nil
+		}
+		spec.DataSource = types.NewVolumeDataSourceTypeSnapshot(sourceVolume.Name, cloneSchedule.Status.LastRefreshSource)
+		sourceSize = sourceVolume.Spec.Size
+	}
+	if sourceSize > spec.Size {
+		spec.Size = sourceSize
+	}
+	return spec, "", nil
+}
+
+func (csc *CloneScheduleController) getBackupByURL(volumeName, url string) (*longhorn.Backup, error) {
+	backups, err := csc.ds.ListBackupsWithVolumeNameRO(volumeName, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list backups of source volume %v", volumeName)
+	}
+	for _, backup := range backups {
+		if backup.Status.URL == url {
+			return backup, nil
+		}
+	}
+	return nil, nil
+}
+
+// reconcileAttaching waits for the data of the recreated target volume to be ready, then hands the
+// attachment tickets back to the target volume.
+func (csc *CloneScheduleController) reconcileAttaching(cloneSchedule *longhorn.CloneSchedule) error {
+	log := getLoggerForCloneSchedule(csc.logger, cloneSchedule)
+
+	targetVolume, err := csc.ds.GetVolumeRO(cloneSchedule.Spec.TargetVolume)
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			return err
+		}
+		return csc.setError(cloneSchedule, fmt.Sprintf("target volume %v is not found", cloneSchedule.Spec.TargetVolume))
+	}
+
+	if cloneSchedule.Spec.SourceType == longhorn.CloneScheduleSourceTypeBackup {
+		if !targetVolume.Status.RestoreInitiated || targetVolume.Status.RestoreRequired {
+			return nil
+		}
+	} else {
+		switch targetVolume.Status.CloneStatus.State {
+		case longhorn.VolumeCloneStateCompleted:
+		case longhorn.VolumeCloneStateFailed:
+			return csc.setError(cloneSchedule, fmt.Sprintf("failed to clone snapshot %v to target volume %v",
+				cloneSchedule.Status.LastRefreshSource, targetVolume.Name))
+		default:
+			return nil
+		}
+	}
+
+	if err := csc.restoreAttachmentTickets(cloneSchedule); err != nil {
+		return err
+	}
+	if len(cloneSchedule.Status.AttachmentTickets) > 0 {
+		// The volume attachment is created by the volume controller
+		return nil
+	}
+
+	log.Infof("Refreshed target volume from %v %v", cloneSchedule.Spec.SourceType, cloneSchedule.Status.LastRefreshSource)
+	csc.eventRecorder.Eventf(cloneSchedule, corev1.EventTypeNormal, constant.EventReasonRefreshed,
+		"Refreshed volume %v from %v %v", targetVolume.Name, cloneSchedule.Spec.SourceType, cloneSchedule.Status.LastRefreshSource)
+
+	cloneSchedule.Status.LastRefreshTime = util.Now()
+	cloneSchedule.Status.TargetVolumeSpec = nil
+	cloneSchedule.Status.TargetVolumeLabels = nil
+	cloneSchedule.Status.State = longhorn.CloneScheduleStateIdle
+	csc.enqueueCloneSchedule(cloneSchedule)
+	return nil
+}
+
+// restoreAttachmentTickets hands the attachment tickets taken over from the target volume back to it. The tickets
+// are kept in the status while the volume attachment of the target volume doesn't exist.
+func (csc *CloneScheduleController) restoreAttachmentTickets(cloneSchedule *longhorn.CloneSchedule) error {
+	if len(cloneSchedule.Status.AttachmentTickets) == 0 {
+		return nil
+	}
+
+	va, err := csc.ds.GetLHVolumeAttachmentByVolumeName(cloneSchedule.Spec.TargetVolume)
+	if err != nil {
+		if datastore.ErrorIsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if va.Spec.AttachmentTickets == nil {
+		va.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{}
+	}
+	for id, ticket := range cloneSchedule.Status.AttachmentTickets {
+		if _, ok := va.Spec.AttachmentTickets[id]; !ok {
+			va.Spec.AttachmentTickets[id] = ticket
+		}
+	}
+	if _, err := csc.ds.UpdateLHVolumeAttachment(va); err != nil {
+		return err
+	}
+	cloneSchedule.Status.AttachmentTickets = nil
+	return nil
+}
+
+// setError records the failure of the current refresh and hands the attachment tickets taken over from the target
+// volume back to it right away.
+func (csc *CloneScheduleController) setError(cloneSchedule *longhorn.CloneSchedule, message string) error {
+	getLoggerForCloneSchedule(csc.logger, cloneSchedule).Warnf("Failed to refresh target volume: %v", message)
+	csc.eventRecorder.Event(cloneSchedule, corev1.EventTypeWarning, constant.EventReasonFailed, message)
+
+	cloneSchedule.Status.State = longhorn.CloneScheduleStateError
+	cloneSchedule.Status.Message = message
+	return csc.restoreAttachmentTickets(cloneSchedule)
+}
@@ -0,0 +1,402 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+
+	. "gopkg.in/check.v1"
+)
+
+const (
+	TestCloneScheduleName         = "test-clone-schedule"
+	TestCloneScheduleSourceVolume = "test-clone-source"
+	TestCloneScheduleTargetVolume = "test-clone-target"
+	TestCloneScheduleSnapshot     = "test-clone-snapshot"
+	TestCloneScheduleTicketID     = "test-clone-ticket"
+)
+
+type CloneScheduleControllerTestCase struct {
+	currentCloneSchedule *longhorn.CloneSchedule
+	currentSourceVolume  *longhorn.Volume
+	currentTargetVolume  *longhorn.Volume
+	currentSnapshot      *longhorn.Snapshot
+	currentVA            *longhorn.VolumeAttachment
+
+	expectedCloneSchedule *longhorn.CloneSchedule
+	expectedTargetVolume  *longhorn.Volume
+	expectedVA            *longhorn.VolumeAttachment
+}
+
+func newTestCloneScheduleController(lhClient *lhfake.Clientset, kubeClient *fake.Clientset, extensionsClient *apiextensionsfake.Clientset,
+	informerFactories *util.InformerFactories) (*CloneScheduleController, error) {
+	// Skip the Lister check that occurs on creation of a volume.
+	datastore.SkipListerCheck = true
+
+	ds := datastore.NewDataStore(TestNamespace, lhClient, kubeClient, extensionsClient, informerFactories)
+
+	logger := logrus.StandardLogger()
+	csc, err := NewCloneScheduleController(logger, ds, scheme.Scheme, kubeClient, TestNode1, TestNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	fakeRecorder := record.NewFakeRecorder(100)
+	csc.eventRecorder = fakeRecorder
+	for index := range csc.cacheSyncs {
+		csc.cacheSyncs[index] = alwaysReady
+	}
+
+	return csc, nil
+}
+
+func newCloneScheduleAttachmentTicket() *longhorn.AttachmentTicket {
+	return &longhorn.AttachmentTicket{
+		ID:     TestCloneScheduleTicketID,
+		Type:   longhorn.AttacherTypeLonghornAPI,
+		NodeID: TestNode1,
+	}
+}
+
+func getTestCloneScheduleTime(offset time.Duration) time.Time {
+	now, _ := time.Parse(time.RFC3339, getTestNow())
+	return now.Add(offset)
+}
+
+func getCloneScheduleControllerTestTemplate() *CloneScheduleControllerTestCase {
+	tc := &CloneScheduleControllerTestCase{
+		currentCloneSchedule: &longhorn.CloneSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              TestCloneScheduleName,
+				Namespace:         TestNamespace,
+				CreationTimestamp: metav1.NewTime(getTestCloneScheduleTime(-3 * time.Hour)),
+				Finalizers: []string{
+					longhorn.SchemeGroupVersion.Group,
+				},
+			},
+			Spec: longhorn.CloneScheduleSpec{
+				SourceVolume: TestCloneScheduleSourceVolume,
+				TargetVolume: TestCloneScheduleTargetVolume,
+				SourceType:   longhorn.CloneScheduleSourceTypeSnapshot,
+				Cron:         "0 * * * *",
+			},
+			Status: longhorn.CloneScheduleStatus{
+				OwnerID:          TestNode1,
+				State:            longhorn.CloneScheduleStateIdle,
+				LastScheduleTime: getTestNow(),
+			},
+		},
+		currentSourceVolume: newVolume(TestCloneScheduleSourceVolume, 1),
+		currentTargetVolume: newVolume(TestCloneScheduleTargetVolume, 1),
+		currentSnapshot:     newSnapshot(TestCloneScheduleSnapshot),
+		currentVA:           newVolumeAttachment(TestCloneScheduleTargetVolume),
+	}
+
+	tc.currentSourceVolume.Namespace = TestNamespace
+	tc.currentSourceVolume.Status.OwnerID = TestNode1
+	tc.currentTargetVolume.Namespace = TestNamespace
+	tc.currentTargetVolume.CreationTimestamp = metav1.NewTime(getTestCloneScheduleTime(-3 * time.Hour))
+	tc.currentTargetVolume.Status.State = longhorn.VolumeStateAttached
+
+	tc.currentSnapshot.Labels = map[string]string{types.LonghornLabelVolume: TestCloneScheduleSourceVolume}
+	tc.currentSnapshot.Spec.Volume = TestCloneScheduleSourceVolume
+	tc.currentSnapshot.Status.ReadyToUse = true
+	tc.currentSnapshot.Status.CreationTime = getTestNow()
+
+	tc.currentVA.Name = types.GetLHVolumeAttachmentNameFromVolumeName(TestCloneScheduleTargetVolume)
+	tc.currentVA.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{
+		TestCloneScheduleTicketID: newCloneScheduleAttachmentTicket(),
+	}
+
+	return tc
+}
+
+// setRefreshInProgress moves the template to the given refresh state, with the attachment tickets of the target
+// volume taken over by the clone schedule.
+func (tc *CloneScheduleControllerTestCase) setRefreshInProgress(state longhorn.CloneScheduleState) {
+	tc.currentCloneSchedule.Status.State = state
+	tc.currentCloneSchedule.Status.LastRefreshSource = TestCloneScheduleSnapshot
+	tc.currentCloneSchedule.Status.AttachmentTickets = map[string]*longhorn.AttachmentTicket{
+		TestCloneScheduleTicketID: newCloneScheduleAttachmentTicket(),
+	}
+	tc.currentVA.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{}
+	tc.currentTargetVolume.Status.State = longhorn.VolumeStateDetached
+	if state != longhorn.CloneScheduleStateDetaching {
+		tc.currentCloneSchedule.Status.TargetVolumeSpec = tc.currentTargetVolume.Spec.DeepCopy()
+		tc.currentCloneSchedule.Status.TargetVolumeLabels = map[string]string{"app": "test"}
+	}
+}
+
+func (tc *CloneScheduleControllerTestCase) copyCurrentToExpected() {
+	if tc.currentCloneSchedule != nil {
+		tc.expectedCloneSchedule = tc.currentCloneSchedule.DeepCopy()
+	}
+	if tc.currentTargetVolume != nil {
+		tc.expectedTargetVolume = tc.currentTargetVolume.DeepCopy()
+	}
+	if tc.currentVA != nil {
+		tc.expectedVA = tc.currentVA.DeepCopy()
+	}
+}
+
+func generateCloneScheduleControllerTestCases() map[string]*CloneScheduleControllerTestCase {
+	var tc *CloneScheduleControllerTestCase
+	testCases := map[string]*CloneScheduleControllerTestCase{}
+	ticket := newCloneScheduleAttachmentTicket()
+	labelKey := types.GetLonghornLabelKey(types.LonghornLabelCloneSchedule)
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.currentCloneSchedule.Status.LastScheduleTime = util.Now()
+	tc.copyCurrentToExpected()
+	testCases["clone schedule is not due"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.currentCloneSchedule.Spec.Suspend = true
+	tc.copyCurrentToExpected()
+	testCases["clone schedule is suspended"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.currentSnapshot = nil
+	tc.copyCurrentToExpected()
+	tc.expectedCloneSchedule.Status.State = longhorn.CloneScheduleStateError
+	tc.expectedCloneSchedule.Status.Message = fmt.Sprintf("no ready snapshot of source volume %v", TestCloneScheduleSourceVolume)
+	testCases["clone schedule fails without snapshot and keeps the target volume attached"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.copyCurrentToExpected()
+	tc.expectedCloneSchedule.Status.State = longhorn.CloneScheduleStateDetaching
+	tc.expectedCloneSchedule.Status.LastRefreshSource = TestCloneScheduleSnapshot
+	tc.expectedCloneSchedule.Status.AttachmentTickets = map[string]*longhorn.AttachmentTicket{ticket.ID: ticket}
+	tc.expectedVA.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{}
+	testCases["clone schedule starts refresh and takes over attachment tickets"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.currentVA.Spec.AttachmentTickets["csi-ticket"] = &longhorn.AttachmentTicket{
+		ID:     "csi-ticket",
+		Type:   longhorn.AttacherTypeCSIAttacher,
+		NodeID: TestNode1,
+	}
+	tc.copyCurrentToExpected()
+	tc.expectedCloneSchedule.Status.State = longhorn.CloneScheduleStateDetaching
+	tc.expectedCloneSchedule.Status.LastRefreshSource = TestCloneScheduleSnapshot
+	tc.expectedCloneSchedule.Status.Message = fmt.Sprintf("waiting for CSI attachment ticket csi-ticket using target volume %v to be scaled down", TestCloneScheduleTargetVolume)
+	testCases["clone schedule postpones refresh and keeps attachment tickets while the target volume has a CSI ticket"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateDetaching)
+	tc.currentTargetVolume.Status.KubernetesStatus = longhorn.KubernetesStatus{
+		Namespace:       "default",
+		WorkloadsStatus: []longhorn.WorkloadStatus{{PodName: "test-pod", PodStatus: string(corev1.PodRunning)}},
+	}
+	tc.copyCurrentToExpected()
+	tc.expectedCloneSchedule.Status.Message = fmt.Sprintf("waiting for pod default/test-pod using target volume %v to be scaled down", TestCloneScheduleTargetVolume)
+	tc.expectedCloneSchedule.Status.AttachmentTickets = nil
+	tc.expectedVA.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{ticket.ID: ticket}
+	testCases["clone schedule hands back attachment tickets while a workload pod uses the target volume"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateDetaching)
+	tc.currentTargetVolume.Status.State = longhorn.VolumeStateDetaching
+	tc.copyCurrentToExpected()
+	testCases["clone schedule waits for target volume detachment"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateDetaching)
+	tc.currentTargetVolume = nil
+	tc.copyCurrentToExpected()
+	tc.expectedCloneSchedule.Status.State = longhorn.CloneScheduleStateError
+	tc.expectedCloneSchedule.Status.Message = fmt.Sprintf("target volume %v is not found", TestCloneScheduleTargetVolume)
+	tc.expectedCloneSchedule.Status.AttachmentTickets = nil
+	tc.expectedVA.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{ticket.ID: ticket}
+	testCases["clone schedule hands back attachment tickets once the target volume is gone while detaching"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateDetaching)
+	tc.currentTargetVolume.Labels = map[string]string{"app": "test", labelKey: TestCloneScheduleName}
+	tc.copyCurrentToExpected()
+	tc.expectedCloneSchedule.Status.State = longhorn.CloneScheduleStateRefreshing
+	tc.expectedCloneSchedule.Status.TargetVolumeSpec = tc.currentTargetVolume.Spec.DeepCopy()
+	tc.expectedCloneSchedule.Status.TargetVolumeLabels = map[string]string{"app": "test"}
+	testCases["clone schedule records target volume without the clone schedule label once detached"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateRefreshing)
+	tc.copyCurrentToExpected()
+	tc.expectedTargetVolume.Labels = map[string]string{labelKey: TestCloneScheduleName}
+	testCases["clone schedule labels target volume before deleting it for the refresh"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateRefreshing)
+	tc.currentSourceVolume = nil
+	tc.copyCurrentToExpected()
+	tc.expectedCloneSchedule.Status.State = longhorn.CloneScheduleStateError
+	tc.expectedCloneSchedule.Status.Message = fmt.Sprintf("source volume %v is not found", TestCloneScheduleSourceVolume)
+	tc.expectedCloneSchedule.Status.AttachmentTickets = nil
+	tc.expectedVA.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{ticket.ID: ticket}
+	testCases["clone schedule keeps the target volume and hands back attachment tickets once the source volume is gone"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateRefreshing)
+	tc.currentTargetVolume.Labels = map[string]string{labelKey: TestCloneScheduleName}
+	tc.copyCurrentToExpected()
+	tc.expectedTargetVolume = nil
+	testCases["clone schedule deletes target volume for the refresh"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateRefreshing)
+	tc.currentCloneSchedule.Status.TargetVolumeSpec.Size = 2 * TestVolumeSize
+	tc.currentTargetVolume = nil
+	tc.copyCurrentToExpected()
+	tc.expectedCloneSchedule.Status.State = longhorn.CloneScheduleStateAttaching
+	tc.expectedTargetVolume = newVolume(TestCloneScheduleTargetVolume, 1)
+	tc.expectedTargetVolume.Spec.Size = 2 * TestVolumeSize
+	tc.expectedTargetVolume.Spec.DataSource = types.NewVolumeDataSourceTypeSnapshot(TestCloneScheduleSourceVolume, TestCloneScheduleSnapshot)
+	tc.expectedTargetVolume.Labels = map[string]string{"app": "test"}
+	testCases["clone schedule recreates expanded target volume with its own size"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateRefreshing)
+	tc.currentSourceVolume.Spec.Size = 2 * TestVolumeSize
+	tc.currentTargetVolume = nil
+	tc.copyCurrentToExpected()
+	tc.expectedCloneSchedule.Status.State = longhorn.CloneScheduleStateAttaching
+	tc.expectedTargetVolume = newVolume(TestCloneScheduleTargetVolume, 1)
+	tc.expectedTargetVolume.Spec.Size = 2 * TestVolumeSize
+	tc.expectedTargetVolume.Spec.DataSource = types.NewVolumeDataSourceTypeSnapshot(TestCloneScheduleSourceVolume, TestCloneScheduleSnapshot)
+	tc.expectedTargetVolume.Labels = map[string]string{"app": "test"}
+	testCases["clone schedule recreates target volume with the size of the expanded source volume"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateAttaching)
+	tc.currentTargetVolume.Status.CloneStatus.State = longhorn.VolumeCloneStateInitiated
+	tc.copyCurrentToExpected()
+	testCases["clone schedule waits for clone completion"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateAttaching)
+	tc.currentTargetVolume.Status.CloneStatus.State = longhorn.VolumeCloneStateFailed
+	tc.copyCurrentToExpected()
+	tc.expectedCloneSchedule.Status.State = longhorn.CloneScheduleStateError
+	tc.expectedCloneSchedule.Status.Message = fmt.Sprintf("failed to clone snapshot %v to target volume %v", TestCloneScheduleSnapshot, TestCloneScheduleTargetVolume)
+	tc.expectedCloneSchedule.Status.AttachmentTickets = nil
+	tc.expectedVA.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{ticket.ID: ticket}
+	testCases["clone schedule hands back attachment tickets once the clone failed"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateAttaching)
+	tc.currentTargetVolume.Status.CloneStatus.State = longhorn.VolumeCloneStateCompleted
+	tc.copyCurrentToExpected()
+	tc.expectedCloneSchedule.Status.State = longhorn.CloneScheduleStateIdle
+	tc.expectedCloneSchedule.Status.LastRefreshTime = getTestNow()
+	tc.expectedCloneSchedule.Status.TargetVolumeSpec = nil
+	tc.expectedCloneSchedule.Status.TargetVolumeLabels = nil
+	tc.expectedCloneSchedule.Status.AttachmentTickets = nil
+	tc.expectedVA.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{ticket.ID: ticket}
+	testCases["clone schedule hands back attachment tickets after clone completion"] = tc
+
+	tc = getCloneScheduleControllerTestTemplate()
+	tc.setRefreshInProgress(longhorn.CloneScheduleStateDetaching)
+	tc.currentCloneSchedule.DeletionTimestamp = &metav1.Time{Time: getTestCloneScheduleTime(0)}
+	tc.copyCurrentToExpected()
+	tc.expectedCloneSchedule.Finalizers = nil
+	tc.expectedCloneSchedule.Status.AttachmentTickets = nil
+	tc.expectedVA.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{ticket.ID: ticket}
+	testCases["clone schedule hands back attachment tickets once deleted during a refresh"] = tc
+
+	return testCases
+}
+
+func (s *TestSuite) TestCloneSchedule(c *C) {
+	testCases := generateCloneScheduleControllerTestCases()
+	for name, tc := range testCases {
+		var err error
+		logrus.Debugf("Testing clone schedule controller: %v", name)
+
+		kubeClient := fake.NewSimpleClientset()
+		lhClient := lhfake.NewSimpleClientset()
+		extensionsClient := apiextensionsfake.NewSimpleClientset()
+
+		informerFactories := util.NewInformerFactories(TestNamespace, kubeClient, lhClient, controller.NoResyncPeriodFunc())
+
+		csIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().CloneSchedules().Informer().GetIndexer()
+		vIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Volumes().Informer().GetIndexer()
+		snapIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Snapshots().Informer().GetIndexer()
+		vaIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().VolumeAttachments().Informer().GetIndexer()
+
+		csc, err := newTestCloneScheduleController(lhClient, kubeClient, extensionsClient, informerFactories)
+		c.Assert(err, IsNil)
+
+		for _, v := range []*longhorn.Volume{tc.currentSourceVolume, tc.currentTargetVolume} {
+			if v == nil {
+				continue
+			}
+			v, err = lhClient.LonghornV1beta2().Volumes(TestNamespace).Create(context.TODO(), v, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = vIndexer.Add(v)
+			c.Assert(err, IsNil)
+		}
+		if tc.currentSnapshot != nil {
+			snapshot, err := lhClient.LonghornV1beta2().Snapshots(TestNamespace).Create(context.TODO(), tc.currentSnapshot, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = snapIndexer.Add(snapshot)
+			c.Assert(err, IsNil)
+		}
+		va, err := lhClient.LonghornV1beta2().VolumeAttachments(TestNamespace).Create(context.TODO(), tc.currentVA, metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		err = vaIndexer.Add(va)
+		c.Assert(err, IsNil)
+		cloneSchedule, err := lhClient.LonghornV1beta2().CloneSchedules(TestNamespace).Create(context.TODO(), tc.currentCloneSchedule, metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		err = csIndexer.Add(cloneSchedule)
+		c.Assert(err, IsNil)
+
+		err = csc.reconcile(TestCloneScheduleName)
+		c.Assert(err, IsNil)
+
+		cloneSchedule, err = lhClient.LonghornV1beta2().CloneSchedules(TestNamespace).Get(context.TODO(), TestCloneScheduleName, metav1.GetOptions{})
+		c.Assert(err, IsNil)
+		// The timestamps are set to the current time by the controller, only check if they are set
+		for _, timestamps := range [][2]*string{
+			{&cloneSchedule.Status.LastScheduleTime, &tc.expectedCloneSchedule.Status.LastScheduleTime},
+			{&cloneSchedule.Status.LastRefreshTime, &tc.expectedCloneSchedule.Status.LastRefreshTime},
+		} {
+			c.Assert(*timestamps[0] != "", Equals, *timestamps[1] != "")
+			*timestamps[0] = *timestamps[1]
+		}
+		c.Assert(cloneSchedule.Finalizers, DeepEquals, tc.expectedCloneSchedule.Finalizers)
+		c.Assert(cloneSchedule.Status, DeepEquals, tc.expectedCloneSchedule.Status)
+
+		targetVolume, err := lhClient.LonghornV1beta2().Volumes(TestNamespace).Get(context.TODO(), TestCloneScheduleTargetVolume, metav1.GetOptions{})
+		if tc.expectedTargetVolume == nil {
+			c.Assert(datastore.ErrorIsNotFound(err), Equals, true)
+		} else {
+			c.Assert(err, IsNil)
+			for _, labelKey := range []string{types.GetLonghornLabelKey(types.LonghornLabelCloneSchedule), "app"} {
+				c.Assert(targetVolume.Labels[labelKey], Equals, tc.expectedTargetVolume.Labels[labelKey])
+			}
+			c.Assert(targetVolume.Spec, DeepEquals, tc.expectedTargetVolume.Spec)
+		}
+
+		va, err = lhClient.LonghornV1beta2().VolumeAttachments(TestNamespace).Get(context.TODO(), tc.currentVA.Name, metav1.GetOptions{})
+		c.Assert(err, IsNil)
+		c.Assert(va.Spec.AttachmentTickets, DeepEquals, tc.expectedVA.Spec.AttachmentTickets)
+	}
+}
@@ -77,7 +77,7 @@ func StartControllers(logger logrus.FieldLogger, clients *client.Clients,
 	if err != nil {
 		return nil, err
 	}
-	backupController, err := NewBackupController(logger, ds, scheme, kubeClient, controllerID, namespace, proxyConnCounter)
+	backupController, err := NewBackupController(logger, ds, scheme, kubeClient, clients.RESTConfig, controllerID, namespace, proxyConnCounter)
 	if err != nil {
 		return nil, err
 	}
@@ -113,6 +113,18 @@ func StartControllers(logger logrus.FieldLogger, clients *client.Clients,
 	if err != nil {
 		return nil, err
 	}
+	cloneScheduleController, err := NewCloneScheduleController(logger, ds, scheme, kubeClient, controllerID, namespace)
+	if err != nil {
+		return nil, err
+	}
+	restoreTestController, err := NewRestoreTestController(logger, ds, scheme, kubeClient, controllerID, namespace)
+	if err != nil {
+		return nil, err
+	}
+	replicaCountPolicyController, err := NewReplicaCountPolicyController(logger, ds, scheme, kubeClient, controllerID, namespace)
+	if err != nil {
+		return nil, err
+	}
 	snapshotController, err := NewSnapshotController(logger, ds, scheme, kubeClient, namespace, controllerID, &engineapi.EngineCollection{}, proxyConnCounter)
 	if err != nil {
 		return nil, err
@@ -203,6 +215,9 @@ func StartControllers(logger logrus.FieldLogger, clients *client.Clients,
 	go backupBackingImageController.Run(Workers, stopCh)
 	go recurringJobController.Run(Workers, stopCh)
 	go orphanController.Run(Workers, stopCh)
+	go cloneScheduleController.Run(Workers, stopCh)
+	go restoreTestController.Run(Workers, stopCh)
+	go replicaCountPolicyController.Run(Workers, stopCh)
 	go snapshotController.Run(Workers, stopCh)
 	go supportBundleController.Run(Workers, stopCh)
 	go systemBackupController.Run(Workers, stopCh)
@@ -59,6 +59,10 @@ var (
 	EnginePollInterval = 5 * time.Second
 	EnginePollTimeout  = 30 * time.Second
 
+	// ReplicaModeMapCheckInterval is how often the engine monitor refreshes engine.Status.ReplicaModeMapCheckedAt
+	// while the replica modes do not change.
+	ReplicaModeMapCheckInterval = time.Minute
+
 	EngineMonitorConflictRetryCount = 5
 
 	purgeWaitIntervalInSecond = 24 * 60 * 60
@@ -798,6 +802,15 @@ func (m *EngineMonitor) sync() bool {
 	return false
 }
 
+// isReplicaModeMapCheckedAfter returns true if the engine monitor got the replica modes of the engine after the time.
+func isReplicaModeMapCheckedAfter(e *longhorn.Engine, t time.Time) bool {
+	checkedAt, err := util.ParseTime(e.Status.ReplicaModeMapCheckedAt)
+	if err != nil {
+		return false
+	}
+	return checkedAt.After(t)
+}
+
 func (m *EngineMonitor) refresh(engine *longhorn.Engine) error {
 	existingEngine := engine.DeepCopy()
 
@@ -874,6 +887,11 @@ func (m *EngineMonitor) refresh(engine *longhorn.Engine) error {
 			}
 		}
 	}
+	// The check time is not refreshed on every poll to avoid updating the engine status every few seconds
+	if !reflect.DeepEqual(engine.Status.ReplicaModeMap, currentReplicaModeMap) ||
+		!isReplicaModeMapCheckedAfter(engine, time.Now().Add(-ReplicaModeMapCheckInterval)) {
+		engine.Status.ReplicaModeMapCheckedAt = util.Now()
+	}
 	engine.Status.ReplicaModeMap = currentReplicaModeMap
 	engine.Status.ReplicaTransitionTimeMap = currentReplicaTransitionTimeMap
 
@@ -2017,7 +2035,7 @@ func (ec *EngineController) getReplicaRebuildFailedReason(replicaNodeID, errMsg
 
 		replicaRebuildFailedCondition := types.GetCondition(replicaNode.Status.Conditions, longhorn.NodeConditionTypeReady)
 		switch replicaRebuildFailedCondition.Reason {
-		case longhorn.NodeConditionReasonManagerPodDown, longhorn.NodeConditionReasonKubernetesNodeGone, longhorn.NodeConditionReasonKubernetesNodeNotReady:
+		case longhorn.NodeConditionReasonManagerPodDown, longhorn.NodeConditionReasonKubernetesNodeGone, longhorn.NodeConditionReasonKubernetesNodeNotReady, longhorn.NodeConditionReasonNetworkPartitioned:
 			failedReason = replicaRebuildFailedCondition.Reason
 		}
 	}
@@ -2132,6 +2150,7 @@ func (ec *EngineController) Upgrade(e *longhorn.Engine, log *logrus.Entry) (err
 	e.Status.CurrentReplicaAddressMap = e.Spec.UpgradedReplicaAddressMap
 	// reset ReplicaModeMap to reflect the new replicas
 	e.Status.ReplicaModeMap = nil
+	e.Status.ReplicaModeMapCheckedAt = ""
 	e.Status.ReplicaTransitionTimeMap = nil
 	e.Status.RestoreStatus = nil
 	e.Status.RebuildStatus = nil
@@ -26,6 +26,7 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 
+	"github.com/longhorn/longhorn-manager/constant"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/types"
@@ -56,6 +57,8 @@ type EngineImageController struct {
 
 	cacheSyncs []cache.InformerSynced
 
+	heldUpgrades *heldUpgradeTracker
+
 	// for unit test
 	nowHandler                func() string
 	engineBinaryChecker       func(string) (bool, error)
@@ -86,6 +89,8 @@ func NewEngineImageController(
 
 		ds: ds,
 
+		heldUpgrades: newHeldUpgradeTracker(),
+
 		nowHandler:                util.Now,
 		engineBinaryChecker:       types.EngineBinaryExistOnHostForImage,
 		engineImageVersionUpdater: updateEngineImageVersion,
@@ -436,7 +441,25 @@ func (ic *EngineImageController) handleAutoUpgradeEngineImageToDefaultEngineImag
 		return err
 	}
 
-	candidates, inProgress := ic.getVolumesForEngineImageUpgrading(volumes, defaultEngineImageResource)
+	candidates, inProgress := GetVolumesForEngineImageUpgrading(ic.ds, volumes, defaultEngineImageResource)
+
+	upgradeFrozen, err := ic.ds.GetSettingAsBool(types.SettingNameUpgradeFreeze)
+	if err != nil {
+		return err
+	}
+	if upgradeFrozen {
+		for _, vs := range candidates {
+			for _, v := range vs {
+				if !ic.heldUpgrades.hold(v.Name + "/" + defaultEngineImage) {
+					continue
+				}
+				ic.logger.WithFields(logrus.Fields{"volume": v.Name, "image": v.Spec.Image}).Infof("Skipped upgrading volume engine image to the default engine image %v automatically since the upgrade freeze is enabled", defaultEngineImage)
+				ic.eventRecorder.Eventf(v, corev1.EventTypeNormal, constant.EventReasonUpgradeFrozen, "Skipped upgrading engine image to the default engine image %v automatically since the upgrade freeze is enabled", defaultEngineImage)
+			}
+		}
+		return nil
+	}
+	ic.heldUpgrades.releaseAll()
 
 	limitedCandidates := limitAutomaticEngineUpgradePerNode(candidates, inProgress, int(concurrentAutomaticEngineUpgradePerNodeLimit))
 
@@ -473,7 +496,7 @@ func limitAutomaticEngineUpgradePerNode(candidates, inProgress map[string][]*lon
 	return limitedCandidates
 }
 
-// getVolumesForEngineImageUpgrading returns 2 maps: map of volumes that are qualified for engine image upgrading
+// GetVolumesForEngineImageUpgrading returns 2 maps: map of volumes that are qualified for engine image upgrading
 // and map of volumes that are upgrading engine image
 // A volume is qualified for engine image upgrading if it meets one of the following case:
 // Case 1:
@@ -484,7 +507,7 @@ func limitAutomaticEngineUpgradePerNode(candidates, inProgress map[string][]*lon
 //  1. Volume is not in engine upgrading process
 //  2. newEngineImageResource is deployed on attaching node and the all volume's replicas' nodes
 //  3. Volume is in attached state and it is able to do live upgrade
-func (ic *EngineImageController) getVolumesForEngineImageUpgrading(volumes map[string]*longhorn.Volume, newEngineImageResource *longhorn.EngineImage) (candidates, inProgress map[string][]*longhorn.Volume) {
+func GetVolumesForEngineImageUpgrading(ds *datastore.DataStore, volumes map[string]*longhorn.Volume, newEngineImageResource *longhorn.EngineImage) (candidates, inProgress map[string][]*longhorn.Volume) {
 	candidates = make(map[string][]*longhorn.Volume)
 	inProgress = make(map[string][]*longhorn.Volume)
 
@@ -493,9 +516,9 @@ func (ic *EngineImageController) getVolumesForEngineImageUpgrading(volumes map[s
 			inProgress[v.Status.OwnerID] = append(inProgress[v.Status.OwnerID], v)
 			continue
 		}
-		canBeUpgraded := ic.canDoOfflineEngineImageUpgrade(v, newEngineImageResource) || ic.canDoLiveEngineImageUpgrade(v, newEngineImageResource)
-		isCurrentEIAvailable, _ := ic.ds.CheckImageReadyOnAllVolumeReplicas(v.Status.CurrentImage, v.Name, v.Status.CurrentNodeID, v.Spec.DataEngine)
-		isNewEIAvailable, _ := ic.ds.CheckImageReadyOnAllVolumeReplicas(newEngineImageResource.Spec.Image, v.Name, v.Status.CurrentNodeID, v.Spec.DataEngine)
+		canBeUpgraded := canDoOfflineEngineImageUpgrade(v, newEngineImageResource) || canDoLiveEngineImageUpgrade(ds, v, newEngineImageResource)
+		isCurrentEIAvailable, _ := ds.CheckImageReadyOnAllVolumeReplicas(v.Status.CurrentImage, v.Name, v.Status.CurrentNodeID, v.Spec.DataEngine)
+		isNewEIAvailable, _ := ds.CheckImageReadyOnAllVolumeReplicas(newEngineImageResource.Spec.Image, v.Name, v.Status.CurrentNodeID, v.Spec.DataEngine)
 		validCandidate := v.Spec.Image != newEngineImageResource.Spec.Image && canBeUpgraded && isCurrentEIAvailable && isNewEIAvailable
 		if validCandidate {
 			candidates[v.Status.OwnerID] = append(candidates[v.Status.OwnerID], v)
@@ -505,7 +528,7 @@ func (ic *EngineImageController) getVolumesForEngineImageUpgrading(volumes map[s
 	return candidates, inProgress
 }
 
-func (ic *EngineImageController) canDoOfflineEngineImageUpgrade(v *longhorn.Volume, newEngineImageResource *longhorn.EngineImage) bool {
+func canDoOfflineEngineImageUpgrade(v *longhorn.Volume, newEngineImageResource *longhorn.EngineImage) bool {
 	return v.Status.State == longhorn.VolumeStateDetached
 }
 
@@ -517,8 +540,9 @@ func (ic *EngineImageController) canDoOfflineEngineImageUpgrade(v *longhorn.Volu
 //  4. Volume is not expanding AND
 //  5. Volume is not migrating AND
 //  6. Volume is not strict-local AND
-//  7. The current volume's engine image is compatible with the new engine image
-func (ic *EngineImageController) canDoLiveEngineImageUpgrade(v *longhorn.Volume, newEngineImageResource *longhorn.EngineImage) bool {
+//  7. The current volume's engine image is compatible with the new engine image AND
+//  8. The live engine upgrade pre-checks of the volume pass
+func canDoLiveEngineImageUpgrade(ds *datastore.DataStore, v *longhorn.Volume, newEngineImageResource *longhorn.EngineImage) bool {
 	if v.Status.State != longhorn.VolumeStateAttached {
 		return false
 	}
@@ -537,7 +561,7 @@ func (ic *EngineImageController) canDoLiveEngineImageUpgrade(v *longhorn.Volume,
 	if v.Spec.DataLocality == longhorn.DataLocalityStrictLocal {
 		return false
 	}
-	oldEngineImageResource, err := ic.ds.GetEngineImage(types.GetEngineImageChecksumName(v.Status.CurrentImage))
+	oldEngineImageResource, err := ds.GetEngineImage(types.GetEngineImageChecksumName(v.Status.CurrentImage))
 	if err != nil {
 		return false
 	}
@@ -545,6 +569,10 @@ func (ic *EngineImageController) canDoLiveEngineImageUpgrade(v *longhorn.Volume,
 		oldEngineImageResource.Status.ControllerAPIVersion < newEngineImageResource.Status.ControllerAPIMinVersion {
 		return false
 	}
+	failures, err := ds.CheckEngineUpgradePreconditions(v)
+	if err != nil || len(failures) > 0 {
+		return false
+	}
 	return true
 }
 
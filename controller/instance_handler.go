@@ -361,6 +361,13 @@ func (h *InstanceHandler) ReconcileInstanceState(obj interface{}, spec *longhorn
 			break
 		}
 
+		// The pod of a draining instance manager is going to be replaced, so new instances
+		// should wait for the replacement instead of being killed again.
+		if im.Status.DrainState != longhorn.InstanceManagerDrainStateNone {
+			log.Debugf("Waiting for instance manager %v to finish draining before starting the instance", im.Name)
+			break
+		}
+
 		err = h.createInstance(instanceName, spec.DataEngine, runtimeObj)
 		if err != nil {
 			return err
@@ -31,6 +31,7 @@ import (
 
 	imapi "github.com/longhorn/longhorn-instance-manager/pkg/api"
 
+	"github.com/longhorn/longhorn-manager/constant"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/types"
@@ -43,6 +44,12 @@ var (
 	mountPropagationHostToContainer = corev1.MountPropagationHostToContainer
 )
 
+const (
+	// drainRetryInterval is how often an instance manager waiting for another drain or for its remaining
+	// instances is checked again.
+	drainRetryInterval = 30 * time.Second
+)
+
 type InstanceManagerController struct {
 	*baseController
 
@@ -62,6 +69,8 @@ type InstanceManagerController struct {
 
 	proxyConnCounter util.Counter
 
+	heldUpgrades *heldUpgradeTracker
+
 	// for unit test
 	versionUpdater func(*longhorn.InstanceManager) error
 }
@@ -139,6 +148,8 @@ func NewInstanceManagerController(
 
 		proxyConnCounter: proxyConnCounter,
 
+		heldUpgrades: newHeldUpgradeTracker(),
+
 		versionUpdater: updateInstanceManagerVersion,
 	}
 
@@ -210,7 +221,8 @@ func (imc *InstanceManagerController) isResponsibleForSetting(obj interface{}) b
 
 	return types.SettingName(setting.Name) == types.SettingNameKubernetesClusterAutoscalerEnabled ||
 		types.SettingName(setting.Name) == types.SettingNameV2DataEngineCPUMask ||
-		types.SettingName(setting.Name) == types.SettingNameOrphanResourceAutoDeletion
+		types.SettingName(setting.Name) == types.SettingNameOrphanResourceAutoDeletion ||
+		types.SettingName(setting.Name) == types.SettingNameInstanceManagerPodDrainTimeout
 }
 
 func isInstanceManagerPod(obj interface{}) bool {
@@ -528,7 +540,7 @@ func (imc *InstanceManagerController) syncInstanceStatus(im *longhorn.InstanceMa
 	return nil
 }
 
-func (imc *InstanceManagerController) isDateEngineCPUMaskApplied(im *longhorn.InstanceManager) (bool, error) {
+func isDateEngineCPUMaskApplied(ds *datastore.DataStore, im *longhorn.InstanceManager) (bool, error) {
 	if types.IsDataEngineV1(im.Spec.DataEngine) {
 		return true, nil
 	}
@@ -541,7 +553,7 @@ func (imc *InstanceManagerController) isDateEngineCPUMaskApplied(im *longhorn.In
 		return im.Spec.DataEngineSpec.V2.CPUMask == im.Status.DataEngineStatus.V2.CPUMask, nil
 	}
 
-	setting, err := imc.ds.GetSettingWithAutoFillingRO(types.SettingNameV2DataEngineCPUMask)
+	setting, err := ds.GetSettingWithAutoFillingRO(types.SettingNameV2DataEngineCPUMask)
 	if err != nil {
 		return true, errors.Wrapf(err, "failed to get %v setting for updating data engine CPU mask", types.SettingNameV2DataEngineCPUMask)
 	}
@@ -617,7 +629,7 @@ func (imc *InstanceManagerController) handlePod(im *longhorn.InstanceManager) er
 		log.WithError(err).Warnf("Failed to sync log settings to instance manager pod %v", im.Name)
 	}
 
-	dataEngineCPUMaskIsApplied, err := imc.isDateEngineCPUMaskApplied(im)
+	dataEngineCPUMaskIsApplied, err := isDateEngineCPUMaskApplied(imc.ds, im)
 	if err != nil {
 		log.WithError(err).Warnf("Failed to sync date engine CPU mask to instance manager pod %v", im.Name)
 	}
@@ -627,11 +639,29 @@ func (imc *InstanceManagerController) handlePod(im *longhorn.InstanceManager) er
 		return err
 	}
 
+	drainTimedOut, err := imc.syncInstanceManagerDrain(im, areInstancesRunningInPod)
+	if err != nil {
+		return err
+	}
+	if drainTimedOut {
+		log.Warnf("Deleting instance manager pod %v since the drain timed out and the remaining replicas have healthy replicas elsewhere", im.Name)
+		return imc.cleanupInstanceManagerPod(im.Name)
+	}
+
 	isPodDeletionNotRequired := (isSettingSynced && dataEngineCPUMaskIsApplied) || areInstancesRunningInPod || isPodDeletedOrNotRunning
 	if im.Status.CurrentState != longhorn.InstanceManagerStateError &&
-		im.Status.CurrentState != longhorn.InstanceManagerStateStopped &&
-		isPodDeletionNotRequired {
-		return nil
+		im.Status.CurrentState != longhorn.InstanceManagerStateStopped {
+		if isPodDeletionNotRequired {
+			return nil
+		}
+		// The pod is healthy and only replaced to apply the settings
+		frozen, err := imc.isPodReplacementFrozen(im)
+		if err != nil {
+			return err
+		}
+		if frozen {
+			return nil
+		}
 	}
 
 	log.Warnf("Deleting instance manager pod %v since one of the following conditions is met: "+
@@ -715,38 +745,254 @@ func (imc *InstanceManagerController) areDangerZoneSettingsSyncedToIMPod(im *lon
 		return false, true, false, nil
 	}
 
+	isSynced, err = areDangerZoneSettingsSyncedToPod(imc.ds, im, pod)
+	if err != nil {
+		return false, false, false, err
+	}
+
+	return isSynced, false, false, nil
+}
+
+func areDangerZoneSettingsSyncedToPod(ds *datastore.DataStore, im *longhorn.InstanceManager, pod *corev1.Pod) (bool, error) {
 	for settingName := range types.GetDangerZoneSettings() {
 		isSettingSynced := true
-		setting, err := imc.ds.GetSettingWithAutoFillingRO(settingName)
+		setting, err := ds.GetSettingWithAutoFillingRO(settingName)
 		if err != nil {
-			return false, false, false, err
+			return false, err
 		}
 		switch settingName {
 		case types.SettingNameTaintToleration:
-			isSettingSynced, err = imc.isSettingTaintTolerationSynced(setting, pod)
+			isSettingSynced, err = isSettingTaintTolerationSynced(setting, pod)
 		case types.SettingNameSystemManagedComponentsNodeSelector:
-			isSettingSynced, err = imc.isSettingNodeSelectorSynced(setting, pod)
+			isSettingSynced, err = isSettingNodeSelectorSynced(setting, pod)
 		case types.SettingNameGuaranteedInstanceManagerCPU, types.SettingNameV2DataEngineGuaranteedInstanceManagerCPU:
-			isSettingSynced, err = imc.isSettingGuaranteedInstanceManagerCPUSynced(setting, pod)
+			isSettingSynced, err = isSettingGuaranteedInstanceManagerCPUSynced(ds, setting, pod)
 		case types.SettingNamePriorityClass:
-			isSettingSynced, err = imc.isSettingPriorityClassSynced(setting, pod)
+			isSettingSynced, err = isSettingPriorityClassSynced(setting, pod)
 		case types.SettingNameStorageNetwork:
-			isSettingSynced, err = imc.isSettingStorageNetworkSynced(setting, pod)
+			isSettingSynced, err = isSettingStorageNetworkSynced(setting, pod)
 		case types.SettingNameV1DataEngine, types.SettingNameV2DataEngine:
-			isSettingSynced, err = imc.isSettingDataEngineSynced(settingName, im)
+			isSettingSynced, err = isSettingDataEngineSynced(ds, settingName, im)
 		}
 		if err != nil {
-			return false, false, false, err
+			return false, err
 		}
 		if !isSettingSynced {
-			return false, false, false, nil
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// IsInstanceManagerPodReplacementRequired checks if the pod of a running instance manager is going to be recreated
+// in place, regardless of whether there are instances running in it. An instance manager using an outdated image is
+// not replaced but removed by the node controller once it is empty, so it doesn't need a drain.
+func IsInstanceManagerPodReplacementRequired(ds *datastore.DataStore, im *longhorn.InstanceManager) (bool, string, error) {
+	dataEngineCPUMaskIsApplied, err := isDateEngineCPUMaskApplied(ds, im)
+	if err != nil {
+		return false, "", err
+	}
+	if !dataEngineCPUMaskIsApplied {
+		return true, "the data engine CPU mask is not applied", nil
+	}
+
+	pod, err := ds.GetPodRO(im.Namespace, im.Name)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "cannot get pod for instance manager %v", im.Name)
+	}
+	if pod == nil {
+		return false, "", nil
+	}
+
+	isSynced, err := areDangerZoneSettingsSyncedToPod(ds, im, pod)
+	if err != nil {
+		return false, "", err
+	}
+	if !isSynced {
+		return true, "the danger zone settings are not synced to the pod", nil
+	}
+
+	return false, "", nil
+}
+
+// isPodReplacementFrozen checks if replacing the pod of a running instance manager is held back by the upgrade freeze.
+func (imc *InstanceManagerController) isPodReplacementFrozen(im *longhorn.InstanceManager) (bool, error) {
+	upgradeFrozen, err := imc.ds.GetSettingAsBool(types.SettingNameUpgradeFreeze)
+	if err != nil {
+		return false, err
+	}
+	if !upgradeFrozen {
+		imc.heldUpgrades.release(im.Name)
+		return false, nil
+	}
+
+	if imc.heldUpgrades.hold(im.Name) {
+		getLoggerForInstanceManager(imc.logger, im).Infof("Skipped replacing the instance manager pod since the upgrade freeze is enabled")
+		imc.eventRecorder.Eventf(im, corev1.EventTypeNormal, constant.EventReasonUpgradeFrozen,
+			"Skipped replacing the pod of instance manager %v since the upgrade freeze is enabled", im.Name)
+	}
+	return true, nil
+}
+
+// getPrecedingDrainingInstanceManager returns another instance manager in the cluster that is being drained and
+// started draining before the given one. Only one instance manager is drained at a time so that the replica
+// evictions don't degrade volumes across the whole cluster.
+func (imc *InstanceManagerController) getPrecedingDrainingInstanceManager(im *longhorn.InstanceManager) (*longhorn.InstanceManager, error) {
+	ims, err := imc.ds.ListInstanceManagersRO()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range ims {
+		if other.Name == im.Name || other.Status.DrainState == longhorn.InstanceManagerDrainStateNone {
+			continue
+		}
+		if im.Status.DrainState == longhorn.InstanceManagerDrainStateNone {
+			return other, nil
+		}
+		// Both are draining, which happens when they started at the same time. Keep the earlier one.
+		if other.Status.DrainStartedAt < im.Status.DrainStartedAt ||
+			(other.Status.DrainStartedAt == im.Status.DrainStartedAt && other.Name < im.Name) {
+			return other, nil
+		}
+	}
+	return nil, nil
+}
+
+// isDrainedPodDeletable checks if the pod of an instance manager whose drain timed out can be deleted without
+// killing engines or the last healthy replica of a volume.
+func (imc *InstanceManagerController) isDrainedPodDeletable(im *longhorn.InstanceManager) (bool, string, error) {
+	for name, engine := range im.Status.InstanceEngines {
+		if engine.Status.State == longhorn.InstanceStateRunning || engine.Status.State == longhorn.InstanceStateStarting {
+			return false, fmt.Sprintf("engine %v is still running", name), nil
+		}
+	}
+
+	for name, process := range im.Status.InstanceReplicas {
+		if process.Status.State != longhorn.InstanceStateRunning {
+			continue
+		}
+		replica, err := imc.ds.GetReplicaRO(name)
+		if err != nil {
+			if datastore.ErrorIsNotFound(err) {
+				continue
+			}
+			return false, "", err
+		}
+		replicas, err := imc.ds.ListVolumeReplicasRO(replica.Spec.VolumeName)
+		if err != nil {
+			return false, "", err
+		}
+		hasOtherHealthyReplica := false
+		for _, r := range replicas {
+			if r.Name == replica.Name || r.Spec.FailedAt != "" || r.Spec.HealthyAt == "" ||
+				r.Status.InstanceManagerName == im.Name || r.Status.CurrentState != longhorn.InstanceStateRunning {
+				continue
+			}
+			hasOtherHealthyReplica = true
+			break
+		}
+		if !hasOtherHealthyReplica {
+			return false, fmt.Sprintf("replica %v is the last healthy replica of volume %v", name, replica.Spec.VolumeName), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+// syncInstanceManagerDrain drains a running instance manager whose pod is going to be recreated while
+// instances are still running in it. During the drain, the node controller evicts the replicas and the
+// instance handler does not start new instances in the instance manager, while the running engines leave
+// once their volumes are detached. It returns true once the drain timeout expires and the remaining
+// instances can be stopped safely, then the caller should delete the pod. Engines are never killed: the
+// pod is kept until they are gone.
+func (imc *InstanceManagerController) syncInstanceManagerDrain(im *longhorn.InstanceManager, areInstancesRunningInPod bool) (deletePod bool, err error) {
+	log := getLoggerForInstanceManager(imc.logger, im)
+
+	drainTimeout, err := imc.ds.GetSettingAsInt(types.SettingNameInstanceManagerPodDrainTimeout)
+	if err != nil {
+		return false, err
+	}
+
+	isDrainRequired := false
+	reason := ""
+	if drainTimeout > 0 && areInstancesRunningInPod && im.Status.CurrentState == longhorn.InstanceManagerStateRunning {
+		isDrainRequired, reason, err = IsInstanceManagerPodReplacementRequired(imc.ds, im)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Drains that are already in progress are finished, only new ones are blocked by the upgrade freeze.
+	if isDrainRequired && im.Status.DrainState == longhorn.InstanceManagerDrainStateNone {
+		frozen, err := imc.isPodReplacementFrozen(im)
+		if err != nil {
+			return false, err
+		}
+		if frozen {
+			isDrainRequired = false
+		}
+	}
+
+	if isDrainRequired {
+		preceding, err := imc.getPrecedingDrainingInstanceManager(im)
+		if err != nil {
+			return false, err
+		}
+		if preceding != nil {
+			log.Infof("Waiting for instance manager %v to finish draining before draining this one", preceding.Name)
+			isDrainRequired = false
+			imc.enqueueInstanceManagerAfter(im, drainRetryInterval)
+		}
+	}
+
+	if !isDrainRequired {
+		if im.Status.DrainState != longhorn.InstanceManagerDrainStateNone {
+			log.Infof("Stopped draining instance manager since draining is no longer required")
+			im.Status.DrainState = longhorn.InstanceManagerDrainStateNone
+			im.Status.DrainStartedAt = ""
+		}
+		return false, nil
+	}
+
+	if im.Status.DrainState == longhorn.InstanceManagerDrainStateNone || im.Status.DrainStartedAt == "" {
+		log.Infof("Draining instance manager before replacing its pod since %v", reason)
+		imc.eventRecorder.Eventf(im, corev1.EventTypeNormal, constant.EventReasonDraining,
+			"Draining instance manager %v before replacing its pod since %v", im.Name, reason)
+		im.Status.DrainState = longhorn.InstanceManagerDrainStateDraining
+		im.Status.DrainStartedAt = util.Now()
+	}
+
+	if im.Status.DrainState == longhorn.InstanceManagerDrainStateDraining {
+		drainStartedAt, err := util.ParseTime(im.Status.DrainStartedAt)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to parse drain start time %v", im.Status.DrainStartedAt)
 		}
+		deadline := drainStartedAt.Add(time.Duration(drainTimeout) * time.Second)
+		if remaining := time.Until(deadline); remaining > 0 {
+			imc.enqueueInstanceManagerAfter(im, remaining)
+			return false, nil
+		}
+
+		imc.eventRecorder.Eventf(im, corev1.EventTypeWarning, constant.EventReasonDrainTimeout,
+			"Instance manager %v still has running instances after the drain timeout %v seconds", im.Name, drainTimeout)
+		im.Status.DrainState = longhorn.InstanceManagerDrainStateTimedOut
 	}
 
-	return true, false, false, nil
+	deletable, reason, err := imc.isDrainedPodDeletable(im)
+	if err != nil {
+		return false, err
+	}
+	if !deletable {
+		log.Debugf("Keeping the pod of the drained instance manager since %v", reason)
+		imc.enqueueInstanceManagerAfter(im, drainRetryInterval)
+		return false, nil
+	}
+	return true, nil
 }
 
-func (imc *InstanceManagerController) isSettingTaintTolerationSynced(setting *longhorn.Setting, pod *corev1.Pod) (bool, error) {
+func isSettingTaintTolerationSynced(setting *longhorn.Setting, pod *corev1.Pod) (bool, error) {
 	newTolerationsList, err := types.UnmarshalTolerations(setting.Value)
 	if err != nil {
 		return false, err
@@ -760,7 +1006,7 @@ func (imc *InstanceManagerController) isSettingTaintTolerationSynced(setting *lo
 	return reflect.DeepEqual(util.TolerationListToMap(lastAppliedTolerations), newTolerationsMap), nil
 }
 
-func (imc *InstanceManagerController) isSettingNodeSelectorSynced(setting *longhorn.Setting, pod *corev1.Pod) (bool, error) {
+func isSettingNodeSelectorSynced(setting *longhorn.Setting, pod *corev1.Pod) (bool, error) {
 	newNodeSelector, err := types.UnmarshalNodeSelector(setting.Value)
 	if err != nil {
 		return false, err
@@ -772,8 +1018,8 @@ func (imc *InstanceManagerController) isSettingNodeSelectorSynced(setting *longh
 	return reflect.DeepEqual(pod.Spec.NodeSelector, newNodeSelector), nil
 }
 
-func (imc *InstanceManagerController) isSettingGuaranteedInstanceManagerCPUSynced(setting *longhorn.Setting, pod *corev1.Pod) (bool, error) {
-	lhNode, err := imc.ds.GetNode(pod.Spec.NodeName)
+func isSettingGuaranteedInstanceManagerCPUSynced(ds *datastore.DataStore, setting *longhorn.Setting, pod *corev1.Pod) (bool, error) {
+	lhNode, err := ds.GetNode(pod.Spec.NodeName)
 	if err != nil {
 		return false, err
 	}
@@ -781,7 +1027,7 @@ func (imc *InstanceManagerController) isSettingGuaranteedInstanceManagerCPUSynce
 		return true, nil
 	}
 
-	resourceReq, err := GetInstanceManagerCPURequirement(imc.ds, pod.Name)
+	resourceReq, err := GetInstanceManagerCPURequirement(ds, pod.Name)
 	if err != nil {
 		return false, err
 	}
@@ -789,18 +1035,18 @@ func (imc *InstanceManagerController) isSettingGuaranteedInstanceManagerCPUSynce
 	return IsSameGuaranteedCPURequirement(resourceReq, &podResourceReq), nil
 }
 
-func (imc *InstanceManagerController) isSettingPriorityClassSynced(setting *longhorn.Setting, pod *corev1.Pod) (bool, error) {
+func isSettingPriorityClassSynced(setting *longhorn.Setting, pod *corev1.Pod) (bool, error) {
 	return pod.Spec.PriorityClassName == setting.Value, nil
 }
 
-func (imc *InstanceManagerController) isSettingStorageNetworkSynced(setting *longhorn.Setting, pod *corev1.Pod) (bool, error) {
+func isSettingStorageNetworkSynced(setting *longhorn.Setting, pod *corev1.Pod) (bool, error) {
 	nadAnnot := string(types.CNIAnnotationNetworks)
 	nadAnnotValue := types.CreateCniAnnotationFromSetting(setting)
 	return pod.Annotations[nadAnnot] == nadAnnotValue, nil
 }
 
-func (imc *InstanceManagerController) isSettingDataEngineSynced(settingName types.SettingName, im *longhorn.InstanceManager) (bool, error) {
-	enabled, err := imc.ds.GetSettingAsBool(settingName)
+func isSettingDataEngineSynced(ds *datastore.DataStore, settingName types.SettingName, im *longhorn.InstanceManager) (bool, error) {
+	enabled, err := ds.GetSettingAsBool(settingName)
 	if err != nil {
 		return false, errors.Wrapf(err, "failed to get %v setting for updating data engine", settingName)
 	}
@@ -1240,6 +1486,16 @@ func (imc *InstanceManagerController) enqueueInstanceManager(instanceManager int
 	imc.queue.Add(key)
 }
 
+func (imc *InstanceManagerController) enqueueInstanceManagerAfter(instanceManager interface{}, duration time.Duration) {
+	key, err := controller.KeyFunc(instanceManager)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to get key for object %#v: %v", instanceManager, err))
+		return
+	}
+
+	imc.queue.AddAfter(key, duration)
+}
+
 func (imc *InstanceManagerController) enqueueInstanceManagerPod(obj interface{}) {
 	pod, ok := obj.(*corev1.Pod)
 	if !ok {
@@ -3,6 +3,8 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -311,3 +313,216 @@ func (s *TestSuite) TestSyncInstanceManager(c *C) {
 		c.Assert(updatedIM.Status, DeepEquals, tc.expectedStatus)
 	}
 }
+
+func (s *TestSuite) TestSyncInstanceManagerDrain(c *C) {
+	runningEngines := map[string]longhorn.InstanceProcess{
+		TestEngineName: {
+			Spec: longhorn.InstanceProcessSpec{
+				Name: TestEngineName,
+			},
+			Status: longhorn.InstanceProcessStatus{
+				State: longhorn.InstanceStateRunning,
+			},
+		},
+	}
+
+	type drainTestCase struct {
+		drainTimeout    string
+		priorityClass   string
+		image           string
+		engines         map[string]longhorn.InstanceProcess
+		replicaHealthy  bool
+		drainState      longhorn.InstanceManagerDrainState
+		drainStartedAgo time.Duration
+		otherDraining   bool
+		upgradeFrozen   bool
+
+		expectedDrainState longhorn.InstanceManagerDrainState
+		expectedDeletePod  bool
+	}
+	testCases := map[string]drainTestCase{
+		"drain disabled": {
+			drainTimeout:       "0",
+			priorityClass:      "new-priority-class",
+			image:              TestInstanceManagerImage,
+			engines:            runningEngines,
+			expectedDrainState: longhorn.InstanceManagerDrainStateNone,
+		},
+		"no drain for outdated instance manager image": {
+			drainTimeout:       "300",
+			image:              TestInstanceManagerImage + "-old",
+			engines:            runningEngines,
+			expectedDrainState: longhorn.InstanceManagerDrainStateNone,
+		},
+		"drain started for pod replacement": {
+			drainTimeout:       "300",
+			priorityClass:      "new-priority-class",
+			image:              TestInstanceManagerImage,
+			engines:            runningEngines,
+			expectedDrainState: longhorn.InstanceManagerDrainStateDraining,
+		},
+		"drain waits for another draining instance manager": {
+			drainTimeout:       "300",
+			priorityClass:      "new-priority-class",
+			image:              TestInstanceManagerImage,
+			engines:            runningEngines,
+			otherDraining:      true,
+			expectedDrainState: longhorn.InstanceManagerDrainStateNone,
+		},
+		"pod kept after timeout with running engine": {
+			drainTimeout:       "300",
+			priorityClass:      "new-priority-class",
+			image:              TestInstanceManagerImage,
+			engines:            runningEngines,
+			drainState:         longhorn.InstanceManagerDrainStateDraining,
+			drainStartedAgo:    10 * time.Minute,
+			expectedDrainState: longhorn.InstanceManagerDrainStateTimedOut,
+		},
+		"pod kept after timeout with the last healthy replica": {
+			drainTimeout:       "300",
+			priorityClass:      "new-priority-class",
+			image:              TestInstanceManagerImage,
+			drainState:         longhorn.InstanceManagerDrainStateDraining,
+			drainStartedAgo:    10 * time.Minute,
+			expectedDrainState: longhorn.InstanceManagerDrainStateTimedOut,
+		},
+		"pod deleted after timeout with a healthy replica elsewhere": {
+			drainTimeout:       "300",
+			priorityClass:      "new-priority-class",
+			image:              TestInstanceManagerImage,
+			replicaHealthy:     true,
+			drainState:         longhorn.InstanceManagerDrainStateDraining,
+			drainStartedAgo:    10 * time.Minute,
+			expectedDrainState: longhorn.InstanceManagerDrainStateTimedOut,
+			expectedDeletePod:  true,
+		},
+		"drain not started while the upgrade freeze is enabled": {
+			drainTimeout:       "300",
+			priorityClass:      "new-priority-class",
+			image:              TestInstanceManagerImage,
+			engines:            runningEngines,
+			upgradeFrozen:      true,
+			expectedDrainState: longhorn.InstanceManagerDrainStateNone,
+		},
+		"drain in progress continues while the upgrade freeze is enabled": {
+			drainTimeout:       "300",
+			priorityClass:      "new-priority-class",
+			image:              TestInstanceManagerImage,
+			engines:            runningEngines,
+			drainState:         longhorn.InstanceManagerDrainStateDraining,
+			drainStartedAgo:    time.Minute,
+			upgradeFrozen:      true,
+			expectedDrainState: longhorn.InstanceManagerDrainStateDraining,
+		},
+		"drain stopped since the pod is synced": {
+			drainTimeout:       "300",
+			image:              TestInstanceManagerImage,
+			engines:            runningEngines,
+			drainState:         longhorn.InstanceManagerDrainStateDraining,
+			drainStartedAgo:    time.Minute,
+			expectedDrainState: longhorn.InstanceManagerDrainStateNone,
+		},
+	}
+
+	for name, tc := range testCases {
+		fmt.Printf("testing %v\n", name)
+
+		kubeClient := fake.NewSimpleClientset()
+		lhClient := lhfake.NewSimpleClientset()
+		extensionsClient := apiextensionsfake.NewSimpleClientset()
+
+		informerFactories := util.NewInformerFactories(TestNamespace, kubeClient, lhClient, controller.NoResyncPeriodFunc())
+		sIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Settings().Informer().GetIndexer()
+		imIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().InstanceManagers().Informer().GetIndexer()
+		rIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Replicas().Informer().GetIndexer()
+		lhNodeIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Nodes().Informer().GetIndexer()
+		pIndexer := informerFactories.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+
+		imc, err := newTestInstanceManagerController(lhClient, kubeClient, extensionsClient, informerFactories, TestNode1)
+		c.Assert(err, IsNil)
+
+		imImageSetting := newDefaultInstanceManagerImageSetting()
+		imImageSetting.Namespace = TestNamespace
+		err = sIndexer.Add(imImageSetting)
+		c.Assert(err, IsNil)
+		for settingName, value := range map[types.SettingName]string{
+			types.SettingNameInstanceManagerPodDrainTimeout: tc.drainTimeout,
+			types.SettingNamePriorityClass:                  tc.priorityClass,
+			types.SettingNameUpgradeFreeze:                  strconv.FormatBool(tc.upgradeFrozen),
+		} {
+			err = sIndexer.Add(&longhorn.Setting{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      string(settingName),
+					Namespace: TestNamespace,
+				},
+				Value: value,
+			})
+			c.Assert(err, IsNil)
+		}
+
+		err = lhNodeIndexer.Add(newNode(TestNode1, TestNamespace, true, longhorn.ConditionStatusFalse, ""))
+		c.Assert(err, IsNil)
+		err = pIndexer.Add(newPod(&corev1.PodStatus{PodIP: TestIP1, Phase: corev1.PodRunning}, TestInstanceManagerName, TestNamespace, TestNode1))
+		c.Assert(err, IsNil)
+
+		volume := newVolume(TestVolumeName, 2)
+		engine := newEngineForVolume(volume)
+		drainedReplica := newReplicaForVolume(volume, engine, TestNode1, TestDiskID1)
+		drainedReplica.Status.InstanceManagerName = TestInstanceManagerName
+		otherReplica := newReplicaForVolume(volume, engine, TestNode2, TestDiskID1)
+		otherReplica.Status.InstanceManagerName = TestInstanceManagerName + "-2"
+		for _, r := range []*longhorn.Replica{drainedReplica, otherReplica} {
+			r.Namespace = TestNamespace
+			r.Spec.HealthyAt = util.Now()
+			r.Status.CurrentState = longhorn.InstanceStateRunning
+		}
+		if !tc.replicaHealthy {
+			otherReplica.Spec.FailedAt = util.Now()
+		}
+		for _, r := range []*longhorn.Replica{drainedReplica, otherReplica} {
+			err = rIndexer.Add(r)
+			c.Assert(err, IsNil)
+		}
+
+		im := newInstanceManager(
+			TestInstanceManagerName, longhorn.InstanceManagerStateRunning,
+			TestNode1, TestNode1, TestIP1,
+			tc.engines,
+			map[string]longhorn.InstanceProcess{
+				drainedReplica.Name: {
+					Spec:   longhorn.InstanceProcessSpec{Name: drainedReplica.Name},
+					Status: longhorn.InstanceProcessStatus{State: longhorn.InstanceStateRunning},
+				},
+			},
+			longhorn.DataEngineTypeV1,
+			tc.image,
+			false,
+		)
+		im.Status.DrainState = tc.drainState
+		if tc.drainState != longhorn.InstanceManagerDrainStateNone {
+			im.Status.DrainStartedAt = util.FormatTimeZ(time.Now().Add(-tc.drainStartedAgo))
+		}
+		err = imIndexer.Add(im)
+		c.Assert(err, IsNil)
+
+		if tc.otherDraining {
+			otherIM := newInstanceManager(
+				TestInstanceManagerName+"-2", longhorn.InstanceManagerStateRunning,
+				TestNode2, TestNode2, TestIP2,
+				nil, nil,
+				longhorn.DataEngineTypeV1,
+				TestInstanceManagerImage,
+				false,
+			)
+			otherIM.Status.DrainState = longhorn.InstanceManagerDrainStateDraining
+			otherIM.Status.DrainStartedAt = util.Now()
+			err = imIndexer.Add(otherIM)
+			c.Assert(err, IsNil)
+		}
+
+		deletePod, err := imc.syncInstanceManagerDrain(im, true)
+		c.Assert(err, IsNil)
+		c.Assert(deletePod, Equals, tc.expectedDeletePod)
+		c.Assert(im.Status.DrainState, Equals, tc.expectedDrainState)
+	}
+}
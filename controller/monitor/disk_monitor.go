@@ -16,6 +16,7 @@ import (
 
 	lhtypes "github.com/longhorn/go-common-libs/types"
 
+	"github.com/longhorn/longhorn-manager/csi/crypto"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/types"
@@ -265,7 +266,23 @@ func (m *DiskMonitor) collectDiskData(node *longhorn.Node) map[string]*Collected
 		diskInfoMap[diskName] = NewDiskInfo(diskName, "", disk.Path, diskDriver, nodeOrDiskEvicted, nil,
 			orphanedReplicaDataStores, instanceManagerName, errReason, errMsg)
 
-		diskConfig, err := m.getDiskConfigHandler(disk.Type, diskName, disk.Path, diskDriver, diskServiceClient)
+		// The disk service works on the opened dm-crypt device of an encrypted disk
+		devicePath := disk.Path
+		if disk.Encrypted {
+			devicePath = crypto.DiskMapper(diskName)
+			isOpen, err := crypto.IsDeviceOpen(devicePath)
+			if err == nil && !isOpen {
+				err = fmt.Errorf("encrypted device %v is not opened", devicePath)
+			}
+			if err != nil {
+				diskInfoMap[diskName] = NewDiskInfo(diskName, "", disk.Path, diskDriver, nodeOrDiskEvicted, nil,
+					orphanedReplicaDataStores, instanceManagerName, string(longhorn.DiskConditionReasonDiskEncryptionFailed),
+					fmt.Sprintf("Disk %v(%v) on node %v is not ready: %v", diskName, disk.Path, node.Name, err))
+				continue
+			}
+		}
+
+		diskConfig, err := m.getDiskConfigHandler(disk.Type, diskName, devicePath, diskDriver, diskServiceClient)
 		if err != nil {
 			if !types.ErrorIsNotFound(err) {
 				diskInfoMap[diskName] = NewDiskInfo(diskName, "", disk.Path, diskDriver, nodeOrDiskEvicted, nil,
@@ -291,7 +308,7 @@ func (m *DiskMonitor) collectDiskData(node *longhorn.Node) map[string]*Collected
 			//   The handling of all disks containing the same fsid will be done in NodeController.
 			// Block-type disk
 			//   Create a bdev lvstore
-			if diskConfig, err = m.generateDiskConfigHandler(disk.Type, diskName, diskUUID, disk.Path, string(diskDriver), diskServiceClient, m.ds); err != nil {
+			if diskConfig, err = m.generateDiskConfigHandler(disk.Type, diskName, diskUUID, devicePath, string(diskDriver), diskServiceClient, m.ds); err != nil {
 				diskInfoMap[diskName] = NewDiskInfo(diskName, diskUUID, disk.Path, diskDriver, nodeOrDiskEvicted, nil,
 					orphanedReplicaDataStores, instanceManagerName, string(longhorn.DiskConditionReasonNoDiskInfo),
 					fmt.Sprintf("Disk %v(%v) on node %v is not ready: failed to generate disk config: error: %v",
@@ -300,7 +317,7 @@ func (m *DiskMonitor) collectDiskData(node *longhorn.Node) map[string]*Collected
 			}
 		}
 
-		stat, err := m.getDiskStatHandler(disk.Type, diskName, disk.Path, diskDriver, diskServiceClient)
+		stat, err := m.getDiskStatHandler(disk.Type, diskName, devicePath, diskDriver, diskServiceClient)
 		if err != nil {
 			diskInfoMap[diskName] = NewDiskInfo(diskName, "", disk.Path, diskDriver, nodeOrDiskEvicted, nil,
 				orphanedReplicaDataStores, instanceManagerName, string(longhorn.DiskConditionReasonNoDiskInfo),
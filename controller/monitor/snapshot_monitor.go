@@ -3,6 +3,7 @@ package monitor
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"reflect"
 	"strings"
 	"sync"
@@ -11,6 +12,7 @@ import (
 	"github.com/avast/retry-go"
 	"github.com/go-co-op/gocron"
 	"github.com/pkg/errors"
+	"github.com/robfig/cron"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/time/rate"
 
@@ -44,6 +46,11 @@ const (
 
 	snapshotHashSyncStatusPeriod   = 5 // seconds
 	snapshotHashSyncStatusAttempts = (24 * 60 * 60 / snapshotHashSyncStatusPeriod)
+
+	// SnapshotMonitorConfigVolumeCronJobsChanged is set in the configuration
+	// passed to UpdateConfiguration when the snapshot check cron job of any
+	// volume has changed since the last call.
+	SnapshotMonitorConfigVolumeCronJobsChanged = "volumeCronJobsChanged"
 )
 
 type SnapshotChangeEvent struct {
@@ -76,7 +83,12 @@ type SnapshotMonitor struct {
 	inProgressSnapshotCheckTasks     map[string]struct{}
 	inProgressSnapshotCheckTasksLock sync.RWMutex
 
-	existingDataIntegrityCronJob string
+	// existingDataIntegrityCronJobs maps the cron jobs of the global setting
+	// and the per-volume overrides to their scheduled jobs.
+	existingDataIntegrityCronJobs map[string]*gocron.Job
+	// volumeDataIntegrityCronJobs caches the valid per-volume overrides, so
+	// the volumes are only listed again when one of them has changed.
+	volumeDataIntegrityCronJobs map[string]struct{}
 
 	syncCallback func(key string)
 
@@ -108,6 +120,8 @@ func NewSnapshotMonitor(logger logrus.FieldLogger, ds *datastore.DataStore, node
 
 		inProgressSnapshotCheckTasks: map[string]struct{}{},
 
+		existingDataIntegrityCronJobs: map[string]*gocron.Job{},
+
 		syncCallback:     syncCallback,
 		proxyConnCounter: util.NewAtomicCounter(),
 	}
@@ -150,9 +164,9 @@ func (m *SnapshotMonitor) processSnapshotChangeEvent() {
 	}
 }
 
-func (m *SnapshotMonitor) checkSnapshots() {
-	m.logger.WithField("monitor", monitorName).Info("Starting checking snapshots")
-	defer m.logger.WithField("monitor", monitorName).Infof("Finished checking snapshots")
+func (m *SnapshotMonitor) checkSnapshots(cronJob string) {
+	m.logger.WithField("monitor", monitorName).Infof("Starting checking snapshots for cron job %v", cronJob)
+	defer m.logger.WithField("monitor", monitorName).Infof("Finished checking snapshots for cron job %v", cronJob)
 
 	engines, err := m.ds.ListEnginesByNodeRO(m.nodeName)
 	if err != nil {
@@ -166,13 +180,43 @@ func (m *SnapshotMonitor) checkSnapshots() {
 		m.LastSnapshotPeriodicCheckedAt = metav1.Time{Time: time.Now().UTC()}
 	}()
 
+	staggerWindow, err := m.ds.GetSettingAsInt(types.SettingNameSnapshotDataIntegrityCheckStaggerWindow)
+	if err != nil {
+		m.logger.WithField("monitor", monitorName).WithError(err).Warnf("Failed to get %v setting, starting snapshot checks without delay",
+			types.SettingNameSnapshotDataIntegrityCheckStaggerWindow)
+		staggerWindow = 0
+	}
+
 	for _, engine := range engines {
-		m.logger.WithField("monitor", monitorName).Infof("Populating engine %v snapshots", engine.Name)
-		m.populateEngineSnapshots(engine)
+		volumeCronJob, err := m.ds.GetVolumeSnapshotDataIntegrityCronJob(engine.Spec.VolumeName)
+		if err != nil {
+			m.logger.WithField("monitor", monitorName).WithError(err).Warnf("Failed to get snapshot data integrity cron job for volume %v", engine.Spec.VolumeName)
+			continue
+		}
+		if volumeCronJob != cronJob {
+			continue
+		}
+
+		delay := getSnapshotCheckStaggerDelay(engine.Spec.VolumeName, time.Duration(staggerWindow)*time.Minute)
+		m.logger.WithField("monitor", monitorName).Infof("Populating engine %v snapshots with delay %v", engine.Name, delay)
+		m.populateEngineSnapshots(engine, delay)
 	}
 }
 
-func (m *SnapshotMonitor) populateEngineSnapshots(engine *longhorn.Engine) {
+// getSnapshotCheckStaggerDelay returns a delay within the window derived from
+// the volume name, so the checks of volumes sharing a cron job are spread out.
+func getSnapshotCheckStaggerDelay(volumeName string, window time.Duration) time.Duration {
+	if window <= 0 {
+		return 0
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(volumeName))
+
+	return time.Duration(uint64(h.Sum32())%uint64(window/time.Second)) * time.Second
+}
+
+func (m *SnapshotMonitor) populateEngineSnapshots(engine *longhorn.Engine, delay time.Duration) {
 	snapshots := engine.Status.Snapshots
 	for _, snapshot := range snapshots {
 		// Skip volume-head because it is not a real snapshot.
@@ -182,11 +226,11 @@ func (m *SnapshotMonitor) populateEngineSnapshots(engine *longhorn.Engine) {
 			continue
 		}
 
-		m.snapshotCheckTaskQueue.Add(snapshotCheckTask{
+		m.snapshotCheckTaskQueue.AddAfter(snapshotCheckTask{
 			volumeName:   engine.Spec.VolumeName,
 			snapshotName: snapshot.Name,
 			changeEvent:  false,
-		})
+		}, delay)
 	}
 }
 
@@ -208,6 +252,14 @@ func (m *SnapshotMonitor) processNextWorkItem(id int) bool {
 		return true
 	}
 
+	// Defer the hashing instead of consuming the retries of the task, since
+	// rebuilding or backup on the node can last longer than the retry backoff.
+	if err := m.checkNodeIsNotBusy(); err != nil {
+		m.logger.WithField("monitor", monitorName).WithError(err).Debugf("Deferring snapshot check task %v", key)
+		m.snapshotCheckTaskQueue.AddAfter(key, snapshotCheckProcessPeriod)
+		return true
+	}
+
 	err = m.run(task)
 	m.handleErr(err, key)
 
@@ -254,41 +306,85 @@ func (m *SnapshotMonitor) RunOnce() error {
 	return fmt.Errorf("RunOnce is not implemented")
 }
 
-func (m *SnapshotMonitor) UpdateConfiguration(map[string]interface{}) error {
+func (m *SnapshotMonitor) UpdateConfiguration(config map[string]interface{}) error {
 	dataIntegrityCronJob, err := m.ds.GetSettingValueExisted(types.SettingNameSnapshotDataIntegrityCronJob)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get %v setting", types.SettingNameSnapshotDataIntegrityCronJob)
 	}
 
-	m.RLock()
-	if dataIntegrityCronJob == m.existingDataIntegrityCronJob && m.checkScheduler.Len() > 0 {
-		m.RUnlock()
-		return nil
+	m.Lock()
+	defer m.Unlock()
+
+	volumeCronJobsChanged, _ := config[SnapshotMonitorConfigVolumeCronJobsChanged].(bool)
+	if volumeCronJobsChanged || m.volumeDataIntegrityCronJobs == nil {
+		volumeCronJobs, err := m.getVolumeDataIntegrityCronJobs()
+		if err != nil {
+			return err
+		}
+		m.volumeDataIntegrityCronJobs = volumeCronJobs
 	}
-	m.RUnlock()
 
-	if m.checkScheduler.Len() > 0 {
-		m.checkScheduler.Remove(m.checkSnapshots)
+	cronJobs := map[string]struct{}{
+		dataIntegrityCronJob: {},
+	}
+	for cronJob := range m.volumeDataIntegrityCronJobs {
+		cronJobs[cronJob] = struct{}{}
 	}
 
-	job, err := m.checkScheduler.Cron(dataIntegrityCronJob).Do(m.checkSnapshots)
-	if err != nil {
-		return errors.Wrap(err, "failed to schedule snapshot check job")
+	for cronJob, job := range m.existingDataIntegrityCronJobs {
+		if _, ok := cronJobs[cronJob]; ok {
+			continue
+		}
+		m.checkScheduler.RemoveByReference(job)
+		delete(m.existingDataIntegrityCronJobs, cronJob)
+
+		m.logger.WithField("monitor", monitorName).Infof("Removed snapshot check job for cron job %v", cronJob)
 	}
 
-	m.Lock()
-	previousDataIntegrityCronJob := m.existingDataIntegrityCronJob
-	m.existingDataIntegrityCronJob = dataIntegrityCronJob
-	m.Unlock()
+	for cronJob := range cronJobs {
+		if _, ok := m.existingDataIntegrityCronJobs[cronJob]; ok {
+			continue
+		}
 
-	m.checkScheduler.StartAsync()
+		job, err := m.checkScheduler.Cron(cronJob).Do(m.checkSnapshots, cronJob)
+		if err != nil {
+			return errors.Wrapf(err, "failed to schedule snapshot check job for cron job %v", cronJob)
+		}
+		m.existingDataIntegrityCronJobs[cronJob] = job
 
-	m.logger.WithField("monitor", monitorName).Infof("Cron is changed from %v to %v. Next snapshot check job will be executed at %v",
-		previousDataIntegrityCronJob, m.existingDataIntegrityCronJob, job.NextRun())
+		m.logger.WithField("monitor", monitorName).Infof("Added snapshot check job for cron job %v. Next snapshot check job will be executed at %v",
+			cronJob, job.NextRun())
+	}
+
+	m.checkScheduler.StartAsync()
 
 	return nil
 }
 
+// getVolumeDataIntegrityCronJobs returns the snapshot check cron job overrides
+// of the volumes. Invalid overrides are skipped.
+func (m *SnapshotMonitor) getVolumeDataIntegrityCronJobs() (map[string]struct{}, error) {
+	volumes, err := m.ds.ListVolumesRO()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list volumes for snapshot data integrity cron jobs")
+	}
+
+	cronJobs := map[string]struct{}{}
+	for _, v := range volumes {
+		if v.Spec.SnapshotDataIntegrityCronJob == "" {
+			continue
+		}
+		if _, err := cron.ParseStandard(v.Spec.SnapshotDataIntegrityCronJob); err != nil {
+			m.logger.WithField("monitor", monitorName).WithError(err).Warnf("Skipping invalid snapshot data integrity cron job %v of volume %v",
+				v.Spec.SnapshotDataIntegrityCronJob, v.Name)
+			continue
+		}
+		cronJobs[v.Spec.SnapshotDataIntegrityCronJob] = struct{}{}
+	}
+
+	return cronJobs, nil
+}
+
 func (m *SnapshotMonitor) GetCollectedData() (interface{}, error) {
 	m.RLock()
 	defer m.RUnlock()
@@ -438,6 +534,73 @@ func (m *SnapshotMonitor) waitAndHandleSnapshotHashing(engine *longhorn.Engine,
 	return nil
 }
 
+// checkNodeIsNotBusy returns an error if any replica on the node is being
+// rebuilt, serves as a rebuild source or backs up a snapshot, to avoid hashing
+// snapshots along with them. The engines of these replicas may run on other
+// nodes.
+func (m *SnapshotMonitor) checkNodeIsNotBusy() error {
+	replicas, err := m.ds.ListReplicasByNodeRO(m.nodeName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list replicas on node %v", m.nodeName)
+	}
+
+	replicaNamesByVolume := map[string]map[string]struct{}{}
+	for _, r := range replicas {
+		if replicaNamesByVolume[r.Spec.VolumeName] == nil {
+			replicaNamesByVolume[r.Spec.VolumeName] = map[string]struct{}{}
+		}
+		replicaNamesByVolume[r.Spec.VolumeName][r.Name] = struct{}{}
+	}
+
+	for volumeName, replicaNames := range replicaNamesByVolume {
+		engines, err := m.ds.ListVolumeEnginesRO(volumeName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list engines of volume %v", volumeName)
+		}
+		for _, e := range engines {
+			if isEngineBusyWithReplicas(e, replicaNames) {
+				return fmt.Errorf("cannot hash snapshot during rebuilding or backing up of volume %v with replicas on node %v", volumeName, m.nodeName)
+			}
+		}
+	}
+	return nil
+}
+
+// isEngineBusyWithReplicas returns true if the engine is rebuilding one of the
+// replicas, rebuilding from one of them or backing up from one of them.
+func isEngineBusyWithReplicas(e *longhorn.Engine, replicaNames map[string]struct{}) bool {
+	addresses := map[string]struct{}{}
+	for replicaName, address := range e.Status.CurrentReplicaAddressMap {
+		if _, ok := replicaNames[replicaName]; ok {
+			addresses[address] = struct{}{}
+		}
+	}
+	if len(addresses) == 0 {
+		return false
+	}
+
+	for url, status := range e.Status.RebuildStatus {
+		if status == nil || !status.IsRebuilding {
+			continue
+		}
+		if _, ok := addresses[engineapi.GetAddressFromBackendReplicaURL(url)]; ok {
+			return true
+		}
+		if _, ok := addresses[engineapi.GetAddressFromBackendReplicaURL(status.FromReplicaAddress)]; ok {
+			return true
+		}
+	}
+	for _, status := range e.Status.BackupStatus {
+		if status == nil || status.State != string(engineapi.ProcessStateInProgress) {
+			continue
+		}
+		if _, ok := addresses[engineapi.GetAddressFromBackendReplicaURL(status.ReplicaAddress)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *SnapshotMonitor) checkVolumeNotInMigration(volumeName string) error {
 	v, err := m.ds.GetVolume(volumeName)
 	if err != nil {
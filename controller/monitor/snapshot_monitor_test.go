@@ -3,6 +3,7 @@ package monitor
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
@@ -546,3 +547,72 @@ func TestDetermineChecksumFromHashStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestGetSnapshotCheckStaggerDelay(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal(time.Duration(0), getSnapshotCheckStaggerDelay("vol-01", 0))
+
+	window := 30 * time.Minute
+	delays := map[time.Duration]struct{}{}
+	for i := 0; i < 10; i++ {
+		volumeName := fmt.Sprintf("vol-%02d", i)
+		delay := getSnapshotCheckStaggerDelay(volumeName, window)
+		assert.True(delay >= 0 && delay < window)
+		assert.Equal(delay, getSnapshotCheckStaggerDelay(volumeName, window))
+		delays[delay] = struct{}{}
+	}
+	assert.True(len(delays) > 1)
+}
+
+func TestIsEngineBusyWithReplicas(t *testing.T) {
+	assert := require.New(t)
+
+	newEngine := func() *longhorn.Engine {
+		return &longhorn.Engine{
+			Status: longhorn.EngineStatus{
+				CurrentReplicaAddressMap: map[string]string{
+					"replica-local":  "10.0.0.1:10000",
+					"replica-remote": "10.0.0.2:10000",
+				},
+			},
+		}
+	}
+	localReplicas := map[string]struct{}{"replica-local": {}}
+
+	e := newEngine()
+	assert.False(isEngineBusyWithReplicas(e, localReplicas))
+
+	// Rebuilding the local replica
+	e = newEngine()
+	e.Status.RebuildStatus = map[string]*longhorn.RebuildStatus{
+		"tcp://10.0.0.1:10000": {IsRebuilding: true, FromReplicaAddress: "tcp://10.0.0.2:10000"},
+	}
+	assert.True(isEngineBusyWithReplicas(e, localReplicas))
+
+	// Rebuilding a remote replica from the local replica
+	e = newEngine()
+	e.Status.RebuildStatus = map[string]*longhorn.RebuildStatus{
+		"tcp://10.0.0.2:10000": {IsRebuilding: true, FromReplicaAddress: "tcp://10.0.0.1:10000"},
+	}
+	assert.True(isEngineBusyWithReplicas(e, localReplicas))
+
+	// Rebuilding a remote replica from another remote replica
+	e = newEngine()
+	e.Status.CurrentReplicaAddressMap["replica-remote-2"] = "10.0.0.3:10000"
+	e.Status.RebuildStatus = map[string]*longhorn.RebuildStatus{
+		"tcp://10.0.0.3:10000": {IsRebuilding: true, FromReplicaAddress: "tcp://10.0.0.2:10000"},
+	}
+	assert.False(isEngineBusyWithReplicas(e, localReplicas))
+
+	// Backing up from the local replica
+	e = newEngine()
+	e.Status.BackupStatus = map[string]*longhorn.EngineBackupStatus{
+		"backup-01": {State: "in_progress", ReplicaAddress: "tcp://10.0.0.1:10000"},
+	}
+	assert.True(isEngineBusyWithReplicas(e, localReplicas))
+
+	// Completed backup from the local replica
+	e.Status.BackupStatus["backup-01"].State = "complete"
+	assert.False(isEngineBusyWithReplicas(e, localReplicas))
+}
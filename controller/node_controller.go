@@ -2,9 +2,11 @@ package controller
 
 import (
 	"fmt"
+	"net"
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -17,7 +19,10 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/kubernetes/pkg/controller"
 
+	imutil "github.com/longhorn/longhorn-instance-manager/pkg/util"
+
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -25,6 +30,7 @@ import (
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 
 	"github.com/longhorn/longhorn-manager/constant"
+	"github.com/longhorn/longhorn-manager/csi/crypto"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/scheduler"
@@ -40,6 +46,9 @@ const (
 	nodeControllerResyncPeriod = 30 * time.Second
 	ignoreKubeletNotReadyTime  = 15 * time.Second
 
+	instanceManagerReachabilityTimeout = 3 * time.Second
+	instanceManagerReachabilityMaxAge  = nodeControllerResyncPeriod
+
 	unknownDiskID = "UNKNOWN_DISKID"
 
 	snapshotChangeEventQueueMax = 1048576
@@ -61,6 +70,10 @@ type NodeController struct {
 	snapshotMonitor              monitor.Monitor
 	snapshotChangeEventQueue     workqueue.TypedInterface[any]
 	snapshotChangeEventQueueLock sync.Mutex
+	// volumeSnapshotCheckCronJobsChanged is set when the snapshot check cron
+	// job of a volume changes, so the snapshot monitor reloads the volume
+	// cron jobs on the next sync only.
+	volumeSnapshotCheckCronJobsChanged atomic.Bool
 
 	ds *datastore.DataStore
 
@@ -68,11 +81,25 @@ type NodeController struct {
 
 	topologyLabelsChecker TopologyLabelsChecker
 
+	instanceManagerReachabilityChecker InstanceManagerReachabilityChecker
+	instanceManagerReachability        *instanceManagerReachabilityCache
+
+	encryptedDiskOpener EncryptedDiskOpener
+	// openedEncryptedDisks records the block device paths of the encrypted disks opened by this controller,
+	// so that cryptsetup is not run on the host for every node sync.
+	openedEncryptedDisks map[string]string
+
+	heldUpgrades *heldUpgradeTracker
+
 	scheduler *scheduler.ReplicaScheduler
 }
 
 type TopologyLabelsChecker func(kubeClient clientset.Interface, vers string) (bool, error)
 
+type InstanceManagerReachabilityChecker func(im *longhorn.InstanceManager) bool
+
+type EncryptedDiskOpener func(diskName, devicePath, passphrase string, cryptoParams *crypto.EncryptParams) error
+
 func NewNodeController(
 	logger logrus.FieldLogger,
 	ds *datastore.DataStore,
@@ -98,6 +125,14 @@ func NewNodeController(
 
 		topologyLabelsChecker: util.IsKubernetesVersionAtLeast,
 
+		instanceManagerReachabilityChecker: isInstanceManagerReachable,
+		instanceManagerReachability:        newInstanceManagerReachabilityCache(),
+
+		encryptedDiskOpener:  openEncryptedDisk,
+		openedEncryptedDisks: map[string]string{},
+
+		heldUpgrades: newHeldUpgradeTracker(),
+
 		snapshotChangeEventQueue: workqueue.NewTyped[any](),
 	}
 
@@ -152,6 +187,15 @@ func NewNodeController(
 	}
 	nc.cacheSyncs = append(nc.cacheSyncs, ds.SnapshotInformer.HasSynced)
 
+	if _, err = ds.VolumeInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    nc.enqueueVolumeSnapshotCheckCronJob,
+		UpdateFunc: nc.enqueueVolumeSnapshotCheckCronJobChange,
+		DeleteFunc: nc.enqueueVolumeSnapshotCheckCronJob,
+	}, 0); err != nil {
+		return nil, err
+	}
+	nc.cacheSyncs = append(nc.cacheSyncs, ds.VolumeInformer.HasSynced)
+
 	if _, err = ds.PodInformer.AddEventHandlerWithResyncPeriod(
 		cache.FilteringResourceEventHandler{
 			FilterFunc: isManagerPod,
@@ -173,6 +217,14 @@ func NewNodeController(
 	}
 	nc.cacheSyncs = append(nc.cacheSyncs, ds.KubeNodeInformer.HasSynced)
 
+	if _, err = ds.CSINodeInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    nc.enqueueCSINode,
+		UpdateFunc: func(old, cur interface{}) { nc.enqueueCSINode(cur) },
+	}, 0); err != nil {
+		return nil, err
+	}
+	nc.cacheSyncs = append(nc.cacheSyncs, ds.CSINodeInformer.HasSynced)
+
 	return nc, nil
 }
 
@@ -194,7 +246,8 @@ func (nc *NodeController) isResponsibleForSetting(obj interface{}) bool {
 	return types.SettingName(setting.Name) == types.SettingNameStorageMinimalAvailablePercentage ||
 		types.SettingName(setting.Name) == types.SettingNameBackingImageCleanupWaitInterval ||
 		types.SettingName(setting.Name) == types.SettingNameOrphanResourceAutoDeletion ||
-		types.SettingName(setting.Name) == types.SettingNameNodeDrainPolicy
+		types.SettingName(setting.Name) == types.SettingNameNodeDrainPolicy ||
+		types.SettingName(setting.Name) == types.SettingNameSnapshotDataIntegrityCronJob
 }
 
 func (nc *NodeController) isResponsibleForReplica(obj interface{}) bool {
@@ -418,6 +471,10 @@ func (nc *NodeController) syncNode(key string) (err error) {
 		return nil
 	}
 
+	if err := nc.syncKubernetesNodeIdentity(node, kubeNode); err != nil {
+		return err
+	}
+
 	// Getting here is enough proof of life to turn on the services that might
 	// have been turned off for RWX failover.
 	labels := types.MergeStringMaps(types.GetAdmissionWebhookLabel(), types.GetRecoveryBackendLabel())
@@ -436,6 +493,9 @@ func (nc *NodeController) syncNode(key string) (err error) {
 		return err
 	}
 
+	// Open the dm-crypt layers before the disk monitor collects the disk data
+	encryptedDevicePaths := nc.openEncryptedDisks(node)
+
 	collectedDiskInfo, err := nc.syncWithDiskMonitor(node)
 	if err != nil {
 		if strings.Contains(err.Error(), "mismatching disks") {
@@ -450,6 +510,14 @@ func (nc *NodeController) syncNode(key string) (err error) {
 		return err
 	}
 
+	for diskName, diskStatus := range node.Status.DiskStatus {
+		diskStatus.EncryptedDevicePath = encryptedDevicePaths[diskName]
+		// The disk monitor found the device closed, e.g. after the node rebooted. Open it again in the next sync.
+		if types.GetCondition(diskStatus.Conditions, longhorn.DiskConditionTypeReady).Reason == longhorn.DiskConditionReasonDiskEncryptionFailed {
+			delete(nc.openedEncryptedDisks, diskName)
+		}
+	}
+
 	collectedEnvironmentCheckConditions, err := nc.syncWithEnvironmentCheckMonitor()
 	if err == nil {
 		// Best effort to update the environment check conditions
@@ -607,6 +675,47 @@ func (nc *NodeController) enqueueSnapshot(old, cur interface{}) {
 	}
 }
 
+func (nc *NodeController) enqueueVolumeSnapshotCheckCronJob(obj interface{}) {
+	volume, ok := obj.(*longhorn.Volume)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("received unexpected obj: %#v", obj))
+			return
+		}
+
+		// use the last known state, to enqueue, dependent objects
+		volume, ok = deletedState.Obj.(*longhorn.Volume)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("DeletedFinalStateUnknown contained invalid object: %#v", deletedState.Obj))
+			return
+		}
+	}
+
+	if volume.Spec.SnapshotDataIntegrityCronJob == "" {
+		return
+	}
+	nc.volumeSnapshotCheckCronJobsChanged.Store(true)
+	nc.queue.Add(nc.namespace + "/" + nc.controllerID)
+}
+
+func (nc *NodeController) enqueueVolumeSnapshotCheckCronJobChange(old, cur interface{}) {
+	oldVolume, ok := old.(*longhorn.Volume)
+	if !ok {
+		return
+	}
+	curVolume, ok := cur.(*longhorn.Volume)
+	if !ok {
+		return
+	}
+
+	if oldVolume.Spec.SnapshotDataIntegrityCronJob == curVolume.Spec.SnapshotDataIntegrityCronJob {
+		return
+	}
+	nc.volumeSnapshotCheckCronJobsChanged.Store(true)
+	nc.queue.Add(nc.namespace + "/" + nc.controllerID)
+}
+
 func (nc *NodeController) enqueueManagerPod(obj interface{}) {
 	nodes, err := nc.ds.ListNodesRO()
 	if err != nil {
@@ -645,6 +754,23 @@ func (nc *NodeController) enqueueKubernetesNode(obj interface{}) {
 	nc.enqueueNode(nodeRO)
 }
 
+func (nc *NodeController) enqueueCSINode(obj interface{}) {
+	csiNode, ok := obj.(*storagev1.CSINode)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("received unexpected obj: %#v", obj))
+		return
+	}
+
+	nodeRO, err := nc.ds.GetNodeRO(csiNode.Name)
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("failed to get longhorn node %v: %v ", csiNode.Name, err))
+		}
+		return
+	}
+	nc.enqueueNode(nodeRO)
+}
+
 func (nc *NodeController) syncDiskStatus(node *longhorn.Node, collectedDataInfo map[string]*monitor.CollectedDiskInfo) error {
 	nc.alignDiskSpecAndStatus(node)
 
@@ -1081,6 +1207,22 @@ func (nc *NodeController) syncInstanceManagers(node *longhorn.Node) error {
 						cleanupRequired = false
 					}
 
+					if cleanupRequired && im.DeletionTimestamp == nil {
+						upgradeFrozen, err := nc.ds.GetSettingAsBool(types.SettingNameUpgradeFreeze)
+						if err != nil {
+							return err
+						}
+						if upgradeFrozen {
+							cleanupRequired = false
+							if nc.heldUpgrades.hold(im.Name) {
+								log.Infof("Skipped replacing instance manager %v with image %v since the upgrade freeze is enabled", im.Name, im.Spec.Image)
+								nc.eventRecorder.Eventf(node, corev1.EventTypeNormal, constant.EventReasonUpgradeFrozen, "Skipped replacing instance manager %v with image %v since the upgrade freeze is enabled", im.Name, im.Spec.Image)
+							}
+						} else {
+							nc.heldUpgrades.release(im.Name)
+						}
+					}
+
 					if im.Status.CurrentState == longhorn.InstanceManagerStateUnknown && im.DeletionTimestamp == nil {
 						cleanupRequired = false
 						log.Debugf("Skipping cleaning up non-default unknown instance manager %s", im.Name)
@@ -1567,14 +1709,84 @@ func (nc *NodeController) alignDiskSpecAndStatus(node *longhorn.Node) {
 			if diskInstanceName == "" {
 				diskInstanceName = diskName
 			}
-			if err := nc.deleteDisk(diskStatus.Type, diskInstanceName, diskStatus.DiskUUID, diskStatus.DiskPath, string(diskStatus.DiskDriver)); err != nil {
+			// The disk service works on the opened dm-crypt device of an encrypted disk
+			diskPath := diskStatus.DiskPath
+			if diskStatus.EncryptedDevicePath != "" {
+				diskPath = diskStatus.EncryptedDevicePath
+			}
+			if err := nc.deleteDisk(diskStatus.Type, diskInstanceName, diskStatus.DiskUUID, diskPath, string(diskStatus.DiskDriver)); err != nil {
 				nc.logger.WithError(err).Warnf("Failed to delete disk %v", diskInstanceName)
 			}
+			if diskStatus.EncryptedDevicePath != "" {
+				if err := crypto.CloseDisk(diskName); err != nil {
+					nc.logger.WithError(err).Warnf("Failed to close encrypted disk %v", diskName)
+				}
+			}
+			delete(nc.openedEncryptedDisks, diskName)
 			delete(node.Status.DiskStatus, diskName)
 		}
 	}
 }
 
+// openEncryptedDisks formats the block devices of the encrypted disks with
+// LUKS if they are not yet, and opens the dm-crypt layers on the node. It
+// returns the paths of the opened devices. The disks already opened by this
+// controller are skipped.
+func (nc *NodeController) openEncryptedDisks(node *longhorn.Node) map[string]string {
+	encryptedDevicePaths := map[string]string{}
+
+	for diskName, disk := range node.Spec.Disks {
+		if !disk.Encrypted {
+			continue
+		}
+
+		if nc.openedEncryptedDisks[diskName] == disk.Path {
+			encryptedDevicePaths[diskName] = crypto.DiskMapper(diskName)
+			continue
+		}
+
+		if err := nc.openEncryptedDisk(diskName, disk); err != nil {
+			nc.logger.WithError(err).Warnf("Failed to open encrypted disk %v (%v)", diskName, disk.Path)
+			nc.eventRecorder.Eventf(node, corev1.EventTypeWarning, constant.EventReasonFailedEncrypting,
+				"Failed to open encrypted disk %v (%v): %v", diskName, disk.Path, err)
+			continue
+		}
+		nc.openedEncryptedDisks[diskName] = disk.Path
+		encryptedDevicePaths[diskName] = crypto.DiskMapper(diskName)
+	}
+
+	return encryptedDevicePaths
+}
+
+func (nc *NodeController) openEncryptedDisk(diskName string, disk longhorn.DiskSpec) error {
+	if disk.Type != longhorn.DiskTypeBlock {
+		return fmt.Errorf("disk type %v is not supported for disk encryption", disk.Type)
+	}
+	if disk.EncryptionSecret == "" {
+		return fmt.Errorf("encryption secret is not specified")
+	}
+
+	secret, err := nc.ds.GetEncryptionSecret(nc.namespace, disk.EncryptionSecret)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get encryption secret %v", disk.EncryptionSecret)
+	}
+	passphrase := secret[types.CryptoKeyValue]
+	if passphrase == "" {
+		return fmt.Errorf("missing %v in encryption secret %v", types.CryptoKeyValue, disk.EncryptionSecret)
+	}
+
+	cryptoParams := crypto.NewEncryptParams(secret[types.CryptoKeyProvider], secret[types.CryptoKeyCipher],
+		secret[types.CryptoKeyHash], secret[types.CryptoKeySize], secret[types.CryptoPBKDF])
+	return nc.encryptedDiskOpener(diskName, disk.Path, passphrase, cryptoParams)
+}
+
+func openEncryptedDisk(diskName, devicePath, passphrase string, cryptoParams *crypto.EncryptParams) error {
+	if err := crypto.EncryptVolume(devicePath, passphrase, cryptoParams); err != nil {
+		return err
+	}
+	return crypto.OpenDisk(diskName, devicePath, passphrase)
+}
+
 func (nc *NodeController) deleteDisk(diskType longhorn.DiskType, diskName, diskUUID, diskPath, diskDriver string) error {
 	nc.logger.Infof("Deleting disk %v with diskUUID %v", diskName, diskUUID)
 
@@ -1630,7 +1842,9 @@ func isDiskMatched(node *longhorn.Node, collectedDiskInfo map[string]*monitor.Co
 func (nc *NodeController) createSnapshotMonitor() (mon monitor.Monitor, err error) {
 	defer func() {
 		if err == nil {
-			err = nc.snapshotMonitor.UpdateConfiguration(map[string]interface{}{})
+			err = nc.snapshotMonitor.UpdateConfiguration(map[string]interface{}{
+				monitor.SnapshotMonitorConfigVolumeCronJobsChanged: nc.volumeSnapshotCheckCronJobsChanged.Swap(false),
+			})
 		}
 	}()
 
@@ -1789,6 +2003,11 @@ func (nc *NodeController) shouldEvictReplica(node *longhorn.Node, kubeNode *core
 	if node.Spec.EvictionRequested || diskSpec.EvictionRequested {
 		return true, constant.EventReasonEvictionUserRequested, nil
 	}
+	if isDraining, err := nc.isReplicaInstanceManagerDraining(replica); err != nil {
+		return false, "", err
+	} else if isDraining {
+		return true, constant.EventReasonEvictionAutomatic, nil
+	}
 	if !kubeNode.Spec.Unschedulable {
 		// Node drain policy only takes effect on cordoned nodes.
 		return false, constant.EventReasonEvictionCanceled, nil
@@ -1818,6 +2037,24 @@ func (nc *NodeController) shouldEvictReplica(node *longhorn.Node, kubeNode *core
 	return false, constant.EventReasonEvictionCanceled, nil
 }
 
+// isReplicaInstanceManagerDraining checks if the instance manager that the replica is running in is being drained
+// before its pod is replaced.
+func (nc *NodeController) isReplicaInstanceManagerDraining(replica *longhorn.Replica) (bool, error) {
+	if replica.Status.InstanceManagerName == "" || replica.Status.CurrentState != longhorn.InstanceStateRunning {
+		return false, nil
+	}
+
+	im, err := nc.ds.GetInstanceManagerRO(replica.Status.InstanceManagerName)
+	if err != nil {
+		if datastore.ErrorIsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return im.Status.DrainState != longhorn.InstanceManagerDrainStateNone, nil
+}
+
 func isNodeOrDisksEvictionRequested(node *longhorn.Node) bool {
 	if node.Spec.EvictionRequested {
 		return true
@@ -1899,6 +2136,15 @@ func (nc *NodeController) setReadyConditionForKubeNode(node *longhorn.Node, kube
 					// undesirable churn. See https://github.com/longhorn/longhorn/issues/7302 for an example.
 					nc.logger.Warnf("Ignoring %v == %v condition due to %v until %v", corev1.NodeReady, con.Status,
 						con.Reason, con.LastTransitionTime.Add(ignoreKubeletNotReadyTime))
+				} else if aliveSignals := nc.getNetworkPartitionedNodeAliveSignals(node, con); len(aliveSignals) > 0 {
+					// The kubelet heartbeat is lost, but the data path of the node still works. Report the node as
+					// not ready without handling it as a down node, so its replicas are not rebuilt elsewhere.
+					nodeReady = false
+					node.Status.Conditions = types.SetConditionAndRecord(node.Status.Conditions,
+						longhorn.NodeConditionTypeReady, longhorn.ConditionStatusFalse,
+						string(longhorn.NodeConditionReasonNetworkPartitioned),
+						fmt.Sprintf("Kubernetes node %v is network partitioned: %v, but %v", node.Name, con.Reason, strings.Join(aliveSignals, ", ")),
+						nc.eventRecorder, node, corev1.EventTypeWarning)
 				} else {
 					nodeReady = false
 					node.Status.Conditions = types.SetConditionAndRecord(node.Status.Conditions,
@@ -1925,6 +2171,107 @@ func (nc *NodeController) setReadyConditionForKubeNode(node *longhorn.Node, kube
 	return nodeReady
 }
 
+// getNetworkPartitionedNodeAliveSignals returns the signals showing the node is still alive although its kubelet
+// stopped posting the node status. A node with any of these signals is network partitioned rather than down, until
+// the node network partition grace period elapses.
+func (nc *NodeController) getNetworkPartitionedNodeAliveSignals(node *longhorn.Node, kubeReadyCondition corev1.NodeCondition) []string {
+	log := getLoggerForNode(nc.logger, node)
+
+	// The node lifecycle controller sets the Unknown status once the kubelet heartbeat is lost. The False status
+	// is reported by a running kubelet, so the node is not partitioned.
+	if kubeReadyCondition.Status != corev1.ConditionUnknown {
+		return nil
+	}
+
+	gracePeriod, err := nc.ds.GetSettingAsInt(types.SettingNameNodeNetworkPartitionGracePeriod)
+	if err != nil {
+		log.WithError(err).Warnf("Failed to get %v setting", types.SettingNameNodeNetworkPartitionGracePeriod)
+		return nil
+	}
+	if gracePeriod == 0 ||
+		time.Since(kubeReadyCondition.LastTransitionTime.Time) >= time.Duration(gracePeriod)*time.Second {
+		return nil
+	}
+
+	signals := []string{}
+
+	ims, err := nc.ds.ListInstanceManagersByNodeRO(node.Name, longhorn.InstanceManagerTypeAllInOne, "")
+	if err != nil {
+		log.WithError(err).Warn("Failed to list instance managers for network partition detection")
+		return nil
+	}
+	for _, im := range ims {
+		if im.Status.IP == "" {
+			continue
+		}
+		// The node is synced again once the instance manager is probed
+		reachable, probed := nc.instanceManagerReachability.get(im, instanceManagerReachabilityMaxAge,
+			nc.instanceManagerReachabilityChecker, func() { nc.enqueueNode(node) })
+		if probed && reachable {
+			signals = append(signals, fmt.Sprintf("instance manager %v is reachable from node %v", im.Name, nc.controllerID))
+		}
+	}
+
+	connectedReplicaCount, err := nc.countReplicasConnectedToRemoteEngines(node.Name, kubeReadyCondition.LastTransitionTime.Time)
+	if err != nil {
+		log.WithError(err).Warn("Failed to check engine-replica connectivity for network partition detection")
+		return nil
+	}
+	if connectedReplicaCount > 0 {
+		signals = append(signals, fmt.Sprintf("%v replicas are connected to engines on other nodes", connectedReplicaCount))
+	}
+
+	return signals
+}
+
+// countReplicasConnectedToRemoteEngines returns how many replicas on the node are in RW mode in running engines
+// on the other nodes. An engine drops a replica soon after losing the connection to it. Only the replica modes
+// got from the engines after the kubelet heartbeat was lost are counted, since the engine status is not updated
+// once the engine monitor stops, e.g. if the node of the engine is partitioned as well.
+func (nc *NodeController) countReplicasConnectedToRemoteEngines(nodeName string, heartbeatLostAt time.Time) (int, error) {
+	replicas, err := nc.ds.ListReplicasByNodeRO(nodeName)
+	if err != nil {
+		return 0, err
+	}
+	if len(replicas) == 0 {
+		return 0, nil
+	}
+	replicaNames := map[string]struct{}{}
+	for _, r := range replicas {
+		replicaNames[r.Name] = struct{}{}
+	}
+
+	engines, err := nc.ds.ListEnginesRO()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, e := range engines {
+		if e.Spec.NodeID == "" || e.Spec.NodeID == nodeName || e.Status.CurrentState != longhorn.InstanceStateRunning {
+			continue
+		}
+		if !isReplicaModeMapCheckedAfter(e, heartbeatLostAt) ||
+			!isReplicaModeMapCheckedAfter(e, time.Now().Add(-2*ReplicaModeMapCheckInterval)) {
+			continue
+		}
+		for replicaName, mode := range e.Status.ReplicaModeMap {
+			if _, exists := replicaNames[replicaName]; exists && mode == longhorn.ReplicaModeRW {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+func isInstanceManagerReachable(im *longhorn.InstanceManager) bool {
+	conn, err := net.DialTimeout("tcp", imutil.GetURL(im.Status.IP, engineapi.InstanceManagerProcessManagerServiceDefaultPort), instanceManagerReachabilityTimeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
 // Update node condition based on DisableSchedulingOnCordonedNode setting and Kubernetes node status.
 func (nc *NodeController) SetSchedulableCondition(node *longhorn.Node, kubeNode *corev1.Node,
 	disableSchedulingOnCordonedNode bool) {
@@ -1987,3 +2334,191 @@ func (nc *NodeController) clearDelinquentLeasesIfNodeNotReady(node *longhorn.Nod
 
 	return storedError
 }
+
+// syncKubernetesNodeIdentity records the identity of the Kubernetes node the Longhorn node is running on. When the
+// node is reinstalled with the same name, the identity changes and the attachments made before the reinstallation
+// become stale, so they are re-driven to let the workloads recover. The identity consists of the node UID, the
+// machine ID and the node ID registered by the Longhorn CSI driver.
+func (nc *NodeController) syncKubernetesNodeIdentity(node *longhorn.Node, kubeNode *corev1.Node) error {
+	log := getLoggerForNode(nc.logger, node)
+
+	uid := string(kubeNode.UID)
+	machineID := kubeNode.Status.NodeInfo.MachineID
+	csiDriverNodeID, err := nc.getCSIDriverNodeID(kubeNode.Name)
+	if err != nil {
+		return err
+	}
+
+	uidChanged := node.Status.KubernetesNodeUID != "" && uid != "" && node.Status.KubernetesNodeUID != uid
+	machineIDChanged := node.Status.MachineID != "" && machineID != "" && node.Status.MachineID != machineID
+	csiDriverNodeIDChanged := node.Status.CSIDriverNodeID != "" && csiDriverNodeID != "" && node.Status.CSIDriverNodeID != csiDriverNodeID
+	if uidChanged || machineIDChanged || csiDriverNodeIDChanged {
+		reason, err := nc.getStaleAttachmentsResetBlocker(node.Name)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check if the stale attachments of node %v can be reset", node.Name)
+		}
+		if reason != "" {
+			// Keep the recorded identity so that the reset is retried in the following syncs
+			log.Debugf("Kubernetes node identity changed, but cannot reset stale attachments since %v", reason)
+			return nil
+		}
+
+		message := fmt.Sprintf("Kubernetes node identity changed from UID %v, machine ID %v, CSI driver node ID %v to UID %v, machine ID %v, CSI driver node ID %v, resetting stale attachments",
+			node.Status.KubernetesNodeUID, node.Status.MachineID, node.Status.CSIDriverNodeID, uid, machineID, csiDriverNodeID)
+		log.Warn(message)
+		nc.eventRecorder.Event(node, corev1.EventTypeWarning, constant.EventReasonNodeIdentityChanged, message)
+
+		if err := nc.resetStaleAttachments(node.Name); err != nil {
+			return errors.Wrapf(err, "failed to reset stale attachments after the identity of node %v changed", node.Name)
+		}
+	}
+
+	node.Status.KubernetesNodeUID = uid
+	node.Status.MachineID = machineID
+	node.Status.CSIDriverNodeID = csiDriverNodeID
+	return nil
+}
+
+// getCSIDriverNodeID returns the node ID registered by the Longhorn CSI driver in the CSINode of the Kubernetes node.
+// It returns an empty string if the driver is not registered yet.
+func (nc *NodeController) getCSIDriverNodeID(nodeName string) (string, error) {
+	csiNode, err := nc.ds.GetCSINodeRO(nodeName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get CSINode %v", nodeName)
+	}
+	for _, driver := range csiNode.Spec.Drivers {
+		if driver.Name == types.LonghornDriverName {
+			return driver.NodeID, nil
+		}
+	}
+	return "", nil
+}
+
+// getStaleAttachmentsResetBlocker returns the reason why the attachments on the node cannot be reset, or an empty
+// string if they can. The attachments are only stale once the engines on the node are gone or unreachable and no
+// workload pods using the attached volumes are running on the node.
+func (nc *NodeController) getStaleAttachmentsResetBlocker(nodeName string) (string, error) {
+	engines, err := nc.ds.ListEnginesByNodeRO(nodeName)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range engines {
+		if e.Status.CurrentState != longhorn.InstanceStateRunning || e.Status.InstanceManagerName == "" {
+			continue
+		}
+		im, err := nc.ds.GetInstanceManagerRO(e.Status.InstanceManagerName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", err
+		}
+		if im.Status.CurrentState != longhorn.InstanceManagerStateRunning {
+			continue
+		}
+		if instance, ok := im.Status.InstanceEngines[e.Name]; ok && instance.Status.State == longhorn.InstanceStateRunning {
+			return fmt.Sprintf("engine %v is still running in instance manager %v", e.Name, im.Name), nil
+		}
+	}
+
+	vas, err := nc.ds.ListLHVolumeAttachmentsRO()
+	if err != nil {
+		return "", err
+	}
+	for _, va := range vas {
+		if !hasCSIAttachmentTicketOnNode(va, nodeName) {
+			continue
+		}
+		v, err := nc.ds.GetVolumeRO(va.Spec.Volume)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", err
+		}
+		for _, workload := range v.Status.KubernetesStatus.WorkloadsStatus {
+			pod, err := nc.ds.GetPodRO(v.Status.KubernetesStatus.Namespace, workload.PodName)
+			if err != nil {
+				return "", err
+			}
+			if pod != nil && pod.Spec.NodeName == nodeName && isPodContainerRunning(pod) {
+				return fmt.Sprintf("pod %v/%v using volume %v is still running", pod.Namespace, pod.Name, v.Name), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func hasCSIAttachmentTicketOnNode(va *longhorn.VolumeAttachment, nodeName string) bool {
+	for _, ticket := range va.Spec.AttachmentTickets {
+		if ticket != nil && ticket.NodeID == nodeName && ticket.Type == longhorn.AttacherTypeCSIAttacher {
+			return true
+		}
+	}
+	return false
+}
+
+// isPodContainerRunning returns true if the pod is running with at least one container started.
+func isPodContainerRunning(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Running != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// resetStaleAttachments removes the CSI attachment tickets on the node so that the engines started before the node
+// identity changed are stopped, and deletes the Kubernetes VolumeAttachments of the node so that the workloads
+// request the attachments again.
+func (nc *NodeController) resetStaleAttachments(nodeName string) error {
+	log := nc.logger.WithField("node", nodeName)
+
+	vas, err := nc.ds.ListLHVolumeAttachmentsRO()
+	if err != nil {
+		return err
+	}
+	for _, va := range vas {
+		staleTicketIDs := []string{}
+		for id, ticket := range va.Spec.AttachmentTickets {
+			if ticket != nil && ticket.NodeID == nodeName && ticket.Type == longhorn.AttacherTypeCSIAttacher {
+				staleTicketIDs = append(staleTicketIDs, id)
+			}
+		}
+		if len(staleTicketIDs) == 0 {
+			continue
+		}
+
+		va = va.DeepCopy()
+		for _, id := range staleTicketIDs {
+			delete(va.Spec.AttachmentTickets, id)
+		}
+		if _, err := nc.ds.UpdateLHVolumeAttachment(va); err != nil {
+			return err
+		}
+		log.Infof("Removed stale attachment tickets %v of volume %v", staleTicketIDs, va.Spec.Volume)
+	}
+
+	kubeVAs, err := nc.ds.ListVolumeAttachmentsRO()
+	if err != nil {
+		return err
+	}
+	for _, kubeVA := range kubeVAs {
+		if kubeVA.Spec.Attacher != types.LonghornDriverName || kubeVA.Spec.NodeName != nodeName || kubeVA.DeletionTimestamp != nil {
+			continue
+		}
+		if err := nc.ds.DeleteVolumeAttachment(kubeVA.Name); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		log.Infof("Deleted stale Kubernetes VolumeAttachment %v", kubeVA.Name)
+	}
+
+	return nil
+}
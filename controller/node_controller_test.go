@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	. "gopkg.in/check.v1"
@@ -15,12 +16,14 @@ import (
 	"k8s.io/kubernetes/pkg/controller"
 
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	fake "k8s.io/client-go/kubernetes/fake"
 
+	"github.com/longhorn/longhorn-manager/csi/crypto"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
@@ -79,6 +82,7 @@ type NodeControllerSuite struct {
 	lhSettingsIndexer        cache.Indexer
 	lhInstanceManagerIndexer cache.Indexer
 	lhOrphanIndexer          cache.Indexer
+	lhEngineIndexer          cache.Indexer
 
 	podIndexer  cache.Indexer
 	nodeIndexer cache.Indexer
@@ -96,6 +100,7 @@ type NodeControllerFixture struct {
 	lhSettings         map[string]*longhorn.Setting
 	lhInstanceManagers map[string]*longhorn.InstanceManager
 	lhOrphans          map[string]*longhorn.Orphan
+	lhEngines          []*longhorn.Engine
 	pods               map[string]*corev1.Pod
 	nodes              map[string]*corev1.Node
 }
@@ -128,6 +133,7 @@ func (s *NodeControllerSuite) SetUpTest(c *C) {
 	s.lhSettingsIndexer = s.informerFactories.LhInformerFactory.Longhorn().V1beta2().Settings().Informer().GetIndexer()
 	s.lhInstanceManagerIndexer = s.informerFactories.LhInformerFactory.Longhorn().V1beta2().InstanceManagers().Informer().GetIndexer()
 	s.lhOrphanIndexer = s.informerFactories.LhInformerFactory.Longhorn().V1beta2().Orphans().Informer().GetIndexer()
+	s.lhEngineIndexer = s.informerFactories.LhInformerFactory.Longhorn().V1beta2().Engines().Informer().GetIndexer()
 
 	s.podIndexer = s.informerFactories.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
 	s.nodeIndexer = s.informerFactories.KubeInformerFactory.Core().V1().Nodes().Informer().GetIndexer()
@@ -396,6 +402,185 @@ func (s *NodeControllerSuite) TestKubeNodeDown(c *C) {
 	s.checkOrphans(c, expectation)
 }
 
+func (s *NodeControllerSuite) TestKubeNodeNetworkPartitionedWithReachableInstanceManager(c *C) {
+	s.testKubeNodeHeartbeatLost(c, &kubeNodeHeartbeatLostTestCase{
+		imProbed:       true,
+		imReachable:    true,
+		expectedReason: longhorn.NodeConditionReasonNetworkPartitioned,
+	})
+}
+
+func (s *NodeControllerSuite) TestKubeNodeHeartbeatLostWithUnprobedInstanceManager(c *C) {
+	s.testKubeNodeHeartbeatLost(c, &kubeNodeHeartbeatLostTestCase{
+		imReachable:    true,
+		expectedReason: longhorn.NodeConditionReasonKubernetesNodeNotReady,
+	})
+}
+
+func (s *NodeControllerSuite) TestKubeNodeNetworkPartitionedWithConnectedReplica(c *C) {
+	s.testKubeNodeHeartbeatLost(c, &kubeNodeHeartbeatLostTestCase{
+		imProbed:         true,
+		replicaConnected: true,
+		expectedReason:   longhorn.NodeConditionReasonNetworkPartitioned,
+	})
+}
+
+func (s *NodeControllerSuite) TestKubeNodeHeartbeatLostWithStaleEngineStatus(c *C) {
+	s.testKubeNodeHeartbeatLost(c, &kubeNodeHeartbeatLostTestCase{
+		imProbed:          true,
+		replicaConnected:  true,
+		engineStatusStale: true,
+		expectedReason:    longhorn.NodeConditionReasonKubernetesNodeNotReady,
+	})
+}
+
+func (s *NodeControllerSuite) TestKubeNodeHeartbeatLostWithoutAliveSignal(c *C) {
+	s.testKubeNodeHeartbeatLost(c, &kubeNodeHeartbeatLostTestCase{
+		imProbed:       true,
+		expectedReason: longhorn.NodeConditionReasonKubernetesNodeNotReady,
+	})
+}
+
+type kubeNodeHeartbeatLostTestCase struct {
+	// imProbed is whether the reachability of the instance manager on the node has been probed before the sync
+	imProbed    bool
+	imReachable bool
+	// replicaConnected is whether an engine on the other node reports the replica on the node in RW mode
+	replicaConnected bool
+	// engineStatusStale is whether the engine got the replica modes before the kubelet heartbeat was lost
+	engineStatusStale bool
+
+	expectedReason string
+}
+
+// testKubeNodeHeartbeatLost syncs TestNode2, whose kubelet has just stopped posting the node status, from the node
+// controller of TestNode1.
+func (s *NodeControllerSuite) testKubeNodeHeartbeatLost(c *C, tc *kubeNodeHeartbeatLostTestCase) {
+	probedInstanceManagers := make(chan string, 1)
+	s.controller.instanceManagerReachabilityChecker = func(im *longhorn.InstanceManager) bool {
+		probedInstanceManagers <- im.Name
+		return tc.imReachable
+	}
+
+	heartbeatLostAt := time.Now().Add(-10 * time.Second)
+	kubeNode2 := newKubernetesNode(
+		TestNode2,
+		corev1.ConditionUnknown,
+		corev1.ConditionFalse,
+		corev1.ConditionFalse,
+		corev1.ConditionFalse,
+		corev1.ConditionFalse,
+		corev1.ConditionTrue,
+	)
+	kubeNode2.Status.Conditions[0].Reason = "NodeStatusUnknown"
+	kubeNode2.Status.Conditions[0].LastTransitionTime = metav1.NewTime(heartbeatLostAt)
+
+	vol := newVolume(TestVolumeName, 2)
+	eng := newEngineForVolume(vol)
+	eng.Spec.NodeID = TestNode1
+	eng.Status.CurrentState = longhorn.InstanceStateRunning
+	replica1 := newReplicaForVolume(vol, eng, TestNode1, TestDiskID1)
+	replica1.Namespace = TestNamespace
+	replica2 := newReplicaForVolume(vol, eng, TestNode2, TestDiskID2)
+	replica2.Namespace = TestNamespace
+	eng.Status.ReplicaModeMap = map[string]longhorn.ReplicaMode{
+		replica1.Name: longhorn.ReplicaModeRW,
+		replica2.Name: longhorn.ReplicaModeERR,
+	}
+	if tc.replicaConnected {
+		eng.Status.ReplicaModeMap[replica2.Name] = longhorn.ReplicaModeRW
+	}
+	eng.Status.ReplicaModeMapCheckedAt = util.Now()
+	if tc.engineStatusStale {
+		eng.Status.ReplicaModeMapCheckedAt = heartbeatLostAt.Add(-10 * time.Second).UTC().Format(time.RFC3339)
+	}
+
+	im2 := newInstanceManager(
+		"instance-manager-node2",
+		longhorn.InstanceManagerStateRunning,
+		TestOwnerID2, TestNode2, TestIP2,
+		map[string]longhorn.InstanceProcess{},
+		map[string]longhorn.InstanceProcess{},
+		longhorn.DataEngineTypeV1,
+		TestInstanceManagerImage,
+		false,
+	)
+	if tc.imProbed {
+		s.controller.instanceManagerReachability.set(im2, tc.imReachable, instanceManagerReachabilityMaxAge)
+	}
+
+	fixture := &NodeControllerFixture{
+		lhNodes: map[string]*longhorn.Node{
+			TestNode1: newNode(TestNode1, TestNamespace, true, longhorn.ConditionStatusTrue, ""),
+			TestNode2: newNode(TestNode2, TestNamespace, true, longhorn.ConditionStatusTrue, ""),
+		},
+		lhReplicas: []*longhorn.Replica{replica1, replica2},
+		lhEngines:  []*longhorn.Engine{eng},
+		lhSettings: map[string]*longhorn.Setting{
+			string(types.SettingNameDefaultInstanceManagerImage): newDefaultInstanceManagerImageSetting(),
+		},
+		lhInstanceManagers: map[string]*longhorn.InstanceManager{
+			TestInstanceManagerName: DefaultInstanceManagerTestNode1,
+			im2.Name:                im2,
+		},
+		pods: map[string]*corev1.Pod{
+			TestDaemon1: newDaemonPod(corev1.PodRunning, TestDaemon1, TestNamespace, TestNode1, TestIP1, &MountPropagationBidirectional),
+			TestDaemon2: newDaemonPod(corev1.PodRunning, TestDaemon2, TestNamespace, TestNode2, TestIP2, &MountPropagationBidirectional),
+		},
+		nodes: map[string]*corev1.Node{
+			TestNode1: newKubernetesNode(
+				TestNode1,
+				corev1.ConditionTrue,
+				corev1.ConditionFalse,
+				corev1.ConditionFalse,
+				corev1.ConditionFalse,
+				corev1.ConditionFalse,
+				corev1.ConditionTrue,
+			),
+			TestNode2: kubeNode2,
+		},
+	}
+
+	expectation := &NodeControllerExpectation{
+		nodeStatus: map[string]*longhorn.NodeStatus{
+			TestNode2: {
+				Conditions: []longhorn.Condition{
+					newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
+					newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusFalse, tc.expectedReason),
+				},
+			},
+		},
+	}
+
+	s.initTest(c, fixture)
+
+	node := fixture.lhNodes[TestNode2]
+	err := s.controller.syncNode(getKey(node, c))
+	c.Assert(err, IsNil)
+
+	n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
+	c.Assert(err, IsNil)
+	s.checkNodeConditions(c, expectation, n)
+
+	// The sync does not wait for the instance manager to be probed
+	if tc.imProbed {
+		c.Assert(probedInstanceManagers, HasLen, 0)
+	} else {
+		select {
+		case name := <-probedInstanceManagers:
+			c.Assert(name, Equals, im2.Name)
+		case <-time.After(10 * time.Second):
+			c.Fatal("instance manager is not probed in the background")
+		}
+	}
+
+	err = s.lhNodeIndexer.Update(n)
+	c.Assert(err, IsNil)
+	isDown, err := s.controller.ds.IsNodeDownOrDeleted(TestNode2)
+	c.Assert(err, IsNil)
+	c.Assert(isDown, Equals, tc.expectedReason != longhorn.NodeConditionReasonNetworkPartitioned)
+}
+
 func (s *NodeControllerSuite) TestKubeNodePressure(c *C) {
 	var err error
 
@@ -1210,6 +1395,149 @@ func (s *NodeControllerSuite) TestCleanupRedundantInstanceManagers(c *C) {
 	s.checkOrphans(c, expectation)
 }
 
+func (s *NodeControllerSuite) TestSkipCleanupInstanceManagersOnUpgradeFreeze(c *C) {
+	var err error
+
+	node1 := newNode(TestNode1, TestNamespace, true, longhorn.ConditionStatusUnknown, "")
+	node1.Status.DiskStatus = map[string]*longhorn.DiskStatus{
+		TestDiskID1: {
+			StorageScheduled: 0,
+			StorageAvailable: 0,
+			Conditions: []longhorn.Condition{
+				newNodeCondition(longhorn.DiskConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
+			},
+			Type:     longhorn.DiskTypeFilesystem,
+			DiskName: TestDiskID1,
+		},
+	}
+
+	extraInstanceManager := newInstanceManager(
+		"extra-instance-manager-name", longhorn.InstanceManagerStateRunning,
+		TestOwnerID1, TestNode1, TestIP1,
+		map[string]longhorn.InstanceProcess{},
+		map[string]longhorn.InstanceProcess{},
+		longhorn.DataEngineTypeV1,
+		TestExtraInstanceManagerImage,
+		false,
+	)
+	extraInstanceManager.Spec.Image = TestExtraInstanceManagerImage
+
+	fixture := &NodeControllerFixture{
+		lhNodes: map[string]*longhorn.Node{
+			TestNode1: node1,
+		},
+		lhSettings: map[string]*longhorn.Setting{
+			string(types.SettingNameDefaultInstanceManagerImage): newDefaultInstanceManagerImageSetting(),
+			string(types.SettingNameUpgradeFreeze): {
+				ObjectMeta: metav1.ObjectMeta{
+					Name: string(types.SettingNameUpgradeFreeze),
+				},
+				Value: "true",
+			},
+		},
+		lhInstanceManagers: map[string]*longhorn.InstanceManager{
+			TestInstanceManagerName:       DefaultInstanceManagerTestNode1,
+			"extra-instance-manager-name": extraInstanceManager,
+		},
+		lhOrphans: map[string]*longhorn.Orphan{
+			DefaultOrphanTestNode1.Name: DefaultOrphanTestNode1,
+		},
+		pods: map[string]*corev1.Pod{
+			TestDaemon1: newDaemonPod(corev1.PodRunning, TestDaemon1, TestNamespace, TestNode1, TestIP1, &MountPropagationBidirectional),
+			TestDaemon2: newDaemonPod(corev1.PodRunning, TestDaemon2, TestNamespace, TestNode2, TestIP2, &MountPropagationBidirectional),
+		},
+		nodes: map[string]*corev1.Node{
+			TestNode1: newKubernetesNode(
+				TestNode1,
+				corev1.ConditionTrue,
+				corev1.ConditionFalse,
+				corev1.ConditionFalse,
+				corev1.ConditionFalse,
+				corev1.ConditionFalse,
+				corev1.ConditionTrue,
+			),
+			TestNode2: newKubernetesNode(
+				TestNode2,
+				corev1.ConditionTrue,
+				corev1.ConditionFalse,
+				corev1.ConditionFalse,
+				corev1.ConditionFalse,
+				corev1.ConditionFalse,
+				corev1.ConditionTrue,
+			),
+		},
+	}
+
+	expectation := &NodeControllerExpectation{
+		nodeStatus: map[string]*longhorn.NodeStatus{
+			TestNode1: {
+				Conditions: []longhorn.Condition{
+					newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
+					newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusTrue, ""),
+					newNodeCondition(longhorn.NodeConditionTypeMountPropagation, longhorn.ConditionStatusTrue, ""),
+				},
+				DiskStatus: map[string]*longhorn.DiskStatus{
+					TestDiskID1: {
+						StorageScheduled: 0,
+						StorageAvailable: 0,
+						Conditions: []longhorn.Condition{
+							newNodeCondition(longhorn.DiskConditionTypeSchedulable, longhorn.ConditionStatusFalse, string(longhorn.DiskConditionReasonDiskPressure)),
+							newNodeCondition(longhorn.DiskConditionTypeReady, longhorn.ConditionStatusTrue, ""),
+						},
+						DiskName:              TestDiskID1,
+						ScheduledReplica:      map[string]int64{},
+						ScheduledBackingImage: map[string]int64{},
+						DiskUUID:              TestDiskID1,
+						Type:                  longhorn.DiskTypeFilesystem,
+						FSType:                TestDiskPathFSType,
+						DiskPath:              TestDefaultDataPath,
+						InstanceManagerName:   TestInstanceManagerName,
+					},
+				},
+			},
+		},
+		instanceManagers: map[string]*longhorn.InstanceManager{
+			TestInstanceManagerName: newInstanceManager(
+				TestInstanceManagerName, longhorn.InstanceManagerStateRunning,
+				TestOwnerID1, TestNode1, TestIP1,
+				map[string]longhorn.InstanceProcess{},
+				map[string]longhorn.InstanceProcess{},
+				longhorn.DataEngineTypeV1,
+				TestInstanceManagerImage,
+				false,
+			),
+			// The idle instance manager with the old image is kept since the upgrade freeze is enabled
+			"extra-instance-manager-name": extraInstanceManager,
+		},
+		orphans: map[string]*longhorn.Orphan{
+			DefaultOrphanTestNode1.Name: DefaultOrphanTestNode1,
+		},
+	}
+
+	s.initTest(c, fixture)
+
+	for _, node := range fixture.lhNodes {
+		if s.controller.controllerID == node.Name {
+			err = s.controller.diskMonitor.RunOnce()
+			c.Assert(err, IsNil)
+			err = s.controller.environmentCheckMonitor.RunOnce()
+			c.Assert(err, IsNil)
+		}
+
+		err = s.controller.syncNode(getKey(node, c))
+		c.Assert(err, IsNil)
+
+		n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
+		c.Assert(err, IsNil)
+
+		s.checkNodeConditions(c, expectation, n)
+		s.checkDiskConditions(c, expectation, n)
+	}
+
+	s.checkInstanceManagers(c, expectation)
+	s.checkOrphans(c, expectation)
+}
+
 func (s *NodeControllerSuite) TestCleanupAllInstanceManagers(c *C) {
 	var err error
 
@@ -2156,8 +2484,263 @@ CONFIG_NFS_V4_2=y`
 	}
 }
 
+func (s *NodeControllerSuite) TestOpenEncryptedDisks(c *C) {
+	openedDevicePaths := []string{}
+	s.controller.encryptedDiskOpener = func(diskName, devicePath, passphrase string, cryptoParams *crypto.EncryptParams) error {
+		c.Assert(passphrase, Equals, "passphrase")
+		c.Assert(cryptoParams.KeyCipher, Equals, "aes-xts-plain64")
+		openedDevicePaths = append(openedDevicePaths, devicePath)
+		return nil
+	}
+
+	secretIndexer := s.informerFactories.KubeNamespaceFilteredInformerFactory.Core().V1().Secrets().Informer().GetIndexer()
+	err := secretIndexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "disk-encryption",
+			Namespace: TestNamespace,
+		},
+		Data: map[string][]byte{
+			types.CryptoKeyValue:  []byte("passphrase"),
+			types.CryptoKeyCipher: []byte("aes-xts-plain64"),
+		},
+	})
+	c.Assert(err, IsNil)
+
+	node := newNode(TestNode1, TestNamespace, true, longhorn.ConditionStatusTrue, "")
+	node.Spec.Disks = map[string]longhorn.DiskSpec{
+		"encrypted-disk": {
+			Type:             longhorn.DiskTypeBlock,
+			Path:             "/dev/sdb",
+			Encrypted:        true,
+			EncryptionSecret: "disk-encryption",
+		},
+		"filesystem-disk": {
+			Type:             longhorn.DiskTypeFilesystem,
+			Path:             TestDefaultDataPath,
+			Encrypted:        true,
+			EncryptionSecret: "disk-encryption",
+		},
+		"missing-secret-disk": {
+			Type:             longhorn.DiskTypeBlock,
+			Path:             "/dev/sdc",
+			Encrypted:        true,
+			EncryptionSecret: "nonexistent",
+		},
+		"plain-disk": {
+			Type: longhorn.DiskTypeBlock,
+			Path: "/dev/sdd",
+		},
+	}
+
+	expectedEncryptedDevicePaths := map[string]string{
+		"encrypted-disk": "/dev/mapper/longhorn-disk-encrypted-disk",
+	}
+
+	encryptedDevicePaths := s.controller.openEncryptedDisks(node)
+	c.Assert(encryptedDevicePaths, DeepEquals, expectedEncryptedDevicePaths)
+	c.Assert(openedDevicePaths, DeepEquals, []string{"/dev/sdb"})
+	c.Assert(len(s.eventRecorder.Events), Equals, 2)
+
+	// The opened disk is not opened again in the following syncs
+	encryptedDevicePaths = s.controller.openEncryptedDisks(node)
+	c.Assert(encryptedDevicePaths, DeepEquals, expectedEncryptedDevicePaths)
+	c.Assert(openedDevicePaths, DeepEquals, []string{"/dev/sdb"})
+
+	// Until the disk monitor reports that the device is closed
+	delete(s.controller.openedEncryptedDisks, "encrypted-disk")
+	encryptedDevicePaths = s.controller.openEncryptedDisks(node)
+	c.Assert(encryptedDevicePaths, DeepEquals, expectedEncryptedDevicePaths)
+	c.Assert(openedDevicePaths, DeepEquals, []string{"/dev/sdb", "/dev/sdb"})
+}
+
 // -- Helpers --
 
+func (s *NodeControllerSuite) TestResetStaleAttachmentsOnNodeIdentityChange(c *C) {
+	workloadPodName := "test-workload-pod"
+
+	testCases := map[string]struct {
+		recordedUID             string
+		recordedCSIDriverNodeID string
+		engineState             longhorn.InstanceState
+		imState                 longhorn.InstanceManagerState
+		engineInIM              bool
+		podRunning              bool
+		expectedResetTicket     bool
+		expectedRecordedUID     string
+	}{
+		"node identity is recorded for the first time": {
+			recordedUID:         "",
+			expectedResetTicket: false,
+			expectedRecordedUID: "test-kube-node-uid",
+		},
+		"node identity is unchanged": {
+			recordedUID:         "test-kube-node-uid",
+			expectedResetTicket: false,
+			expectedRecordedUID: "test-kube-node-uid",
+		},
+		"node is reinstalled with the same name": {
+			recordedUID:         "test-old-kube-node-uid",
+			expectedResetTicket: true,
+			expectedRecordedUID: "test-kube-node-uid",
+		},
+		"node is reinstalled with a new CSI driver node ID": {
+			recordedUID:             "test-kube-node-uid",
+			recordedCSIDriverNodeID: "test-old-csi-node-id",
+			expectedResetTicket:     true,
+			expectedRecordedUID:     "test-kube-node-uid",
+		},
+		"node is reinstalled and the engine is gone from the instance manager": {
+			recordedUID:         "test-old-kube-node-uid",
+			engineState:         longhorn.InstanceStateRunning,
+			imState:             longhorn.InstanceManagerStateRunning,
+			expectedResetTicket: true,
+			expectedRecordedUID: "test-kube-node-uid",
+		},
+		"node is reinstalled and the instance manager is unreachable": {
+			recordedUID:         "test-old-kube-node-uid",
+			engineState:         longhorn.InstanceStateRunning,
+			imState:             longhorn.InstanceManagerStateUnknown,
+			engineInIM:          true,
+			expectedResetTicket: true,
+			expectedRecordedUID: "test-kube-node-uid",
+		},
+		"node identity changed while the engine is still running": {
+			recordedUID:         "test-old-kube-node-uid",
+			engineState:         longhorn.InstanceStateRunning,
+			imState:             longhorn.InstanceManagerStateRunning,
+			engineInIM:          true,
+			expectedResetTicket: false,
+			expectedRecordedUID: "test-old-kube-node-uid",
+		},
+		"node identity changed while the workload pod is still running": {
+			recordedUID:         "test-old-kube-node-uid",
+			podRunning:          true,
+			expectedResetTicket: false,
+			expectedRecordedUID: "test-old-kube-node-uid",
+		},
+	}
+
+	for name, tc := range testCases {
+		fmt.Printf("testing %v\n", name)
+		s.SetUpTest(c)
+
+		node := newNode(TestNode1, TestNamespace, true, longhorn.ConditionStatusTrue, "")
+		node.Status.KubernetesNodeUID = tc.recordedUID
+		node.Status.CSIDriverNodeID = tc.recordedCSIDriverNodeID
+		kubeNode := newKubernetesNode(TestNode1, corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionFalse,
+			corev1.ConditionFalse, corev1.ConditionFalse, corev1.ConditionTrue)
+		kubeNode.UID = "test-kube-node-uid"
+
+		csiNode := &storagev1.CSINode{
+			ObjectMeta: metav1.ObjectMeta{Name: TestNode1},
+			Spec: storagev1.CSINodeSpec{
+				Drivers: []storagev1.CSINodeDriver{
+					{Name: types.LonghornDriverName, NodeID: TestNode1},
+				},
+			},
+		}
+		csiNode, err := s.kubeClient.StorageV1().CSINodes().Create(context.TODO(), csiNode, metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		err = s.informerFactories.KubeInformerFactory.Storage().V1().CSINodes().Informer().GetIndexer().Add(csiNode)
+		c.Assert(err, IsNil)
+
+		if tc.engineState != "" {
+			instanceEngines := map[string]longhorn.InstanceProcess{}
+			if tc.engineInIM {
+				instanceEngines[TestEngineName] = longhorn.InstanceProcess{
+					Status: longhorn.InstanceProcessStatus{State: longhorn.InstanceStateRunning},
+				}
+			}
+			im := newInstanceManager(TestInstanceManagerName, tc.imState, TestNode1, TestNode1, TestIP1,
+				instanceEngines, nil, longhorn.DataEngineTypeV1, TestInstanceManagerImage, false)
+			im, err = s.lhClient.LonghornV1beta2().InstanceManagers(TestNamespace).Create(context.TODO(), im, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = s.lhInstanceManagerIndexer.Add(im)
+			c.Assert(err, IsNil)
+
+			e := newEngine(TestEngineName, TestEngineImage, TestInstanceManagerName, TestNode1, TestIP1, 0, true, tc.engineState, longhorn.InstanceStateRunning)
+			e.Labels[types.LonghornNodeKey] = TestNode1
+			e, err = s.lhClient.LonghornV1beta2().Engines(TestNamespace).Create(context.TODO(), e, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = s.lhEngineIndexer.Add(e)
+			c.Assert(err, IsNil)
+		}
+
+		v := newVolume(TestVolumeName, 2)
+		v.Status.KubernetesStatus = longhorn.KubernetesStatus{
+			Namespace:       TestNamespace,
+			WorkloadsStatus: []longhorn.WorkloadStatus{{PodName: workloadPodName}},
+		}
+		v, err = s.lhClient.LonghornV1beta2().Volumes(TestNamespace).Create(context.TODO(), v, metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		err = s.informerFactories.LhInformerFactory.Longhorn().V1beta2().Volumes().Informer().GetIndexer().Add(v)
+		c.Assert(err, IsNil)
+
+		podStatus := &corev1.PodStatus{Phase: corev1.PodRunning}
+		if tc.podRunning {
+			podStatus.ContainerStatuses = []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			}
+		} else {
+			podStatus.ContainerStatuses = []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+			}
+		}
+		pod := newPod(podStatus, workloadPodName, TestNamespace, TestNode1)
+		err = s.podIndexer.Add(pod)
+		c.Assert(err, IsNil)
+
+		csiTicketID := "test-csi-ticket"
+		apiTicketID := "test-api-ticket"
+		va := newVolumeAttachment(TestVolumeName)
+		va.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{
+			csiTicketID: {ID: csiTicketID, Type: longhorn.AttacherTypeCSIAttacher, NodeID: TestNode1},
+			apiTicketID: {ID: apiTicketID, Type: longhorn.AttacherTypeLonghornAPI, NodeID: TestNode1},
+		}
+		va, err = s.lhClient.LonghornV1beta2().VolumeAttachments(TestNamespace).Create(context.TODO(), va, metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		err = s.informerFactories.LhInformerFactory.Longhorn().V1beta2().VolumeAttachments().Informer().GetIndexer().Add(va)
+		c.Assert(err, IsNil)
+
+		kubeVAs := map[string]string{
+			"test-kube-va-node-1": TestNode1,
+			"test-kube-va-node-2": TestNode2,
+		}
+		for kubeVAName, nodeName := range kubeVAs {
+			kubeVA := &storagev1.VolumeAttachment{
+				ObjectMeta: metav1.ObjectMeta{Name: kubeVAName},
+				Spec: storagev1.VolumeAttachmentSpec{
+					Attacher: types.LonghornDriverName,
+					NodeName: nodeName,
+				},
+			}
+			kubeVA, err = s.kubeClient.StorageV1().VolumeAttachments().Create(context.TODO(), kubeVA, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = s.informerFactories.KubeInformerFactory.Storage().V1().VolumeAttachments().Informer().GetIndexer().Add(kubeVA)
+			c.Assert(err, IsNil)
+		}
+
+		err = s.controller.syncKubernetesNodeIdentity(node, kubeNode)
+		c.Assert(err, IsNil)
+		c.Assert(node.Status.KubernetesNodeUID, Equals, tc.expectedRecordedUID)
+		if tc.expectedRecordedUID == string(kubeNode.UID) {
+			c.Assert(node.Status.CSIDriverNodeID, Equals, TestNode1)
+		}
+
+		va, err = s.lhClient.LonghornV1beta2().VolumeAttachments(TestNamespace).Get(context.TODO(), va.Name, metav1.GetOptions{})
+		c.Assert(err, IsNil)
+		_, hasCSITicket := va.Spec.AttachmentTickets[csiTicketID]
+		c.Assert(hasCSITicket, Equals, !tc.expectedResetTicket)
+		_, hasAPITicket := va.Spec.AttachmentTickets[apiTicketID]
+		c.Assert(hasAPITicket, Equals, true)
+
+		_, err = s.kubeClient.StorageV1().VolumeAttachments().Get(context.TODO(), "test-kube-va-node-1", metav1.GetOptions{})
+		c.Assert(apierrors.IsNotFound(err), Equals, tc.expectedResetTicket)
+		_, err = s.kubeClient.StorageV1().VolumeAttachments().Get(context.TODO(), "test-kube-va-node-2", metav1.GetOptions{})
+		c.Assert(err, IsNil)
+	}
+}
+
 func (s *NodeControllerSuite) checkNodeConditions(c *C, expectation *NodeControllerExpectation, node *longhorn.Node) {
 	// Check that all node status conditions match the expected node status
 	// conditions - save for the last transition timestamp and the actual
@@ -2311,6 +2894,14 @@ func (s *NodeControllerSuite) initTest(c *C, fixture *NodeControllerFixture) {
 		c.Assert(err, IsNil)
 	}
 
+	for _, engine := range fixture.lhEngines {
+		e, err := s.lhClient.LonghornV1beta2().Engines(TestNamespace).Create(context.TODO(), engine, metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		c.Assert(e, NotNil)
+		err = s.lhEngineIndexer.Add(e)
+		c.Assert(err, IsNil)
+	}
+
 	for _, node := range fixture.nodes {
 		n, err := s.kubeClient.CoreV1().Nodes().Create(context.TODO(), node, metav1.CreateOptions{})
 		c.Assert(err, IsNil)
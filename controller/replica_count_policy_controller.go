@@ -0,0 +1,587 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientset "k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/longhorn/longhorn-manager/constant"
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/scheduler"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	replicaCountPolicyRetryInterval = 10 * time.Second
+)
+
+type ReplicaCountPolicyController struct {
+	*baseController
+
+	// which namespace controller is running with
+	namespace string
+	// use as the OwnerID of the controller
+	controllerID string
+
+	kubeClient    clientset.Interface
+	eventRecorder record.EventRecorder
+
+	ds        *datastore.DataStore
+	scheduler *scheduler.ReplicaScheduler
+
+	cacheSyncs []cache.InformerSynced
+
+	nowHandler func() string // Used for unit test injection
+}
+
+func NewReplicaCountPolicyController(
+	logger logrus.FieldLogger,
+	ds *datastore.DataStore,
+	scheme *runtime.Scheme,
+	kubeClient clientset.Interface,
+	controllerID string,
+	namespace string) (*ReplicaCountPolicyController, error) {
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(logrus.Infof)
+	// TODO: remove the wrapper when every clients have moved to use the clientset.
+	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{
+		Interface: v1core.New(kubeClient.CoreV1().RESTClient()).Events(""),
+	})
+
+	rcpc := &ReplicaCountPolicyController{
+		baseController: newBaseController("longhorn-replica-count-policy", logger),
+
+		namespace:    namespace,
+		controllerID: controllerID,
+
+		ds:        ds,
+		scheduler: scheduler.NewReplicaScheduler(ds),
+
+		kubeClient:    kubeClient,
+		eventRecorder: eventBroadcaster.NewRecorder(scheme, corev1.EventSource{Component: "longhorn-replica-count-policy-controller"}),
+
+		nowHandler: util.Now,
+	}
+
+	var err error
+	if _, err = ds.ReplicaCountPolicyInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    rcpc.enqueueReplicaCountPolicy,
+		UpdateFunc: func(old, cur interface{}) { rcpc.enqueueReplicaCountPolicy(cur) },
+		DeleteFunc: rcpc.enqueueReplicaCountPolicy,
+	}); err != nil {
+		return nil, err
+	}
+	rcpc.cacheSyncs = append(rcpc.cacheSyncs, ds.ReplicaCountPolicyInformer.HasSynced)
+
+	if _, err = ds.VolumeInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { rcpc.enqueueForVolume(nil, obj) },
+		UpdateFunc: rcpc.enqueueForVolume,
+		DeleteFunc: func(obj interface{}) { rcpc.enqueueForVolume(nil, obj) },
+	}, 0); err != nil {
+		return nil, err
+	}
+	rcpc.cacheSyncs = append(rcpc.cacheSyncs, ds.VolumeInformer.HasSynced)
+
+	// The cluster capacity changes with the nodes and their disks
+	if _, err = ds.NodeInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    rcpc.enqueueAllReplicaCountPolicies,
+		UpdateFunc: func(old, cur interface{}) { rcpc.enqueueAllReplicaCountPolicies(cur) },
+		DeleteFunc: rcpc.enqueueAllReplicaCountPolicies,
+	}, 0); err != nil {
+		return nil, err
+	}
+	rcpc.cacheSyncs = append(rcpc.cacheSyncs, ds.NodeInformer.HasSynced)
+
+	return rcpc, nil
+}
+
+func (rcpc *ReplicaCountPolicyController) enqueueReplicaCountPolicy(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to get key for object %#v: %v", obj, err))
+		return
+	}
+
+	rcpc.queue.Add(key)
+}
+
+func (rcpc *ReplicaCountPolicyController) enqueueReplicaCountPolicyAfter(obj interface{}, duration time.Duration) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to get key for object %#v: %v", obj, err))
+		return
+	}
+
+	rcpc.queue.AddAfter(key, duration)
+}
+
+// enqueueForVolume enqueues the policies selecting the volume before or after the change of its labels.
+func (rcpc *ReplicaCountPolicyController) enqueueForVolume(old, cur interface{}) {
+	var volumes []*longhorn.Volume
+	for _, obj := range []interface{}{old, cur} {
+		if deletedState, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			// use the last known state, to enqueue, dependent objects
+			obj = deletedState.Obj
+		}
+		if volume, ok := obj.(*longhorn.Volume); ok {
+			volumes = append(volumes, volume)
+		}
+	}
+
+	policies, err := rcpc.ds.ListReplicaCountPoliciesRO()
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list replica count policies: %v", err))
+		return
+	}
+	for _, policy := range policies {
+		for _, volume := range volumes {
+			if replicaCountPolicySelectsVolume(policy, volume) {
+				rcpc.enqueueReplicaCountPolicy(policy)
+				break
+			}
+		}
+	}
+}
+
+func (rcpc *ReplicaCountPolicyController) enqueueAllReplicaCountPolicies(obj interface{}) {
+	policies, err := rcpc.ds.ListReplicaCountPoliciesRO()
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list replica count policies: %v", err))
+		return
+	}
+	for _, policy := range policies {
+		rcpc.enqueueReplicaCountPolicy(policy)
+	}
+}
+
+func (rcpc *ReplicaCountPolicyController) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer rcpc.queue.ShutDown()
+
+	rcpc.logger.Info("Starting Longhorn ReplicaCountPolicy controller")
+	defer rcpc.logger.Info("Shut down Longhorn ReplicaCountPolicy controller")
+
+	if !cache.WaitForNamedCacheSync(rcpc.name, stopCh, rcpc.cacheSyncs...) {
+		return
+	}
+	for i := 0; i < workers; i++ {
+		go wait.Until(rcpc.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (rcpc *ReplicaCountPolicyController) worker() {
+	for rcpc.processNextWorkItem() {
+	}
+}
+
+func (rcpc *ReplicaCountPolicyController) processNextWorkItem() bool {
+	key, quit := rcpc.queue.Get()
+	if quit {
+		return false
+	}
+	defer rcpc.queue.Done(key)
+	err := rcpc.syncReplicaCountPolicy(key.(string))
+	rcpc.handleErr(err, key)
+	return true
+}
+
+func (rcpc *ReplicaCountPolicyController) handleErr(err error, key interface{}) {
+	if err == nil {
+		rcpc.queue.Forget(key)
+		return
+	}
+
+	log := rcpc.logger.WithField("replicaCountPolicy", key)
+	if rcpc.queue.NumRequeues(key) < maxRetries {
+		handleReconcileErrorLogging(log, err, "Failed to sync Longhorn replica count policy")
+		rcpc.queue.AddRateLimited(key)
+		return
+	}
+
+	utilruntime.HandleError(err)
+	handleReconcileErrorLogging(log, err, "Dropping Longhorn replica count policy out of the queue")
+	rcpc.queue.Forget(key)
+}
+
+func (rcpc *ReplicaCountPolicyController) syncReplicaCountPolicy(key string) (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "failed to sync replica count policy %v", key)
+	}()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	if namespace != rcpc.namespace {
+		return nil
+	}
+	return rcpc.reconcile(name)
+}
+
+func getLoggerForReplicaCountPolicy(logger logrus.FieldLogger, policy *longhorn.ReplicaCountPolicy) *logrus.Entry {
+	return logger.WithField("replicaCountPolicy", policy.Name)
+}
+
+func (rcpc *ReplicaCountPolicyController) isResponsibleFor(policy *longhorn.ReplicaCountPolicy) bool {
+	return isControllerResponsibleFor(rcpc.controllerID, rcpc.ds, policy.Name, "", policy.Status.OwnerID)
+}
+
+func (rcpc *ReplicaCountPolicyController) reconcile(name string) (err error) {
+	policy, err := rcpc.ds.GetReplicaCountPolicy(name)
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	log := getLoggerForReplicaCountPolicy(rcpc.logger, policy)
+
+	if !rcpc.isResponsibleFor(policy) {
+		return nil
+	}
+
+	if policy.Status.OwnerID != rcpc.controllerID {
+		policy.Status.OwnerID = rcpc.controllerID
+		policy, err = rcpc.ds.UpdateReplicaCountPolicyStatus(policy)
+		if err != nil {
+			// we don't mind others coming first
+			if datastore.ErrorIsConflict(errors.Cause(err)) {
+				return nil
+			}
+			return err
+		}
+		log.Infof("Replica count policy got new owner %v", rcpc.controllerID)
+	}
+
+	if !policy.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	existingPolicy := policy.DeepCopy()
+	defer func() {
+		if err != nil {
+			return
+		}
+		if reflect.DeepEqual(existingPolicy.Status, policy.Status) {
+			return
+		}
+		if _, err := rcpc.ds.UpdateReplicaCountPolicyStatus(policy); err != nil && datastore.ErrorIsConflict(errors.Cause(err)) {
+			log.WithError(err).Debugf("Requeue %v due to conflict", name)
+			rcpc.enqueueReplicaCountPolicy(policy)
+		}
+	}()
+
+	return rcpc.reconcileDecisions(policy)
+}
+
+// reconcileDecisions decides the number of replicas of every volume governed by the policy, and applies the decisions
+// which have been stable for the stabilization window unless the policy is a dry run.
+func (rcpc *ReplicaCountPolicyController) reconcileDecisions(policy *longhorn.ReplicaCountPolicy) error {
+	log := getLoggerForReplicaCountPolicy(rcpc.logger, policy)
+
+	policies, err := rcpc.ds.ListReplicaCountPoliciesRO()
+	if err != nil {
+		return err
+	}
+	volumes, err := rcpc.ds.ListVolumesRO()
+	if err != nil {
+		return err
+	}
+	nodes, err := rcpc.ds.ListNodesRO()
+	if err != nil {
+		return err
+	}
+	schedulableNodes, err := rcpc.ds.ListReadyAndSchedulableNodesRO()
+	if err != nil {
+		return err
+	}
+
+	previousDecisions := map[string]*longhorn.ReplicaCountPolicyDecision{}
+	for i := range policy.Status.Decisions {
+		previousDecisions[policy.Status.Decisions[i].VolumeName] = &policy.Status.Decisions[i]
+	}
+
+	window := time.Duration(policy.Spec.StabilizationWindowSeconds) * time.Second
+	requeueAfter := time.Duration(0)
+	decisions := []longhorn.ReplicaCountPolicyDecision{}
+	for _, volume := range volumes {
+		if !volume.DeletionTimestamp.IsZero() || volume.Spec.Standby {
+			continue
+		}
+		if governing := getGoverningReplicaCountPolicy(policies, volume); governing == nil || governing.Name != policy.Name {
+			continue
+		}
+
+		capacity, err := rcpc.getReplicaCapacity(volume, nodes, schedulableNodes)
+		if err != nil {
+			return err
+		}
+
+		decision := decideNumberOfReplicas(policy, volume, capacity, previousDecisions[volume.Name], rcpc.nowHandler())
+		if decision.DesiredNumberOfReplicas != decision.CurrentNumberOfReplicas && !policy.Spec.DryRun {
+			remaining, err := getReplicaCountPolicyDecisionWait(decision, window, rcpc.nowHandler())
+			if err != nil {
+				return err
+			}
+			if remaining <= 0 {
+				if err := rcpc.applyDecision(policy, volume, decision); err != nil {
+					log.WithError(err).Warnf("Failed to change the number of replicas of volume %v", volume.Name)
+					remaining = replicaCountPolicyRetryInterval
+				}
+			}
+			if remaining > 0 && (requeueAfter == 0 || remaining < requeueAfter) {
+				requeueAfter = remaining
+			}
+		}
+		decisions = append(decisions, *decision)
+	}
+	sort.Slice(decisions, func(i, j int) bool {
+		return decisions[i].VolumeName < decisions[j].VolumeName
+	})
+	policy.Status.Decisions = decisions
+
+	if requeueAfter > 0 {
+		rcpc.enqueueReplicaCountPolicyAfter(policy, requeueAfter)
+	}
+	return nil
+}
+
+// replicaCapacity is the capacity of the cluster for the replicas of a volume.
+type replicaCapacity struct {
+	// replicas is the number of replicas the cluster can host for the volume, including the existing ones.
+	replicas int
+	// healthyReplicas is the number of healthy replicas of the volume on the nodes which are up.
+	healthyReplicas int
+	// downNodes is the number of nodes which are down but still hold replicas of the volume.
+	downNodes int
+}
+
+// getReplicaCapacity returns the number of replicas the cluster can host for the volume. The existing replicas count,
+// including the ones on the nodes which are down since they come back with the nodes. Every other disk with the space
+// for a replica of the volume adds one, but only one disk per node counts unless the replica node soft anti-affinity
+// allows several replicas on a node.
+func (rcpc *ReplicaCountPolicyController) getReplicaCapacity(volume *longhorn.Volume, nodes []*longhorn.Node, schedulableNodes map[string]*longhorn.Node) (*replicaCapacity, error) {
+	replicas, err := rcpc.ds.ListVolumeReplicasRO(volume.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeSoftAntiAffinity, err := rcpc.ds.GetSettingAsBool(types.SettingNameReplicaSoftAntiAffinity)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %v setting", types.SettingNameReplicaSoftAntiAffinity)
+	}
+	if volume.Spec.ReplicaSoftAntiAffinity != longhorn.ReplicaSoftAntiAffinityDefault &&
+		volume.Spec.ReplicaSoftAntiAffinity != "" {
+		nodeSoftAntiAffinity = volume.Spec.ReplicaSoftAntiAffinity == longhorn.ReplicaSoftAntiAffinityEnabled
+	}
+
+	existingNodes := map[string]*longhorn.Node{}
+	for _, node := range nodes {
+		existingNodes[node.Name] = node
+	}
+
+	capacity := &replicaCapacity{}
+	downNodes := map[string]struct{}{}
+	usedNodes := map[string]struct{}{}
+	// The disks are keyed by the node name and the disk UUID
+	usedDisks := map[string]struct{}{}
+	for _, r := range replicas {
+		if r.Spec.NodeID == "" || !r.DeletionTimestamp.IsZero() {
+			continue
+		}
+		node, exists := existingNodes[r.Spec.NodeID]
+		if !exists {
+			continue
+		}
+		if isReplicaCountPolicyNodeDown(node) {
+			// The replicas are marked as failed once the node is down, but they are reused once the node is back
+			capacity.replicas++
+			downNodes[node.Name] = struct{}{}
+			continue
+		}
+		if r.Spec.FailedAt != "" {
+			continue
+		}
+		capacity.replicas++
+		if r.Spec.HealthyAt != "" {
+			capacity.healthyReplicas++
+		}
+		usedNodes[node.Name] = struct{}{}
+		usedDisks[node.Name+"/"+r.Spec.DiskID] = struct{}{}
+	}
+	capacity.downNodes = len(downNodes)
+
+	for _, node := range schedulableNodes {
+		if _, used := usedNodes[node.Name]; used && !nodeSoftAntiAffinity {
+			continue
+		}
+		disks, err := rcpc.countDisksWithSpaceForReplica(node, volume, usedDisks)
+		if err != nil {
+			return nil, err
+		}
+		if !nodeSoftAntiAffinity {
+			disks = min(disks, 1)
+		}
+		capacity.replicas += disks
+	}
+	return capacity, nil
+}
+
+// countDisksWithSpaceForReplica returns the number of schedulable disks on the node which have the space for another
+// replica of the volume and do not hold one yet.
+func (rcpc *ReplicaCountPolicyController) countDisksWithSpaceForReplica(node *longhorn.Node, volume *longhorn.Volume, usedDisks map[string]struct{}) (int, error) {
+	count := 0
+	for diskName, diskStatus := range node.Status.DiskStatus {
+		diskSpec, exists := node.Spec.Disks[diskName]
+		if !exists || !diskSpec.AllowScheduling || diskSpec.EvictionRequested {
+			continue
+		}
+		if _, used := usedDisks[node.Name+"/"+diskStatus.DiskUUID]; used {
+			continue
+		}
+		if types.GetCondition(diskStatus.Conditions, longhorn.DiskConditionTypeSchedulable).Status != longhorn.ConditionStatusTrue {
+			continue
+		}
+		diskInfo, err := rcpc.scheduler.GetDiskSchedulingInfo(diskSpec, diskStatus)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to get scheduling info of disk %v on node %v", diskName, node.Name)
+		}
+		if isSchedulable, _ := rcpc.scheduler.IsSchedulableToDisk(volume.Spec.Size, volume.Status.ActualSize, diskInfo); isSchedulable {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func isReplicaCountPolicyNodeDown(node *longhorn.Node) bool {
+	cond := types.GetCondition(node.Status.Conditions, longhorn.NodeConditionTypeReady)
+	return cond.Status == longhorn.ConditionStatusFalse &&
+		(cond.Reason == string(longhorn.NodeConditionReasonKubernetesNodeGone) ||
+			cond.Reason == string(longhorn.NodeConditionReasonKubernetesNodeNotReady) ||
+			cond.Reason == string(longhorn.NodeConditionReasonManagerPodDown) ||
+			cond.Reason == string(longhorn.NodeConditionReasonManagerPodMissing))
+}
+
+func (rcpc *ReplicaCountPolicyController) applyDecision(policy *longhorn.ReplicaCountPolicy, volumeRO *longhorn.Volume, decision *longhorn.ReplicaCountPolicyDecision) error {
+	volume := volumeRO.DeepCopy()
+	volume.Spec.NumberOfReplicas = decision.DesiredNumberOfReplicas
+	if _, err := rcpc.ds.UpdateVolume(volume); err != nil {
+		return err
+	}
+
+	rcpc.eventRecorder.Eventf(policy, corev1.EventTypeNormal, constant.EventReasonUpdate,
+		"Changed the number of replicas of volume %v from %v to %v: %v",
+		volume.Name, decision.CurrentNumberOfReplicas, decision.DesiredNumberOfReplicas, decision.Reason)
+
+	decision.CurrentNumberOfReplicas = decision.DesiredNumberOfReplicas
+	decision.PendingSince = ""
+	decision.LastAppliedTime = rcpc.nowHandler()
+	return nil
+}
+
+func replicaCountPolicySelectsVolume(policy *longhorn.ReplicaCountPolicy, volume *longhorn.Volume) bool {
+	if len(policy.Spec.Selector) == 0 {
+		return false
+	}
+	return labels.SelectorFromSet(policy.Spec.Selector).Matches(labels.Set(volume.Labels))
+}
+
+// getGoverningReplicaCountPolicy returns the policy with the highest priority among the policies selecting the volume.
+// The name breaks the ties.
+func getGoverningReplicaCountPolicy(policies []*longhorn.ReplicaCountPolicy, volume *longhorn.Volume) *longhorn.ReplicaCountPolicy {
+	var governing *longhorn.ReplicaCountPolicy
+	for _, policy := range policies {
+		if !policy.DeletionTimestamp.IsZero() || !replicaCountPolicySelectsVolume(policy, volume) {
+			continue
+		}
+		if governing == nil ||
+			policy.Spec.Priority > governing.Spec.Priority ||
+			(policy.Spec.Priority == governing.Spec.Priority && policy.Name < governing.Name) {
+			governing = policy
+		}
+	}
+	return governing
+}
+
+// decideNumberOfReplicas returns the decision for the volume. The desired number of replicas is the number of the
+// policy, reduced to the replica capacity but not below the minimum number of the policy. While nodes holding replicas
+// of the volume are down, the number is never reduced below the healthy replicas left.
+func decideNumberOfReplicas(policy *longhorn.ReplicaCountPolicy, volume *longhorn.Volume, capacity *replicaCapacity, previous *longhorn.ReplicaCountPolicyDecision, now string) *longhorn.ReplicaCountPolicyDecision {
+	decision := &longhorn.ReplicaCountPolicyDecision{
+		VolumeName:              volume.Name,
+		CurrentNumberOfReplicas: volume.Spec.NumberOfReplicas,
+		DesiredNumberOfReplicas: policy.Spec.NumberOfReplicas,
+		Reason:                  fmt.Sprintf("volume labels match policy %v", policy.Name),
+	}
+	if capacity.replicas < decision.DesiredNumberOfReplicas {
+		decision.DesiredNumberOfReplicas = max(capacity.replicas, policy.Spec.MinNumberOfReplicas)
+		decision.Reason = fmt.Sprintf("the disks only have the space for %v replicas of the volume", capacity.replicas)
+	}
+	if capacity.downNodes > 0 {
+		if healthy := min(capacity.healthyReplicas, decision.CurrentNumberOfReplicas); decision.DesiredNumberOfReplicas < healthy {
+			decision.DesiredNumberOfReplicas = healthy
+			decision.Reason = fmt.Sprintf("%v nodes holding replicas of the volume are down, keeping the %v healthy replicas", capacity.downNodes, healthy)
+		}
+	}
+
+	if previous != nil {
+		decision.LastAppliedTime = previous.LastAppliedTime
+	}
+	if decision.DesiredNumberOfReplicas != decision.CurrentNumberOfReplicas {
+		// The stabilization window restarts whenever the desired number changes
+		if previous != nil && previous.PendingSince != "" && previous.DesiredNumberOfReplicas == decision.DesiredNumberOfReplicas {
+			decision.PendingSince = previous.PendingSince
+		} else {
+			decision.PendingSince = now
+		}
+	}
+	return decision
+}
+
+// getReplicaCountPolicyDecisionWait returns how long the decision has to wait before it can be applied. A decision
+// must be pending for the stabilization window, and the volume must not be changed within the window since the
+// last change.
+func getReplicaCountPolicyDecisionWait(decision *longhorn.ReplicaCountPolicyDecision, window time.Duration, now string) (time.Duration, error) {
+	nowTime, err := util.ParseTime(now)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse current time %v", now)
+	}
+	pendingSince, err := util.ParseTime(decision.PendingSince)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse pending time %v of volume %v", decision.PendingSince, decision.VolumeName)
+	}
+	wait := pendingSince.Add(window).Sub(nowTime)
+
+	if decision.LastAppliedTime != "" {
+		lastAppliedTime, err := util.ParseTime(decision.LastAppliedTime)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to parse last applied time %v of volume %v", decision.LastAppliedTime, decision.VolumeName)
+		}
+		if cooldown := lastAppliedTime.Add(window).Sub(nowTime); cooldown > wait {
+			wait = cooldown
+		}
+	}
+	return wait, nil
+}
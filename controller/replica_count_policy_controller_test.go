@@ -0,0 +1,384 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+
+	. "gopkg.in/check.v1"
+)
+
+const (
+	TestReplicaCountPolicyName      = "test-replica-count-policy"
+	TestReplicaCountPolicyOtherName = "test-replica-count-policy-other"
+	TestReplicaCountPolicyReason    = "volume labels match policy " + TestReplicaCountPolicyName
+
+	TestReplicaCountPolicyLabelKey = "criticality"
+
+	TestReplicaCountPolicyNode3 = "test-node-name-3"
+	TestReplicaCountPolicyDisk2 = "fsid-2"
+	TestReplicaCountPolicyDisk3 = "fsid-3"
+
+	TestReplicaCountPolicyLongAgo  = "2015-01-01T23:00:00Z"
+	TestReplicaCountPolicyRecently = "2015-01-01T23:59:00Z"
+)
+
+type ReplicaCountPolicyControllerTestCase struct {
+	nodes       []*longhorn.Node
+	replicas    []*longhorn.Replica
+	otherPolicy *longhorn.ReplicaCountPolicy
+
+	currentPolicy *longhorn.ReplicaCountPolicy
+	currentVolume *longhorn.Volume
+
+	expectedPolicy *longhorn.ReplicaCountPolicy
+	expectedVolume *longhorn.Volume
+}
+
+func newTestReplicaCountPolicyController(lhClient *lhfake.Clientset, kubeClient *fake.Clientset, extensionsClient *apiextensionsfake.Clientset,
+	informerFactories *util.InformerFactories, controllerID string) (*ReplicaCountPolicyController, error) {
+	// Skip the Lister check that occurs on update of a volume.
+	datastore.SkipListerCheck = true
+
+	ds := datastore.NewDataStore(TestNamespace, lhClient, kubeClient, extensionsClient, informerFactories)
+
+	logger := logrus.StandardLogger()
+	rcpc, err := NewReplicaCountPolicyController(logger, ds, scheme.Scheme, kubeClient, controllerID, TestNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	fakeRecorder := record.NewFakeRecorder(100)
+	rcpc.eventRecorder = fakeRecorder
+	for index := range rcpc.cacheSyncs {
+		rcpc.cacheSyncs[index] = alwaysReady
+	}
+	rcpc.nowHandler = getTestNow
+
+	return rcpc, nil
+}
+
+func newReplicaCountPolicy(name string, numberOfReplicas, minNumberOfReplicas, priority int) *longhorn.ReplicaCountPolicy {
+	return &longhorn.ReplicaCountPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: TestNamespace,
+		},
+		Spec: longhorn.ReplicaCountPolicySpec{
+			Selector:            map[string]string{TestReplicaCountPolicyLabelKey: "high"},
+			NumberOfReplicas:    numberOfReplicas,
+			MinNumberOfReplicas: minNumberOfReplicas,
+			Priority:            priority,
+		},
+		Status: longhorn.ReplicaCountPolicyStatus{
+			OwnerID: TestNode1,
+		},
+	}
+}
+
+func newReplicaCountPolicyDecision(current, desired int, reason string) longhorn.ReplicaCountPolicyDecision {
+	return longhorn.ReplicaCountPolicyDecision{
+		VolumeName:              TestVolumeName,
+		CurrentNumberOfReplicas: current,
+		DesiredNumberOfReplicas: desired,
+		Reason:                  reason,
+	}
+}
+
+func getReplicaCountPolicyControllerTestTemplate() *ReplicaCountPolicyControllerTestCase {
+	volume := newVolume(TestVolumeName, 1)
+	volume.Labels = map[string]string{TestReplicaCountPolicyLabelKey: "high"}
+
+	tc := &ReplicaCountPolicyControllerTestCase{
+		nodes: []*longhorn.Node{
+			newNode(TestNode1, TestNamespace, true, longhorn.ConditionStatusTrue, ""),
+			newNode(TestNode2, TestNamespace, true, longhorn.ConditionStatusTrue, ""),
+			newNode(TestReplicaCountPolicyNode3, TestNamespace, true, longhorn.ConditionStatusTrue, ""),
+		},
+		currentPolicy: newReplicaCountPolicy(TestReplicaCountPolicyName, 3, 1, 0),
+		currentVolume: volume,
+	}
+	tc.addHealthyReplica(TestNode1, TestDiskID1)
+	return tc
+}
+
+func (tc *ReplicaCountPolicyControllerTestCase) copyCurrentToExpected() {
+	tc.expectedPolicy = tc.currentPolicy.DeepCopy()
+	tc.expectedVolume = tc.currentVolume.DeepCopy()
+}
+
+func (tc *ReplicaCountPolicyControllerTestCase) addHealthyReplica(nodeID, diskID string) *longhorn.Replica {
+	engine := &longhorn.Engine{ObjectMeta: metav1.ObjectMeta{Name: TestEngineName}}
+	r := newReplicaForVolume(tc.currentVolume, engine, nodeID, diskID)
+	r.Spec.HealthyAt = TestTimeNow
+	tc.replicas = append(tc.replicas, r)
+	return r
+}
+
+// setReplicaOnEveryNode puts a healthy replica of the volume on every node, the one on the down node is failed
+func (tc *ReplicaCountPolicyControllerTestCase) setReplicaOnEveryNode(downNode string) {
+	tc.currentVolume.Spec.NumberOfReplicas = len(tc.nodes)
+	tc.replicas = nil
+	for i, node := range tc.nodes {
+		r := tc.addHealthyReplica(node.Name, TestDiskID1)
+		if node.Name == downNode {
+			r.Spec.FailedAt = TestTimeNow
+			tc.nodes[i] = newNode(node.Name, TestNamespace, true, longhorn.ConditionStatusFalse, string(longhorn.NodeConditionReasonKubernetesNodeNotReady))
+		}
+	}
+}
+
+// addDisk adds a schedulable disk with the same space as the default disk to the node
+func addDisk(node *longhorn.Node, diskID string) {
+	template := newNode(node.Name, TestNamespace, true, longhorn.ConditionStatusTrue, "")
+	node.Spec.Disks[diskID] = template.Spec.Disks[TestDiskID1]
+	diskStatus := template.Status.DiskStatus[TestDiskID1]
+	diskStatus.DiskUUID = diskID
+	node.Status.DiskStatus[diskID] = diskStatus
+}
+
+func generateReplicaCountPolicyControllerTestCases() map[string]*ReplicaCountPolicyControllerTestCase {
+	var tc *ReplicaCountPolicyControllerTestCase
+	testCases := map[string]*ReplicaCountPolicyControllerTestCase{}
+
+	// capacity
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.copyCurrentToExpected()
+	tc.expectedVolume.Spec.NumberOfReplicas = 3
+	decision := newReplicaCountPolicyDecision(3, 3, TestReplicaCountPolicyReason)
+	decision.LastAppliedTime = TestTimeNow
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	testCases["policy scales up the volume"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.nodes[2].Status.DiskStatus[TestDiskID1].StorageAvailable = TestVolumeSize
+	tc.copyCurrentToExpected()
+	tc.expectedVolume.Spec.NumberOfReplicas = 2
+	decision = newReplicaCountPolicyDecision(2, 2, "the disks only have the space for 2 replicas of the volume")
+	decision.LastAppliedTime = TestTimeNow
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	testCases["policy limits the replicas to the disk space"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.nodes[1].Spec.Disks[TestDiskID1] = longhorn.DiskSpec{AllowScheduling: false}
+	tc.copyCurrentToExpected()
+	tc.expectedVolume.Spec.NumberOfReplicas = 2
+	decision = newReplicaCountPolicyDecision(2, 2, "the disks only have the space for 2 replicas of the volume")
+	decision.LastAppliedTime = TestTimeNow
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	testCases["policy ignores the disk disallowing scheduling"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.nodes = tc.nodes[:1]
+	addDisk(tc.nodes[0], TestReplicaCountPolicyDisk2)
+	addDisk(tc.nodes[0], TestReplicaCountPolicyDisk3)
+	tc.currentVolume.Spec.ReplicaSoftAntiAffinity = longhorn.ReplicaSoftAntiAffinityEnabled
+	tc.copyCurrentToExpected()
+	tc.expectedVolume.Spec.NumberOfReplicas = 3
+	decision = newReplicaCountPolicyDecision(3, 3, TestReplicaCountPolicyReason)
+	decision.LastAppliedTime = TestTimeNow
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	testCases["policy counts every disk of the node with replica soft anti-affinity"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.nodes = tc.nodes[:1]
+	addDisk(tc.nodes[0], TestReplicaCountPolicyDisk2)
+	addDisk(tc.nodes[0], TestReplicaCountPolicyDisk3)
+	tc.currentVolume.Spec.ReplicaSoftAntiAffinity = longhorn.ReplicaSoftAntiAffinityDisabled
+	tc.copyCurrentToExpected()
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{
+		newReplicaCountPolicyDecision(1, 1, "the disks only have the space for 1 replicas of the volume"),
+	}
+	testCases["policy counts one replica per node without replica soft anti-affinity"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.nodes = nil
+	tc.replicas = nil
+	tc.currentPolicy.Spec.MinNumberOfReplicas = 2
+	tc.copyCurrentToExpected()
+	tc.expectedVolume.Spec.NumberOfReplicas = 2
+	decision = newReplicaCountPolicyDecision(2, 2, "the disks only have the space for 0 replicas of the volume")
+	decision.LastAppliedTime = TestTimeNow
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	testCases["policy keeps the minimum replicas without capacity"] = tc
+
+	// outage
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.setReplicaOnEveryNode(TestReplicaCountPolicyNode3)
+	tc.copyCurrentToExpected()
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{
+		newReplicaCountPolicyDecision(3, 3, TestReplicaCountPolicyReason),
+	}
+	testCases["policy counts the down node holding a replica"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.setReplicaOnEveryNode(TestReplicaCountPolicyNode3)
+	tc.currentPolicy.Spec.NumberOfReplicas = 1
+	tc.copyCurrentToExpected()
+	decision = newReplicaCountPolicyDecision(3, 2, "1 nodes holding replicas of the volume are down, keeping the 2 healthy replicas")
+	decision.LastAppliedTime = TestTimeNow
+	tc.expectedVolume.Spec.NumberOfReplicas = 2
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	tc.expectedPolicy.Status.Decisions[0].CurrentNumberOfReplicas = 2
+	testCases["policy does not reduce the replicas below the healthy replicas during an outage"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.setReplicaOnEveryNode("")
+	tc.currentPolicy.Spec.NumberOfReplicas = 1
+	tc.copyCurrentToExpected()
+	tc.expectedVolume.Spec.NumberOfReplicas = 1
+	decision = newReplicaCountPolicyDecision(1, 1, TestReplicaCountPolicyReason)
+	decision.LastAppliedTime = TestTimeNow
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	testCases["policy reduces the replicas without an outage"] = tc
+
+	// stabilization
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.currentPolicy.Spec.DryRun = true
+	tc.copyCurrentToExpected()
+	decision = newReplicaCountPolicyDecision(1, 3, TestReplicaCountPolicyReason)
+	decision.PendingSince = TestTimeNow
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	testCases["dry run policy only reports the decision"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.currentPolicy.Spec.StabilizationWindowSeconds = 300
+	tc.copyCurrentToExpected()
+	decision = newReplicaCountPolicyDecision(1, 3, TestReplicaCountPolicyReason)
+	decision.PendingSince = TestTimeNow
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	testCases["policy waits for the stabilization window"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.currentPolicy.Spec.StabilizationWindowSeconds = 300
+	decision = newReplicaCountPolicyDecision(1, 3, TestReplicaCountPolicyReason)
+	decision.PendingSince = TestReplicaCountPolicyLongAgo
+	tc.currentPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	tc.copyCurrentToExpected()
+	tc.expectedVolume.Spec.NumberOfReplicas = 3
+	decision = newReplicaCountPolicyDecision(3, 3, TestReplicaCountPolicyReason)
+	decision.LastAppliedTime = TestTimeNow
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	testCases["policy applies the decision stable for the stabilization window"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.currentPolicy.Spec.StabilizationWindowSeconds = 300
+	decision = newReplicaCountPolicyDecision(1, 2, TestReplicaCountPolicyReason)
+	decision.PendingSince = TestReplicaCountPolicyLongAgo
+	tc.currentPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	tc.copyCurrentToExpected()
+	decision = newReplicaCountPolicyDecision(1, 3, TestReplicaCountPolicyReason)
+	decision.PendingSince = TestTimeNow
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	testCases["policy restarts the stabilization window once the decision changes"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.currentPolicy.Spec.StabilizationWindowSeconds = 300
+	decision = newReplicaCountPolicyDecision(1, 3, TestReplicaCountPolicyReason)
+	decision.PendingSince = TestReplicaCountPolicyLongAgo
+	decision.LastAppliedTime = TestReplicaCountPolicyRecently
+	tc.currentPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	tc.copyCurrentToExpected()
+	testCases["policy does not change the volume again within the stabilization window"] = tc
+
+	// selection
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.currentVolume.Labels[TestReplicaCountPolicyLabelKey] = "low"
+	tc.copyCurrentToExpected()
+	testCases["policy ignores the unselected volume"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.otherPolicy = newReplicaCountPolicy(TestReplicaCountPolicyOtherName, 1, 1, 10)
+	tc.copyCurrentToExpected()
+	testCases["policy yields to the policy with the higher priority"] = tc
+
+	tc = getReplicaCountPolicyControllerTestTemplate()
+	tc.otherPolicy = newReplicaCountPolicy(TestReplicaCountPolicyOtherName, 1, 1, -10)
+	tc.copyCurrentToExpected()
+	tc.expectedVolume.Spec.NumberOfReplicas = 3
+	decision = newReplicaCountPolicyDecision(3, 3, TestReplicaCountPolicyReason)
+	decision.LastAppliedTime = TestTimeNow
+	tc.expectedPolicy.Status.Decisions = []longhorn.ReplicaCountPolicyDecision{decision}
+	testCases["policy governs over the policy with the lower priority"] = tc
+
+	return testCases
+}
+
+func (s *TestSuite) TestReconcileReplicaCountPolicy(c *C) {
+	testCases := generateReplicaCountPolicyControllerTestCases()
+	for name, tc := range testCases {
+		var err error
+		fmt.Printf("testing replica count policy controller: %v\n", name)
+
+		kubeClient := fake.NewSimpleClientset()
+		lhClient := lhfake.NewSimpleClientset()
+		extensionsClient := apiextensionsfake.NewSimpleClientset()
+
+		informerFactories := util.NewInformerFactories(TestNamespace, kubeClient, lhClient, controller.NoResyncPeriodFunc())
+
+		nIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Nodes().Informer().GetIndexer()
+		vIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Volumes().Informer().GetIndexer()
+		rIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Replicas().Informer().GetIndexer()
+		rcpIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().ReplicaCountPolicies().Informer().GetIndexer()
+
+		rcpc, err := newTestReplicaCountPolicyController(lhClient, kubeClient, extensionsClient, informerFactories, TestNode1)
+		c.Assert(err, IsNil)
+
+		for _, node := range tc.nodes {
+			n, err := lhClient.LonghornV1beta2().Nodes(TestNamespace).Create(context.TODO(), node, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = nIndexer.Add(n)
+			c.Assert(err, IsNil)
+		}
+		for _, replica := range tc.replicas {
+			r, err := lhClient.LonghornV1beta2().Replicas(TestNamespace).Create(context.TODO(), replica, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = rIndexer.Add(r)
+			c.Assert(err, IsNil)
+		}
+		v, err := lhClient.LonghornV1beta2().Volumes(TestNamespace).Create(context.TODO(), tc.currentVolume, metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		err = vIndexer.Add(v)
+		c.Assert(err, IsNil)
+		if tc.otherPolicy != nil {
+			p, err := lhClient.LonghornV1beta2().ReplicaCountPolicies(TestNamespace).Create(context.TODO(), tc.otherPolicy, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = rcpIndexer.Add(p)
+			c.Assert(err, IsNil)
+		}
+		p, err := lhClient.LonghornV1beta2().ReplicaCountPolicies(TestNamespace).Create(context.TODO(), tc.currentPolicy, metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		err = rcpIndexer.Add(p)
+		c.Assert(err, IsNil)
+
+		err = rcpc.reconcile(TestReplicaCountPolicyName)
+		c.Assert(err, IsNil)
+
+		v, err = lhClient.LonghornV1beta2().Volumes(TestNamespace).Get(context.TODO(), TestVolumeName, metav1.GetOptions{})
+		c.Assert(err, IsNil)
+		c.Assert(v.Spec, DeepEquals, tc.expectedVolume.Spec, Commentf("test case: %v", name))
+
+		p, err = lhClient.LonghornV1beta2().ReplicaCountPolicies(TestNamespace).Get(context.TODO(), TestReplicaCountPolicyName, metav1.GetOptions{})
+		c.Assert(err, IsNil)
+		if len(tc.expectedPolicy.Status.Decisions) == 0 {
+			c.Assert(p.Status.Decisions, HasLen, 0, Commentf("test case: %v", name))
+		} else {
+			c.Assert(p.Status, DeepEquals, tc.expectedPolicy.Status, Commentf("test case: %v", name))
+		}
+	}
+}
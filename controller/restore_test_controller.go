@@ -0,0 +1,808 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientset "k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/longhorn/longhorn-manager/constant"
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	restoreTestCheckerContainerName = "checker"
+	restoreTestDataVolumeName       = "data"
+	restoreTestDataMountPath        = "/data"
+
+	// restoreTestFailedMountEventReason is the reason of the kubelet events for failing to mount a pod volume
+	restoreTestFailedMountEventReason = "FailedMount"
+
+	restoreTestCleanupInterval = 5 * time.Second
+)
+
+type RestoreTestController struct {
+	*baseController
+
+	// which namespace controller is running with
+	namespace string
+	// use as the OwnerID of the controller
+	controllerID string
+
+	kubeClient    clientset.Interface
+	eventRecorder record.EventRecorder
+
+	ds *datastore.DataStore
+
+	cacheSyncs []cache.InformerSynced
+
+	nowHandler func() string
+}
+
+func NewRestoreTestController(
+	logger logrus.FieldLogger,
+	ds *datastore.DataStore,
+	scheme *runtime.Scheme,
+	kubeClient clientset.Interface,
+	controllerID string,
+	namespace string) (*RestoreTestController, error) {
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(logrus.Infof)
+	// TODO: remove the wrapper when every clients have moved to use the clientset.
+	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{
+		Interface: v1core.New(kubeClient.CoreV1().RESTClient()).Events(""),
+	})
+
+	rtc := &RestoreTestController{
+		baseController: newBaseController("longhorn-restore-test", logger),
+
+		namespace:    namespace,
+		controllerID: controllerID,
+
+		ds: ds,
+
+		kubeClient:    kubeClient,
+		eventRecorder: eventBroadcaster.NewRecorder(scheme, corev1.EventSource{Component: "longhorn-restore-test-controller"}),
+
+		nowHandler: util.Now,
+	}
+
+	var err error
+	if _, err = ds.RestoreTestInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    rtc.enqueueRestoreTest,
+		UpdateFunc: func(old, cur interface{}) { rtc.enqueueRestoreTest(cur) },
+		DeleteFunc: rtc.enqueueRestoreTest,
+	}); err != nil {
+		return nil, err
+	}
+	rtc.cacheSyncs = append(rtc.cacheSyncs, ds.RestoreTestInformer.HasSynced)
+
+	if _, err = ds.VolumeInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    rtc.enqueueForLabeledObject,
+		UpdateFunc: func(old, cur interface{}) { rtc.enqueueForLabeledObject(cur) },
+		DeleteFunc: rtc.enqueueForLabeledObject,
+	}, 0); err != nil {
+		return nil, err
+	}
+	rtc.cacheSyncs = append(rtc.cacheSyncs, ds.VolumeInformer.HasSynced)
+
+	if _, err = ds.PodInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    rtc.enqueueForLabeledObject,
+		UpdateFunc: func(old, cur interface{}) { rtc.enqueueForLabeledObject(cur) },
+		DeleteFunc: rtc.enqueueForLabeledObject,
+	}, 0); err != nil {
+		return nil, err
+	}
+	rtc.cacheSyncs = append(rtc.cacheSyncs, ds.PodInformer.HasSynced)
+
+	return rtc, nil
+}
+
+func (rtc *RestoreTestController) enqueueRestoreTest(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to get key for object %#v: %v", obj, err))
+		return
+	}
+
+	rtc.queue.Add(key)
+}
+
+func (rtc *RestoreTestController) enqueueRestoreTestAfter(obj interface{}, duration time.Duration) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to get key for object %#v: %v", obj, err))
+		return
+	}
+
+	rtc.queue.AddAfter(key, duration)
+}
+
+// enqueueForLabeledObject enqueues the restore test which created the given temporary volume or checker pod.
+func (rtc *RestoreTestController) enqueueForLabeledObject(obj interface{}) {
+	if deletedState, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		// use the last known state, to enqueue, dependent objects
+		obj = deletedState.Obj
+	}
+
+	metaObj, ok := obj.(metav1.Object)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("received unexpected obj: %#v", obj))
+		return
+	}
+
+	restoreTestName, ok := metaObj.GetLabels()[types.GetLonghornLabelKey(types.LonghornLabelRestoreTest)]
+	if !ok || metaObj.GetNamespace() != rtc.namespace {
+		return
+	}
+	rtc.queue.Add(rtc.namespace + "/" + restoreTestName)
+}
+
+func (rtc *RestoreTestController) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer rtc.queue.ShutDown()
+
+	rtc.logger.Info("Starting Longhorn RestoreTest controller")
+	defer rtc.logger.Info("Shut down Longhorn RestoreTest controller")
+
+	if !cache.WaitForNamedCacheSync(rtc.name, stopCh, rtc.cacheSyncs...) {
+		return
+	}
+	for i := 0; i < workers; i++ {
+		go wait.Until(rtc.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (rtc *RestoreTestController) worker() {
+	for rtc.processNextWorkItem() {
+	}
+}
+
+func (rtc *RestoreTestController) processNextWorkItem() bool {
+	key, quit := rtc.queue.Get()
+	if quit {
+		return false
+	}
+	defer rtc.queue.Done(key)
+	err := rtc.syncRestoreTest(key.(string))
+	rtc.handleErr(err, key)
+	return true
+}
+
+func (rtc *RestoreTestController) handleErr(err error, key interface{}) {
+	if err == nil {
+		rtc.queue.Forget(key)
+		return
+	}
+
+	log := rtc.logger.WithField("restoreTest", key)
+	if rtc.queue.NumRequeues(key) < maxRetries {
+		handleReconcileErrorLogging(log, err, "Failed to sync Longhorn restore test")
+		rtc.queue.AddRateLimited(key)
+		return
+	}
+
+	utilruntime.HandleError(err)
+	handleReconcileErrorLogging(log, err, "Dropping Longhorn restore test out of the queue")
+	rtc.queue.Forget(key)
+}
+
+func (rtc *RestoreTestController) syncRestoreTest(key string) (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "failed to sync restore test %v", key)
+	}()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	if namespace != rtc.namespace {
+		return nil
+	}
+	return rtc.reconcile(name)
+}
+
+func getLoggerForRestoreTest(logger logrus.FieldLogger, restoreTest *longhorn.RestoreTest) *logrus.Entry {
+	return logger.WithFields(
+		logrus.Fields{
+			"restoreTest":  restoreTest.Name,
+			"backupTarget": restoreTest.Spec.BackupTargetName,
+		},
+	)
+}
+
+func (rtc *RestoreTestController) now() time.Time {
+	now, err := util.ParseTime(rtc.nowHandler())
+	if err != nil {
+		return time.Now()
+	}
+	return now
+}
+
+func (rtc *RestoreTestController) isResponsibleFor(restoreTest *longhorn.RestoreTest) bool {
+	return isControllerResponsibleFor(rtc.controllerID, rtc.ds, restoreTest.Name, "", restoreTest.Status.OwnerID)
+}
+
+func (rtc *RestoreTestController) reconcile(name string) (err error) {
+	restoreTest, err := rtc.ds.GetRestoreTest(name)
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	log := getLoggerForRestoreTest(rtc.logger, restoreTest)
+
+	if !rtc.isResponsibleFor(restoreTest) {
+		return nil
+	}
+
+	if restoreTest.Status.OwnerID != rtc.controllerID {
+		restoreTest.Status.OwnerID = rtc.controllerID
+		restoreTest, err = rtc.ds.UpdateRestoreTestStatus(restoreTest)
+		if err != nil {
+			// we don't mind others coming first
+			if datastore.ErrorIsConflict(errors.Cause(err)) {
+				return nil
+			}
+			return err
+		}
+		log.Infof("Restore test got new owner %v", rtc.controllerID)
+	}
+
+	// The temporary resources are garbage collected via the owner references
+	if !restoreTest.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	existingRestoreTest := restoreTest.DeepCopy()
+	defer func() {
+		if err != nil {
+			return
+		}
+		if reflect.DeepEqual(existingRestoreTest.Status, restoreTest.Status) {
+			return
+		}
+		if _, err := rtc.ds.UpdateRestoreTestStatus(restoreTest); err != nil && datastore.ErrorIsConflict(errors.Cause(err)) {
+			log.WithError(err).Debugf("Requeue %v due to conflict", name)
+			rtc.enqueueRestoreTest(restoreTest)
+		}
+	}()
+
+	switch restoreTest.Status.State {
+	case longhorn.RestoreTestStateRestoring:
+		return rtc.reconcileRestoring(restoreTest)
+	case longhorn.RestoreTestStateValidating:
+		return rtc.reconcileValidating(restoreTest)
+	case longhorn.RestoreTestStateCleaningUp:
+		return rtc.reconcileCleaningUp(restoreTest)
+	default:
+		return rtc.reconcileSchedule(restoreTest)
+	}
+}
+
+// reconcileSchedule starts a test once the next scheduled time is reached, otherwise requeues the restore test
+// at the next scheduled time.
+func (rtc *RestoreTestController) reconcileSchedule(restoreTest *longhorn.RestoreTest) error {
+	log := getLoggerForRestoreTest(rtc.logger, restoreTest)
+
+	if restoreTest.Status.State == "" {
+		restoreTest.Status.State = longhorn.RestoreTestStateIdle
+	}
+	if restoreTest.Spec.Suspend {
+		return nil
+	}
+
+	schedule, err := cron.ParseStandard(restoreTest.Spec.Cron)
+	if err != nil {
+		return errors.Wrapf(err, "invalid cron %v", restoreTest.Spec.Cron)
+	}
+	lastScheduleTime := restoreTest.CreationTimestamp.Time
+	if restoreTest.Status.LastScheduleTime != "" {
+		if lastScheduleTime, err = util.ParseTime(restoreTest.Status.LastScheduleTime); err != nil {
+			return errors.Wrapf(err, "failed to parse last schedule time %v", restoreTest.Status.LastScheduleTime)
+		}
+	}
+	if remaining := schedule.Next(lastScheduleTime).Sub(rtc.now()); remaining > 0 {
+		rtc.enqueueRestoreTestAfter(restoreTest, remaining)
+		return nil
+	}
+
+	restoreTest.Status.LastScheduleTime = rtc.nowHandler()
+
+	backup, err := rtc.selectBackup(restoreTest)
+	if err != nil {
+		return err
+	}
+	if backup == nil {
+		message := fmt.Sprintf("no completed backup on backup target %v", restoreTest.Spec.BackupTargetName)
+		if restoreTest.Spec.VolumeName != "" {
+			message = fmt.Sprintf("no completed backup of volume %v on backup target %v", restoreTest.Spec.VolumeName, restoreTest.Spec.BackupTargetName)
+		}
+		log.Warnf("Skipped restore test: %v", message)
+		restoreTest.Status.Message = message
+		rtc.enqueueRestoreTest(restoreTest)
+		return nil
+	}
+
+	log.Infof("Starting to test backup %v", backup.Name)
+	rtc.eventRecorder.Eventf(restoreTest, corev1.EventTypeNormal, constant.EventReasonTesting,
+		"Restoring backup %v of volume %v for the test", backup.Name, backup.Status.VolumeName)
+
+	restoreTest.Status.CurrentBackup = backup.Name
+	restoreTest.Status.Message = ""
+	restoreTest.Status.State = longhorn.RestoreTestStateRestoring
+	return rtc.reconcileRestoring(restoreTest)
+}
+
+// selectBackup returns a completed backup on the backup target picked according to the selection policy.
+func (rtc *RestoreTestController) selectBackup(restoreTest *longhorn.RestoreTest) (*longhorn.Backup, error) {
+	backups, err := rtc.ds.ListBackupsRO()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list backups")
+	}
+
+	var candidates []*longhorn.Backup
+	for _, backup := range backups {
+		if backup.Status.State != longhorn.BackupStateCompleted || backup.Status.URL == "" || backup.DeletionTimestamp != nil {
+			continue
+		}
+		if backup.Status.BackupTargetName != restoreTest.Spec.BackupTargetName {
+			continue
+		}
+		if restoreTest.Spec.VolumeName != "" && backup.Status.VolumeName != restoreTest.Spec.VolumeName {
+			continue
+		}
+		candidates = append(candidates, backup)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	if restoreTest.Spec.SelectionPolicy != longhorn.RestoreTestSelectionPolicyLatest {
+		return candidates[rand.Intn(len(candidates))], nil
+	}
+
+	var latest *longhorn.Backup
+	var latestCreationTime time.Time
+	for _, backup := range candidates {
+		creationTime, err := util.ParseTime(backup.Status.SnapshotCreatedAt)
+		if err != nil {
+			continue
+		}
+		if latest == nil || creationTime.After(latestCreationTime) {
+			latest = backup
+			latestCreationTime = creationTime
+		}
+	}
+	return latest, nil
+}
+
+// reconcileRestoring creates the temporary volume from the selected backup and waits for the restore to complete,
+// then exposes the volume to the checker pod.
+func (rtc *RestoreTestController) reconcileRestoring(restoreTest *longhorn.RestoreTest) error {
+	log := getLoggerForRestoreTest(rtc.logger, restoreTest)
+
+	if rtc.checkTimeout(restoreTest) {
+		return rtc.reconcileCleaningUp(restoreTest)
+	}
+
+	name := types.GetRestoreTestResourceName(restoreTest.Name)
+
+	volume, err := rtc.ds.GetVolumeRO(name)
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			return err
+		}
+		backup, err := rtc.ds.GetBackupRO(restoreTest.Status.CurrentBackup)
+		if err != nil {
+			if !datastore.ErrorIsNotFound(err) {
+				return err
+			}
+			rtc.finish(restoreTest, longhorn.RestoreTestResultFailed, fmt.Sprintf("backup %v is not found", restoreTest.Status.CurrentBackup))
+			return rtc.reconcileCleaningUp(restoreTest)
+		}
+		size, err := strconv.ParseInt(backup.Status.VolumeSize, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse volume size %v of backup %v", backup.Status.VolumeSize, backup.Name)
+		}
+		volume = &longhorn.Volume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Labels:          types.GetRestoreTestLabels(restoreTest.Name),
+				OwnerReferences: datastore.GetOwnerReferencesForRestoreTest(restoreTest),
+			},
+			Spec: longhorn.VolumeSpec{
+				Size:             size,
+				FromBackup:       backup.Status.URL,
+				BackupTargetName: backup.Status.BackupTargetName,
+				NumberOfReplicas: 1,
+				Frontend:         longhorn.VolumeFrontendBlockDev,
+				DataEngine:       longhorn.DataEngineTypeV1,
+				AccessMode:       longhorn.AccessModeReadWriteOnce,
+
+				RestoreVolumeRecurringJob: longhorn.RestoreVolumeRecurringJobDisabled,
+			},
+		}
+		if _, err := rtc.ds.CreateVolume(volume); err != nil {
+			return errors.Wrapf(err, "failed to create volume %v for the restore test", name)
+		}
+		log.Infof("Created volume %v from backup %v", name, backup.Name)
+		return nil
+	}
+
+	restoreCondition := types.GetCondition(volume.Status.Conditions, longhorn.VolumeConditionTypeRestore)
+	if restoreCondition.Status == longhorn.ConditionStatusFalse && restoreCondition.Reason == longhorn.VolumeConditionReasonRestoreFailure {
+		rtc.finish(restoreTest, longhorn.RestoreTestResultFailed, fmt.Sprintf("failed to restore backup %v: %v", restoreTest.Status.CurrentBackup, restoreCondition.Message))
+		return rtc.reconcileCleaningUp(restoreTest)
+	}
+	if !volume.Status.RestoreInitiated || volume.Status.RestoreRequired {
+		return nil
+	}
+
+	if err := rtc.createCheckerResources(restoreTest, volume); err != nil {
+		return err
+	}
+	log.Infof("Restored backup %v, validating the data", restoreTest.Status.CurrentBackup)
+
+	restoreTest.Status.State = longhorn.RestoreTestStateValidating
+	return nil
+}
+
+// createCheckerResources creates the PV and PVC of the temporary volume, and the checker pod mounting it. The PV
+// requires the restored volume to contain a filesystem, so that a restored volume without one fails to mount instead
+// of being formatted.
+func (rtc *RestoreTestController) createCheckerResources(restoreTest *longhorn.RestoreTest, volume *longhorn.Volume) error {
+	name := types.GetRestoreTestResourceName(restoreTest.Name)
+
+	storageClassName, err := rtc.ds.GetSettingValueExisted(types.SettingNameDefaultLonghornStaticStorageClass)
+	if err != nil {
+		return errors.Wrap(err, "failed to get default static storage class")
+	}
+
+	if _, err := rtc.ds.GetPersistentVolumeRO(name); err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			return err
+		}
+		fsType, err := rtc.getBackupFSType(restoreTest)
+		if err != nil {
+			return err
+		}
+		pv := datastore.NewPVManifestForVolume(volume, name, storageClassName, fsType)
+		pv.Labels = types.GetRestoreTestLabels(restoreTest.Name)
+		pv.Spec.CSI.VolumeAttributes[types.OptionRequireExistingFilesystem] = "true"
+		if _, err := rtc.ds.CreatePersistentVolume(pv); err != nil && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to create PV %v", name)
+		}
+	}
+
+	if _, err := rtc.ds.GetPersistentVolumeClaimRO(rtc.namespace, name); err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			return err
+		}
+		pvc := datastore.NewPVCManifestForVolume(volume, name, rtc.namespace, name, storageClassName)
+		pvc.Labels = types.GetRestoreTestLabels(restoreTest.Name)
+		pvc.OwnerReferences = datastore.GetOwnerReferencesForRestoreTest(restoreTest)
+		if _, err := rtc.ds.CreatePersistentVolumeClaim(rtc.namespace, pvc); err != nil && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to create PVC %v", name)
+		}
+	}
+
+	pod, err := rtc.ds.GetPod(name)
+	if err != nil {
+		return err
+	}
+	if pod != nil {
+		return nil
+	}
+	pod, err = rtc.newCheckerPod(restoreTest)
+	if err != nil {
+		return err
+	}
+	if _, err := rtc.ds.CreatePod(pod); err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "failed to create checker pod %v", name)
+	}
+	return nil
+}
+
+// getBackupFSType returns the filesystem of the volume the backup was taken from. The backup records the PV of the
+// volume in its KubernetesStatus label, and the filesystem in the restore test spec is used if there is no such PV.
+func (rtc *RestoreTestController) getBackupFSType(restoreTest *longhorn.RestoreTest) (string, error) {
+	backup, err := rtc.ds.GetBackupRO(restoreTest.Status.CurrentBackup)
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			return "", err
+		}
+		return restoreTest.Spec.FSType, nil
+	}
+
+	kubeStatus := &longhorn.KubernetesStatus{}
+	statusJSON, ok := backup.Status.Labels[types.KubernetesStatusLabel]
+	if !ok {
+		return restoreTest.Spec.FSType, nil
+	}
+	if err := json.Unmarshal([]byte(statusJSON), kubeStatus); err != nil {
+		getLoggerForRestoreTest(rtc.logger, restoreTest).WithError(err).Warnf("Ignore KubernetesStatus JSON for backup %v", backup.Name)
+		return restoreTest.Spec.FSType, nil
+	}
+	if kubeStatus.PVName == "" {
+		return restoreTest.Spec.FSType, nil
+	}
+	pv, err := rtc.ds.GetPersistentVolumeRO(kubeStatus.PVName)
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			return "", err
+		}
+		return restoreTest.Spec.FSType, nil
+	}
+	if pv.Spec.CSI == nil || pv.Spec.CSI.FSType == "" {
+		return restoreTest.Spec.FSType, nil
+	}
+	return pv.Spec.CSI.FSType, nil
+}
+
+func (rtc *RestoreTestController) newCheckerPod(restoreTest *longhorn.RestoreTest) (*corev1.Pod, error) {
+	name := types.GetRestoreTestResourceName(restoreTest.Name)
+
+	tolerations, err := rtc.ds.GetSettingTaintToleration()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get taint toleration setting before creating checker pod")
+	}
+	tolerationsByte, err := json.Marshal(tolerations)
+	if err != nil {
+		return nil, err
+	}
+
+	registrySecretSetting, err := rtc.ds.GetSettingWithAutoFillingRO(types.SettingNameRegistrySecret)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get registry secret setting before creating checker pod")
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       rtc.namespace,
+			Labels:          types.GetRestoreTestLabels(restoreTest.Name),
+			Annotations:     map[string]string{types.GetLonghornLabelKey(types.LastAppliedTolerationAnnotationKeySuffix): string(tolerationsByte)},
+			OwnerReferences: datastore.GetOwnerReferencesForRestoreTest(restoreTest),
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Tolerations:   util.GetDistinctTolerations(tolerations),
+			Containers: []corev1.Container{
+				{
+					Name:    restoreTestCheckerContainerName,
+					Image:   restoreTest.Spec.Image,
+					Command: restoreTest.Spec.Command,
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      restoreTestDataVolumeName,
+							MountPath: restoreTestDataMountPath,
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: restoreTestDataVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: name,
+						},
+					},
+				},
+			},
+		},
+	}
+	if registrySecretSetting.Value != "" {
+		pod.Spec.ImagePullSecrets = []corev1.LocalObjectReference{
+			{
+				Name: registrySecretSetting.Value,
+			},
+		}
+	}
+	return pod, nil
+}
+
+// reconcileValidating waits for the checker pod to complete and records the result. A checker pod which cannot mount
+// the volume stays pending, so the test fails once the volume turns out to have no filesystem, the volume becomes
+// faulted or the test times out.
+func (rtc *RestoreTestController) reconcileValidating(restoreTest *longhorn.RestoreTest) error {
+	if rtc.checkTimeout(restoreTest) {
+		return rtc.reconcileCleaningUp(restoreTest)
+	}
+
+	name := types.GetRestoreTestResourceName(restoreTest.Name)
+	pod, err := rtc.ds.GetPod(name)
+	if err != nil {
+		return err
+	}
+	if pod == nil {
+		rtc.finish(restoreTest, longhorn.RestoreTestResultFailed, "checker pod is not found")
+		return rtc.reconcileCleaningUp(restoreTest)
+	}
+
+	if pod.Status.Phase == corev1.PodPending {
+		volume, err := rtc.ds.GetVolumeRO(name)
+		if err != nil && !datastore.ErrorIsNotFound(err) {
+			return err
+		}
+		if volume == nil || volume.Status.Robustness == longhorn.VolumeRobustnessFaulted {
+			rtc.finish(restoreTest, longhorn.RestoreTestResultFailed, fmt.Sprintf("checker pod cannot mount volume %v since it is missing or faulted", name))
+			return rtc.reconcileCleaningUp(restoreTest)
+		}
+		noFilesystem, err := rtc.isCheckerPodMountFailedWithoutFilesystem(pod)
+		if err != nil {
+			return err
+		}
+		if noFilesystem {
+			rtc.finish(restoreTest, longhorn.RestoreTestResultFailed, fmt.Sprintf("checker pod cannot mount volume %v since it has no filesystem", name))
+			return rtc.reconcileCleaningUp(restoreTest)
+		}
+		return nil
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		rtc.finish(restoreTest, longhorn.RestoreTestResultPassed, "")
+	case corev1.PodFailed:
+		rtc.finish(restoreTest, longhorn.RestoreTestResultFailed, getCheckerPodFailureMessage(pod))
+	default:
+		return nil
+	}
+	return rtc.reconcileCleaningUp(restoreTest)
+}
+
+// isCheckerPodMountFailedWithoutFilesystem checks the mount failure events of the pending checker pod for the CSI
+// node server refusing to mount the restored volume since it has no filesystem. The events are not cached, so this is
+// only checked while the checker pod is pending.
+func (rtc *RestoreTestController) isCheckerPodMountFailedWithoutFilesystem(pod *corev1.Pod) (bool, error) {
+	events, err := rtc.ds.GetResourceEventList(types.KubernetesKindPod, pod.Name, pod.Namespace)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list events of checker pod %v", pod.Name)
+	}
+	for _, event := range events.Items {
+		if event.InvolvedObject.UID != pod.UID || event.Reason != restoreTestFailedMountEventReason {
+			continue
+		}
+		if strings.Contains(event.Message, types.NoExistingFilesystemMessage) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func getCheckerPodFailureMessage(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != restoreTestCheckerContainerName || status.State.Terminated == nil {
+			continue
+		}
+		terminated := status.State.Terminated
+		message := fmt.Sprintf("validation command exited with code %v", terminated.ExitCode)
+		if terminated.Reason != "" {
+			message = fmt.Sprintf("%v: %v", message, terminated.Reason)
+		}
+		if terminated.Message != "" {
+			message = fmt.Sprintf("%v: %v", message, terminated.Message)
+		}
+		return message
+	}
+	if pod.Status.Message != "" {
+		return fmt.Sprintf("checker pod failed: %v", pod.Status.Message)
+	}
+	return "checker pod failed"
+}
+
+// checkTimeout fails the test in progress once it takes longer than the timeout, otherwise requeues the restore
+// test at the deadline.
+func (rtc *RestoreTestController) checkTimeout(restoreTest *longhorn.RestoreTest) bool {
+	if restoreTest.Spec.TimeoutSeconds <= 0 {
+		return false
+	}
+	startTime, err := util.ParseTime(restoreTest.Status.LastScheduleTime)
+	if err != nil {
+		return false
+	}
+	timeout := time.Duration(restoreTest.Spec.TimeoutSeconds) * time.Second
+	if remaining := startTime.Add(timeout).Sub(rtc.now()); remaining > 0 {
+		rtc.enqueueRestoreTestAfter(restoreTest, remaining)
+		return false
+	}
+	rtc.finish(restoreTest, longhorn.RestoreTestResultFailed, fmt.Sprintf("test timed out after %v", timeout))
+	return true
+}
+
+// finish records the result of the test in progress and starts cleaning up the temporary resources.
+func (rtc *RestoreTestController) finish(restoreTest *longhorn.RestoreTest, result longhorn.RestoreTestResult, message string) {
+	log := getLoggerForRestoreTest(rtc.logger, restoreTest)
+
+	if result == longhorn.RestoreTestResultPassed {
+		log.Infof("Restore test of backup %v passed", restoreTest.Status.CurrentBackup)
+		rtc.eventRecorder.Eventf(restoreTest, corev1.EventTypeNormal, constant.EventReasonTestPassed,
+			"Restore test of backup %v passed", restoreTest.Status.CurrentBackup)
+		restoreTest.Status.PassedCount++
+	} else {
+		log.Warnf("Restore test of backup %v failed: %v", restoreTest.Status.CurrentBackup, message)
+		rtc.eventRecorder.Eventf(restoreTest, corev1.EventTypeWarning, constant.EventReasonTestFailed,
+			"Restore test of backup %v failed: %v", restoreTest.Status.CurrentBackup, message)
+		restoreTest.Status.FailedCount++
+	}
+
+	restoreTest.Status.LastResult = result
+	restoreTest.Status.LastTestTime = rtc.nowHandler()
+	restoreTest.Status.LastTestedBackup = restoreTest.Status.CurrentBackup
+	restoreTest.Status.CurrentBackup = ""
+	restoreTest.Status.Message = message
+	restoreTest.Status.State = longhorn.RestoreTestStateCleaningUp
+}
+
+// reconcileCleaningUp deletes the checker pod, then the PVC, PV and temporary volume. The restore test becomes
+// idle once all of them are gone.
+func (rtc *RestoreTestController) reconcileCleaningUp(restoreTest *longhorn.RestoreTest) error {
+	name := types.GetRestoreTestResourceName(restoreTest.Name)
+
+	pod, err := rtc.ds.GetPod(name)
+	if err != nil {
+		return err
+	}
+	if pod != nil {
+		if pod.DeletionTimestamp == nil {
+			if err := rtc.ds.DeletePod(name); err != nil && !datastore.ErrorIsNotFound(err) {
+				return err
+			}
+		}
+		rtc.enqueueRestoreTestAfter(restoreTest, restoreTestCleanupInterval)
+		return nil
+	}
+
+	if err := rtc.ds.DeletePersistentVolumeClaim(rtc.namespace, name); err != nil && !datastore.ErrorIsNotFound(err) {
+		return err
+	}
+	if err := rtc.ds.DeletePersistentVolume(name); err != nil && !datastore.ErrorIsNotFound(err) {
+		return err
+	}
+
+	volume, err := rtc.ds.GetVolumeRO(name)
+	if err != nil && !datastore.ErrorIsNotFound(err) {
+		return err
+	}
+	if volume != nil {
+		if volume.DeletionTimestamp == nil {
+			if err := rtc.ds.DeleteVolume(name); err != nil && !datastore.ErrorIsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	restoreTest.Status.State = longhorn.RestoreTestStateIdle
+	rtc.enqueueRestoreTest(restoreTest)
+	return nil
+}
@@ -0,0 +1,534 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+
+	. "gopkg.in/check.v1"
+)
+
+const (
+	TestRestoreTestName      = "test-restore-test"
+	TestRestoreTestBackup    = "test-restore-test-backup"
+	TestRestoreTestBackupURL = "s3://backupbucket@us-east-1/backupstore?backup=" + TestRestoreTestBackup
+	TestRestoreTestImage     = "busybox"
+)
+
+type RestoreTestControllerTestCase struct {
+	backup *longhorn.Backup
+	// sourcePV is the PV of the volume the backup was taken from
+	sourcePV         *corev1.PersistentVolume
+	checkerPodEvents []*corev1.Event
+
+	currentRestoreTest *longhorn.RestoreTest
+	currentVolume      *longhorn.Volume
+	currentPod         *corev1.Pod
+	currentPV          *corev1.PersistentVolume
+	currentPVC         *corev1.PersistentVolumeClaim
+
+	expectedRestoreTest *longhorn.RestoreTest
+	expectedVolume      *longhorn.Volume
+	expectedPod         *corev1.Pod
+	expectedPV          bool
+	expectedPVFSType    string
+	expectedPVC         bool
+}
+
+func newTestRestoreTestController(lhClient *lhfake.Clientset, kubeClient *fake.Clientset, extensionsClient *apiextensionsfake.Clientset,
+	informerFactories *util.InformerFactories, controllerID string) (*RestoreTestController, error) {
+	// Skip the Lister check that occurs on creation of a volume.
+	datastore.SkipListerCheck = true
+
+	ds := datastore.NewDataStore(TestNamespace, lhClient, kubeClient, extensionsClient, informerFactories)
+
+	logger := logrus.StandardLogger()
+	rtc, err := NewRestoreTestController(logger, ds, scheme.Scheme, kubeClient, controllerID, TestNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	fakeRecorder := record.NewFakeRecorder(100)
+	rtc.eventRecorder = fakeRecorder
+	for index := range rtc.cacheSyncs {
+		rtc.cacheSyncs[index] = alwaysReady
+	}
+	rtc.nowHandler = getTestNow
+
+	return rtc, nil
+}
+
+// getTestTimeBeforeNow returns the time the given duration before the test time now
+func getTestTimeBeforeNow(d time.Duration) string {
+	now, _ := util.ParseTime(getTestNow())
+	return now.Add(-d).UTC().Format(time.RFC3339)
+}
+
+func newTestRestoreTestVolume(restored bool) *longhorn.Volume {
+	volume := newVolume(types.GetRestoreTestResourceName(TestRestoreTestName), 1)
+	volume.Labels = types.GetRestoreTestLabels(TestRestoreTestName)
+	volume.Status.RestoreInitiated = true
+	volume.Status.RestoreRequired = !restored
+	return volume
+}
+
+func newTestRestoreTestCheckerPod(phase corev1.PodPhase) *corev1.Pod {
+	pod := newPod(&corev1.PodStatus{Phase: phase}, types.GetRestoreTestResourceName(TestRestoreTestName), TestNamespace, TestNode1)
+	pod.Labels = types.GetRestoreTestLabels(TestRestoreTestName)
+	pod.Spec.Containers = []corev1.Container{
+		{
+			Name:    restoreTestCheckerContainerName,
+			Image:   TestRestoreTestImage,
+			Command: []string{"ls", restoreTestDataMountPath},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      restoreTestDataVolumeName,
+					MountPath: restoreTestDataMountPath,
+				},
+			},
+		},
+	}
+	pod.Spec.Volumes = []corev1.Volume{
+		{
+			Name: restoreTestDataVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: types.GetRestoreTestResourceName(TestRestoreTestName),
+				},
+			},
+		},
+	}
+	return pod
+}
+
+func getRestoreTestControllerTestTemplate() *RestoreTestControllerTestCase {
+	backup := newBackup(TestRestoreTestBackup)
+	backup.Status.State = longhorn.BackupStateCompleted
+	backup.Status.URL = TestRestoreTestBackupURL
+	backup.Status.VolumeName = TestVolumeName
+	backup.Status.VolumeSize = "1073741824"
+	backup.Status.BackupTargetName = types.DefaultBackupTargetName
+	backup.Status.SnapshotCreatedAt = getTestTimeBeforeNow(2 * time.Hour)
+
+	tc := &RestoreTestControllerTestCase{
+		backup: backup,
+		currentRestoreTest: &longhorn.RestoreTest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      TestRestoreTestName,
+				Namespace: TestNamespace,
+			},
+			Spec: longhorn.RestoreTestSpec{
+				BackupTargetName: types.DefaultBackupTargetName,
+				SelectionPolicy:  longhorn.RestoreTestSelectionPolicyLatest,
+				Cron:             "0 * * * *",
+				Image:            TestRestoreTestImage,
+				Command:          []string{"ls", restoreTestDataMountPath},
+				FSType:           "ext4",
+				TimeoutSeconds:   3600,
+			},
+			Status: longhorn.RestoreTestStatus{
+				OwnerID:          TestNode1,
+				State:            longhorn.RestoreTestStateIdle,
+				LastScheduleTime: getTestTimeBeforeNow(2 * time.Hour),
+			},
+		},
+	}
+	tc.currentRestoreTest.CreationTimestamp = metav1.NewTime(time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC))
+	return tc
+}
+
+func (tc *RestoreTestControllerTestCase) copyCurrentToExpected() {
+	if tc.currentRestoreTest != nil {
+		tc.expectedRestoreTest = tc.currentRestoreTest.DeepCopy()
+	}
+	if tc.currentVolume != nil {
+		tc.expectedVolume = tc.currentVolume.DeepCopy()
+	}
+	if tc.currentPod != nil {
+		tc.expectedPod = tc.currentPod.DeepCopy()
+	}
+	tc.expectedPV = tc.currentPV != nil
+	tc.expectedPVC = tc.currentPVC != nil
+}
+
+// setTestInProgress sets the restore test to the given state, testing the backup since the given duration
+func (tc *RestoreTestControllerTestCase) setTestInProgress(state longhorn.RestoreTestState, startedAgo time.Duration) {
+	tc.currentRestoreTest.Status.State = state
+	tc.currentRestoreTest.Status.CurrentBackup = TestRestoreTestBackup
+	tc.currentRestoreTest.Status.LastScheduleTime = getTestTimeBeforeNow(startedAgo)
+}
+
+// setExpectedResult sets the expected result of the test in progress
+func (tc *RestoreTestControllerTestCase) setExpectedResult(result longhorn.RestoreTestResult, message string) {
+	tc.expectedRestoreTest.Status.State = longhorn.RestoreTestStateCleaningUp
+	tc.expectedRestoreTest.Status.LastResult = result
+	tc.expectedRestoreTest.Status.LastTestTime = getTestNow()
+	tc.expectedRestoreTest.Status.LastTestedBackup = TestRestoreTestBackup
+	tc.expectedRestoreTest.Status.CurrentBackup = ""
+	tc.expectedRestoreTest.Status.Message = message
+	if result == longhorn.RestoreTestResultPassed {
+		tc.expectedRestoreTest.Status.PassedCount++
+	} else {
+		tc.expectedRestoreTest.Status.FailedCount++
+	}
+}
+
+func (tc *RestoreTestControllerTestCase) setCheckerResources(podPhase corev1.PodPhase) {
+	name := types.GetRestoreTestResourceName(TestRestoreTestName)
+	tc.currentVolume = newTestRestoreTestVolume(true)
+	tc.currentPV = &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: types.GetRestoreTestLabels(TestRestoreTestName)},
+	}
+	tc.currentPVC = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: TestNamespace, Labels: types.GetRestoreTestLabels(TestRestoreTestName)},
+	}
+	if podPhase != "" {
+		tc.currentPod = newTestRestoreTestCheckerPod(podPhase)
+	}
+}
+
+func generateRestoreTestControllerTestCases() map[string]*RestoreTestControllerTestCase {
+	var tc *RestoreTestControllerTestCase
+	testCases := map[string]*RestoreTestControllerTestCase{}
+
+	// schedule
+	tc = getRestoreTestControllerTestTemplate()
+	tc.currentRestoreTest.Status.LastScheduleTime = getTestNow()
+	tc.copyCurrentToExpected()
+	testCases["restore test is not due"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.currentRestoreTest.Spec.Suspend = true
+	tc.copyCurrentToExpected()
+	testCases["suspended restore test is not started"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.backup = nil
+	tc.copyCurrentToExpected()
+	tc.expectedRestoreTest.Status.LastScheduleTime = getTestNow()
+	tc.expectedRestoreTest.Status.Message = "no completed backup on backup target default"
+	testCases["restore test is skipped without backup"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.copyCurrentToExpected()
+	tc.expectedRestoreTest.Status.State = longhorn.RestoreTestStateRestoring
+	tc.expectedRestoreTest.Status.LastScheduleTime = getTestNow()
+	tc.expectedRestoreTest.Status.CurrentBackup = TestRestoreTestBackup
+	tc.expectedVolume = newVolume(types.GetRestoreTestResourceName(TestRestoreTestName), 1)
+	tc.expectedVolume.Spec = longhorn.VolumeSpec{
+		Size:                      1073741824,
+		FromBackup:                TestRestoreTestBackupURL,
+		BackupTargetName:          types.DefaultBackupTargetName,
+		NumberOfReplicas:          1,
+		Frontend:                  longhorn.VolumeFrontendBlockDev,
+		DataEngine:                longhorn.DataEngineTypeV1,
+		AccessMode:                longhorn.AccessModeReadWriteOnce,
+		RestoreVolumeRecurringJob: longhorn.RestoreVolumeRecurringJobDisabled,
+	}
+	tc.expectedVolume.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: longhorn.SchemeGroupVersion.String(),
+			Kind:       types.LonghornKindRestoreTest,
+			Name:       TestRestoreTestName,
+		},
+	}
+	testCases["restore test restores the selected backup"] = tc
+
+	// restoring
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateRestoring, time.Minute)
+	tc.currentVolume = newTestRestoreTestVolume(false)
+	tc.copyCurrentToExpected()
+	testCases["restore test waits for restore completion"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateRestoring, time.Minute)
+	tc.currentVolume = newTestRestoreTestVolume(true)
+	tc.copyCurrentToExpected()
+	tc.expectedRestoreTest.Status.State = longhorn.RestoreTestStateValidating
+	tc.expectedPod = newTestRestoreTestCheckerPod("")
+	tc.expectedPV = true
+	tc.expectedPVFSType = "ext4"
+	tc.expectedPVC = true
+	testCases["restore test creates checker pod after restore completion"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateRestoring, time.Minute)
+	tc.currentVolume = newTestRestoreTestVolume(true)
+	tc.backup.Status.Labels = map[string]string{
+		types.KubernetesStatusLabel: `{"pvName":"test-source-pv","pvStatus":"Bound"}`,
+	}
+	tc.sourcePV = newPV()
+	tc.sourcePV.Name = "test-source-pv"
+	tc.sourcePV.Spec.CSI.FSType = "xfs"
+	tc.copyCurrentToExpected()
+	tc.expectedRestoreTest.Status.State = longhorn.RestoreTestStateValidating
+	tc.expectedPod = newTestRestoreTestCheckerPod("")
+	tc.expectedPV = true
+	tc.expectedPVFSType = "xfs"
+	tc.expectedPVC = true
+	testCases["restore test mounts the filesystem of the backup source PV"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateRestoring, time.Minute)
+	tc.backup = nil
+	tc.copyCurrentToExpected()
+	tc.setExpectedResult(longhorn.RestoreTestResultFailed, "backup test-restore-test-backup is not found")
+	tc.expectedRestoreTest.Status.State = longhorn.RestoreTestStateIdle
+	testCases["restore test fails once the backup is gone"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateRestoring, time.Minute)
+	tc.currentVolume = newTestRestoreTestVolume(false)
+	tc.currentVolume.Status.Conditions = types.SetCondition(tc.currentVolume.Status.Conditions, longhorn.VolumeConditionTypeRestore,
+		longhorn.ConditionStatusFalse, longhorn.VolumeConditionReasonRestoreFailure, "All replica restore failed and the volume became Faulted")
+	tc.copyCurrentToExpected()
+	tc.setExpectedResult(longhorn.RestoreTestResultFailed,
+		"failed to restore backup test-restore-test-backup: All replica restore failed and the volume became Faulted")
+	tc.expectedVolume = nil
+	testCases["restore test fails on restore failure and deletes the volume"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateRestoring, 2*time.Hour)
+	tc.currentVolume = newTestRestoreTestVolume(false)
+	tc.copyCurrentToExpected()
+	tc.setExpectedResult(longhorn.RestoreTestResultFailed, "test timed out after 1h0m0s")
+	tc.expectedVolume = nil
+	testCases["restore test fails on restore timeout and deletes the volume"] = tc
+
+	// validating
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateValidating, time.Minute)
+	tc.setCheckerResources(corev1.PodRunning)
+	tc.copyCurrentToExpected()
+	testCases["restore test waits for checker pod"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateValidating, time.Minute)
+	tc.setCheckerResources(corev1.PodPending)
+	tc.copyCurrentToExpected()
+	testCases["restore test waits for checker pod mounting the volume"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateValidating, time.Minute)
+	tc.setCheckerResources(corev1.PodPending)
+	tc.currentVolume.Status.Robustness = longhorn.VolumeRobustnessFaulted
+	tc.copyCurrentToExpected()
+	tc.setExpectedResult(longhorn.RestoreTestResultFailed,
+		"checker pod cannot mount volume restore-test-test-restore-test since it is missing or faulted")
+	tc.expectedPod = nil
+	testCases["restore test fails once checker pod cannot mount the faulted volume"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateValidating, time.Minute)
+	tc.setCheckerResources(corev1.PodPending)
+	tc.checkerPodEvents = []*corev1.Event{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-mount-failure", Namespace: TestNamespace},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      types.KubernetesKindPod,
+				Name:      types.GetRestoreTestResourceName(TestRestoreTestName),
+				Namespace: TestNamespace,
+			},
+			Reason: restoreTestFailedMountEventReason,
+			Message: "MountVolume.MountDevice failed for volume \"restore-test-test-restore-test\" : rpc error: code = FailedPrecondition " +
+				"desc = volume restore-test-test-restore-test device /dev/longhorn/restore-test-test-restore-test " + types.NoExistingFilesystemMessage,
+		},
+	}
+	tc.copyCurrentToExpected()
+	tc.setExpectedResult(longhorn.RestoreTestResultFailed,
+		"checker pod cannot mount volume restore-test-test-restore-test since it has no filesystem")
+	tc.expectedPod = nil
+	testCases["restore test fails once checker pod cannot mount the volume without filesystem"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateValidating, 2*time.Hour)
+	tc.setCheckerResources(corev1.PodPending)
+	tc.copyCurrentToExpected()
+	tc.setExpectedResult(longhorn.RestoreTestResultFailed, "test timed out after 1h0m0s")
+	tc.expectedPod = nil
+	testCases["restore test fails on timeout while checker pod cannot mount the volume"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateValidating, time.Minute)
+	tc.setCheckerResources(corev1.PodSucceeded)
+	tc.copyCurrentToExpected()
+	tc.setExpectedResult(longhorn.RestoreTestResultPassed, "")
+	tc.expectedPod = nil
+	testCases["restore test passes once checker pod succeeds"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateValidating, time.Minute)
+	tc.setCheckerResources(corev1.PodFailed)
+	tc.currentPod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name: restoreTestCheckerContainerName,
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"},
+			},
+		},
+	}
+	tc.copyCurrentToExpected()
+	tc.setExpectedResult(longhorn.RestoreTestResultFailed, "validation command exited with code 1: Error")
+	tc.expectedPod = nil
+	testCases["restore test fails once checker pod fails"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.setTestInProgress(longhorn.RestoreTestStateValidating, time.Minute)
+	tc.setCheckerResources("")
+	tc.copyCurrentToExpected()
+	tc.setExpectedResult(longhorn.RestoreTestResultFailed, "checker pod is not found")
+	tc.expectedPV = false
+	tc.expectedPVC = false
+	tc.expectedVolume = nil
+	testCases["restore test fails once checker pod is gone"] = tc
+
+	// cleaning up
+	tc = getRestoreTestControllerTestTemplate()
+	tc.currentRestoreTest.Status.State = longhorn.RestoreTestStateCleaningUp
+	tc.setCheckerResources(corev1.PodSucceeded)
+	tc.copyCurrentToExpected()
+	tc.expectedPod = nil
+	testCases["restore test deletes checker pod first on cleanup"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.currentRestoreTest.Status.State = longhorn.RestoreTestStateCleaningUp
+	tc.setCheckerResources("")
+	tc.copyCurrentToExpected()
+	tc.expectedPV = false
+	tc.expectedPVC = false
+	tc.expectedVolume = nil
+	testCases["restore test deletes PVC, PV and volume once checker pod is gone"] = tc
+
+	tc = getRestoreTestControllerTestTemplate()
+	tc.currentRestoreTest.Status.State = longhorn.RestoreTestStateCleaningUp
+	tc.copyCurrentToExpected()
+	tc.expectedRestoreTest.Status.State = longhorn.RestoreTestStateIdle
+	testCases["restore test becomes idle once cleaned up"] = tc
+
+	return testCases
+}
+
+func (s *TestSuite) TestRestoreTest(c *C) {
+	testCases := generateRestoreTestControllerTestCases()
+	for name, tc := range testCases {
+		var err error
+		fmt.Printf("testing restore test controller: %v\n", name)
+
+		kubeClient := fake.NewSimpleClientset()
+		lhClient := lhfake.NewSimpleClientset()
+		extensionsClient := apiextensionsfake.NewSimpleClientset()
+
+		informerFactories := util.NewInformerFactories(TestNamespace, kubeClient, lhClient, controller.NoResyncPeriodFunc())
+
+		rtIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().RestoreTests().Informer().GetIndexer()
+		backupIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Backups().Informer().GetIndexer()
+		vIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Volumes().Informer().GetIndexer()
+		podIndexer := informerFactories.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+		pvIndexer := informerFactories.KubeInformerFactory.Core().V1().PersistentVolumes().Informer().GetIndexer()
+		pvcIndexer := informerFactories.KubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer()
+
+		rtc, err := newTestRestoreTestController(lhClient, kubeClient, extensionsClient, informerFactories, TestNode1)
+		c.Assert(err, IsNil)
+
+		if tc.backup != nil {
+			backup, err := lhClient.LonghornV1beta2().Backups(TestNamespace).Create(context.TODO(), tc.backup, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = backupIndexer.Add(backup)
+			c.Assert(err, IsNil)
+		}
+		if tc.sourcePV != nil {
+			pv, err := kubeClient.CoreV1().PersistentVolumes().Create(context.TODO(), tc.sourcePV, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = pvIndexer.Add(pv)
+			c.Assert(err, IsNil)
+		}
+		for _, event := range tc.checkerPodEvents {
+			_, err := kubeClient.CoreV1().Events(TestNamespace).Create(context.TODO(), event, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+		}
+		if tc.currentVolume != nil {
+			v, err := lhClient.LonghornV1beta2().Volumes(TestNamespace).Create(context.TODO(), tc.currentVolume, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = vIndexer.Add(v)
+			c.Assert(err, IsNil)
+		}
+		if tc.currentPod != nil {
+			pod, err := kubeClient.CoreV1().Pods(TestNamespace).Create(context.TODO(), tc.currentPod, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = podIndexer.Add(pod)
+			c.Assert(err, IsNil)
+		}
+		if tc.currentPV != nil {
+			pv, err := kubeClient.CoreV1().PersistentVolumes().Create(context.TODO(), tc.currentPV, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = pvIndexer.Add(pv)
+			c.Assert(err, IsNil)
+		}
+		if tc.currentPVC != nil {
+			pvc, err := kubeClient.CoreV1().PersistentVolumeClaims(TestNamespace).Create(context.TODO(), tc.currentPVC, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = pvcIndexer.Add(pvc)
+			c.Assert(err, IsNil)
+		}
+		restoreTest, err := lhClient.LonghornV1beta2().RestoreTests(TestNamespace).Create(context.TODO(), tc.currentRestoreTest, metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		err = rtIndexer.Add(restoreTest)
+		c.Assert(err, IsNil)
+
+		err = rtc.reconcile(TestRestoreTestName)
+		c.Assert(err, IsNil)
+
+		restoreTest, err = lhClient.LonghornV1beta2().RestoreTests(TestNamespace).Get(context.TODO(), TestRestoreTestName, metav1.GetOptions{})
+		c.Assert(err, IsNil)
+		c.Assert(restoreTest.Status, DeepEquals, tc.expectedRestoreTest.Status, Commentf("test case: %v", name))
+
+		resourceName := types.GetRestoreTestResourceName(TestRestoreTestName)
+		v, err := lhClient.LonghornV1beta2().Volumes(TestNamespace).Get(context.TODO(), resourceName, metav1.GetOptions{})
+		if tc.expectedVolume == nil {
+			c.Assert(apierrors.IsNotFound(err), Equals, true, Commentf("test case: %v", name))
+		} else {
+			c.Assert(err, IsNil, Commentf("test case: %v", name))
+			c.Assert(v.Spec, DeepEquals, tc.expectedVolume.Spec, Commentf("test case: %v", name))
+			c.Assert(v.Labels[types.GetLonghornLabelKey(types.LonghornLabelRestoreTest)], Equals, TestRestoreTestName)
+			c.Assert(v.OwnerReferences, DeepEquals, tc.expectedVolume.OwnerReferences, Commentf("test case: %v", name))
+		}
+
+		pod, err := kubeClient.CoreV1().Pods(TestNamespace).Get(context.TODO(), resourceName, metav1.GetOptions{})
+		if tc.expectedPod == nil {
+			c.Assert(apierrors.IsNotFound(err), Equals, true, Commentf("test case: %v", name))
+		} else {
+			c.Assert(err, IsNil, Commentf("test case: %v", name))
+			c.Assert(pod.Spec.Containers, DeepEquals, tc.expectedPod.Spec.Containers, Commentf("test case: %v", name))
+			c.Assert(pod.Spec.Volumes, DeepEquals, tc.expectedPod.Spec.Volumes, Commentf("test case: %v", name))
+		}
+
+		pv, err := kubeClient.CoreV1().PersistentVolumes().Get(context.TODO(), resourceName, metav1.GetOptions{})
+		c.Assert(err == nil, Equals, tc.expectedPV, Commentf("test case: %v", name))
+		if tc.expectedPVFSType != "" {
+			c.Assert(pv.Spec.CSI.FSType, Equals, tc.expectedPVFSType, Commentf("test case: %v", name))
+			c.Assert(pv.Spec.CSI.VolumeAttributes[types.OptionRequireExistingFilesystem], Equals, "true", Commentf("test case: %v", name))
+		}
+		_, err = kubeClient.CoreV1().PersistentVolumeClaims(TestNamespace).Get(context.TODO(), resourceName, metav1.GetOptions{})
+		c.Assert(err == nil, Equals, tc.expectedPVC, Commentf("test case: %v", name))
+	}
+}
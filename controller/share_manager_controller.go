@@ -539,9 +539,10 @@ func (c *ShareManagerController) createShareManagerAttachmentTicket(sm *longhorn
 	if !ok {
 		//create new one
 		shareManagerAttachmentTicket = &longhorn.AttachmentTicket{
-			ID:     shareManagerAttachmentTicketID,
-			Type:   longhorn.AttacherTypeShareManagerController,
-			NodeID: nodeID,
+			ID:         shareManagerAttachmentTicketID,
+			Type:       longhorn.AttacherTypeShareManagerController,
+			AttacherID: getAttacherID(attacherKindShareManager, sm.Name, nil),
+			NodeID:     nodeID,
 			Parameters: map[string]string{
 				longhorn.AttachmentParameterDisableFrontend: longhorn.FalseValue,
 			},
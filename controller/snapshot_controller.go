@@ -633,7 +633,8 @@ func (sc *SnapshotController) handleAttachmentTicketCreation(snap *longhorn.Snap
 	}()
 
 	attachmentID := longhorn.GetAttachmentTicketID(longhorn.AttacherTypeSnapshotController, snap.Name)
-	createOrUpdateAttachmentTicket(va, attachmentID, vol.Status.OwnerID, longhorn.AnyValue, longhorn.AttacherTypeSnapshotController)
+	createOrUpdateAttachmentTicket(va, attachmentID, vol.Status.OwnerID, longhorn.AnyValue, longhorn.AttacherTypeSnapshotController,
+		getAttacherID(attacherKindSnapshot, snap.Name, snap.Spec.Labels))
 
 	return nil
 }
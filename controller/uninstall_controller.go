@@ -45,6 +45,9 @@ const (
 	CRDBackupName                 = "backups.longhorn.io"
 	CRDRecurringJobName           = "recurringjobs.longhorn.io"
 	CRDOrphanName                 = "orphans.longhorn.io"
+	CRDCloneScheduleName          = "cloneschedules.longhorn.io"
+	CRDRestoreTestName            = "restoretests.longhorn.io"
+	CRDReplicaCountPolicyName     = "replicacountpolicies.longhorn.io"
 	CRDSnapshotName               = "snapshots.longhorn.io"
 
 	EnvLonghornNamespace = "LONGHORN_NAMESPACE"
@@ -197,6 +200,24 @@ func NewUninstallController(
 		}
 		cacheSyncs = append(cacheSyncs, ds.OrphanInformer.HasSynced)
 	}
+	if _, err := extensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), CRDCloneScheduleName, metav1.GetOptions{}); err == nil {
+		if _, err = ds.CloneScheduleInformer.AddEventHandler(c.controlleeHandler()); err != nil {
+			return nil, err
+		}
+		cacheSyncs = append(cacheSyncs, ds.CloneScheduleInformer.HasSynced)
+	}
+	if _, err := extensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), CRDRestoreTestName, metav1.GetOptions{}); err == nil {
+		if _, err = ds.RestoreTestInformer.AddEventHandler(c.controlleeHandler()); err != nil {
+			return nil, err
+		}
+		cacheSyncs = append(cacheSyncs, ds.RestoreTestInformer.HasSynced)
+	}
+	if _, err := extensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), CRDReplicaCountPolicyName, metav1.GetOptions{}); err == nil {
+		if _, err = ds.ReplicaCountPolicyInformer.AddEventHandler(c.controlleeHandler()); err != nil {
+			return nil, err
+		}
+		cacheSyncs = append(cacheSyncs, ds.ReplicaCountPolicyInformer.HasSynced)
+	}
 	if _, err := extensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), CRDSnapshotName, metav1.GetOptions{}); err == nil {
 		if _, err = ds.SnapshotInformer.AddEventHandler(c.controlleeHandler()); err != nil {
 			return nil, err
@@ -494,6 +515,30 @@ func (c *UninstallController) deleteRecreatedCRs() (bool, error) {
 // deleteCRs deletes all the longhorn CRs.
 // Note that this function is for those CRs which won't be recreated by managers after deletion.
 func (c *UninstallController) deleteCRs() (bool, error) {
+	// Clone schedules are deleted first, otherwise the target volumes may be recreated by refreshes.
+	if cloneSchedules, err := c.ds.ListCloneSchedules(); err != nil {
+		return true, err
+	} else if len(cloneSchedules) > 0 {
+		c.logger.Infof("Found %d clone schedules remaining", len(cloneSchedules))
+		return true, c.deleteCloneSchedules(cloneSchedules)
+	}
+
+	// Restore tests are deleted before volumes, otherwise the temporary volumes may be recreated by tests.
+	if restoreTests, err := c.ds.ListRestoreTests(); err != nil {
+		return true, err
+	} else if len(restoreTests) > 0 {
+		c.logger.Infof("Found %d restore tests remaining", len(restoreTests))
+		return true, c.deleteRestoreTests(restoreTests)
+	}
+
+	// Replica count policies are deleted before volumes, otherwise the volumes may be updated during the deletion.
+	if policies, err := c.ds.ListReplicaCountPolicies(); err != nil {
+		return true, err
+	} else if len(policies) > 0 {
+		c.logger.Infof("Found %d replica count policies remaining", len(policies))
+		return true, c.deleteReplicaCountPolicies(policies)
+	}
+
 	if volumes, err := c.ds.ListVolumes(); err != nil {
 		return true, err
 	} else if len(volumes) > 0 {
@@ -1177,6 +1222,72 @@ func (c *UninstallController) deleteOrphans(orphans map[string]*longhorn.Orphan)
 	return nil
 }
 
+func (c *UninstallController) deleteCloneSchedules(cloneSchedules map[string]*longhorn.CloneSchedule) (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "failed to delete clone schedules")
+	}()
+	for _, cloneSchedule := range cloneSchedules {
+		log := getLoggerForCloneSchedule(c.logger, cloneSchedule)
+		if cloneSchedule.DeletionTimestamp == nil {
+			if errDelete := c.ds.DeleteCloneSchedule(cloneSchedule.Name); errDelete != nil {
+				if datastore.ErrorIsNotFound(errDelete) {
+					log.Info("Clone schedule is not found")
+				} else {
+					err = errors.Wrap(errDelete, "failed to mark for deletion")
+					return
+				}
+			} else {
+				log.Info("Marked for deletion")
+			}
+		}
+	}
+	return nil
+}
+
+func (c *UninstallController) deleteRestoreTests(restoreTests map[string]*longhorn.RestoreTest) (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "failed to delete restore tests")
+	}()
+	for _, restoreTest := range restoreTests {
+		log := getLoggerForRestoreTest(c.logger, restoreTest)
+		if restoreTest.DeletionTimestamp == nil {
+			if errDelete := c.ds.DeleteRestoreTest(restoreTest.Name); errDelete != nil {
+				if datastore.ErrorIsNotFound(errDelete) {
+					log.Info("Restore test is not found")
+				} else {
+					err = errors.Wrap(errDelete, "failed to mark for deletion")
+					return
+				}
+			} else {
+				log.Info("Marked for deletion")
+			}
+		}
+	}
+	return nil
+}
+
+func (c *UninstallController) deleteReplicaCountPolicies(policies map[string]*longhorn.ReplicaCountPolicy) (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "failed to delete replica count policies")
+	}()
+	for _, policy := range policies {
+		log := getLoggerForReplicaCountPolicy(c.logger, policy)
+		if policy.DeletionTimestamp == nil {
+			if errDelete := c.ds.DeleteReplicaCountPolicy(policy.Name); errDelete != nil {
+				if datastore.ErrorIsNotFound(errDelete) {
+					log.Info("Replica count policy is not found")
+				} else {
+					err = errors.Wrap(errDelete, "failed to mark for deletion")
+					return
+				}
+			} else {
+				log.Info("Marked for deletion")
+			}
+		}
+	}
+	return nil
+}
+
 func (c *UninstallController) deleteSystemRestores(systemRestores map[string]*longhorn.SystemRestore) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to delete SystemRestores")
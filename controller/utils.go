@@ -1,6 +1,10 @@
 package controller
 
 import (
+	"fmt"
+	"sync"
+	"time"
+
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,6 +17,16 @@ import (
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
 
+const (
+	attacherKindVolume                 = "volume"
+	attacherKindDRVolume               = "dr-volume"
+	attacherKindSnapshot               = "snapshot"
+	attacherKindBackup                 = "backup"
+	attacherKindBackingImageDataSource = "backing-image-data-source"
+	attacherKindShareManager           = "share-manager"
+	attacherKindRecurringJob           = "recurring-job"
+)
+
 func hasReplicaEvictionRequested(rs map[string]*longhorn.Replica) bool {
 	for _, r := range rs {
 		if r.Spec.EvictionRequested {
@@ -48,14 +62,15 @@ func isVolumeFullyDetached(vol *longhorn.Volume) bool {
 		vol.Status.State == longhorn.VolumeStateDetached
 }
 
-func createOrUpdateAttachmentTicket(va *longhorn.VolumeAttachment, ticketID, nodeID, disableFrontend string, attacherType longhorn.AttacherType) {
+func createOrUpdateAttachmentTicket(va *longhorn.VolumeAttachment, ticketID, nodeID, disableFrontend string, attacherType longhorn.AttacherType, attacherID string) {
 	attachmentTicket, ok := va.Spec.AttachmentTickets[ticketID]
 	if !ok {
 		// Create new one
 		attachmentTicket = &longhorn.AttachmentTicket{
-			ID:     ticketID,
-			Type:   attacherType,
-			NodeID: nodeID,
+			ID:         ticketID,
+			Type:       attacherType,
+			AttacherID: attacherID,
+			NodeID:     nodeID,
 			Parameters: map[string]string{
 				longhorn.AttachmentParameterDisableFrontend: disableFrontend,
 			},
@@ -64,9 +79,31 @@ func createOrUpdateAttachmentTicket(va *longhorn.VolumeAttachment, ticketID, nod
 	if attachmentTicket.NodeID != nodeID {
 		attachmentTicket.NodeID = nodeID
 	}
+	if attachmentTicket.AttacherID != attacherID {
+		attachmentTicket.AttacherID = attacherID
+	}
 	va.Spec.AttachmentTickets[attachmentTicket.ID] = attachmentTicket
 }
 
+// createMaintenanceAttachmentTicket creates a frontend-disabled attachment ticket which attaches the volume
+// in the background only. The existing ticket with the same ID is left as it is.
+func createMaintenanceAttachmentTicket(va *longhorn.VolumeAttachment, ticketID, nodeID string, attacherType longhorn.AttacherType, attacherID string) {
+	if _, ok := va.Spec.AttachmentTickets[ticketID]; ok {
+		return
+	}
+	createOrUpdateAttachmentTicket(va, ticketID, nodeID, longhorn.TrueValue, attacherType, attacherID)
+	va.Spec.AttachmentTickets[ticketID].Parameters[longhorn.AttachmentParameterMaintenance] = longhorn.TrueValue
+}
+
+// getAttacherID returns the identity of the entity requesting an attachment on behalf of the object
+// in the form of <kind>/<name>. The recurring job is reported instead if the object is created by one.
+func getAttacherID(kind, name string, labels map[string]string) string {
+	if jobName := labels[types.RecurringJobLabel]; jobName != "" {
+		return fmt.Sprintf("%v/%v", attacherKindRecurringJob, jobName)
+	}
+	return fmt.Sprintf("%v/%v", kind, name)
+}
+
 func handleReconcileErrorLogging(logger logrus.FieldLogger, err error, mesg string) {
 	if types.ErrorIsInvalidState(err) {
 		logger.WithError(err).Trace(mesg)
@@ -134,3 +171,103 @@ func isSnapshotExistInEngine(snapshotName string, engine *longhorn.Engine) bool
 	}
 	return false
 }
+
+// heldUpgradeTracker remembers the upgrade actions held back by the upgrade freeze, so that each of them is only
+// logged and recorded as an event once instead of on every reconcile.
+type heldUpgradeTracker struct {
+	lock sync.Mutex
+	held map[string]struct{}
+}
+
+func newHeldUpgradeTracker() *heldUpgradeTracker {
+	return &heldUpgradeTracker{
+		held: map[string]struct{}{},
+	}
+}
+
+// hold marks the action as held back and returns true if it wasn't held back before.
+func (t *heldUpgradeTracker) hold(key string) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if _, ok := t.held[key]; ok {
+		return false
+	}
+	t.held[key] = struct{}{}
+	return true
+}
+
+func (t *heldUpgradeTracker) release(key string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.held, key)
+}
+
+func (t *heldUpgradeTracker) releaseAll() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.held = map[string]struct{}{}
+}
+
+// instanceManagerReachabilityCache remembers whether the instance managers accept connections from this node.
+// The instance managers are probed in the background, so that a sync never waits for the connection timeout.
+type instanceManagerReachabilityCache struct {
+	lock    sync.Mutex
+	results map[string]instanceManagerReachability
+	probing map[string]struct{}
+}
+
+type instanceManagerReachability struct {
+	ip        string
+	reachable bool
+	probedAt  time.Time
+}
+
+func newInstanceManagerReachabilityCache() *instanceManagerReachabilityCache {
+	return &instanceManagerReachabilityCache{
+		results: map[string]instanceManagerReachability{},
+		probing: map[string]struct{}{},
+	}
+}
+
+// get returns whether the instance manager was reachable at its current IP, and whether that was probed within
+// maxAge. Otherwise, the instance manager is probed by the checker in the background, and onProbed is called once
+// the result is available.
+func (c *instanceManagerReachabilityCache) get(im *longhorn.InstanceManager, maxAge time.Duration,
+	checker InstanceManagerReachabilityChecker, onProbed func()) (reachable, probed bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	result, ok := c.results[im.Name]
+	if ok && result.ip == im.Status.IP && time.Since(result.probedAt) < maxAge {
+		return result.reachable, true
+	}
+
+	if _, ok := c.probing[im.Name]; !ok {
+		c.probing[im.Name] = struct{}{}
+		im = im.DeepCopy()
+		go func() {
+			reachable := checker(im)
+			c.set(im, reachable, maxAge)
+			onProbed()
+		}()
+	}
+	return false, false
+}
+
+// set records the probe result of the instance manager, and drops the results that are too old to be used.
+func (c *instanceManagerReachabilityCache) set(im *longhorn.InstanceManager, reachable bool, maxAge time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.probing, im.Name)
+	for name, result := range c.results {
+		if time.Since(result.probedAt) >= maxAge {
+			delete(c.results, name)
+		}
+	}
+	c.results[im.Name] = instanceManagerReachability{
+		ip:        im.Status.IP,
+		reachable: reachable,
+		probedAt:  time.Now(),
+	}
+}
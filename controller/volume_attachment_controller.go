@@ -332,6 +332,8 @@ func (vac *VolumeAttachmentController) reconcile(vaName string) (err error) {
 
 	vac.handleVolumeDetachment(va, vol)
 
+	vac.handleMaintenanceAttachmentInterruption(va, vol)
+
 	vac.handleVolumeAttachment(va, vol)
 
 	vac.handleVolumeMigration(va, vol)
@@ -527,6 +529,29 @@ func hasSameKeys(map1, map2 map[string]*longhorn.SnapshotInfo) bool {
 	return true
 }
 
+// handleMaintenanceAttachmentInterruption turns the maintenance attachment tickets into regular ones accepting
+// any frontend setting once a workload requests the volume. Otherwise the frontend-disabled tickets would stay
+// unsatisfied, and the operations waiting for them would hang, as long as the workload keeps the volume attached.
+// The volume attached in maintenance mode is detached by handleVolumeDetachment first.
+func (vac *VolumeAttachmentController) handleMaintenanceAttachmentInterruption(va *longhorn.VolumeAttachment, vol *longhorn.Volume) {
+	if vol.Spec.NodeID != "" && vol.Spec.DisableFrontend {
+		return
+	}
+	if !hasWorkloadTicket(va.Spec.AttachmentTickets, longhorn.AnyValue) {
+		return
+	}
+
+	log := getLoggerForLHVolumeAttachment(vac.logger, va)
+	for _, ticket := range va.Spec.AttachmentTickets {
+		if !longhorn.IsMaintenanceAttachmentTicket(ticket) {
+			continue
+		}
+		log.Infof("Attachment ticket %v leaves maintenance mode since a workload requests the volume", ticket.ID)
+		delete(ticket.Parameters, longhorn.AttachmentParameterMaintenance)
+		ticket.Parameters[longhorn.AttachmentParameterDisableFrontend] = longhorn.AnyValue
+	}
+}
+
 func (vac *VolumeAttachmentController) handleVolumeMigrationRollback(va *longhorn.VolumeAttachment, vol *longhorn.Volume) {
 	// Nothing to rollback
 	if vol.Spec.MigrationNodeID == "" {
@@ -568,6 +593,11 @@ func (vac *VolumeAttachmentController) shouldDoDetach(va *longhorn.VolumeAttachm
 	if vol.Status.Robustness == longhorn.VolumeRobustnessFaulted {
 		return true
 	}
+	// Nothing can use a volume in the recycle bin, including the Longhorn controllers
+	if vol.Spec.TrashedAt != "" {
+		log.Infof("Should detach volume %v since it is in the recycle bin", vol.Name)
+		return true
+	}
 	if util.IsMigratableVolume(vol) && util.IsVolumeMigrating(vol) {
 		// if the volume is migrating, the detachment will be handled by handleVolumeMigration()
 		return false
@@ -604,9 +634,41 @@ func (vac *VolumeAttachmentController) shouldDoDetach(va *longhorn.VolumeAttachm
 		return true
 	}
 
+	// The volume attached in maintenance mode gives way to any workload ticket that cannot be
+	// satisfied by the current attachment, e.g. the one on the same node requiring the frontend.
+	if hasOnlyMaintenanceTicket(currentAttachmentTickets) {
+		unsatisfiableAttachmentTickets := map[string]*longhorn.AttachmentTicket{}
+		for _, attachmentTicket := range va.Spec.AttachmentTickets {
+			if _, ok := currentAttachmentTickets[attachmentTicket.ID]; !ok && !isCSIAttacherTicketOfRegularRWXVolume(attachmentTicket, vol) {
+				unsatisfiableAttachmentTickets[attachmentTicket.ID] = attachmentTicket
+			}
+		}
+		if hasWorkloadTicket(unsatisfiableAttachmentTickets, longhorn.AnyValue) {
+			log.Info("Workload attachment ticket interrupted maintenance attachment tickets")
+			return true
+		}
+	}
+
 	return false
 }
 
+// hasOnlyMaintenanceTicket returns true if the volume is attached for maintenance attachment tickets only.
+// Tickets accepting any frontend setting, like the ones from the snapshot controller, go along with them.
+func hasOnlyMaintenanceTicket(attachmentTickets map[string]*longhorn.AttachmentTicket) bool {
+	hasMaintenanceTicket := false
+	for _, ticket := range attachmentTickets {
+		if longhorn.IsMaintenanceAttachmentTicket(ticket) {
+			hasMaintenanceTicket = true
+			continue
+		}
+		if ticket.Type != longhorn.AttacherTypeSnapshotController &&
+			ticket.Type != longhorn.AttacherTypeBackupController {
+			return false
+		}
+	}
+	return hasMaintenanceTicket
+}
+
 func hasUninterruptibleTicket(attachmentTickets map[string]*longhorn.AttachmentTicket) bool {
 	for _, ticket := range attachmentTickets {
 		if ticket.Type != longhorn.AttacherTypeSnapshotController &&
@@ -650,6 +712,10 @@ func (vac *VolumeAttachmentController) handleVolumeAttachment(va *longhorn.Volum
 		return
 	}
 
+	if vol.Spec.TrashedAt != "" {
+		return
+	}
+
 	attachmentTicket := vac.selectAttachmentTicketToAttach(va, vol)
 	if attachmentTicket == nil {
 		return
@@ -757,8 +823,22 @@ func (vac *VolumeAttachmentController) updateStatusForDesiredAttachingAttachment
 
 	defer func() {
 		attachmentTicketStatus.Generation = attachmentTicket.Generation
+		attachmentTicketStatus.AttacherType = attachmentTicket.Type
+		attachmentTicketStatus.AttacherID = attachmentTicket.AttacherID
 	}()
 
+	if vol.Spec.TrashedAt != "" {
+		attachmentTicketStatus.Satisfied = false
+		attachmentTicketStatus.Conditions = types.SetCondition(
+			attachmentTicketStatus.Conditions,
+			longhorn.AttachmentStatusConditionTypeSatisfied,
+			longhorn.ConditionStatusFalse,
+			"",
+			fmt.Sprintf("volume %v is in the recycle bin", vol.Name),
+		)
+		return
+	}
+
 	if isCSIAttacherTicketOfRegularRWXVolume(attachmentTicket, vol) {
 		if isVolumeShareAvailable(vol) {
 			attachmentTicketStatus.Satisfied = true
@@ -46,6 +46,7 @@ func (s *TestSuite) TestVolumeAttachmentLifeCycle(c *C) {
 		"attachment-01": &longhorn.AttachmentTicket{
 			ID:         "attachment-01",
 			Type:       longhorn.AttacherTypeCSIAttacher,
+			AttacherID: TestNode1,
 			NodeID:     TestNode1,
 			Parameters: map[string]string{},
 			Generation: 0,
@@ -56,8 +57,10 @@ func (s *TestSuite) TestVolumeAttachmentLifeCycle(c *C) {
 	tc.copyCurrentToExpect()
 	tc.expectedVolAttachment.Status.AttachmentTicketStatuses = map[string]*longhorn.AttachmentTicketStatus{
 		"attachment-01": &longhorn.AttachmentTicketStatus{
-			ID:        "attachment-01",
-			Satisfied: false,
+			ID:           "attachment-01",
+			AttacherType: longhorn.AttacherTypeCSIAttacher,
+			AttacherID:   TestNode1,
+			Satisfied:    false,
 			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
 				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusFalse, "", ""),
 			Generation: 0,
@@ -90,15 +93,17 @@ func (s *TestSuite) TestVolumeAttachmentLifeCycle(c *C) {
 	tc.copyCurrentToExpect()
 	tc.expectedVolAttachment.Status.AttachmentTicketStatuses = map[string]*longhorn.AttachmentTicketStatus{
 		"attachment-01": &longhorn.AttachmentTicketStatus{
-			ID:        "attachment-01",
-			Satisfied: false,
+			ID:           "attachment-01",
+			AttacherType: longhorn.AttacherTypeSnapshotController,
+			Satisfied:    false,
 			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
 				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusFalse, "", ""),
 			Generation: 0,
 		},
 		"attachment-02": &longhorn.AttachmentTicketStatus{
-			ID:        "attachment-02",
-			Satisfied: false,
+			ID:           "attachment-02",
+			AttacherType: longhorn.AttacherTypeCSIAttacher,
+			Satisfied:    false,
 			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
 				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusFalse, "", ""),
 			Generation: 0,
@@ -131,15 +136,17 @@ func (s *TestSuite) TestVolumeAttachmentLifeCycle(c *C) {
 	tc.copyCurrentToExpect()
 	tc.expectedVolAttachment.Status.AttachmentTicketStatuses = map[string]*longhorn.AttachmentTicketStatus{
 		"attachment-01": &longhorn.AttachmentTicketStatus{
-			ID:        "attachment-01",
-			Satisfied: false,
+			ID:           "attachment-01",
+			AttacherType: longhorn.AttacherTypeCSIAttacher,
+			Satisfied:    false,
 			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
 				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusFalse, "", ""),
 			Generation: 0,
 		},
 		"attachment-02": &longhorn.AttachmentTicketStatus{
-			ID:        "attachment-02",
-			Satisfied: false,
+			ID:           "attachment-02",
+			AttacherType: longhorn.AttacherTypeCSIAttacher,
+			Satisfied:    false,
 			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
 				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusFalse, "", ""),
 			Generation: 0,
@@ -169,8 +176,9 @@ func (s *TestSuite) TestVolumeAttachmentLifeCycle(c *C) {
 	tc.copyCurrentToExpect()
 	tc.expectedVolAttachment.Status.AttachmentTicketStatuses = map[string]*longhorn.AttachmentTicketStatus{
 		"attachment-01": &longhorn.AttachmentTicketStatus{
-			ID:        "attachment-01",
-			Satisfied: true,
+			ID:           "attachment-01",
+			AttacherType: longhorn.AttacherTypeCSIAttacher,
+			Satisfied:    true,
 			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
 				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusTrue, "", ""),
 			Generation: 0,
@@ -206,8 +214,9 @@ func (s *TestSuite) TestVolumeAttachmentLifeCycle(c *C) {
 	tc.copyCurrentToExpect()
 	tc.expectedVolAttachment.Status.AttachmentTicketStatuses = map[string]*longhorn.AttachmentTicketStatus{
 		"attachment-01": &longhorn.AttachmentTicketStatus{
-			ID:        "attachment-01",
-			Satisfied: false,
+			ID:           "attachment-01",
+			AttacherType: longhorn.AttacherTypeVolumeRestoreController,
+			Satisfied:    false,
 			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
 				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusFalse,
 				longhorn.AttachmentStatusConditionReasonAttachedWithIncompatibleParameters,
@@ -215,8 +224,9 @@ func (s *TestSuite) TestVolumeAttachmentLifeCycle(c *C) {
 			Generation: 0,
 		},
 		"attachment-02": &longhorn.AttachmentTicketStatus{
-			ID:        "attachment-02",
-			Satisfied: true,
+			ID:           "attachment-02",
+			AttacherType: longhorn.AttacherTypeCSIAttacher,
+			Satisfied:    true,
 			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
 				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusTrue, "", ""),
 			Generation: 0,
@@ -280,6 +290,7 @@ func (s *TestSuite) TestVolumeAttachmentLifeCycle(c *C) {
 	tc.vol.Status.State = longhorn.VolumeStateAttached
 	tc.copyCurrentToExpect()
 	delete(tc.expectedVolAttachment.Status.AttachmentTicketStatuses, "attachment-02")
+	tc.expectedVolAttachment.Status.AttachmentTicketStatuses["attachment-01"].AttacherType = longhorn.AttacherTypeCSIAttacher
 	testCases["test case 7: detach: detach while there are still other attachments requesting the same node"] = tc
 	///////////////////////////////////////////////////////////////////
 
@@ -302,8 +313,9 @@ func (s *TestSuite) TestVolumeAttachmentLifeCycle(c *C) {
 	tc.copyCurrentToExpect()
 	tc.expectedVolAttachment.Status.AttachmentTicketStatuses = map[string]*longhorn.AttachmentTicketStatus{
 		"attachment-01": &longhorn.AttachmentTicketStatus{
-			ID:        "attachment-01",
-			Satisfied: true,
+			ID:           "attachment-01",
+			AttacherType: longhorn.AttacherTypeCSIAttacher,
+			Satisfied:    true,
 			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
 				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusTrue, "", ""),
 			Generation: 0,
@@ -342,8 +354,9 @@ func (s *TestSuite) TestVolumeAttachmentLifeCycle(c *C) {
 	tc.copyCurrentToExpect()
 	tc.expectedVolAttachment.Status.AttachmentTicketStatuses = map[string]*longhorn.AttachmentTicketStatus{
 		"attachment-01": &longhorn.AttachmentTicketStatus{
-			ID:        "attachment-01",
-			Satisfied: false,
+			ID:           "attachment-01",
+			AttacherType: longhorn.AttacherTypeCSIAttacher,
+			Satisfied:    false,
 			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
 				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusFalse, "",
 				fmt.Sprintf("the volume is currently attached to different node %v ", TestNode1)),
@@ -390,15 +403,17 @@ func (s *TestSuite) TestVolumeAttachmentLifeCycle(c *C) {
 	tc.copyCurrentToExpect()
 	tc.expectedVolAttachment.Status.AttachmentTicketStatuses = map[string]*longhorn.AttachmentTicketStatus{
 		"attachment-01": &longhorn.AttachmentTicketStatus{
-			ID:        "attachment-01",
-			Satisfied: true,
+			ID:           "attachment-01",
+			AttacherType: longhorn.AttacherTypeSnapshotController,
+			Satisfied:    true,
 			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
 				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusTrue, "", ""),
 			Generation: 0,
 		},
 		"attachment-02": &longhorn.AttachmentTicketStatus{
-			ID:        "attachment-02",
-			Satisfied: false,
+			ID:           "attachment-02",
+			AttacherType: longhorn.AttacherTypeCSIAttacher,
+			Satisfied:    false,
 			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
 				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusFalse, "",
 				fmt.Sprintf("the volume is currently attached to different node %v ", TestNode1)),
@@ -409,6 +424,93 @@ func (s *TestSuite) TestVolumeAttachmentLifeCycle(c *C) {
 	testCases["test case 10: ticket with higher priority interrupts ticket with lower priority"] = tc
 	///////////////////////////////////////////////////////////////////
 
+	///////////////////////////////////////////////////////////////////
+	tc = generateVolumeAttachmentTestCaseTemplate(TestVolumeName)
+	tc.volAttachment.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{
+		"attachment-01": &longhorn.AttachmentTicket{
+			ID:     "attachment-01",
+			Type:   longhorn.AttacherTypeBackupController,
+			NodeID: TestNode1,
+			Parameters: map[string]string{
+				longhorn.AttachmentParameterDisableFrontend: longhorn.TrueValue,
+				longhorn.AttachmentParameterMaintenance:     longhorn.TrueValue,
+			},
+			Generation: 0,
+		},
+		"attachment-02": &longhorn.AttachmentTicket{
+			ID:         "attachment-02",
+			Type:       longhorn.AttacherTypeCSIAttacher,
+			NodeID:     TestNode1,
+			Parameters: map[string]string{},
+			Generation: 0,
+		},
+	}
+	tc.vol.Status.OwnerID = TestNode1
+	tc.vol.Spec.NodeID = TestNode1
+	tc.vol.Spec.DisableFrontend = true
+	tc.vol.Status.CurrentNodeID = TestNode1
+	tc.vol.Status.State = longhorn.VolumeStateAttached
+	tc.copyCurrentToExpect()
+	// The backup ticket leaves maintenance mode so that it goes along with the workload attachment
+	// instead of staying unsatisfied while the workload keeps the volume attached.
+	tc.expectedVolAttachment.Spec.AttachmentTickets["attachment-01"].Parameters = map[string]string{
+		longhorn.AttachmentParameterDisableFrontend: longhorn.AnyValue,
+	}
+	tc.expectedVolAttachment.Status.AttachmentTicketStatuses = map[string]*longhorn.AttachmentTicketStatus{
+		"attachment-01": &longhorn.AttachmentTicketStatus{
+			ID:           "attachment-01",
+			AttacherType: longhorn.AttacherTypeBackupController,
+			Satisfied:    true,
+			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
+				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusTrue, "", ""),
+			Generation: 0,
+		},
+		"attachment-02": &longhorn.AttachmentTicketStatus{
+			ID:           "attachment-02",
+			AttacherType: longhorn.AttacherTypeCSIAttacher,
+			Satisfied:    true,
+			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
+				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusTrue, "", ""),
+			Generation: 0,
+		},
+	}
+	tc.expectedVol.Spec.NodeID = ""
+	tc.expectedVol.Spec.DisableFrontend = false
+	testCases["test case 11: workload ticket interrupts maintenance ticket on the same node"] = tc
+	///////////////////////////////////////////////////////////////////
+
+	///////////////////////////////////////////////////////////////////
+	tc = generateVolumeAttachmentTestCaseTemplate(TestVolumeName)
+	tc.volAttachment.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{
+		"attachment-01": &longhorn.AttachmentTicket{
+			ID:         "attachment-01",
+			Type:       longhorn.AttacherTypeVolumeRebuildingController,
+			NodeID:     TestNode1,
+			Parameters: map[string]string{},
+			Generation: 0,
+		},
+	}
+	tc.vol.Status.OwnerID = TestNode1
+	tc.vol.Spec.NodeID = TestNode1
+	tc.vol.Spec.TrashedAt = util.Now()
+	tc.vol.Status.CurrentNodeID = TestNode1
+	tc.vol.Status.State = longhorn.VolumeStateAttached
+	tc.copyCurrentToExpect()
+	tc.expectedVolAttachment.Status.AttachmentTicketStatuses = map[string]*longhorn.AttachmentTicketStatus{
+		"attachment-01": &longhorn.AttachmentTicketStatus{
+			ID:           "attachment-01",
+			AttacherType: longhorn.AttacherTypeVolumeRebuildingController,
+			Satisfied:    false,
+			Conditions: types.SetConditionWithoutTimestamp([]longhorn.Condition{},
+				longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusFalse, "",
+				fmt.Sprintf("volume %v is in the recycle bin", TestVolumeName)),
+			Generation: 0,
+		},
+	}
+	tc.expectedVol.Spec.NodeID = ""
+	testCases["test case 12: volume in the recycle bin is detached regardless of the tickets"] = tc
+	///////////////////////////////////////////////////////////////////
+
 	for name, tc := range testCases {
 		//uncomment this block to test individual test case
 		//if name != "test case 10: ticket with higher priority interrupts ticket with lower priority" {
@@ -476,6 +578,7 @@ func (s *TestSuite) runVolumeAttachmentTestCase(c *C, tc *volumeAttachmentTestCa
 		}
 	}
 	c.Assert(retVolAttachment.Status, DeepEquals, tc.expectedVolAttachment.Status)
+	c.Assert(retVolAttachment.Spec.AttachmentTickets, DeepEquals, tc.expectedVolAttachment.Spec.AttachmentTickets)
 
 }
 
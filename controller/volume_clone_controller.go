@@ -219,7 +219,8 @@ func (vcc *VolumeCloneController) reconcile(volName string) (err error) {
 	// case 1: this volume is target of a clone
 	if isTargetVolumeOfAnActiveCloning(vol) {
 		cloningAttachmentTicketID := longhorn.GetAttachmentTicketID(longhorn.AttacherTypeVolumeCloneController, volName)
-		createOrUpdateAttachmentTicket(va, cloningAttachmentTicketID, vol.Status.OwnerID, longhorn.TrueValue, longhorn.AttacherTypeVolumeCloneController)
+		createOrUpdateAttachmentTicket(va, cloningAttachmentTicketID, vol.Status.OwnerID, longhorn.TrueValue, longhorn.AttacherTypeVolumeCloneController,
+			getAttacherID(attacherKindVolume, volName, nil))
 		expectedAttachmentTickets[cloningAttachmentTicketID] = true
 	}
 
@@ -231,7 +232,8 @@ func (vcc *VolumeCloneController) reconcile(volName string) (err error) {
 	for _, v := range vols {
 		attachmentTicketID := longhorn.GetAttachmentTicketID(longhorn.AttacherTypeVolumeCloneController, v.Name)
 		if isTargetVolumeOfAnActiveCloning(v) && types.GetVolumeName(v.Spec.DataSource) == vol.Name {
-			createOrUpdateAttachmentTicket(va, attachmentTicketID, vol.Status.OwnerID, longhorn.AnyValue, longhorn.AttacherTypeVolumeCloneController)
+			createOrUpdateAttachmentTicket(va, attachmentTicketID, vol.Status.OwnerID, longhorn.AnyValue, longhorn.AttacherTypeVolumeCloneController,
+				getAttacherID(attacherKindVolume, v.Name, nil))
 			expectedAttachmentTickets[attachmentTicketID] = true
 		}
 	}
@@ -60,6 +60,8 @@ const (
 
 	initialCloneRetryInterval = 30 * time.Second
 	maxCloneRetry             = 10
+
+	rebuildQueueRecheckInterval = 10 * time.Second
 )
 
 type VolumeController struct {
@@ -404,7 +406,13 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 
 		kubeStatus := volume.Status.KubernetesStatus
 
-		if kubeStatus.PVName != "" {
+		// The PV and PVC are reused by the volume recreated for a clone schedule refresh
+		isRefreshedByCloneSchedule, err := c.isRefreshedByCloneSchedule(volume)
+		if err != nil {
+			return err
+		}
+
+		if kubeStatus.PVName != "" && !isRefreshedByCloneSchedule {
 			if err := c.ds.DeletePersistentVolume(kubeStatus.PVName); err != nil {
 				if !datastore.ErrorIsNotFound(err) {
 					return err
@@ -412,7 +420,7 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 			}
 		}
 
-		if kubeStatus.PVCName != "" && kubeStatus.LastPVCRefAt == "" {
+		if kubeStatus.PVCName != "" && kubeStatus.LastPVCRefAt == "" && !isRefreshedByCloneSchedule {
 			if err := c.ds.DeletePersistentVolumeClaim(kubeStatus.Namespace, kubeStatus.PVCName); err != nil {
 				if !datastore.ErrorIsNotFound(err) {
 					return err
@@ -440,6 +448,10 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 		return c.ds.RemoveFinalizerForVolume(volume)
 	}
 
+	if purged, err := c.purgeExpiredTrashedVolume(volume); err != nil || purged {
+		return err
+	}
+
 	existingVolume := volume.DeepCopy()
 	existingEngines := map[string]*longhorn.Engine{}
 	for k, e := range engines {
@@ -495,7 +507,12 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 		return err
 	}
 
-	if err := c.ReconcileEngineReplicaState(volume, engines, replicas); err != nil {
+	if err := c.syncSelectorViolatingReplicas(volume, replicas); err != nil {
+		return err
+	}
+
+	queue := newRebuildQueue(c.ds)
+	if err := c.ReconcileEngineReplicaState(volume, engines, replicas, queue); err != nil {
 		return err
 	}
 
@@ -531,7 +548,7 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 		return nil
 	}
 
-	if err := c.ReconcileVolumeState(volume, engines, replicas); err != nil {
+	if err := c.ReconcileVolumeState(volume, engines, replicas, queue); err != nil {
 		return err
 	}
 
@@ -558,7 +575,7 @@ func handleConditionLastTransitionTime(existingStatus, newStatus *longhorn.Volum
 
 // EvictReplicas do creating one more replica for eviction, if requested
 func (c *VolumeController) EvictReplicas(v *longhorn.Volume,
-	e *longhorn.Engine, rs map[string]*longhorn.Replica, healthyCount int) (err error) {
+	e *longhorn.Engine, rs map[string]*longhorn.Replica, healthyCount int, queue *rebuildQueue) (err error) {
 	log := getLoggerForVolume(c.logger, v)
 
 	hasNewReplica := false
@@ -576,7 +593,7 @@ func (c *VolumeController) EvictReplicas(v *longhorn.Volume,
 
 	if healthyNonEvictingCount < v.Spec.NumberOfReplicas && !hasNewReplica {
 		log.Info("Creating one more replica for eviction")
-		if err := c.replenishReplicas(v, e, rs, ""); err != nil {
+		if err := c.replenishReplicas(v, e, rs, "", queue); err != nil {
 			c.eventRecorder.Eventf(v, corev1.EventTypeWarning,
 				constant.EventReasonEvictionFailed,
 				"volume %v failed to create one more replica", v.Name)
@@ -613,7 +630,7 @@ func (c *VolumeController) handleVolumeAttachmentCreation(v *longhorn.Volume) er
 
 // ReconcileEngineReplicaState will get the current main engine e.Status.ReplicaModeMap, e.Status.RestoreStatus,
 // e.Status.purgeStatus, and e.Status.SnapshotCloneStatus then update v and rs accordingly.
-func (c *VolumeController) ReconcileEngineReplicaState(v *longhorn.Volume, es map[string]*longhorn.Engine, rs map[string]*longhorn.Replica) (err error) {
+func (c *VolumeController) ReconcileEngineReplicaState(v *longhorn.Volume, es map[string]*longhorn.Engine, rs map[string]*longhorn.Replica, queue *rebuildQueue) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to reconcile engine/replica state for %v", v.Name)
 		if v.Status.Robustness != longhorn.VolumeRobustnessDegraded {
@@ -806,7 +823,7 @@ func (c *VolumeController) ReconcileEngineReplicaState(v *longhorn.Volume, es ma
 
 		if isMigratingDone {
 			// Evict replicas for the volume
-			if err := c.EvictReplicas(v, e, rs, healthyCount); err != nil {
+			if err := c.EvictReplicas(v, e, rs, healthyCount, queue); err != nil {
 				return err
 			}
 
@@ -814,14 +831,14 @@ func (c *VolumeController) ReconcileEngineReplicaState(v *longhorn.Volume, es ma
 			// We turn off data locality while doing auto-attaching or restoring (e.g. frontend is disabled)
 			if v.Status.State == longhorn.VolumeStateAttached && !v.Status.FrontendDisabled &&
 				isDataLocalityBestEffort(v) && !hasLocalReplicaOnSameNodeAsEngine(e, rs) {
-				if err := c.replenishReplicas(v, e, rs, e.Spec.NodeID); err != nil {
+				if err := c.replenishReplicas(v, e, rs, e.Spec.NodeID, queue); err != nil {
 					return err
 				}
 			}
 
 			setting := c.ds.GetAutoBalancedReplicasSetting(v, log)
-			if setting != longhorn.ReplicaAutoBalanceDisabled {
-				if err := c.replenishReplicas(v, e, rs, ""); err != nil {
+			if setting != longhorn.ReplicaAutoBalanceDisabled || c.isSelectorRelocationRequired(v, rs) {
+				if err := c.replenishReplicas(v, e, rs, "", queue); err != nil {
 					return err
 				}
 			}
@@ -846,7 +863,7 @@ func (c *VolumeController) ReconcileEngineReplicaState(v *longhorn.Volume, es ma
 			(types.IsDataEngineV1(e.Spec.DataEngine) && cliAPIVersion < engineapi.CLIVersionFour)
 		isInExpansion := v.Spec.Size != e.Status.CurrentSize
 		if isMigratingDone && !isOldRestoreVolume && !isInExpansion {
-			if err := c.replenishReplicas(v, e, rs, ""); err != nil {
+			if err := c.replenishReplicas(v, e, rs, "", queue); err != nil {
 				return err
 			}
 		}
@@ -1105,6 +1122,10 @@ func (c *VolumeController) cleanupExtraHealthyReplicas(v *longhorn.Volume, e *lo
 		return err
 	}
 
+	if cleaned, err = c.cleanupSelectorViolatingReplicas(v, rs); err != nil || cleaned {
+		return err
+	}
+
 	if cleaned, err = c.cleanupDataLocalityReplicas(v, e, rs); err != nil || cleaned {
 		return err
 	}
@@ -1164,6 +1185,29 @@ func (c *VolumeController) cleanupEvictionRequestedReplicas(v *longhorn.Volume,
 	return false, nil
 }
 
+// cleanupSelectorViolatingReplicas deletes one replica violating the node selector or the disk selector
+// once a compliant replica has been rebuilt to replace it.
+func (c *VolumeController) cleanupSelectorViolatingReplicas(v *longhorn.Volume, rs map[string]*longhorn.Replica) (bool, error) {
+	if !v.Spec.ReplicaSelectorRelocation {
+		return false, nil
+	}
+
+	for _, rName := range v.Status.SelectorViolatingReplicas {
+		r, exists := rs[rName]
+		if !exists {
+			continue
+		}
+		if err := c.deleteReplica(r, rs); err != nil {
+			return false, err
+		}
+		getLoggerForVolume(c.logger, v).Infof("Relocated replica %v in disk %v of node %v violating the selectors", r.Name, r.Spec.DiskID, r.Spec.NodeID)
+		c.eventRecorder.Eventf(v, corev1.EventTypeNormal, constant.EventReasonRelocated,
+			"volume %v relocated replica %v from node %v since it violates the node selector or the disk selector", v.Name, r.Name, r.Spec.NodeID)
+		return true, nil
+	}
+	return false, nil
+}
+
 func (c *VolumeController) cleanupAutoBalancedReplicas(v *longhorn.Volume, e *longhorn.Engine, rs map[string]*longhorn.Replica) (bool, error) {
 	log := getLoggerForVolume(c.logger, v).WithField("replicaAutoBalanceType", "delete")
 
@@ -1283,7 +1327,7 @@ func (c *VolumeController) syncVolumeSnapshotSetting(v *longhorn.Volume, es map[
 }
 
 // ReconcileVolumeState handles the attaching and detaching of volume
-func (c *VolumeController) ReconcileVolumeState(v *longhorn.Volume, es map[string]*longhorn.Engine, rs map[string]*longhorn.Replica) (err error) {
+func (c *VolumeController) ReconcileVolumeState(v *longhorn.Volume, es map[string]*longhorn.Engine, rs map[string]*longhorn.Replica, queue *rebuildQueue) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to reconcile volume state for %v", v.Name)
 	}()
@@ -1315,7 +1359,7 @@ func (c *VolumeController) ReconcileVolumeState(v *longhorn.Volume, es map[strin
 		return err
 	}
 
-	isNewVolume, e, err := c.reconcileVolumeCreation(v, e, es, rs)
+	isNewVolume, e, err := c.reconcileVolumeCreation(v, e, es, rs, queue)
 	if err != nil {
 		return err
 	}
@@ -1572,6 +1616,17 @@ func (c *VolumeController) reconcileAttachDetachStateMachine(v *longhorn.Volume,
 				// This is a stable state.
 				// We attempt to close the resources anyway to make sure that they are closed
 				c.closeVolumeDependentResources(v, e, rs)
+				if v.Spec.TrashedAt != "" {
+					v.Status.State = longhorn.VolumeStateTrashed
+					c.eventRecorder.Eventf(v, corev1.EventTypeNormal, constant.EventReasonTrashed, "volume %v has been moved to the recycle bin", v.Name)
+				}
+			case longhorn.VolumeStateTrashed:
+				// The volume stays detached while it is in the recycle bin
+				c.closeVolumeDependentResources(v, e, rs)
+				if v.Spec.TrashedAt == "" {
+					v.Status.State = longhorn.VolumeStateDetached
+					c.eventRecorder.Eventf(v, corev1.EventTypeNormal, constant.EventReasonRestored, "volume %v has been restored from the recycle bin", v.Name)
+				}
 			}
 			return nil
 		}
@@ -1680,7 +1735,7 @@ func (c *VolumeController) reconcileAttachDetachStateMachine(v *longhorn.Volume,
 	return nil
 }
 
-func (c *VolumeController) reconcileVolumeCreation(v *longhorn.Volume, e *longhorn.Engine, es map[string]*longhorn.Engine, rs map[string]*longhorn.Replica) (bool, *longhorn.Engine, error) {
+func (c *VolumeController) reconcileVolumeCreation(v *longhorn.Volume, e *longhorn.Engine, es map[string]*longhorn.Engine, rs map[string]*longhorn.Replica, queue *rebuildQueue) (bool, *longhorn.Engine, error) {
 	// first time engine creation etc
 
 	var isNewVolume bool
@@ -1698,7 +1753,7 @@ func (c *VolumeController) reconcileVolumeCreation(v *longhorn.Volume, e *longho
 
 	if len(rs) == 0 {
 		// first time creation
-		if err = c.replenishReplicas(v, e, rs, ""); err != nil {
+		if err = c.replenishReplicas(v, e, rs, "", queue); err != nil {
 			return false, e, err
 		}
 	}
@@ -2236,10 +2291,61 @@ func hasLocalReplicaOnSameNodeAsEngine(e *longhorn.Engine, rs map[string]*longho
 	return false
 }
 
+// rebuildQueue lists the cluster-wide rebuild queue at most once per volume sync, since listing it goes
+// through all the volumes, replicas and engines while replenishReplicas can be called several times.
+type rebuildQueue struct {
+	ds      *datastore.DataStore
+	entries []*datastore.RebuildQueueEntry
+	listed  bool
+}
+
+func newRebuildQueue(ds *datastore.DataStore) *rebuildQueue {
+	return &rebuildQueue{ds: ds}
+}
+
+func (q *rebuildQueue) list() ([]*datastore.RebuildQueueEntry, error) {
+	if !q.listed {
+		entries, err := q.ds.ListRebuildQueueRO()
+		if err != nil {
+			return nil, err
+		}
+		q.entries = entries
+		q.listed = true
+	}
+	return q.entries, nil
+}
+
+// isQueuedForRebuild returns true if the volume has to wait in the cluster-wide rebuild queue before rebuilding
+// a replica. The rebuilding volumes and the waiting volumes with a higher priority take the concurrent volume
+// rebuild limit first. A volume not in the queue, e.g. a healthy volume rebalancing its replicas, comes last.
+func (c *VolumeController) isQueuedForRebuild(v *longhorn.Volume, queue *rebuildQueue) (bool, error) {
+	limit, err := c.ds.GetSettingAsInt(types.SettingNameConcurrentVolumeRebuildLimit)
+	if err != nil {
+		return false, err
+	}
+	if limit < 1 {
+		return false, nil
+	}
+
+	entries, err := queue.list()
+	if err != nil {
+		return false, err
+	}
+
+	available := int(limit)
+	for _, entry := range entries {
+		if entry.VolumeName == v.Name {
+			return available <= 0, nil
+		}
+		available--
+	}
+	return available <= 0, nil
+}
+
 // replenishReplicas will keep replicas count to v.Spec.NumberOfReplicas
 // It will count all the potentially usable replicas, since some replicas maybe
 // blank or in rebuilding state
-func (c *VolumeController) replenishReplicas(v *longhorn.Volume, e *longhorn.Engine, rs map[string]*longhorn.Replica, hardNodeAffinity string) error {
+func (c *VolumeController) replenishReplicas(v *longhorn.Volume, e *longhorn.Engine, rs map[string]*longhorn.Replica, hardNodeAffinity string, queue *rebuildQueue) error {
 	concurrentRebuildingLimit, err := c.ds.GetSettingAsInt(types.SettingNameConcurrentReplicaRebuildPerNodeLimit)
 	if err != nil {
 		return err
@@ -2286,6 +2392,18 @@ func (c *VolumeController) replenishReplicas(v *longhorn.Volume, e *longhorn.Eng
 
 	newVolume := len(rs) == 0
 
+	if !newVolume && replenishCount > 0 {
+		queued, err := c.isQueuedForRebuild(v, queue)
+		if err != nil {
+			return err
+		}
+		if queued {
+			log.Debug("Waiting in the rebuild queue since the concurrent volume rebuild limit is reached")
+			c.enqueueVolumeAfter(v, rebuildQueueRecheckInterval)
+			return nil
+		}
+	}
+
 	// For regular rebuild case or data locality case, rebuild one replica at a time
 	if (!newVolume && replenishCount > 0) || hardNodeAffinity != "" {
 		replenishCount = 1
@@ -2926,6 +3044,10 @@ func (c *VolumeController) getReplenishReplicasCount(v *longhorn.Volume, rs map[
 	case v.Spec.NumberOfReplicas > usableCount:
 		return v.Spec.NumberOfReplicas - usableCount, ""
 	case v.Spec.NumberOfReplicas == usableCount:
+		// Rebuild one compliant replica at a time, the violating replica is cleaned up once it is rebuilt
+		if c.isSelectorRelocationRequired(v, rs) {
+			return 1, ""
+		}
 		if adjustCount := c.getReplicaCountForAutoBalanceLeastEffort(v, e, rs, c.getReplicaCountForAutoBalanceZone); adjustCount != 0 {
 			return adjustCount, ""
 		}
@@ -2951,6 +3073,120 @@ func (c *VolumeController) getReplenishReplicasCount(v *longhorn.Volume, rs map[
 	return 0, ""
 }
 
+// syncSelectorViolatingReplicas records the scheduled replicas placed on nodes or disks that don't match
+// the node selector or the disk selector of the volume.
+func (c *VolumeController) syncSelectorViolatingReplicas(v *longhorn.Volume, rs map[string]*longhorn.Replica) error {
+	allowEmptyNodeSelectorVolume, err := c.ds.GetSettingAsBool(types.SettingNameAllowEmptyNodeSelectorVolume)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get %v setting", types.SettingNameAllowEmptyNodeSelectorVolume)
+	}
+	allowEmptyDiskSelectorVolume, err := c.ds.GetSettingAsBool(types.SettingNameAllowEmptyDiskSelectorVolume)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get %v setting", types.SettingNameAllowEmptyDiskSelectorVolume)
+	}
+
+	violatingReplicas := []string{}
+	for _, r := range rs {
+		if r.Spec.NodeID == "" || r.Spec.FailedAt != "" || r.DeletionTimestamp != nil {
+			continue
+		}
+		node, err := c.ds.GetNodeRO(r.Spec.NodeID)
+		if err != nil {
+			if datastore.ErrorIsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !types.IsSelectorsInTags(node.Spec.Tags, v.Spec.NodeSelector, allowEmptyNodeSelectorVolume) {
+			violatingReplicas = append(violatingReplicas, r.Name)
+			continue
+		}
+		for diskName, diskStatus := range node.Status.DiskStatus {
+			if diskStatus == nil || diskStatus.DiskUUID != r.Spec.DiskID {
+				continue
+			}
+			if diskSpec, exists := node.Spec.Disks[diskName]; exists &&
+				!types.IsSelectorsInTags(diskSpec.Tags, v.Spec.DiskSelector, allowEmptyDiskSelectorVolume) {
+				violatingReplicas = append(violatingReplicas, r.Name)
+			}
+			break
+		}
+	}
+	sort.Strings(violatingReplicas)
+
+	// Keep the status unchanged for the volumes without any violation
+	if len(violatingReplicas) == 0 {
+		violatingReplicas = nil
+	}
+	v.Status.SelectorViolatingReplicas = violatingReplicas
+	return nil
+}
+
+// isSelectorRelocationRequired returns true if the volume opts in to relocating the replicas violating the selectors
+// and a compliant disk is available for the replacement replica.
+func (c *VolumeController) isSelectorRelocationRequired(v *longhorn.Volume, rs map[string]*longhorn.Replica) bool {
+	if !v.Spec.ReplicaSelectorRelocation || len(v.Status.SelectorViolatingReplicas) == 0 {
+		return false
+	}
+
+	log := getLoggerForVolume(c.logger, v)
+
+	allowEmptyNodeSelectorVolume, err := c.ds.GetSettingAsBool(types.SettingNameAllowEmptyNodeSelectorVolume)
+	if err != nil {
+		log.WithError(err).Warnf("Failed to get %v setting", types.SettingNameAllowEmptyNodeSelectorVolume)
+		return false
+	}
+	allowEmptyDiskSelectorVolume, err := c.ds.GetSettingAsBool(types.SettingNameAllowEmptyDiskSelectorVolume)
+	if err != nil {
+		log.WithError(err).Warnf("Failed to get %v setting", types.SettingNameAllowEmptyDiskSelectorVolume)
+		return false
+	}
+
+	nodes, err := c.ds.ListReadyAndSchedulableNodesRO()
+	if err != nil {
+		log.WithError(err).Warn("Failed to list ready and schedulable nodes for replica relocation")
+		return false
+	}
+	nodes = c.scheduler.FilterNodesSchedulableForVolume(nodes, v)
+
+	violating := map[string]bool{}
+	for _, rName := range v.Status.SelectorViolatingReplicas {
+		violating[rName] = true
+	}
+	usedDisks := map[string]bool{}
+	nodesWithCompliantReplica := map[string]bool{}
+	for _, r := range rs {
+		if r.Spec.NodeID == "" || r.Spec.FailedAt != "" {
+			continue
+		}
+		usedDisks[r.Spec.DiskID] = true
+		if !violating[r.Name] {
+			nodesWithCompliantReplica[r.Spec.NodeID] = true
+		}
+	}
+
+	for _, node := range nodes {
+		if nodesWithCompliantReplica[node.Name] {
+			continue
+		}
+		if !types.IsSelectorsInTags(node.Spec.Tags, v.Spec.NodeSelector, allowEmptyNodeSelectorVolume) {
+			continue
+		}
+		for diskName, diskSpec := range node.Spec.Disks {
+			diskStatus, exists := node.Status.DiskStatus[diskName]
+			if !exists || diskStatus == nil || !diskSpec.AllowScheduling || usedDisks[diskStatus.DiskUUID] {
+				continue
+			}
+			if types.IsSelectorsInTags(diskSpec.Tags, v.Spec.DiskSelector, allowEmptyDiskSelectorVolume) {
+				return true
+			}
+		}
+	}
+
+	log.Debugf("No compliant disk is available to relocate replicas %v violating the selectors", v.Status.SelectorViolatingReplicas)
+	return false
+}
+
 func (c *VolumeController) getNodeCandidatesForAutoBalanceZone(v *longhorn.Volume, e *longhorn.Engine, rs map[string]*longhorn.Replica, zones []string) (candidateNames []string) {
 	log := getLoggerForVolume(c.logger, v).WithFields(
 		logrus.Fields{
@@ -3071,6 +3307,7 @@ func (c *VolumeController) upgradeEngineForVolume(v *longhorn.Volume, es map[str
 	})
 
 	if !isVolumeUpgrading(v) {
+		v.Status.Conditions = types.RemoveCondition(v.Status.Conditions, longhorn.VolumeConditionTypeEngineUpgradeReady)
 		// it must be a rollback
 		if e.Spec.Image != v.Spec.Image {
 			e.Spec.Image = v.Spec.Image
@@ -3149,6 +3386,39 @@ func (c *VolumeController) upgradeEngineForVolume(v *longhorn.Volume, es map[str
 		return nil
 	}
 
+	// Live upgrades that are already in progress are finished, only new ones are blocked by the upgrade freeze.
+	if e.Spec.Image != v.Spec.Image && !hasReplicaWithImage(rs, v.Spec.Image) {
+		upgradeFrozen, err := c.ds.GetSettingAsBool(types.SettingNameUpgradeFreeze)
+		if err != nil {
+			return err
+		}
+		if upgradeFrozen {
+			if types.GetCondition(v.Status.Conditions, longhorn.VolumeConditionTypeEngineUpgradeReady).Reason != longhorn.VolumeConditionReasonUpgradeFrozen {
+				log.Infof("Skipped starting the live engine upgrade since the upgrade freeze is enabled")
+				c.eventRecorder.Eventf(v, corev1.EventTypeNormal, constant.EventReasonUpgradeFrozen, "Skipped live upgrading engine to image %v since the upgrade freeze is enabled", v.Spec.Image)
+			}
+			v.Status.Conditions = types.SetCondition(v.Status.Conditions,
+				longhorn.VolumeConditionTypeEngineUpgradeReady, longhorn.ConditionStatusFalse, longhorn.VolumeConditionReasonUpgradeFrozen,
+				fmt.Sprintf("Live upgrading engine to image %v is held back by the upgrade freeze", v.Spec.Image))
+			return nil
+		}
+
+		failures, err := c.ds.CheckEngineUpgradePreconditions(v)
+		if err != nil {
+			return err
+		}
+		if len(failures) > 0 {
+			message := datastore.EngineUpgradePreCheckMessage(failures)
+			if types.GetCondition(v.Status.Conditions, longhorn.VolumeConditionTypeEngineUpgradeReady).Status != longhorn.ConditionStatusFalse {
+				log.Infof("Blocked starting the live engine upgrade: %v", message)
+			}
+			v.Status.Conditions = types.SetCondition(v.Status.Conditions,
+				longhorn.VolumeConditionTypeEngineUpgradeReady, longhorn.ConditionStatusFalse, failures[0].Reason, message)
+			return nil
+		}
+	}
+	v.Status.Conditions = types.RemoveCondition(v.Status.Conditions, longhorn.VolumeConditionTypeEngineUpgradeReady)
+
 	volumeAndReplicaNodes := []string{v.Status.CurrentNodeID}
 	for _, r := range rs {
 		if r.Spec.NodeID == "" {
@@ -3206,6 +3476,15 @@ func (c *VolumeController) upgradeEngineForVolume(v *longhorn.Volume, es map[str
 	return nil
 }
 
+func hasReplicaWithImage(rs map[string]*longhorn.Replica, image string) bool {
+	for _, r := range rs {
+		if r.Spec.Image == image {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *VolumeController) constructReplicaAddressMap(v *longhorn.Volume, e *longhorn.Engine, dataPathToNewReplica map[string]*longhorn.Replica) (map[string]string, error) {
 	log := getLoggerForVolume(c.logger, v).WithFields(logrus.Fields{
 		"engine":                   e.Name,
@@ -4785,6 +5064,13 @@ func (c *VolumeController) enqueueNodeChange(obj interface{}) {
 		replicaAutoBalance := c.ds.GetAutoBalancedReplicasSetting(vol, log)
 		if r.Spec.NodeID == "" || r.Spec.FailedAt != "" || replicaAutoBalance != longhorn.ReplicaAutoBalanceDisabled {
 			c.enqueueVolume(vol)
+			continue
+		}
+
+		// The node or disk tags may have changed, so the selector violations need to be re-evaluated
+		hasSelector := len(vol.Spec.NodeSelector) != 0 || len(vol.Spec.DiskSelector) != 0 || len(vol.Status.SelectorViolatingReplicas) != 0
+		if r.Spec.NodeID == node.Name && hasSelector {
+			c.enqueueVolume(vol)
 		}
 	}
 }
@@ -4834,6 +5120,56 @@ func (c *VolumeController) enqueueSettingChange(obj interface{}) {
 	}
 }
 
+// isRefreshedByCloneSchedule returns true if the volume is deleted by a clone schedule that is refreshing it. The
+// label alone is not enough, it may be left from an earlier refresh or a clone schedule that no longer exists.
+func (c *VolumeController) isRefreshedByCloneSchedule(volume *longhorn.Volume) (bool, error) {
+	cloneScheduleName, ok := volume.Labels[types.GetLonghornLabelKey(types.LonghornLabelCloneSchedule)]
+	if !ok {
+		return false, nil
+	}
+
+	cloneSchedule, err := c.ds.GetCloneScheduleRO(cloneScheduleName)
+	if err != nil {
+		if datastore.ErrorIsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return cloneSchedule.DeletionTimestamp == nil &&
+		cloneSchedule.Spec.TargetVolume == volume.Name &&
+		cloneSchedule.Status.State == longhorn.CloneScheduleStateRefreshing, nil
+}
+
+// purgeExpiredTrashedVolume deletes the volume once it has stayed in the recycle bin longer than the retention period.
+// Otherwise, the volume is requeued to be checked again when the retention period expires.
+func (c *VolumeController) purgeExpiredTrashedVolume(v *longhorn.Volume) (bool, error) {
+	if v.Spec.TrashedAt == "" {
+		return false, nil
+	}
+
+	retentionPeriod, err := c.ds.GetSettingAsInt(types.SettingNameVolumeRecycleBinRetentionPeriod)
+	if err != nil {
+		return false, err
+	}
+	trashedAt, err := util.ParseTime(v.Spec.TrashedAt)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse the time volume %v was moved to the recycle bin", v.Name)
+	}
+
+	if remaining := time.Until(trashedAt.Add(time.Duration(retentionPeriod) * time.Hour)); remaining > 0 {
+		c.enqueueVolumeAfter(v, remaining)
+		return false, nil
+	}
+
+	getLoggerForVolume(c.logger, v).Infof("Purging volume since it has been in the recycle bin since %v", v.Spec.TrashedAt)
+	if err := c.ds.DeleteVolume(v.Name); err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	c.eventRecorder.Eventf(v, corev1.EventTypeNormal, constant.EventReasonPurged, "volume %v has been purged from the recycle bin", v.Name)
+	return true, nil
+}
+
 // ReconcileBackupVolumeState is responsible for syncing the state of backup volumes to volume.status
 func (c *VolumeController) ReconcileBackupVolumeState(volume *longhorn.Volume) error {
 	log := getLoggerForVolume(c.logger, volume)
@@ -4856,6 +5192,12 @@ func (c *VolumeController) ReconcileBackupVolumeState(volume *longhorn.Volume) e
 	if err != nil && !apierrors.IsNotFound(err) {
 		return errors.Wrapf(err, "failed to get backup volume %s for backup target %v and volume %v", backupVolumeName, volume.Spec.BackupTargetName, volume.Name)
 	}
+	if !volume.Status.RestoreRequired && volume.Spec.BackupTargetName == types.DefaultBackupTargetName {
+		// The backups of the volume may be uploaded to the nearest backup target instead
+		if bv, err = c.getLatestBackupVolumeForZoneAwareSelection(backupVolumeName, bv); err != nil {
+			return err
+		}
+	}
 
 	// Clean up last backup if the BackupVolume CR gone
 	if bv == nil || !bv.DeletionTimestamp.IsZero() {
@@ -4869,6 +5211,42 @@ func (c *VolumeController) ReconcileBackupVolumeState(volume *longhorn.Volume) e
 	return nil
 }
 
+// getLatestBackupVolumeForZoneAwareSelection returns the backup volume with the latest backup among all backup targets
+// if the setting backup-target-zone-aware-selection is enabled. Otherwise, it returns the given backup volume.
+func (c *VolumeController) getLatestBackupVolumeForZoneAwareSelection(backupVolumeName string, bv *longhorn.BackupVolume) (*longhorn.BackupVolume, error) {
+	zoneAwareSelection, err := c.ds.GetSettingAsBool(types.SettingNameBackupTargetZoneAwareSelection)
+	if err != nil {
+		return nil, err
+	}
+	if !zoneAwareSelection {
+		return bv, nil
+	}
+
+	bvs, err := c.ds.ListBackupVolumesWithVolumeNameRO(backupVolumeName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list backup volumes of volume %v", backupVolumeName)
+	}
+	latest := bv
+	var latestBackupAt time.Time
+	if latest != nil && latest.DeletionTimestamp.IsZero() {
+		latestBackupAt, _ = util.ParseTime(latest.Status.LastBackupAt)
+	}
+	for _, candidate := range bvs {
+		if !candidate.DeletionTimestamp.IsZero() || candidate.Status.LastBackupName == "" {
+			continue
+		}
+		backupAt, err := util.ParseTime(candidate.Status.LastBackupAt)
+		if err != nil {
+			continue
+		}
+		if latest == nil || !latest.DeletionTimestamp.IsZero() || backupAt.After(latestBackupAt) {
+			latest = candidate
+			latestBackupAt = backupAt
+		}
+	}
+	return latest, nil
+}
+
 // TODO: this block of code is duplicated of CreateSnapshot in MANAGER package.
 // Once we have Snapshot CR, we should refactor this
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"time"
 
@@ -88,6 +89,13 @@ type VolumeTestCase struct {
 	volumeAutoSalvage                           string
 	replicaReplenishmentWaitInterval            string
 	allowVolumeCreationWithDegradedAvailability string
+	volumeRecycleBinRetentionPeriod             string
+
+	cloneSchedule *longhorn.CloneSchedule
+	pv            *corev1.PersistentVolume
+	pvc           *corev1.PersistentVolumeClaim
+	// expectPVAndPVCKept is checked only if the PV and PVC are set
+	expectPVAndPVCKept bool
 }
 
 func (s *TestSuite) TestVolumeLifeCycle(c *C) {
@@ -188,6 +196,64 @@ func (s *TestSuite) TestVolumeLifeCycle(c *C) {
 	tc.expectVolume.Status.CurrentImage = tc.volume.Spec.Image
 	testCases["volume detached"] = tc
 
+	// replicas placed on the nodes without the selected tag are reported
+	tc = generateVolumeTestCaseTemplate()
+	tc.volume.Spec.NodeSelector = []string{"ssd"}
+	tc.volume.Status.State = longhorn.VolumeStateDetaching
+	for _, e := range tc.engines {
+		e.Status.CurrentState = longhorn.InstanceStateStopped
+	}
+	for _, r := range tc.replicas {
+		r.Status.CurrentState = longhorn.InstanceStateStopped
+	}
+	tc.copyCurrentToExpect()
+	tc.expectVolume.Status.Conditions = setVolumeConditionWithoutTimestamp(tc.volume.Status.Conditions,
+		longhorn.VolumeConditionTypeRestore, longhorn.ConditionStatusFalse, "", "")
+	tc.expectVolume.Status.State = longhorn.VolumeStateDetached
+	tc.expectVolume.Status.Robustness = longhorn.VolumeRobustnessUnknown
+	tc.expectVolume.Status.CurrentImage = tc.volume.Spec.Image
+	for name := range tc.replicas {
+		tc.expectVolume.Status.SelectorViolatingReplicas = append(tc.expectVolume.Status.SelectorViolatingReplicas, name)
+	}
+	sort.Strings(tc.expectVolume.Status.SelectorViolatingReplicas)
+	testCases["volume detached - replicas violating the node selector"] = tc
+
+	// detached volume moved to the recycle bin
+	tc = generateVolumeTestCaseTemplate()
+	tc.volume.Spec.TrashedAt = util.Now()
+	tc.volume.Status.State = longhorn.VolumeStateDetached
+	for _, e := range tc.engines {
+		e.Status.CurrentState = longhorn.InstanceStateStopped
+	}
+	for _, r := range tc.replicas {
+		r.Status.CurrentState = longhorn.InstanceStateStopped
+	}
+	tc.volumeRecycleBinRetentionPeriod = "24"
+	tc.copyCurrentToExpect()
+	tc.expectVolume.Status.Conditions = setVolumeConditionWithoutTimestamp(tc.volume.Status.Conditions,
+		longhorn.VolumeConditionTypeRestore, longhorn.ConditionStatusFalse, "", "")
+	tc.expectVolume.Status.State = longhorn.VolumeStateTrashed
+	tc.expectVolume.Status.Robustness = longhorn.VolumeRobustnessUnknown
+	tc.expectVolume.Status.CurrentImage = tc.volume.Spec.Image
+	testCases["volume trashed"] = tc
+
+	// trashed volume restored from the recycle bin
+	tc = generateVolumeTestCaseTemplate()
+	tc.volume.Status.State = longhorn.VolumeStateTrashed
+	for _, e := range tc.engines {
+		e.Status.CurrentState = longhorn.InstanceStateStopped
+	}
+	for _, r := range tc.replicas {
+		r.Status.CurrentState = longhorn.InstanceStateStopped
+	}
+	tc.copyCurrentToExpect()
+	tc.expectVolume.Status.Conditions = setVolumeConditionWithoutTimestamp(tc.volume.Status.Conditions,
+		longhorn.VolumeConditionTypeRestore, longhorn.ConditionStatusFalse, "", "")
+	tc.expectVolume.Status.State = longhorn.VolumeStateDetached
+	tc.expectVolume.Status.Robustness = longhorn.VolumeRobustnessUnknown
+	tc.expectVolume.Status.CurrentImage = tc.volume.Spec.Image
+	testCases["volume restored from recycle bin"] = tc
+
 	// volume attaching, start replicas
 	tc = generateVolumeTestCaseTemplate()
 	tc.volume.Spec.NodeID = TestNode1
@@ -854,6 +920,45 @@ func (s *TestSuite) TestVolumeLifeCycle(c *C) {
 	tc.expectReplicas = nil
 	testCases["volume deleting"] = tc
 
+	// volume deleting, previously refreshed by a clone schedule that has finished the refresh
+	tc = generateVolumeTestCaseTemplate()
+	tc.volume.SetDeletionTimestamp(&now)
+	tc.volume.Status.Conditions = []longhorn.Condition{}
+	tc.volume.Labels = map[string]string{types.GetLonghornLabelKey(types.LonghornLabelCloneSchedule): TestCloneScheduleName}
+	tc.volume.Status.KubernetesStatus = longhorn.KubernetesStatus{
+		PVName:    TestPVName,
+		PVCName:   TestPVCName,
+		Namespace: TestNamespace,
+	}
+	tc.cloneSchedule = newCloneScheduleForVolume(tc.volume.Name, longhorn.CloneScheduleStateIdle)
+	tc.pv = newPV()
+	tc.pvc = newPVC()
+	tc.copyCurrentToExpect()
+	tc.expectVolume.Status.State = longhorn.VolumeStateDeleting
+	tc.expectEngines = nil
+	tc.expectReplicas = nil
+	testCases["volume deleting - previously refreshed by clone schedule"] = tc
+
+	// volume deleting for a clone schedule refresh
+	tc = generateVolumeTestCaseTemplate()
+	tc.volume.SetDeletionTimestamp(&now)
+	tc.volume.Status.Conditions = []longhorn.Condition{}
+	tc.volume.Labels = map[string]string{types.GetLonghornLabelKey(types.LonghornLabelCloneSchedule): TestCloneScheduleName}
+	tc.volume.Status.KubernetesStatus = longhorn.KubernetesStatus{
+		PVName:    TestPVName,
+		PVCName:   TestPVCName,
+		Namespace: TestNamespace,
+	}
+	tc.cloneSchedule = newCloneScheduleForVolume(tc.volume.Name, longhorn.CloneScheduleStateRefreshing)
+	tc.pv = newPV()
+	tc.pvc = newPVC()
+	tc.expectPVAndPVCKept = true
+	tc.copyCurrentToExpect()
+	tc.expectVolume.Status.State = longhorn.VolumeStateDeleting
+	tc.expectEngines = nil
+	tc.expectReplicas = nil
+	testCases["volume deleting - refreshed by clone schedule"] = tc
+
 	// volume attaching, start replicas, one node down
 	tc = generateVolumeTestCaseTemplate()
 	tc.volume.Spec.NodeID = TestNode1
@@ -1122,6 +1227,21 @@ func (s *TestSuite) TestVolumeLifeCycle(c *C) {
 	s.runTestCases(c, testCases)
 }
 
+func newCloneScheduleForVolume(volumeName string, state longhorn.CloneScheduleState) *longhorn.CloneSchedule {
+	return &longhorn.CloneSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TestCloneScheduleName,
+			Namespace: TestNamespace,
+		},
+		Spec: longhorn.CloneScheduleSpec{
+			TargetVolume: volumeName,
+		},
+		Status: longhorn.CloneScheduleStatus{
+			State: state,
+		},
+	}
+}
+
 func newVolume(name string, replicaCount int) *longhorn.Volume {
 	return &longhorn.Volume{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1438,6 +1558,16 @@ func (s *TestSuite) runTestCases(c *C, testCases map[string]*VolumeTestCase) {
 			err = sIndexer.Add(setting)
 			c.Assert(err, IsNil)
 		}
+		// Set volume recycle bin retention period
+		if tc.volumeRecycleBinRetentionPeriod != "" {
+			s := initSettingsNameValue(
+				string(types.SettingNameVolumeRecycleBinRetentionPeriod), tc.volumeRecycleBinRetentionPeriod)
+			setting, err :=
+				lhClient.LonghornV1beta2().Settings(TestNamespace).Create(context.TODO(), s, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			err = sIndexer.Add(setting)
+			c.Assert(err, IsNil)
+		}
 		// Set New Replica Replenishment Wait Interval
 		if tc.replicaReplenishmentWaitInterval != "" {
 			s := initSettingsNameValue(
@@ -1510,9 +1640,34 @@ func (s *TestSuite) runTestCases(c *C, testCases map[string]*VolumeTestCase) {
 			}
 		}
 
+		if tc.cloneSchedule != nil {
+			cs, err := lhClient.LonghornV1beta2().CloneSchedules(TestNamespace).Create(context.TODO(), tc.cloneSchedule, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			csIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().CloneSchedules().Informer().GetIndexer()
+			err = csIndexer.Add(cs)
+			c.Assert(err, IsNil)
+		}
+		if tc.pv != nil {
+			_, err = kubeClient.CoreV1().PersistentVolumes().Create(context.TODO(), tc.pv, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+		}
+		if tc.pvc != nil {
+			_, err = kubeClient.CoreV1().PersistentVolumeClaims(TestNamespace).Create(context.TODO(), tc.pvc, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+		}
+
 		err = vc.syncVolume(getKey(v, c))
 		c.Assert(err, IsNil)
 
+		if tc.pv != nil {
+			_, err = kubeClient.CoreV1().PersistentVolumes().Get(context.TODO(), tc.pv.Name, metav1.GetOptions{})
+			c.Assert(err == nil, Equals, tc.expectPVAndPVCKept, Commentf("PV error: %v", err))
+		}
+		if tc.pvc != nil {
+			_, err = kubeClient.CoreV1().PersistentVolumeClaims(TestNamespace).Get(context.TODO(), tc.pvc.Name, metav1.GetOptions{})
+			c.Assert(err == nil, Equals, tc.expectPVAndPVCKept, Commentf("PVC error: %v", err))
+		}
+
 		retV, err := lhClient.LonghornV1beta2().Volumes(TestNamespace).Get(context.TODO(), v.Name, metav1.GetOptions{})
 		c.Assert(err, IsNil)
 		c.Assert(retV.Spec, DeepEquals, tc.expectVolume.Spec)
@@ -1571,3 +1726,145 @@ func (s *TestSuite) runTestCases(c *C, testCases map[string]*VolumeTestCase) {
 		}
 	}
 }
+
+func (s *TestSuite) TestVolumeRebuildQueue(c *C) {
+	datastore.SkipListerCheck = true
+
+	type queueVolume struct {
+		healthyReplicas int
+		// rebuildingReplicas are scheduled and reported by the engine in WO mode
+		rebuildingReplicas int
+		// pendingReplicas are scheduled but not added to the engine yet
+		pendingReplicas int
+		// unscheduledReplicas failed to be scheduled
+		unscheduledReplicas int
+		state               longhorn.VolumeState
+		priority            string
+		size                int64
+	}
+	volumes := map[string]queueVolume{
+		// A single remaining replica goes first regardless of the priority label
+		"single-replica": {healthyReplicas: 1, state: longhorn.VolumeStateAttached, size: 2 * TestVolumeSize},
+		"high-priority":  {healthyReplicas: 2, state: longhorn.VolumeStateAttached, priority: "10", size: 2 * TestVolumeSize},
+		"small":          {healthyReplicas: 2, state: longhorn.VolumeStateAttached, size: TestVolumeSize},
+		"large":          {healthyReplicas: 2, state: longhorn.VolumeStateAttached, size: 2 * TestVolumeSize},
+		"rebuilding":     {healthyReplicas: 2, rebuildingReplicas: 1, state: longhorn.VolumeStateAttached, size: TestVolumeSize},
+		// A replica not added to the engine yet does not hold a place of the rebuild limit
+		"pending": {healthyReplicas: 2, pendingReplicas: 1, state: longhorn.VolumeStateAttached, size: 3 * TestVolumeSize},
+		// A volume that cannot schedule its replica does not wait in the queue
+		"unscheduled": {healthyReplicas: 2, unscheduledReplicas: 1, state: longhorn.VolumeStateAttached, size: TestVolumeSize},
+		"detached":    {healthyReplicas: 1, state: longhorn.VolumeStateDetached, size: TestVolumeSize},
+		"healthy":     {healthyReplicas: 3, state: longhorn.VolumeStateAttached, size: TestVolumeSize},
+	}
+
+	kubeClient := fake.NewSimpleClientset()
+	lhClient := lhfake.NewSimpleClientset()
+	extensionsClient := apiextensionsfake.NewSimpleClientset()
+
+	informerFactories := util.NewInformerFactories(TestNamespace, kubeClient, lhClient, controller.NoResyncPeriodFunc())
+	vIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Volumes().Informer().GetIndexer()
+	eIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Engines().Informer().GetIndexer()
+	rIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Replicas().Informer().GetIndexer()
+	sIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Settings().Informer().GetIndexer()
+
+	vc, err := newTestVolumeController(lhClient, kubeClient, extensionsClient, informerFactories, TestOwnerID1)
+	c.Assert(err, IsNil)
+
+	for name, qv := range volumes {
+		v := newVolume(name, 3)
+		v.Namespace = TestNamespace
+		v.Spec.Size = qv.size
+		v.Status.State = qv.state
+		v.Status.Robustness = longhorn.VolumeRobustnessDegraded
+		if qv.healthyReplicas == v.Spec.NumberOfReplicas {
+			v.Status.Robustness = longhorn.VolumeRobustnessHealthy
+		}
+		if qv.priority != "" {
+			v.Labels = map[string]string{types.GetLonghornLabelKey(types.LonghornLabelRebuildPriority): qv.priority}
+		}
+		if qv.unscheduledReplicas > 0 {
+			v.Status.Conditions = types.SetCondition(v.Status.Conditions, longhorn.VolumeConditionTypeScheduled,
+				longhorn.ConditionStatusFalse, longhorn.VolumeConditionReasonReplicaSchedulingFailure, "")
+		}
+		err = vIndexer.Add(v)
+		c.Assert(err, IsNil)
+
+		e := newEngineForVolume(v)
+		e.Status.ReplicaModeMap = map[string]longhorn.ReplicaMode{}
+		for i := 0; i < qv.healthyReplicas+qv.rebuildingReplicas+qv.pendingReplicas+qv.unscheduledReplicas; i++ {
+			r := newReplicaForVolume(v, e, TestNode1, TestDiskID1)
+			r.Namespace = TestNamespace
+			switch {
+			case i < qv.healthyReplicas:
+				r.Spec.HealthyAt = getTestNow()
+				e.Status.ReplicaModeMap[r.Name] = longhorn.ReplicaModeRW
+			case i < qv.healthyReplicas+qv.rebuildingReplicas:
+				e.Status.ReplicaModeMap[r.Name] = longhorn.ReplicaModeWO
+			case i >= qv.healthyReplicas+qv.rebuildingReplicas+qv.pendingReplicas:
+				r.Spec.NodeID = ""
+			}
+			err = rIndexer.Add(r)
+			c.Assert(err, IsNil)
+		}
+		err = eIndexer.Add(e)
+		c.Assert(err, IsNil)
+	}
+
+	queue, err := vc.ds.ListRebuildQueueRO()
+	c.Assert(err, IsNil)
+	queuedVolumes := []string{}
+	for _, entry := range queue {
+		queuedVolumes = append(queuedVolumes, entry.VolumeName)
+	}
+	c.Assert(queuedVolumes, DeepEquals, []string{"rebuilding", "single-replica", "high-priority", "small", "large", "pending"})
+
+	limitSetting := initSettingsNameValue(string(types.SettingNameConcurrentVolumeRebuildLimit), "0")
+	limitSetting.Namespace = TestNamespace
+	err = sIndexer.Add(limitSetting)
+	c.Assert(err, IsNil)
+
+	for limit, expectedAllowed := range map[string][]string{
+		"0": {"single-replica", "high-priority", "small", "large", "pending", "healthy"},
+		"1": {},
+		"3": {"single-replica", "high-priority"},
+		"5": {"single-replica", "high-priority", "small", "large"},
+		"6": {"single-replica", "high-priority", "small", "large", "pending"},
+		"7": {"single-replica", "high-priority", "small", "large", "pending", "healthy"},
+	} {
+		limitSetting = limitSetting.DeepCopy()
+		limitSetting.Value = limit
+		err = sIndexer.Update(limitSetting)
+		c.Assert(err, IsNil)
+
+		allowed := []string{}
+		queue := newRebuildQueue(vc.ds)
+		for _, name := range []string{"single-replica", "high-priority", "small", "large", "pending", "healthy"} {
+			v, err := vc.ds.GetVolumeRO(name)
+			c.Assert(err, IsNil)
+			queued, err := vc.isQueuedForRebuild(v, queue)
+			c.Assert(err, IsNil)
+			if !queued {
+				allowed = append(allowed, name)
+			}
+		}
+		c.Assert(allowed, DeepEquals, expectedAllowed, Commentf("limit %v", limit))
+	}
+
+	// The queue is listed once per volume sync
+	syncQueue := newRebuildQueue(vc.ds)
+	entries, err := syncQueue.list()
+	c.Assert(err, IsNil)
+	c.Assert(entries, HasLen, 6)
+	v, err := vc.ds.GetVolumeRO("healthy")
+	c.Assert(err, IsNil)
+	v = v.DeepCopy()
+	v.Status.Robustness = longhorn.VolumeRobustnessDegraded
+	err = vIndexer.Update(v)
+	c.Assert(err, IsNil)
+	entries, err = syncQueue.list()
+	c.Assert(err, IsNil)
+	c.Assert(entries, HasLen, 6)
+	entries, err = newRebuildQueue(vc.ds).list()
+	c.Assert(err, IsNil)
+	c.Assert(entries, HasLen, 7)
+}
@@ -207,7 +207,8 @@ func (vec *VolumeEvictionController) reconcile(volName string) (err error) {
 
 	if hasReplicaEvictionRequested(replicas) {
 		if vec.hasDiskCandidateForReplicaEviction(replicas, vol) {
-			createOrUpdateAttachmentTicket(va, evictingAttachmentTicketID, vol.Status.OwnerID, longhorn.AnyValue, longhorn.AttacherTypeVolumeEvictionController)
+			createOrUpdateAttachmentTicket(va, evictingAttachmentTicketID, vol.Status.OwnerID, longhorn.AnyValue, longhorn.AttacherTypeVolumeEvictionController,
+				getAttacherID(attacherKindVolume, volName, nil))
 		}
 	} else {
 		delete(va.Spec.AttachmentTickets, evictingAttachmentTicketID)
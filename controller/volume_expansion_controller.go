@@ -194,7 +194,8 @@ func (vec *VolumeExpansionController) reconcile(volName string) (err error) {
 	expandingAttachmentTicketID := longhorn.GetAttachmentTicketID(longhorn.AttacherTypeVolumeExpansionController, volName)
 
 	if vol.Status.ExpansionRequired {
-		createOrUpdateAttachmentTicket(va, expandingAttachmentTicketID, vol.Status.OwnerID, longhorn.FalseValue, longhorn.AttacherTypeVolumeExpansionController)
+		createOrUpdateAttachmentTicket(va, expandingAttachmentTicketID, vol.Status.OwnerID, longhorn.FalseValue, longhorn.AttacherTypeVolumeExpansionController,
+			getAttacherID(attacherKindVolume, volName, nil))
 	} else {
 		delete(va.Spec.AttachmentTickets, expandingAttachmentTicketID)
 	}
@@ -365,7 +365,8 @@ func (vbc *VolumeRebuildingController) syncLHVolumeAttachmentForOfflineRebuild(v
 		if va.Spec.AttachmentTickets == nil {
 			va.Spec.AttachmentTickets = map[string]*longhorn.AttachmentTicket{}
 		}
-		createOrUpdateAttachmentTicket(va, attachmentID, vol.Status.OwnerID, longhorn.AnyValue, longhorn.AttacherTypeVolumeRebuildingController)
+		createOrUpdateAttachmentTicket(va, attachmentID, vol.Status.OwnerID, longhorn.AnyValue, longhorn.AttacherTypeVolumeRebuildingController,
+			getAttacherID(attacherKindVolume, vol.Name, nil))
 	}
 	return va, nil
 }
@@ -194,7 +194,12 @@ func (vrsc *VolumeRestoreController) reconcile(volName string) (err error) {
 	restoringAttachmentTicketID := longhorn.GetAttachmentTicketID(longhorn.AttacherTypeVolumeRestoreController, volName)
 
 	if vol.Status.RestoreRequired {
-		createOrUpdateAttachmentTicket(va, restoringAttachmentTicketID, vol.Status.OwnerID, longhorn.TrueValue, longhorn.AttacherTypeVolumeRestoreController)
+		attacherKind := attacherKindVolume
+		if vol.Spec.Standby {
+			attacherKind = attacherKindDRVolume
+		}
+		createOrUpdateAttachmentTicket(va, restoringAttachmentTicketID, vol.Status.OwnerID, longhorn.TrueValue, longhorn.AttacherTypeVolumeRestoreController,
+			getAttacherID(attacherKind, volName, nil))
 	} else {
 		delete(va.Spec.AttachmentTickets, restoringAttachmentTicketID)
 	}
@@ -64,6 +64,7 @@ func NewControllerServer(apiClient *longhornclient.RancherClient, nodeID string)
 				csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME,
 				csi.ControllerServiceCapability_RPC_EXPAND_VOLUME,
 				csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT,
+				csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS,
 				csi.ControllerServiceCapability_RPC_CLONE_VOLUME,
 			}),
 		accessModes: getVolumeCapabilityAccessModes(
@@ -126,26 +127,22 @@ func (cs *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVol
 					if id == "" {
 						return nil, status.Errorf(codes.NotFound, "volume source snapshot %v is not found", snapshot.SnapshotId)
 					}
-					backupName := id
-					bvs, err := cs.getBackupVolumes(sourceVolumeName)
+					backup, err := cs.getBackupByName(sourceVolumeName, id)
 					if err != nil {
-						return nil, status.Errorf(codes.Internal, "failed to retrieve backup volumes of volume %v: %v", sourceVolumeName, err)
+						return nil, status.Errorf(codes.Internal, "failed to restore CSI snapshot %v: %v", snapshot.SnapshotId, err)
 					}
-					if len(bvs) == 0 {
-						return nil, status.Errorf(codes.NotFound, "failed to restore CSI snapshot %v of volume %v: there is no backup volume", snapshot.SnapshotId, sourceVolumeName)
+					if backup == nil {
+						return nil, status.Errorf(codes.NotFound, "failed to restore CSI snapshot %v backup %s unavailable", snapshot.SnapshotId, id)
 					}
-					var backup *longhornclient.Backup
-					for _, bv := range bvs {
-						backup, err = cs.apiClient.BackupVolume.ActionBackupGet(bv, &longhornclient.BackupInput{Name: backupName})
-						if err != nil {
-							return nil, status.Errorf(codes.NotFound, "failed to restore CSI snapshot %v : failed to get backup %v: %v", snapshot.SnapshotId, backupName, err)
-						}
-						if backup != nil {
-							break
-						}
+					if backup.State != string(longhorn.BackupStateCompleted) {
+						return nil, status.Errorf(codes.Unavailable, "failed to restore CSI snapshot %v: backup %v is in state %v", snapshot.SnapshotId, id, backup.State)
 					}
-					if backup == nil {
-						return nil, status.Errorf(codes.NotFound, "failed to restore CSI snapshot %v backup %s unavailable", snapshot.SnapshotId, backupName)
+					backupSize, err := util.ConvertSize(backup.VolumeSize)
+					if err != nil {
+						return nil, status.Errorf(codes.Internal, "failed to parse size %v of backup %v: %v", backup.VolumeSize, id, err)
+					}
+					if util.RoundUpSize(backupSize) > reqVolSizeBytes {
+						return nil, status.Errorf(codes.OutOfRange, "requested capacity %v is smaller than the size %v of backup %v", reqVolSizeBytes, backupSize, id)
 					}
 
 					// use the fromBackup method for the csi snapshot restores as well
@@ -294,6 +291,29 @@ func (cs *ControllerServer) getBackupVolumes(volumeName string) ([]*longhornclie
 	return bvs, nil
 }
 
+// getBackupByName returns the backup backupName belonging to one of the backup volumes of volume volumeName. This
+// works for backups that are only present in the backup store, e.g. backups referenced by a pre-provisioned
+// VolumeSnapshotContent, as long as the backup target has been synced.
+func (cs *ControllerServer) getBackupByName(volumeName, backupName string) (*longhornclient.Backup, error) {
+	bvs, err := cs.getBackupVolumes(volumeName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve backup volumes of volume %v", volumeName)
+	}
+	for _, bv := range bvs {
+		backup, err := cs.apiClient.BackupVolume.ActionBackupGet(bv, &longhornclient.BackupInput{Name: backupName})
+		if err != nil {
+			if longhornclient.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get backup %v", backupName)
+		}
+		if backup != nil && backup.Name != "" {
+			return backup, nil
+		}
+	}
+	return nil, nil
+}
+
 func (cs *ControllerServer) checkAndPrepareBackingImage(volumeName, backingImageName string, volumeParameters map[string]string, dataEngine string) error {
 	if backingImageName == "" {
 		return nil
@@ -396,8 +416,9 @@ func (cs *ControllerServer) DeleteVolume(ctx context.Context, req *csi.DeleteVol
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	// With the recycle bin enabled, the volume is kept in the trashed state instead of being deleted
 	checkVolumeDeleted := func(vol *longhornclient.Volume) bool {
-		return vol == nil
+		return vol == nil || vol.TrashedAt != ""
 	}
 	if !cs.waitForVolumeState(req.GetVolumeId(), "volume deleted", checkVolumeDeleted, false, true) {
 		return nil, status.Errorf(codes.DeadlineExceeded, "failed to delete volume %s", volumeID)
@@ -536,6 +557,7 @@ func (cs *ControllerServer) publishVolume(volume *longhornclient.Volume, nodeID,
 		HostId:          nodeID,
 		DisableFrontend: false,
 		AttacherType:    string(longhorn.AttacherTypeCSIAttacher),
+		AttacherID:      getCSIAttacherID(volume),
 		AttachmentID:    attachmentID,
 	}
 
@@ -574,6 +596,16 @@ func (cs *ControllerServer) updateVolumeAccessMode(volume *longhornclient.Volume
 	return volume, nil
 }
 
+// getCSIAttacherID returns the identity of the CSI attachment requester in the
+// form of persistentvolume/<name>. It is left empty if the volume is not bound
+// to a PV yet.
+func getCSIAttacherID(volume *longhornclient.Volume) string {
+	if volume.KubernetesStatus.PvName == "" {
+		return ""
+	}
+	return fmt.Sprintf("persistentvolume/%v", volume.KubernetesStatus.PvName)
+}
+
 // ControllerUnpublishVolume will detach the volume
 func (cs *ControllerServer) ControllerUnpublishVolume(ctx context.Context, req *csi.ControllerUnpublishVolumeRequest) (*csi.ControllerUnpublishVolumeResponse, error) {
 	log := cs.log.WithFields(logrus.Fields{"function": "ControllerUnpublishVolume"})
@@ -867,31 +899,23 @@ func (cs *ControllerServer) createCSISnapshotTypeLonghornBackup(req *csi.CreateS
 		}
 	}
 
-	// no existing backup and no local snapshot, create a new one
-	if snapshotCR == nil {
-		log.Infof("Creating Volume %s snapshot %s", existVol.Name, csiSnapshotName)
-		snapshotCR, err = cs.apiClient.Volume.ActionSnapshotCRCreate(existVol, &longhornclient.SnapshotCRInput{
-			Labels: csiLabels,
-			Name:   csiSnapshotName,
-		})
-		// failed to create snapshot, so there is no way to backup
+	// No existing backup and no local snapshot. The backup controller takes the snapshot between the backup hooks
+	// of the workload pods.
+	if snapshotCR != nil {
+		// wait for the snapshot creation to be fully finished
+		snapshotCR, err = cs.waitForSnapshotToBeReady(snapshotCR.Name, existVol.Name)
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 	}
 
-	// wait for the snapshot creation to be fully finished
-	snapshotCR, err = cs.waitForSnapshotToBeReady(snapshotCR.Name, existVol.Name)
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-
 	// create backup based on local volume snapshot
 	log.Infof("Creating volume %s backup for snapshot %s", existVol.Name, csiSnapshotName)
 	existVol, err = cs.apiClient.Volume.ActionSnapshotBackup(existVol, &longhornclient.SnapshotInput{
-		Labels:     csiLabels,
-		Name:       csiSnapshotName,
-		BackupMode: backupMode,
+		Labels:       csiLabels,
+		Name:         csiSnapshotName,
+		BackupMode:   backupMode,
+		TakeSnapshot: snapshotCR == nil,
 	})
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -909,7 +933,14 @@ func (cs *ControllerServer) createCSISnapshotTypeLonghornBackup(req *csi.CreateS
 
 	log.Infof("Volume %s backup %s of snapshot %s created", existVol.Name, backup.Id, csiSnapshotName)
 	snapshotID := encodeSnapshotID(csiSnapshotTypeLonghornBackup, existVol.Name, backup.Id)
-	rsp := createSnapshotResponseForSnapshotTypeLonghornBackup(existVol.Name, snapshotID, snapshotCR.CreationTime,
+	snapshotTime := backup.SnapshotCreated
+	if snapshotCR != nil {
+		snapshotTime = snapshotCR.CreationTime
+	} else if snapshotTime == "" {
+		// The snapshot is being taken by the backup controller
+		snapshotTime = util.Now()
+	}
+	rsp := createSnapshotResponseForSnapshotTypeLonghornBackup(existVol.Name, snapshotID, snapshotTime,
 		existVol.Size, backup.State == string(longhorn.BackupStateCompleted))
 	return rsp, nil
 }
@@ -1111,8 +1142,127 @@ func (cs *ControllerServer) cleanupBackup(sourceVolumeName, id string) error {
 	return nil
 }
 
-func (cs *ControllerServer) ListSnapshots(context.Context, *csi.ListSnapshotsRequest) (*csi.ListSnapshotsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+// ListSnapshots allows the snapshotter to check the readiness and restore size of pre-provisioned
+// VolumeSnapshotContents. Without a snapshot ID filter, only the backups are listed since they are the only CSI
+// snapshots that can exist outside the cluster.
+func (cs *ControllerServer) ListSnapshots(ctx context.Context, req *csi.ListSnapshotsRequest) (*csi.ListSnapshotsResponse, error) {
+	log := cs.log.WithFields(logrus.Fields{"function": "ListSnapshots"})
+
+	log.Debugf("ListSnapshots is called with req %+v", req)
+
+	if snapshotID := req.GetSnapshotId(); snapshotID != "" {
+		snapshot, err := cs.getCSISnapshot(snapshotID)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot == nil {
+			// Per the CSI spec, an unknown snapshot ID results in an empty list
+			return &csi.ListSnapshotsResponse{}, nil
+		}
+		return &csi.ListSnapshotsResponse{
+			Entries: []*csi.ListSnapshotsResponse_Entry{{Snapshot: snapshot}},
+		}, nil
+	}
+
+	bvList, err := cs.apiClient.BackupVolume.List(&longhornclient.ListOpts{})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	entries := []*csi.ListSnapshotsResponse_Entry{}
+	for _, bv := range bvList.Data {
+		if req.GetSourceVolumeId() != "" && bv.VolumeName != req.GetSourceVolumeId() {
+			continue
+		}
+		backupListOutput, err := cs.apiClient.BackupVolume.ActionBackupList(&bv)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		for _, b := range backupListOutput.Data {
+			entries = append(entries, &csi.ListSnapshotsResponse_Entry{Snapshot: toCSISnapshotForBackup(&b)})
+		}
+	}
+
+	start := 0
+	if token := req.GetStartingToken(); token != "" {
+		start, err = strconv.Atoi(token)
+		if err != nil || start < 0 || start > len(entries) {
+			return nil, status.Errorf(codes.Aborted, "invalid starting token %v", token)
+		}
+	}
+	end := len(entries)
+	if maxEntries := int(req.GetMaxEntries()); maxEntries > 0 && start+maxEntries < end {
+		end = start + maxEntries
+	}
+
+	rsp := &csi.ListSnapshotsResponse{Entries: entries[start:end]}
+	if end < len(entries) {
+		rsp.NextToken = strconv.Itoa(end)
+	}
+	return rsp, nil
+}
+
+// getCSISnapshot returns the CSI snapshot for snapshotID, or nil if the underlying Longhorn object doesn't exist
+func (cs *ControllerServer) getCSISnapshot(snapshotID string) (*csi.Snapshot, error) {
+	csiSnapshotType, sourceVolumeName, id := decodeSnapshotID(snapshotID)
+	switch csiSnapshotType {
+	case csiSnapshotTypeLonghornBackingImage:
+		backingImageParameters := decodeSnapshoBackingImageID(snapshotID)
+		backingImage, err := cs.apiClient.BackingImage.ById(backingImageParameters[longhorn.BackingImageParameterName])
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if backingImage == nil {
+			return nil, nil
+		}
+		return &csi.Snapshot{
+			SizeBytes:      backingImage.Size,
+			SnapshotId:     snapshotID,
+			SourceVolumeId: backingImageParameters[longhorn.DataSourceTypeExportParameterVolumeName],
+			ReadyToUse:     true,
+		}, nil
+	case csiSnapshotTypeLonghornSnapshot:
+		if id == "" {
+			return nil, nil
+		}
+		vol, err := cs.apiClient.Volume.ById(sourceVolumeName)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if vol == nil {
+			return nil, nil
+		}
+		snapshotCR, err := cs.apiClient.Volume.ActionSnapshotCRGet(vol, &longhornclient.SnapshotCRInput{Name: id})
+		if err != nil {
+			if longhornclient.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if snapshotCR == nil || snapshotCR.Name == "" {
+			return nil, nil
+		}
+		return createSnapshotResponseForSnapshotTypeLonghornSnapshot(sourceVolumeName, snapshotID, snapshotCR).Snapshot, nil
+	case csiSnapshotTypeLonghornBackup:
+		if id == "" {
+			return nil, nil
+		}
+		backup, err := cs.getBackupByName(sourceVolumeName, id)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if backup == nil {
+			return nil, nil
+		}
+		return toCSISnapshotForBackup(backup), nil
+	}
+	return nil, nil
+}
+
+func toCSISnapshotForBackup(backup *longhornclient.Backup) *csi.Snapshot {
+	snapshotID := encodeSnapshotID(csiSnapshotTypeLonghornBackup, backup.VolumeName, backup.Name)
+	return createSnapshotResponseForSnapshotTypeLonghornBackup(backup.VolumeName, snapshotID,
+		backup.SnapshotCreated, backup.VolumeSize, backup.State == string(longhorn.BackupStateCompleted)).Snapshot
 }
 
 func (cs *ControllerServer) ControllerExpandVolume(ctx context.Context, req *csi.ControllerExpandVolumeRequest) (*csi.ControllerExpandVolumeResponse, error) {
@@ -18,6 +18,7 @@ import (
 const (
 	mapperFilePathPrefix = "/dev/mapper"
 	mapperV2VolumeSuffix = "-encrypted"
+	mapperDiskPrefix     = "longhorn-disk-"
 
 	CryptoKeyDefaultCipher = "aes-xts-plain64"
 	CryptoKeyDefaultHash   = "sha256"
@@ -187,6 +188,55 @@ func ResizeEncryptoDevice(volume, dataEngine, passphrase string) error {
 	return err
 }
 
+// DiskMapper returns the path for mapped encrypted disk.
+func DiskMapper(diskName string) string {
+	return path.Join(mapperFilePathPrefix, getEncryptDiskName(diskName))
+}
+
+func getEncryptDiskName(diskName string) string {
+	return mapperDiskPrefix + diskName
+}
+
+// OpenDisk opens the encrypted disk so that it can be used by the disk service.
+// devicePath is the path of the block device on the host, for instance '/dev/sdb'
+func OpenDisk(diskName, devicePath, passphrase string) error {
+	if isOpen, _ := IsDeviceOpen(DiskMapper(diskName)); isOpen {
+		return nil
+	}
+
+	namespaces := []lhtypes.Namespace{lhtypes.NamespaceMnt, lhtypes.NamespaceIpc}
+	nsexec, err := lhns.NewNamespaceExecutor(lhtypes.ProcessNone, lhtypes.HostProcDirectory, namespaces)
+	if err != nil {
+		return err
+	}
+
+	encryptDiskName := getEncryptDiskName(diskName)
+	logrus.Infof("Opening disk device %s with LUKS on %s", devicePath, encryptDiskName)
+	_, err = nsexec.LuksOpen(encryptDiskName, devicePath, passphrase, lhtypes.LuksTimeout)
+	if err != nil {
+		logrus.WithError(err).Warnf("Failed to open LUKS disk device %s to %s", devicePath, encryptDiskName)
+	}
+	return err
+}
+
+// CloseDisk closes the encrypted disk after it is removed from the node.
+func CloseDisk(diskName string) error {
+	if isOpen, _ := IsDeviceOpen(DiskMapper(diskName)); !isOpen {
+		return nil
+	}
+
+	namespaces := []lhtypes.Namespace{lhtypes.NamespaceMnt, lhtypes.NamespaceIpc}
+	nsexec, err := lhns.NewNamespaceExecutor(lhtypes.ProcessNone, lhtypes.HostProcDirectory, namespaces)
+	if err != nil {
+		return err
+	}
+
+	encryptDiskName := getEncryptDiskName(diskName)
+	logrus.Infof("Closing LUKS disk device %s", encryptDiskName)
+	_, err = nsexec.LuksClose(encryptDiskName, lhtypes.LuksTimeout)
+	return err
+}
+
 // IsDeviceMappedToNullPath determines if encrypted device is already open at a null path. The command 'cryptsetup status [crypted_device]' show "device:  (null)"
 func IsDeviceMappedToNullPath(device string) (bool, error) {
 	devPath, mappedFile, err := DeviceEncryptionStatus(device)
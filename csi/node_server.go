@@ -498,6 +498,12 @@ func (ns *NodeServer) NodeStageVolume(ctx context.Context, req *csi.NodeStageVol
 	dataEngine := volume.DataEngine
 	log.Infof("Volume %v (%v) device %v contains filesystem of format %v", volumeID, dataEngine, devicePath, diskFormat)
 
+	// A blank device must never be formatted or encrypted if it is expected to have data already
+	requireExistingFilesystem := req.VolumeContext[types.OptionRequireExistingFilesystem] == "true"
+	if requireExistingFilesystem && diskFormat == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "volume %v device %v %v", volumeID, devicePath, types.NoExistingFilesystemMessage)
+	}
+
 	if volume.Encrypted {
 		secrets := req.GetSecrets()
 		keyProvider := secrets[types.CryptoKeyProvider]
@@ -560,6 +566,19 @@ func (ns *NodeServer) NodeStageVolume(ctx context.Context, req *csi.NodeStageVol
 	if fsType == "" {
 		fsType = defaultFsType
 	}
+	if requireExistingFilesystem {
+		existingFsType := diskFormat
+		if volume.Encrypted {
+			if existingFsType, err = getDiskFormat(devicePath); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to evaluate device filesystem %v format: %v", devicePath, err)
+			}
+		}
+		if existingFsType == "" {
+			return nil, status.Errorf(codes.FailedPrecondition, "volume %v device %v %v", volumeID, devicePath, types.NoExistingFilesystemMessage)
+		}
+		// Mount the existing filesystem as is, it must never be formatted
+		fsType = existingFsType
+	}
 	if fsType == "xfs" {
 		// By default, xfs does not allow mounting of two volumes with the same filesystem uuid.
 		// Force ignore this uuid to be able to mount volume + its clone / restored snapshot on the same node.
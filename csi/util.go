@@ -143,6 +143,14 @@ func getVolumeOptions(volumeID string, volOptions map[string]string) (*longhornc
 		vol.Encrypted = isEncrypted
 	}
 
+	if replicaSelectorRelocation, ok := volOptions["replicaSelectorRelocation"]; ok {
+		isReplicaSelectorRelocation, err := strconv.ParseBool(replicaSelectorRelocation)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid parameter replicaSelectorRelocation")
+		}
+		vol.ReplicaSelectorRelocation = isReplicaSelectorRelocation
+	}
+
 	if numberOfReplicas, ok := volOptions["numberOfReplicas"]; ok {
 		nor, err := strconv.Atoi(numberOfReplicas)
 		if err != nil || nor < 0 {
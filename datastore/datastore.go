@@ -16,6 +16,7 @@ import (
 	policylisters "k8s.io/client-go/listers/policy/v1"
 	schedulinglisters "k8s.io/client-go/listers/scheduling/v1"
 	storagelisters_v1 "k8s.io/client-go/listers/storage/v1"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
@@ -47,6 +48,9 @@ type DataStore struct {
 
 	cacheSyncs []cache.InformerSynced
 
+	statusUpdateCoalescer *statusUpdateCoalescer
+	podMemoryUsageCache   *podMemoryUsageCache
+
 	lhClient                       lhclientset.Interface
 	volumeLister                   lhlisters.VolumeLister
 	VolumeInformer                 cache.SharedInformer
@@ -82,6 +86,12 @@ type DataStore struct {
 	RecurringJobInformer           cache.SharedInformer
 	orphanLister                   lhlisters.OrphanLister
 	OrphanInformer                 cache.SharedInformer
+	cloneScheduleLister            lhlisters.CloneScheduleLister
+	CloneScheduleInformer          cache.SharedInformer
+	restoreTestLister              lhlisters.RestoreTestLister
+	RestoreTestInformer            cache.SharedInformer
+	replicaCountPolicyLister       lhlisters.ReplicaCountPolicyLister
+	ReplicaCountPolicyInformer     cache.SharedInformer
 	snapshotLister                 lhlisters.SnapshotLister
 	SnapshotInformer               cache.SharedInformer
 	supportBundleLister            lhlisters.SupportBundleLister
@@ -118,6 +128,8 @@ type DataStore struct {
 	PriorityClassInformer         cache.SharedInformer
 	csiDriverLister               storagelisters_v1.CSIDriverLister
 	CSIDriverInformer             cache.SharedInformer
+	csiNodeLister                 storagelisters_v1.CSINodeLister
+	CSINodeInformer               cache.SharedInformer
 	storageclassLister            storagelisters_v1.StorageClassLister
 	StorageClassInformer          cache.SharedInformer
 	podDisruptionBudgetLister     policylisters.PodDisruptionBudgetLister
@@ -130,6 +142,9 @@ type DataStore struct {
 	LeaseInformer                 cache.SharedInformer
 
 	extensionsClient apiextensionsclientset.Interface
+
+	// kubeMetricsClient is optional, it is nil if the metrics are not needed.
+	kubeMetricsClient metricsclientset.Interface
 }
 
 // NewDataStore creates new DataStore object
@@ -171,6 +186,12 @@ func NewDataStore(namespace string, lhClient lhclientset.Interface, kubeClient c
 	cacheSyncs = append(cacheSyncs, recurringJobInformer.Informer().HasSynced)
 	orphanInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Orphans()
 	cacheSyncs = append(cacheSyncs, orphanInformer.Informer().HasSynced)
+	cloneScheduleInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().CloneSchedules()
+	cacheSyncs = append(cacheSyncs, cloneScheduleInformer.Informer().HasSynced)
+	restoreTestInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().RestoreTests()
+	cacheSyncs = append(cacheSyncs, restoreTestInformer.Informer().HasSynced)
+	replicaCountPolicyInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().ReplicaCountPolicies()
+	cacheSyncs = append(cacheSyncs, replicaCountPolicyInformer.Informer().HasSynced)
 	snapshotInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Snapshots()
 	cacheSyncs = append(cacheSyncs, snapshotInformer.Informer().HasSynced)
 	supportBundleInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().SupportBundles()
@@ -195,6 +216,8 @@ func NewDataStore(namespace string, lhClient lhclientset.Interface, kubeClient c
 	cacheSyncs = append(cacheSyncs, volumeAttachmentInformer.Informer().HasSynced)
 	csiDriverInformer := informerFactories.KubeInformerFactory.Storage().V1().CSIDrivers()
 	cacheSyncs = append(cacheSyncs, csiDriverInformer.Informer().HasSynced)
+	csiNodeInformer := informerFactories.KubeInformerFactory.Storage().V1().CSINodes()
+	cacheSyncs = append(cacheSyncs, csiNodeInformer.Informer().HasSynced)
 	storageclassInformer := informerFactories.KubeInformerFactory.Storage().V1().StorageClasses()
 	cacheSyncs = append(cacheSyncs, storageclassInformer.Informer().HasSynced)
 	priorityClassInformer := informerFactories.KubeInformerFactory.Scheduling().V1().PriorityClasses()
@@ -220,11 +243,13 @@ func NewDataStore(namespace string, lhClient lhclientset.Interface, kubeClient c
 	deploymentInformer := informerFactories.KubeNamespaceFilteredInformerFactory.Apps().V1().Deployments()
 	cacheSyncs = append(cacheSyncs, deploymentInformer.Informer().HasSynced)
 
-	return &DataStore{
+	s := &DataStore{
 		namespace: namespace,
 
 		cacheSyncs: cacheSyncs,
 
+		statusUpdateCoalescer: newStatusUpdateCoalescer(),
+
 		lhClient:                       lhClient,
 		volumeLister:                   volumeInformer.Lister(),
 		VolumeInformer:                 volumeInformer.Informer(),
@@ -260,6 +285,12 @@ func NewDataStore(namespace string, lhClient lhclientset.Interface, kubeClient c
 		RecurringJobInformer:           recurringJobInformer.Informer(),
 		orphanLister:                   orphanInformer.Lister(),
 		OrphanInformer:                 orphanInformer.Informer(),
+		cloneScheduleLister:            cloneScheduleInformer.Lister(),
+		CloneScheduleInformer:          cloneScheduleInformer.Informer(),
+		restoreTestLister:              restoreTestInformer.Lister(),
+		RestoreTestInformer:            restoreTestInformer.Informer(),
+		replicaCountPolicyLister:       replicaCountPolicyInformer.Lister(),
+		ReplicaCountPolicyInformer:     replicaCountPolicyInformer.Informer(),
 		snapshotLister:                 snapshotInformer.Lister(),
 		SnapshotInformer:               snapshotInformer.Informer(),
 		supportBundleLister:            supportBundleInformer.Lister(),
@@ -284,6 +315,8 @@ func NewDataStore(namespace string, lhClient lhclientset.Interface, kubeClient c
 		KubeNodeInformer:              kubeNodeInformer.Informer(),
 		csiDriverLister:               csiDriverInformer.Lister(),
 		CSIDriverInformer:             csiDriverInformer.Informer(),
+		csiNodeLister:                 csiNodeInformer.Lister(),
+		CSINodeInformer:               csiNodeInformer.Informer(),
 		storageclassLister:            storageclassInformer.Lister(),
 		StorageClassInformer:          storageclassInformer.Informer(),
 		priorityClassLister:           priorityClassInformer.Lister(),
@@ -310,6 +343,13 @@ func NewDataStore(namespace string, lhClient lhclientset.Interface, kubeClient c
 
 		extensionsClient: extensionsClient,
 	}
+	s.podMemoryUsageCache = newPodMemoryUsageCache(s.fetchPodMemoryUsage)
+	return s
+}
+
+// SetKubeMetricsClient sets the client used to get the resource usage of the pods
+func (s *DataStore) SetKubeMetricsClient(kubeMetricsClient metricsclientset.Interface) {
+	s.kubeMetricsClient = kubeMetricsClient
 }
 
 // Sync returns WaitForCacheSync for Longhorn DataStore
@@ -0,0 +1,306 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+)
+
+const (
+	testEngineUpgradeNamespace       = "longhorn-system"
+	testEngineUpgradeVolumeName      = "test-volume"
+	testEngineUpgradeNodeName        = "test-node"
+	testEngineUpgradeInstanceManager = "instance-manager-test"
+)
+
+type engineUpgradeTestObjects struct {
+	volume   *longhorn.Volume
+	engine   *longhorn.Engine
+	replicas []*longhorn.Replica
+	settings map[types.SettingName]string
+	kubeNode *corev1.Node
+	imPod    *corev1.Pod
+	// imPodMemoryUsage is the memory usage reported by the metrics server, no metrics are reported if it is empty
+	imPodMemoryUsage string
+	// imPodMemoryUsageFetchCount is the number of times the memory usage is got from the metrics server
+	imPodMemoryUsageFetchCount int
+}
+
+func newEngineUpgradeTestObjects() *engineUpgradeTestObjects {
+	objs := &engineUpgradeTestObjects{
+		volume: &longhorn.Volume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testEngineUpgradeVolumeName,
+				Namespace: testEngineUpgradeNamespace,
+			},
+			Spec: longhorn.VolumeSpec{
+				NumberOfReplicas: 2,
+			},
+			Status: longhorn.VolumeStatus{
+				State: longhorn.VolumeStateAttached,
+			},
+		},
+		engine: &longhorn.Engine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testEngineUpgradeVolumeName + "-e-0",
+				Namespace: testEngineUpgradeNamespace,
+				Labels:    types.GetVolumeLabels(testEngineUpgradeVolumeName),
+			},
+			Spec: longhorn.EngineSpec{
+				InstanceSpec: longhorn.InstanceSpec{
+					NodeID:     testEngineUpgradeNodeName,
+					VolumeName: testEngineUpgradeVolumeName,
+				},
+				Active: true,
+			},
+			Status: longhorn.EngineStatus{
+				InstanceStatus: longhorn.InstanceStatus{
+					InstanceManagerName: testEngineUpgradeInstanceManager,
+				},
+				ReplicaModeMap: map[string]longhorn.ReplicaMode{},
+				Snapshots: map[string]*longhorn.SnapshotInfo{
+					"volume-head": {},
+				},
+			},
+		},
+		settings: map[types.SettingName]string{},
+		kubeNode: &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testEngineUpgradeNodeName,
+			},
+		},
+		imPod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testEngineUpgradeInstanceManager,
+				Namespace: testEngineUpgradeNamespace,
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "instance-manager",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+		},
+		imPodMemoryUsage: "256Mi",
+	}
+	for _, name := range []string{"r-1", "r-2"} {
+		objs.replicas = append(objs.replicas, &longhorn.Replica{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testEngineUpgradeVolumeName + "-" + name,
+				Namespace: testEngineUpgradeNamespace,
+				Labels:    types.GetVolumeLabels(testEngineUpgradeVolumeName),
+			},
+			Spec: longhorn.ReplicaSpec{
+				InstanceSpec: longhorn.InstanceSpec{
+					NodeID:     testEngineUpgradeNodeName,
+					VolumeName: testEngineUpgradeVolumeName,
+				},
+				Active:    true,
+				HealthyAt: "2015-01-02T00:00:00Z",
+			},
+			Status: longhorn.ReplicaStatus{
+				InstanceStatus: longhorn.InstanceStatus{
+					InstanceManagerName: testEngineUpgradeInstanceManager,
+				},
+			},
+		})
+		objs.engine.Status.ReplicaModeMap[testEngineUpgradeVolumeName+"-"+name] = longhorn.ReplicaModeRW
+	}
+	return objs
+}
+
+func newEngineUpgradeTestDataStore(t *testing.T, objs *engineUpgradeTestObjects) *DataStore {
+	kubeClient := fake.NewSimpleClientset()
+	lhClient := lhfake.NewSimpleClientset()
+	informerFactories := util.NewInformerFactories(testEngineUpgradeNamespace, kubeClient, lhClient, 0)
+	s := NewDataStore(testEngineUpgradeNamespace, lhClient, kubeClient, apiextensionsfake.NewSimpleClientset(), informerFactories)
+
+	lhInformers := informerFactories.LhInformerFactory.Longhorn().V1beta2()
+	assert.NoError(t, lhInformers.Volumes().Informer().GetIndexer().Add(objs.volume))
+	assert.NoError(t, lhInformers.Engines().Informer().GetIndexer().Add(objs.engine))
+	for _, r := range objs.replicas {
+		assert.NoError(t, lhInformers.Replicas().Informer().GetIndexer().Add(r))
+	}
+	for name, value := range objs.settings {
+		setting := &longhorn.Setting{
+			ObjectMeta: metav1.ObjectMeta{Name: string(name), Namespace: testEngineUpgradeNamespace},
+			Value:      value,
+		}
+		assert.NoError(t, lhInformers.Settings().Informer().GetIndexer().Add(setting))
+	}
+	assert.NoError(t, informerFactories.KubeInformerFactory.Core().V1().Nodes().Informer().GetIndexer().Add(objs.kubeNode))
+	if objs.imPod != nil {
+		assert.NoError(t, informerFactories.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Add(objs.imPod))
+	}
+
+	// Replace the metrics server, reporting no metrics if the usage is empty
+	s.podMemoryUsageCache.fetch = func(namespace, name string) (int64, error) {
+		objs.imPodMemoryUsageFetchCount++
+		if objs.imPodMemoryUsage == "" || name != testEngineUpgradeInstanceManager {
+			return 0, errors.New("pod metrics not found")
+		}
+		usage := resource.MustParse(objs.imPodMemoryUsage)
+		return usage.Value(), nil
+	}
+
+	return s
+}
+
+func TestCheckEngineUpgradePreconditions(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[string]struct {
+		modify          func(objs *engineUpgradeTestObjects)
+		expectedReasons []string
+	}{
+		"allChecksPassed": {
+			modify:          func(objs *engineUpgradeTestObjects) {},
+			expectedReasons: []string{},
+		},
+		"volumeDetached": {
+			modify: func(objs *engineUpgradeTestObjects) {
+				objs.volume.Status.State = longhorn.VolumeStateDetached
+				objs.engine.Status.ReplicaModeMap = map[string]longhorn.ReplicaMode{}
+			},
+			expectedReasons: nil,
+		},
+		"replicaFailed": {
+			modify: func(objs *engineUpgradeTestObjects) {
+				objs.replicas[1].Spec.FailedAt = "2015-01-02T00:00:00Z"
+				objs.engine.Status.ReplicaModeMap[objs.replicas[1].Name] = longhorn.ReplicaModeERR
+			},
+			expectedReasons: []string{longhorn.VolumeConditionReasonReplicaUnhealthy},
+		},
+		"replicaRebuilding": {
+			modify: func(objs *engineUpgradeTestObjects) {
+				objs.engine.Status.ReplicaModeMap[objs.replicas[1].Name] = longhorn.ReplicaModeWO
+			},
+			expectedReasons: []string{
+				longhorn.VolumeConditionReasonReplicaUnhealthy,
+				longhorn.VolumeConditionReasonRebuildInProgress,
+			},
+		},
+		"snapshotCountNotCheckedByDefault": {
+			modify: func(objs *engineUpgradeTestObjects) {
+				for _, name := range []string{"snap-1", "snap-2", "snap-3"} {
+					objs.engine.Status.Snapshots[name] = &longhorn.SnapshotInfo{}
+				}
+			},
+			expectedReasons: []string{},
+		},
+		"snapshotCountOverLimit": {
+			modify: func(objs *engineUpgradeTestObjects) {
+				objs.settings[types.SettingNameEngineUpgradeSnapshotCountLimit] = "2"
+				for _, name := range []string{"snap-1", "snap-2", "snap-3"} {
+					objs.engine.Status.Snapshots[name] = &longhorn.SnapshotInfo{}
+				}
+			},
+			expectedReasons: []string{longhorn.VolumeConditionReasonTooManySnapshots},
+		},
+		"snapshotCountWithinLimit": {
+			modify: func(objs *engineUpgradeTestObjects) {
+				objs.settings[types.SettingNameEngineUpgradeSnapshotCountLimit] = "3"
+				for _, name := range []string{"snap-1", "snap-2", "snap-3"} {
+					objs.engine.Status.Snapshots[name] = &longhorn.SnapshotInfo{}
+				}
+			},
+			expectedReasons: []string{},
+		},
+		"instanceManagerMemoryUsageOverLimit": {
+			modify: func(objs *engineUpgradeTestObjects) {
+				objs.imPodMemoryUsage = "900Mi"
+			},
+			expectedReasons: []string{longhorn.VolumeConditionReasonInsufficientMemory},
+		},
+		"instanceManagerWithoutMemoryLimit": {
+			modify: func(objs *engineUpgradeTestObjects) {
+				objs.imPod.Spec.Containers[0].Resources = corev1.ResourceRequirements{}
+				objs.imPodMemoryUsage = "900Mi"
+			},
+			expectedReasons: []string{},
+		},
+		"instanceManagerMetricsUnavailable": {
+			modify: func(objs *engineUpgradeTestObjects) {
+				objs.imPodMemoryUsage = ""
+			},
+			expectedReasons: []string{},
+		},
+		"nodeUnderMemoryPressure": {
+			modify: func(objs *engineUpgradeTestObjects) {
+				objs.kubeNode.Status.Conditions = []corev1.NodeCondition{
+					{
+						Type:   corev1.NodeMemoryPressure,
+						Status: corev1.ConditionTrue,
+					},
+				}
+			},
+			expectedReasons: []string{longhorn.VolumeConditionReasonInsufficientMemory},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			objs := newEngineUpgradeTestObjects()
+			tt.modify(objs)
+			s := newEngineUpgradeTestDataStore(t, objs)
+
+			failures, err := s.CheckEngineUpgradePreconditions(objs.volume)
+			assert.NoError(err)
+			if tt.expectedReasons == nil {
+				assert.Nil(failures)
+				return
+			}
+			reasons := []string{}
+			for _, failure := range failures {
+				reasons = append(reasons, failure.Reason)
+			}
+			assert.Equal(tt.expectedReasons, reasons)
+		})
+	}
+}
+
+func TestCheckEngineUpgradePreconditionsMemoryUsageCached(t *testing.T) {
+	assert := assert.New(t)
+
+	for name, usage := range map[string]string{"metricsAvailable": "256Mi", "metricsUnavailable": ""} {
+		t.Run(name, func(t *testing.T) {
+			objs := newEngineUpgradeTestObjects()
+			objs.imPodMemoryUsage = usage
+			s := newEngineUpgradeTestDataStore(t, objs)
+
+			// The volume engine and replicas share the instance manager, and the volume is checked in every sync
+			for i := 0; i < 3; i++ {
+				failures, err := s.CheckEngineUpgradePreconditions(objs.volume)
+				assert.NoError(err)
+				assert.Empty(failures)
+			}
+			assert.Equal(1, objs.imPodMemoryUsageFetchCount)
+
+			for _, u := range s.podMemoryUsageCache.usages {
+				u.fetchedAt = u.fetchedAt.Add(-PodMemoryUsageCacheTTL)
+			}
+			_, err := s.CheckEngineUpgradePreconditions(objs.volume)
+			assert.NoError(err)
+			assert.Equal(2, objs.imPodMemoryUsageFetchCount)
+		})
+	}
+}
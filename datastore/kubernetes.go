@@ -469,6 +469,41 @@ func (s *DataStore) GetPodRO(namespace, name string) (*corev1.Pod, error) {
 	return pod, err
 }
 
+// GetPodMemoryUsage returns the memory bytes used by all containers of the pod for the given name and namespace.
+// The usage may be up to PodMemoryUsageCacheTTL old.
+func (s *DataStore) GetPodMemoryUsage(namespace, name string) (int64, error) {
+	return s.podMemoryUsageCache.get(namespace, name)
+}
+
+func (s *DataStore) fetchPodMemoryUsage(namespace, name string) (int64, error) {
+	if s.kubeMetricsClient == nil {
+		return 0, errors.New("metrics client is not available")
+	}
+	podMetrics, err := s.kubeMetricsClient.MetricsV1beta1().PodMetricses(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+	var usage int64
+	for _, c := range podMetrics.Containers {
+		usage += c.Usage.Memory().Value()
+	}
+	return usage, nil
+}
+
+// GetPodMemoryLimit returns the sum of the memory limits of the pod containers. It returns 0 if any container has
+// no memory limit, since the pod memory is unlimited then.
+func GetPodMemoryLimit(pod *corev1.Pod) int64 {
+	var limit int64
+	for _, c := range pod.Spec.Containers {
+		containerLimit, ok := c.Resources.Limits[corev1.ResourceMemory]
+		if !ok || containerLimit.IsZero() {
+			return 0
+		}
+		limit += containerLimit.Value()
+	}
+	return limit
+}
+
 // GetPodContainerLog dumps the log of a container in a Pod object for the given name and namespace.
 // Be careful that this function will directly talk with the API server.
 func (s *DataStore) GetPodContainerLog(podName, containerName string) ([]byte, error) {
@@ -852,6 +887,18 @@ func (s *DataStore) ListVolumeAttachmentsRO() ([]*storagev1.VolumeAttachment, er
 	return s.volumeAttachmentLister.List(labels.Everything())
 }
 
+// GetCSINodeRO gets the CSINode of the Kubernetes node with the given name from the index
+// This function returns direct reference to the internal cache object and should not be mutated.
+// Consider using this function when you can guarantee read only access and don't want the overhead of deep copies
+func (s *DataStore) GetCSINodeRO(name string) (*storagev1.CSINode, error) {
+	return s.csiNodeLister.Get(name)
+}
+
+// DeleteVolumeAttachment deletes the Kubernetes VolumeAttachment with the given name
+func (s *DataStore) DeleteVolumeAttachment(name string) error {
+	return s.kubeClient.StorageV1().VolumeAttachments().Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
 // CreateConfigMap creates a ConfigMap resource
 func (s *DataStore) CreateConfigMap(configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
 	return s.kubeClient.CoreV1().ConfigMaps(s.namespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
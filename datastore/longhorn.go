@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -831,6 +832,27 @@ func (s *DataStore) GetVolumeSnapshotDataIntegrity(volumeName string) (longhorn.
 	return longhorn.SnapshotDataIntegrity(dataIntegrity), nil
 }
 
+// GetVolumeSnapshotDataIntegrityCronJob returns the cron job of the periodic
+// snapshot data integrity check for the volume. The volume spec takes
+// precedence over the global setting.
+func (s *DataStore) GetVolumeSnapshotDataIntegrityCronJob(volumeName string) (string, error) {
+	volume, err := s.GetVolumeRO(volumeName)
+	if err != nil {
+		return "", err
+	}
+
+	if volume.Spec.SnapshotDataIntegrityCronJob != "" {
+		return volume.Spec.SnapshotDataIntegrityCronJob, nil
+	}
+
+	cronJob, err := s.GetSettingValueExisted(types.SettingNameSnapshotDataIntegrityCronJob)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get %v setting", types.SettingNameSnapshotDataIntegrityCronJob)
+	}
+
+	return cronJob, nil
+}
+
 func (s *DataStore) GetEncryptionSecret(secretNamespace, secretName string) (map[string]string, error) {
 	secret, err := s.GetSecretRO(secretNamespace, secretName)
 	if err != nil {
@@ -943,6 +965,19 @@ func GetOwnerReferencesForRecurringJob(recurringJob *longhorn.RecurringJob) []me
 	}
 }
 
+// GetOwnerReferencesForRestoreTest returns a list contains single OwnerReference for the
+// given restoreTest object
+func GetOwnerReferencesForRestoreTest(restoreTest *longhorn.RestoreTest) []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion: longhorn.SchemeGroupVersion.String(),
+			Kind:       types.LonghornKindRestoreTest,
+			UID:        restoreTest.UID,
+			Name:       restoreTest.Name,
+		},
+	}
+}
+
 // GetOwnerReferencesForBackupTarget returns a list contains single OwnerReference for the
 // given backup target name
 func GetOwnerReferencesForBackupTarget(backupTarget *longhorn.BackupTarget) []metav1.OwnerReference {
@@ -1015,14 +1050,17 @@ func (s *DataStore) UpdateVolume(v *longhorn.Volume) (*longhorn.Volume, error) {
 
 // UpdateVolumeStatus updates Longhorn Volume status and verifies update
 func (s *DataStore) UpdateVolumeStatus(v *longhorn.Volume) (*longhorn.Volume, error) {
-	obj, err := s.lhClient.LonghornV1beta2().Volumes(s.namespace).UpdateStatus(context.TODO(), v, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, err
-	}
-	verifyUpdate(v.Name, obj, func(name string) (k8sruntime.Object, error) {
-		return s.GetVolumeRO(name)
-	})
-	return obj, nil
+	return updateStatus(s, types.LonghornKindVolume, v, func(v *longhorn.Volume) interface{} { return v.Status }, s.GetVolumeRO,
+		func(v *longhorn.Volume) (*longhorn.Volume, error) {
+			obj, err := s.lhClient.LonghornV1beta2().Volumes(s.namespace).UpdateStatus(context.TODO(), v, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			verifyUpdate(v.Name, obj, func(name string) (k8sruntime.Object, error) {
+				return s.GetVolumeRO(name)
+			})
+			return obj, nil
+		})
 }
 
 // DeleteVolume won't result in immediately deletion since finalizer was set by
@@ -1457,14 +1495,17 @@ func (s *DataStore) UpdateEngine(e *longhorn.Engine) (*longhorn.Engine, error) {
 
 // UpdateEngineStatus updates Longhorn Engine status and verifies update
 func (s *DataStore) UpdateEngineStatus(e *longhorn.Engine) (*longhorn.Engine, error) {
-	obj, err := s.lhClient.LonghornV1beta2().Engines(s.namespace).UpdateStatus(context.TODO(), e, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, err
-	}
-	verifyUpdate(e.Name, obj, func(name string) (k8sruntime.Object, error) {
-		return s.GetEngineRO(name)
-	})
-	return obj, nil
+	return updateStatus(s, types.LonghornKindEngine, e, func(e *longhorn.Engine) interface{} { return e.Status }, s.GetEngineRO,
+		func(e *longhorn.Engine) (*longhorn.Engine, error) {
+			obj, err := s.lhClient.LonghornV1beta2().Engines(s.namespace).UpdateStatus(context.TODO(), e, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			verifyUpdate(e.Name, obj, func(name string) (k8sruntime.Object, error) {
+				return s.GetEngineRO(name)
+			})
+			return obj, nil
+		})
 }
 
 // DeleteEngine won't result in immediately deletion since finalizer was set by
@@ -1637,18 +1678,21 @@ func (s *DataStore) UpdateReplica(r *longhorn.Replica) (*longhorn.Replica, error
 
 // UpdateReplicaStatus updates Replica status and verifies update
 func (s *DataStore) UpdateReplicaStatus(r *longhorn.Replica) (*longhorn.Replica, error) {
-	if err := checkReplica(r); err != nil {
-		return nil, err
-	}
+	return updateStatus(s, types.LonghornKindReplica, r, func(r *longhorn.Replica) interface{} { return r.Status }, s.GetReplicaRO,
+		func(r *longhorn.Replica) (*longhorn.Replica, error) {
+			if err := checkReplica(r); err != nil {
+				return nil, err
+			}
 
-	obj, err := s.lhClient.LonghornV1beta2().Replicas(s.namespace).UpdateStatus(context.TODO(), r, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, err
-	}
-	verifyUpdate(r.Name, obj, func(name string) (k8sruntime.Object, error) {
-		return s.GetReplicaRO(name)
-	})
-	return obj, nil
+			obj, err := s.lhClient.LonghornV1beta2().Replicas(s.namespace).UpdateStatus(context.TODO(), r, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			verifyUpdate(r.Name, obj, func(name string) (k8sruntime.Object, error) {
+				return s.GetReplicaRO(name)
+			})
+			return obj, nil
+		})
 }
 
 // DeleteReplica won't result in immediately deletion since finalizer was set
@@ -1887,13 +1931,140 @@ func IsReplicaRebuildingFailed(reusableFailedReplica *longhorn.Replica) bool {
 	}
 
 	switch replicaRebuildFailedCondition.Reason {
-	case longhorn.ReplicaConditionReasonRebuildFailedDisconnection, longhorn.NodeConditionReasonManagerPodDown, longhorn.NodeConditionReasonKubernetesNodeGone, longhorn.NodeConditionReasonKubernetesNodeNotReady:
+	case longhorn.ReplicaConditionReasonRebuildFailedDisconnection, longhorn.NodeConditionReasonManagerPodDown, longhorn.NodeConditionReasonKubernetesNodeGone, longhorn.NodeConditionReasonKubernetesNodeNotReady, longhorn.NodeConditionReasonNetworkPartitioned:
 		return false
 	default:
 		return true
 	}
 }
 
+// RebuildQueueEntry is an attached volume rebuilding a replica or waiting in the cluster-wide rebuild queue.
+type RebuildQueueEntry struct {
+	VolumeName       string
+	Rebuilding       bool
+	HealthyReplicas  int
+	NumberOfReplicas int
+	Priority         int
+	Size             int64
+}
+
+func isReplicaRebuildingInEngines(replicaName string, engines []*longhorn.Engine) bool {
+	for _, e := range engines {
+		if e.Status.ReplicaModeMap[replicaName] == longhorn.ReplicaModeWO {
+			return true
+		}
+	}
+	return false
+}
+
+// ListRebuildQueueRO returns the volumes rebuilding replicas followed by the degraded volumes waiting for a
+// rebuild, both in the order of their rebuild priority: the volumes with the fewest healthy replicas first,
+// then the highest redundancy deficit, then the highest rebuild priority label value, and then the smallest size.
+// A volume is rebuilding only if an engine reports a scheduled replica in WO mode, so a replica that failed
+// to be scheduled or has not been added to the engine yet does not hold a place of the rebuild limit.
+func (s *DataStore) ListRebuildQueueRO() ([]*RebuildQueueEntry, error) {
+	volumes, err := s.ListVolumesRO()
+	if err != nil {
+		return nil, err
+	}
+	replicas, err := s.ListReplicasRO()
+	if err != nil {
+		return nil, err
+	}
+	engines, err := s.ListEnginesRO()
+	if err != nil {
+		return nil, err
+	}
+
+	volumeReplicas := map[string][]*longhorn.Replica{}
+	for _, r := range replicas {
+		volumeReplicas[r.Spec.VolumeName] = append(volumeReplicas[r.Spec.VolumeName], r)
+	}
+	volumeEngines := map[string][]*longhorn.Engine{}
+	for _, e := range engines {
+		volumeEngines[e.Spec.VolumeName] = append(volumeEngines[e.Spec.VolumeName], e)
+	}
+
+	queue := []*RebuildQueueEntry{}
+	for _, v := range volumes {
+		if v.Status.State != longhorn.VolumeStateAttached {
+			continue
+		}
+
+		healthyCount, pendingCount := 0, 0
+		for _, r := range volumeReplicas[v.Name] {
+			if r.Spec.FailedAt != "" || r.DeletionTimestamp != nil {
+				continue
+			}
+			if r.Spec.HealthyAt != "" {
+				healthyCount++
+			} else if r.Spec.NodeID != "" && isReplicaRebuildingInEngines(r.Name, volumeEngines[v.Name]) {
+				pendingCount++
+			}
+		}
+		// A volume without any healthy replica is starting up instead of rebuilding
+		if healthyCount == 0 {
+			continue
+		}
+
+		rebuilding := pendingCount > 0
+		if !rebuilding {
+			if v.Status.Robustness != longhorn.VolumeRobustnessDegraded {
+				continue
+			}
+			// The volume cannot rebuild anyway, so it should not hold a place in the queue
+			if types.GetCondition(v.Status.Conditions, longhorn.VolumeConditionTypeScheduled).Status == longhorn.ConditionStatusFalse {
+				continue
+			}
+		}
+
+		queue = append(queue, &RebuildQueueEntry{
+			VolumeName:       v.Name,
+			Rebuilding:       rebuilding,
+			HealthyReplicas:  healthyCount,
+			NumberOfReplicas: v.Spec.NumberOfReplicas,
+			Priority:         GetVolumeRebuildPriority(v),
+			Size:             v.Spec.Size,
+		})
+	}
+
+	sort.Slice(queue, func(i, j int) bool {
+		a, b := queue[i], queue[j]
+		if a.Rebuilding != b.Rebuilding {
+			return a.Rebuilding
+		}
+		if a.HealthyReplicas != b.HealthyReplicas {
+			return a.HealthyReplicas < b.HealthyReplicas
+		}
+		if deficitA, deficitB := a.NumberOfReplicas-a.HealthyReplicas, b.NumberOfReplicas-b.HealthyReplicas; deficitA != deficitB {
+			return deficitA > deficitB
+		}
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if a.Size != b.Size {
+			return a.Size < b.Size
+		}
+		return a.VolumeName < b.VolumeName
+	})
+
+	return queue, nil
+}
+
+// GetVolumeRebuildPriority returns the value of the rebuild priority label of the volume, or 0 if it is not set
+// or invalid.
+func GetVolumeRebuildPriority(v *longhorn.Volume) int {
+	value, ok := v.Labels[types.GetLonghornLabelKey(types.LonghornLabelRebuildPriority)]
+	if !ok {
+		return 0
+	}
+	priority, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return priority
+}
+
 // GetOwnerReferencesForEngineImage returns OwnerReference for the given
 // Longhorn EngineImage name and UID
 func GetOwnerReferencesForEngineImage(ei *longhorn.EngineImage) []metav1.OwnerReference {
@@ -1949,14 +2120,17 @@ func (s *DataStore) UpdateEngineImage(img *longhorn.EngineImage) (*longhorn.Engi
 // UpdateEngineImageStatus updates Longhorn EngineImage resource status and
 // verifies update
 func (s *DataStore) UpdateEngineImageStatus(img *longhorn.EngineImage) (*longhorn.EngineImage, error) {
-	obj, err := s.lhClient.LonghornV1beta2().EngineImages(s.namespace).UpdateStatus(context.TODO(), img, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, err
-	}
-	verifyUpdate(img.Name, obj, func(name string) (k8sruntime.Object, error) {
-		return s.GetEngineImageRO(name)
-	})
-	return obj, nil
+	return updateStatus(s, types.LonghornKindEngineImage, img, func(img *longhorn.EngineImage) interface{} { return img.Status }, s.GetEngineImageRO,
+		func(img *longhorn.EngineImage) (*longhorn.EngineImage, error) {
+			obj, err := s.lhClient.LonghornV1beta2().EngineImages(s.namespace).UpdateStatus(context.TODO(), img, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			verifyUpdate(img.Name, obj, func(name string) (k8sruntime.Object, error) {
+				return s.GetEngineImageRO(name)
+			})
+			return obj, nil
+		})
 }
 
 // DeleteEngineImage won't result in immediately deletion since finalizer was
@@ -2150,6 +2324,179 @@ func (s *DataStore) CheckImageReadyOnAllVolumeReplicas(image, volumeName, nodeID
 	return s.CheckDataEngineImageReadiness(image, dataEngine, nodes...)
 }
 
+// EngineUpgradeInstanceManagerMemoryUsageLimitPercentage is the percentage of its memory limit an instance manager
+// pod can use to start a live engine upgrade, leaving the rest for the new processes.
+const EngineUpgradeInstanceManagerMemoryUsageLimitPercentage = 80
+
+// EngineUpgradePreCheckFailure is a failed pre-check preventing the live engine upgrade of a volume
+type EngineUpgradePreCheckFailure struct {
+	Reason  string
+	Message string
+}
+
+// EngineUpgradePreCheckMessage joins the messages of the failed pre-checks
+func EngineUpgradePreCheckMessage(failures []EngineUpgradePreCheckFailure) string {
+	messages := []string{}
+	for _, failure := range failures {
+		messages = append(messages, failure.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// CheckEngineUpgradePreconditions runs the pre-checks of the live engine upgrade for an attached volume and
+// returns the failed ones. A live engine upgrade requires all replicas healthy, no rebuilding in progress, the
+// snapshot count within the limit, enough memory left under the limits of the volume's instance manager pods and no
+// memory pressure on the nodes running them.
+func (s *DataStore) CheckEngineUpgradePreconditions(v *longhorn.Volume) ([]EngineUpgradePreCheckFailure, error) {
+	if v.Status.State != longhorn.VolumeStateAttached {
+		return nil, nil
+	}
+
+	e, err := s.GetVolumeCurrentEngine(v.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get engine for volume %v", v.Name)
+	}
+	if e == nil {
+		return nil, fmt.Errorf("volume %v has no engine", v.Name)
+	}
+	replicas, err := s.ListVolumeReplicasRO(v.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get replicas for volume %v", v.Name)
+	}
+
+	failures := []EngineUpgradePreCheckFailure{}
+
+	healthyCount := 0
+	unhealthyReplicas := []string{}
+	rebuildingReplicas := []string{}
+	nodes := []string{e.Spec.NodeID}
+	for _, r := range replicas {
+		if !r.Spec.Active {
+			continue
+		}
+		if r.Spec.NodeID != "" && !util.Contains(nodes, r.Spec.NodeID) {
+			nodes = append(nodes, r.Spec.NodeID)
+		}
+		switch {
+		case e.Status.ReplicaModeMap[r.Name] == longhorn.ReplicaModeWO:
+			rebuildingReplicas = append(rebuildingReplicas, r.Name)
+		case r.Spec.FailedAt != "" || r.Spec.HealthyAt == "" || e.Status.ReplicaModeMap[r.Name] != longhorn.ReplicaModeRW:
+			unhealthyReplicas = append(unhealthyReplicas, r.Name)
+		default:
+			healthyCount++
+		}
+	}
+	for replicaName, rebuildStatus := range e.Status.RebuildStatus {
+		if rebuildStatus != nil && rebuildStatus.IsRebuilding && !util.Contains(rebuildingReplicas, replicaName) {
+			rebuildingReplicas = append(rebuildingReplicas, replicaName)
+		}
+	}
+	sort.Strings(unhealthyReplicas)
+	sort.Strings(rebuildingReplicas)
+
+	if len(unhealthyReplicas) > 0 || healthyCount < v.Spec.NumberOfReplicas {
+		failures = append(failures, EngineUpgradePreCheckFailure{
+			Reason: longhorn.VolumeConditionReasonReplicaUnhealthy,
+			Message: fmt.Sprintf("%v of %v replicas are healthy, unhealthy replicas: %v",
+				healthyCount, v.Spec.NumberOfReplicas, unhealthyReplicas),
+		})
+	}
+	if len(rebuildingReplicas) > 0 {
+		failures = append(failures, EngineUpgradePreCheckFailure{
+			Reason:  longhorn.VolumeConditionReasonRebuildInProgress,
+			Message: fmt.Sprintf("replicas %v are rebuilding", rebuildingReplicas),
+		})
+	}
+
+	snapshotCountLimit, err := s.GetSettingAsInt(types.SettingNameEngineUpgradeSnapshotCountLimit)
+	if err != nil {
+		return nil, err
+	}
+	// Counting volume-head here would be confusing
+	if snapshotCount := len(e.Status.Snapshots) - 1; snapshotCountLimit > 0 && int64(snapshotCount) > snapshotCountLimit {
+		failures = append(failures, EngineUpgradePreCheckFailure{
+			Reason:  longhorn.VolumeConditionReasonTooManySnapshots,
+			Message: fmt.Sprintf("snapshot count %v is over the limit %v", snapshotCount, snapshotCountLimit),
+		})
+	}
+
+	instanceManagerNames := []string{}
+	if e.Status.InstanceManagerName != "" {
+		instanceManagerNames = append(instanceManagerNames, e.Status.InstanceManagerName)
+	}
+	for _, r := range replicas {
+		if r.Spec.Active && r.Status.InstanceManagerName != "" && !util.Contains(instanceManagerNames, r.Status.InstanceManagerName) {
+			instanceManagerNames = append(instanceManagerNames, r.Status.InstanceManagerName)
+		}
+	}
+	sort.Strings(instanceManagerNames)
+	for _, imName := range instanceManagerNames {
+		failure, err := s.checkInstanceManagerMemoryForEngineUpgrade(imName)
+		if err != nil {
+			return nil, err
+		}
+		if failure != nil {
+			failures = append(failures, *failure)
+		}
+	}
+
+	memoryPressureNodes := []string{}
+	for _, nodeName := range nodes {
+		kubeNode, err := s.GetKubernetesNodeRO(nodeName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, condition := range kubeNode.Status.Conditions {
+			if condition.Type == corev1.NodeMemoryPressure && condition.Status == corev1.ConditionTrue {
+				memoryPressureNodes = append(memoryPressureNodes, nodeName)
+			}
+		}
+	}
+	if len(memoryPressureNodes) > 0 {
+		sort.Strings(memoryPressureNodes)
+		failures = append(failures, EngineUpgradePreCheckFailure{
+			Reason:  longhorn.VolumeConditionReasonInsufficientMemory,
+			Message: fmt.Sprintf("nodes %v running the instance managers of the volume are under memory pressure", memoryPressureNodes),
+		})
+	}
+
+	return failures, nil
+}
+
+// checkInstanceManagerMemoryForEngineUpgrade checks if the instance manager pod has enough memory left under its limit
+// to run the new processes of a live engine upgrade. The check is skipped if the pod has no memory limit or the pod
+// metrics cannot be retrieved. The memory usage is cached, so checking all volumes of an instance manager in a sync
+// gets it from the metrics server once.
+func (s *DataStore) checkInstanceManagerMemoryForEngineUpgrade(imName string) (*EngineUpgradePreCheckFailure, error) {
+	pod, err := s.GetPodRO(s.namespace, imName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get pod of instance manager %v", imName)
+	}
+	if pod == nil {
+		return nil, nil
+	}
+	limit := GetPodMemoryLimit(pod)
+	if limit == 0 {
+		return nil, nil
+	}
+	usage, err := s.GetPodMemoryUsage(pod.Namespace, pod.Name)
+	if err != nil {
+		logrus.WithError(err).Debugf("Failed to get memory usage of instance manager %v, skipping its memory check for the engine upgrade", imName)
+		return nil, nil
+	}
+	if usage*100 < limit*EngineUpgradeInstanceManagerMemoryUsageLimitPercentage {
+		return nil, nil
+	}
+	return &EngineUpgradePreCheckFailure{
+		Reason: longhorn.VolumeConditionReasonInsufficientMemory,
+		Message: fmt.Sprintf("instance manager %v uses %v%% of its memory limit, the engine upgrade requires below %v%%",
+			imName, usage*100/limit, EngineUpgradeInstanceManagerMemoryUsageLimitPercentage),
+	}, nil
+}
+
 // CreateBackingImage creates a Longhorn BackingImage resource and verifies
 // creation
 func (s *DataStore) CreateBackingImage(backingImage *longhorn.BackingImage) (*longhorn.BackingImage, error) {
@@ -2817,14 +3164,17 @@ func (s *DataStore) UpdateNode(node *longhorn.Node) (*longhorn.Node, error) {
 
 // UpdateNodeStatus updates Longhorn Node status and verifies update
 func (s *DataStore) UpdateNodeStatus(node *longhorn.Node) (*longhorn.Node, error) {
-	obj, err := s.lhClient.LonghornV1beta2().Nodes(s.namespace).UpdateStatus(context.TODO(), node, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, err
-	}
-	verifyUpdate(node.Name, obj, func(name string) (k8sruntime.Object, error) {
-		return s.GetNodeRO(name)
-	})
-	return obj, nil
+	return updateStatus(s, types.LonghornKindNode, node, func(node *longhorn.Node) interface{} { return node.Status }, s.GetNodeRO,
+		func(node *longhorn.Node) (*longhorn.Node, error) {
+			obj, err := s.lhClient.LonghornV1beta2().Nodes(s.namespace).UpdateStatus(context.TODO(), node, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			verifyUpdate(node.Name, obj, func(name string) (k8sruntime.Object, error) {
+				return s.GetNodeRO(name)
+			})
+			return obj, nil
+		})
 }
 
 // ListNodes returns an object contains all Node for the namespace
@@ -3987,14 +4337,17 @@ func (s *DataStore) UpdateInstanceManager(im *longhorn.InstanceManager) (*longho
 // UpdateInstanceManagerStatus updates Longhorn InstanceManager resource status
 // and verifies update
 func (s *DataStore) UpdateInstanceManagerStatus(im *longhorn.InstanceManager) (*longhorn.InstanceManager, error) {
-	obj, err := s.lhClient.LonghornV1beta2().InstanceManagers(s.namespace).UpdateStatus(context.TODO(), im, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, err
-	}
-	verifyUpdate(im.Name, obj, func(name string) (k8sruntime.Object, error) {
-		return s.GetInstanceManagerRO(name)
-	})
-	return obj, nil
+	return updateStatus(s, types.LonghornKindInstanceManager, im, func(im *longhorn.InstanceManager) interface{} { return im.Status }, s.GetInstanceManagerRO,
+		func(im *longhorn.InstanceManager) (*longhorn.InstanceManager, error) {
+			obj, err := s.lhClient.LonghornV1beta2().InstanceManagers(s.namespace).UpdateStatus(context.TODO(), im, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			verifyUpdate(im.Name, obj, func(name string) (k8sruntime.Object, error) {
+				return s.GetInstanceManagerRO(name)
+			})
+			return obj, nil
+		})
 }
 
 func verifyCreation(name, kind string, getMethod func(name string) (k8sruntime.Object, error)) (k8sruntime.Object, error) {
@@ -4153,14 +4506,17 @@ func (s *DataStore) UpdateShareManager(sm *longhorn.ShareManager) (*longhorn.Sha
 
 // UpdateShareManagerStatus updates Longhorn ShareManager resource status and verifies update
 func (s *DataStore) UpdateShareManagerStatus(sm *longhorn.ShareManager) (*longhorn.ShareManager, error) {
-	obj, err := s.lhClient.LonghornV1beta2().ShareManagers(s.namespace).UpdateStatus(context.TODO(), sm, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, err
-	}
-	verifyUpdate(sm.Name, obj, func(name string) (k8sruntime.Object, error) {
-		return s.getShareManagerRO(name)
-	})
-	return obj, nil
+	return updateStatus(s, types.LonghornKindShareManager, sm, func(sm *longhorn.ShareManager) interface{} { return sm.Status }, s.getShareManagerRO,
+		func(sm *longhorn.ShareManager) (*longhorn.ShareManager, error) {
+			obj, err := s.lhClient.LonghornV1beta2().ShareManagers(s.namespace).UpdateStatus(context.TODO(), sm, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			verifyUpdate(sm.Name, obj, func(name string) (k8sruntime.Object, error) {
+				return s.getShareManagerRO(name)
+			})
+			return obj, nil
+		})
 }
 
 // DeleteShareManager won't result in immediately deletion since finalizer was set by default
@@ -4369,6 +4725,20 @@ func (s *DataStore) GetBackupTargetRO(backupTargetName string) (*longhorn.Backup
 	return s.backupTargetLister.BackupTargets(s.namespace).Get(backupTargetName)
 }
 
+// GetConfiguredBackupTargetRO returns the backup target with the given name, or an error if it doesn't exist or has
+// no backup target URL configured. The availability is not checked, since a configured backup target may be briefly
+// unavailable, e.g. before its first poll completes.
+func (s *DataStore) GetConfiguredBackupTargetRO(backupTargetName string) (*longhorn.BackupTarget, error) {
+	backupTarget, err := s.GetBackupTargetRO(backupTargetName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get backup target %v", backupTargetName)
+	}
+	if backupTarget.Spec.BackupTargetURL == "" {
+		return nil, fmt.Errorf("backup target %v is not configured", backupTargetName)
+	}
+	return backupTarget, nil
+}
+
 // GetBackupTarget returns a copy of BackupTarget with the given backup target name in the cluster
 func (s *DataStore) GetBackupTarget(name string) (*longhorn.BackupTarget, error) {
 	resultRO, err := s.GetBackupTargetRO(name)
@@ -4379,6 +4749,72 @@ func (s *DataStore) GetBackupTarget(name string) (*longhorn.BackupTarget, error)
 	return resultRO.DeepCopy(), nil
 }
 
+// GetNearestBackupTargetName returns the available backup target in the same zone as the node the volume is attached
+// to, or in the same region if there is none in the same zone. It returns the given backup target name if the setting
+// backup-target-zone-aware-selection is disabled or if there is no nearer backup target.
+func (s *DataStore) GetNearestBackupTargetName(volume *longhorn.Volume, backupTargetName string) (string, error) {
+	zoneAwareSelection, err := s.GetSettingAsBool(types.SettingNameBackupTargetZoneAwareSelection)
+	if err != nil {
+		return "", err
+	}
+	if !zoneAwareSelection {
+		return backupTargetName, nil
+	}
+
+	nodeID := volume.Status.CurrentNodeID
+	if nodeID == "" {
+		nodeID = volume.Status.OwnerID
+	}
+	node, err := s.GetNodeRO(nodeID)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return backupTargetName, nil
+		}
+		return "", errors.Wrapf(err, "failed to get node %v of volume %v", nodeID, volume.Name)
+	}
+
+	backupTargets, err := s.ListBackupTargetsRO()
+	if err != nil {
+		return "", err
+	}
+
+	getProximity := func(bt *longhorn.BackupTarget) int {
+		if bt.Spec.Region != "" && bt.Spec.Region != node.Status.Region {
+			return 0
+		}
+		if bt.Spec.Zone != "" && bt.Spec.Zone == node.Status.Zone {
+			return 2
+		}
+		if bt.Spec.Region != "" {
+			return 1
+		}
+		return 0
+	}
+
+	nearestName := backupTargetName
+	nearestProximity := 0
+	if bt, ok := backupTargets[backupTargetName]; ok && bt.Status.Available {
+		nearestProximity = getProximity(bt)
+	}
+	// Sort the names to keep the choice stable among the backup targets at the same proximity
+	names := make([]string, 0, len(backupTargets))
+	for name := range backupTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		bt := backupTargets[name]
+		if !bt.Status.Available || bt.Spec.BackupTargetURL == "" {
+			continue
+		}
+		if proximity := getProximity(bt); proximity > nearestProximity {
+			nearestName = name
+			nearestProximity = proximity
+		}
+	}
+	return nearestName, nil
+}
+
 // UpdateBackupTarget updates the given Longhorn backup target in the cluster BackupTargets CR and verifies update
 func (s *DataStore) UpdateBackupTarget(backupTarget *longhorn.BackupTarget) (*longhorn.BackupTarget, error) {
 	if backupTarget.Annotations == nil {
@@ -4397,14 +4833,17 @@ func (s *DataStore) UpdateBackupTarget(backupTarget *longhorn.BackupTarget) (*lo
 
 // UpdateBackupTargetStatus updates the given Longhorn backup target in the cluster BackupTargets CR status and verifies update
 func (s *DataStore) UpdateBackupTargetStatus(backupTarget *longhorn.BackupTarget) (*longhorn.BackupTarget, error) {
-	obj, err := s.lhClient.LonghornV1beta2().BackupTargets(s.namespace).UpdateStatus(context.TODO(), backupTarget, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, err
-	}
-	verifyUpdate(backupTarget.Name, obj, func(name string) (k8sruntime.Object, error) {
-		return s.GetBackupTargetRO(name)
-	})
-	return obj, nil
+	return updateStatus(s, types.LonghornKindBackupTarget, backupTarget, func(backupTarget *longhorn.BackupTarget) interface{} { return backupTarget.Status }, s.GetBackupTargetRO,
+		func(backupTarget *longhorn.BackupTarget) (*longhorn.BackupTarget, error) {
+			obj, err := s.lhClient.LonghornV1beta2().BackupTargets(s.namespace).UpdateStatus(context.TODO(), backupTarget, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			verifyUpdate(backupTarget.Name, obj, func(name string) (k8sruntime.Object, error) {
+				return s.GetBackupTargetRO(name)
+			})
+			return obj, nil
+		})
 }
 
 // DeleteBackupTarget won't result in immediately deletion since finalizer was set by default
@@ -4673,14 +5112,17 @@ func (s *DataStore) UpdateBackupVolume(backupVolume *longhorn.BackupVolume) (*lo
 
 // UpdateBackupVolumeStatus updates the given Longhorn backup volume in the cluster BackupVolumes CR status and verifies update
 func (s *DataStore) UpdateBackupVolumeStatus(backupVolume *longhorn.BackupVolume) (*longhorn.BackupVolume, error) {
-	obj, err := s.lhClient.LonghornV1beta2().BackupVolumes(s.namespace).UpdateStatus(context.TODO(), backupVolume, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, err
-	}
-	verifyUpdate(backupVolume.Name, obj, func(name string) (k8sruntime.Object, error) {
-		return s.GetBackupVolumeRO(name)
-	})
-	return obj, nil
+	return updateStatus(s, types.LonghornKindBackupVolume, backupVolume, func(backupVolume *longhorn.BackupVolume) interface{} { return backupVolume.Status }, s.GetBackupVolumeRO,
+		func(backupVolume *longhorn.BackupVolume) (*longhorn.BackupVolume, error) {
+			obj, err := s.lhClient.LonghornV1beta2().BackupVolumes(s.namespace).UpdateStatus(context.TODO(), backupVolume, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			verifyUpdate(backupVolume.Name, obj, func(name string) (k8sruntime.Object, error) {
+				return s.GetBackupVolumeRO(name)
+			})
+			return obj, nil
+		})
 }
 
 // DeleteBackupVolume won't result in immediately deletion since finalizer was set by default
@@ -4849,14 +5291,17 @@ func (s *DataStore) UpdateBackup(backup *longhorn.Backup) (*longhorn.Backup, err
 
 // UpdateBackupStatus updates the given Longhorn backup status in the cluster Backups CR status and verifies update
 func (s *DataStore) UpdateBackupStatus(backup *longhorn.Backup) (*longhorn.Backup, error) {
-	obj, err := s.lhClient.LonghornV1beta2().Backups(s.namespace).UpdateStatus(context.TODO(), backup, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, err
-	}
-	verifyUpdate(backup.Name, obj, func(name string) (k8sruntime.Object, error) {
-		return s.GetBackupRO(name)
-	})
-	return obj, nil
+	return updateStatus(s, types.LonghornKindBackup, backup, func(backup *longhorn.Backup) interface{} { return backup.Status }, s.GetBackupRO,
+		func(backup *longhorn.Backup) (*longhorn.Backup, error) {
+			obj, err := s.lhClient.LonghornV1beta2().Backups(s.namespace).UpdateStatus(context.TODO(), backup, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			verifyUpdate(backup.Name, obj, func(name string) (k8sruntime.Object, error) {
+				return s.GetBackupRO(name)
+			})
+			return obj, nil
+		})
 }
 
 // DeleteBackup won't result in immediately deletion since finalizer was set by default
@@ -4931,14 +5376,17 @@ func (s *DataStore) GetSnapshot(name string) (*longhorn.Snapshot, error) {
 
 // UpdateSnapshotStatus updates the given Longhorn snapshot status verifies update
 func (s *DataStore) UpdateSnapshotStatus(snap *longhorn.Snapshot) (*longhorn.Snapshot, error) {
-	obj, err := s.lhClient.LonghornV1beta2().Snapshots(s.namespace).UpdateStatus(context.TODO(), snap, metav1.UpdateOptions{})
-	if err != nil {
-		return nil, err
-	}
-	verifyUpdate(snap.Name, obj, func(name string) (k8sruntime.Object, error) {
-		return s.GetSnapshotRO(name)
-	})
-	return obj, nil
+	return updateStatus(s, types.LonghornKindSnapshot, snap, func(snap *longhorn.Snapshot) interface{} { return snap.Status }, s.GetSnapshotRO,
+		func(snap *longhorn.Snapshot) (*longhorn.Snapshot, error) {
+			obj, err := s.lhClient.LonghornV1beta2().Snapshots(s.namespace).UpdateStatus(context.TODO(), snap, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			verifyUpdate(snap.Name, obj, func(name string) (k8sruntime.Object, error) {
+				return s.GetSnapshotRO(name)
+			})
+			return obj, nil
+		})
 }
 
 // RemoveFinalizerForSnapshot will result in deletion if DeletionTimestamp was set
@@ -5073,6 +5521,48 @@ func (s *DataStore) GetRecurringJobRO(name string) (*longhorn.RecurringJob, erro
 	return s.recurringJobLister.RecurringJobs(s.namespace).Get(name)
 }
 
+// CheckVolumeRecurringJobs returns an error if a selected recurring job doesn't exist or a selected group other
+// than the default one has no recurring job. It returns true if any of the selected jobs creates backups.
+func (s *DataStore) CheckVolumeRecurringJobs(volumeRecurringJobs []longhorn.VolumeRecurringJob) (bool, error) {
+	if len(volumeRecurringJobs) == 0 {
+		return false, nil
+	}
+
+	recurringJobs, err := s.ListRecurringJobsRO()
+	if err != nil {
+		return false, err
+	}
+
+	isBackupJob := func(job *longhorn.RecurringJob) bool {
+		return job.Spec.Task == longhorn.RecurringJobTypeBackup || job.Spec.Task == longhorn.RecurringJobTypeBackupForceCreate
+	}
+
+	hasBackupJob := false
+	for _, volumeRecurringJob := range volumeRecurringJobs {
+		if !volumeRecurringJob.IsGroup {
+			job, exists := recurringJobs[volumeRecurringJob.Name]
+			if !exists {
+				return false, fmt.Errorf("recurring job %v not found", volumeRecurringJob.Name)
+			}
+			hasBackupJob = hasBackupJob || isBackupJob(job)
+			continue
+		}
+
+		groupFound := false
+		for _, job := range recurringJobs {
+			if !util.Contains(job.Spec.Groups, volumeRecurringJob.Name) {
+				continue
+			}
+			groupFound = true
+			hasBackupJob = hasBackupJob || isBackupJob(job)
+		}
+		if !groupFound && volumeRecurringJob.Name != longhorn.RecurringJobGroupDefault {
+			return false, fmt.Errorf("recurring job group %v has no recurring job", volumeRecurringJob.Name)
+		}
+	}
+	return hasBackupJob, nil
+}
+
 // UpdateRecurringJob updates Longhorn RecurringJob and verifies update
 func (s *DataStore) UpdateRecurringJob(recurringJob *longhorn.RecurringJob) (*longhorn.RecurringJob, error) {
 	obj, err := s.lhClient.LonghornV1beta2().RecurringJobs(s.namespace).Update(context.TODO(), recurringJob, metav1.UpdateOptions{})
@@ -5368,6 +5858,293 @@ func (s *DataStore) DeleteOrphan(orphanName string) error {
 	return s.lhClient.LonghornV1beta2().Orphans(s.namespace).Delete(context.TODO(), orphanName, metav1.DeleteOptions{})
 }
 
+// CreateCloneSchedule creates a Longhorn CloneSchedule resource and verifies creation
+func (s *DataStore) CreateCloneSchedule(cloneSchedule *longhorn.CloneSchedule) (*longhorn.CloneSchedule, error) {
+	ret, err := s.lhClient.LonghornV1beta2().CloneSchedules(s.namespace).Create(context.TODO(), cloneSchedule, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if SkipListerCheck {
+		return ret, nil
+	}
+
+	obj, err := verifyCreation(ret.Name, "clone schedule", func(name string) (k8sruntime.Object, error) {
+		return s.GetCloneScheduleRO(name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	ret, ok := obj.(*longhorn.CloneSchedule)
+	if !ok {
+		return nil, fmt.Errorf("BUG: datastore: verifyCreation returned wrong type for clone schedule")
+	}
+
+	return ret.DeepCopy(), nil
+}
+
+// GetCloneScheduleRO returns the CloneSchedule with the given name in the cluster
+func (s *DataStore) GetCloneScheduleRO(name string) (*longhorn.CloneSchedule, error) {
+	return s.cloneScheduleLister.CloneSchedules(s.namespace).Get(name)
+}
+
+// GetCloneSchedule returns a copy of CloneSchedule with the given name in the cluster
+func (s *DataStore) GetCloneSchedule(name string) (*longhorn.CloneSchedule, error) {
+	resultRO, err := s.GetCloneScheduleRO(name)
+	if err != nil {
+		return nil, err
+	}
+	// Cannot use cached object from lister
+	return resultRO.DeepCopy(), nil
+}
+
+// UpdateCloneSchedule updates the given Longhorn CloneSchedule in the cluster and verifies update
+func (s *DataStore) UpdateCloneSchedule(cloneSchedule *longhorn.CloneSchedule) (*longhorn.CloneSchedule, error) {
+	obj, err := s.lhClient.LonghornV1beta2().CloneSchedules(s.namespace).Update(context.TODO(), cloneSchedule, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	verifyUpdate(cloneSchedule.Name, obj, func(name string) (k8sruntime.Object, error) {
+		return s.GetCloneScheduleRO(name)
+	})
+	return obj, nil
+}
+
+// UpdateCloneScheduleStatus updates the given Longhorn CloneSchedule status in the cluster and verifies update
+func (s *DataStore) UpdateCloneScheduleStatus(cloneSchedule *longhorn.CloneSchedule) (*longhorn.CloneSchedule, error) {
+	obj, err := s.lhClient.LonghornV1beta2().CloneSchedules(s.namespace).UpdateStatus(context.TODO(), cloneSchedule, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	verifyUpdate(cloneSchedule.Name, obj, func(name string) (k8sruntime.Object, error) {
+		return s.GetCloneScheduleRO(name)
+	})
+	return obj, nil
+}
+
+// ListCloneSchedules returns a map of all CloneSchedules for the given namespace
+func (s *DataStore) ListCloneSchedules() (map[string]*longhorn.CloneSchedule, error) {
+	list, err := s.cloneScheduleLister.CloneSchedules(s.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	itemMap := map[string]*longhorn.CloneSchedule{}
+	for _, itemRO := range list {
+		// Cannot use cached object from lister
+		itemMap[itemRO.Name] = itemRO.DeepCopy()
+	}
+	return itemMap, nil
+}
+
+// ListCloneSchedulesRO returns a list of all CloneSchedules for the given namespace,
+// the list contains direct references to the internal cache objects and should not be mutated.
+func (s *DataStore) ListCloneSchedulesRO() ([]*longhorn.CloneSchedule, error) {
+	return s.cloneScheduleLister.CloneSchedules(s.namespace).List(labels.Everything())
+}
+
+// DeleteCloneSchedule won't result in immediately deletion since finalizer was set by default
+func (s *DataStore) DeleteCloneSchedule(name string) error {
+	return s.lhClient.LonghornV1beta2().CloneSchedules(s.namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
+// RemoveFinalizerForCloneSchedule will result in deletion if DeletionTimestamp was set
+func (s *DataStore) RemoveFinalizerForCloneSchedule(cloneSchedule *longhorn.CloneSchedule) error {
+	if !util.FinalizerExists(longhornFinalizerKey, cloneSchedule) {
+		// finalizer already removed
+		return nil
+	}
+	if err := util.RemoveFinalizer(longhornFinalizerKey, cloneSchedule); err != nil {
+		return err
+	}
+	_, err := s.lhClient.LonghornV1beta2().CloneSchedules(s.namespace).Update(context.TODO(), cloneSchedule, metav1.UpdateOptions{})
+	if err != nil {
+		// workaround `StorageError: invalid object, Code: 4` due to empty object
+		if cloneSchedule.DeletionTimestamp != nil {
+			return nil
+		}
+		return errors.Wrapf(err, "unable to remove finalizer for clone schedule %s", cloneSchedule.Name)
+	}
+	return nil
+}
+
+// CreateRestoreTest creates a Longhorn RestoreTest resource and verifies creation
+func (s *DataStore) CreateRestoreTest(restoreTest *longhorn.RestoreTest) (*longhorn.RestoreTest, error) {
+	ret, err := s.lhClient.LonghornV1beta2().RestoreTests(s.namespace).Create(context.TODO(), restoreTest, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if SkipListerCheck {
+		return ret, nil
+	}
+
+	obj, err := verifyCreation(ret.Name, "restore test", func(name string) (k8sruntime.Object, error) {
+		return s.GetRestoreTestRO(name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	ret, ok := obj.(*longhorn.RestoreTest)
+	if !ok {
+		return nil, fmt.Errorf("BUG: datastore: verifyCreation returned wrong type for restore test")
+	}
+
+	return ret.DeepCopy(), nil
+}
+
+// GetRestoreTestRO returns the RestoreTest with the given name in the cluster
+func (s *DataStore) GetRestoreTestRO(name string) (*longhorn.RestoreTest, error) {
+	return s.restoreTestLister.RestoreTests(s.namespace).Get(name)
+}
+
+// GetRestoreTest returns a copy of RestoreTest with the given name in the cluster
+func (s *DataStore) GetRestoreTest(name string) (*longhorn.RestoreTest, error) {
+	resultRO, err := s.GetRestoreTestRO(name)
+	if err != nil {
+		return nil, err
+	}
+	// Cannot use cached object from lister
+	return resultRO.DeepCopy(), nil
+}
+
+// UpdateRestoreTest updates the given Longhorn RestoreTest in the cluster and verifies update
+func (s *DataStore) UpdateRestoreTest(restoreTest *longhorn.RestoreTest) (*longhorn.RestoreTest, error) {
+	obj, err := s.lhClient.LonghornV1beta2().RestoreTests(s.namespace).Update(context.TODO(), restoreTest, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	verifyUpdate(restoreTest.Name, obj, func(name string) (k8sruntime.Object, error) {
+		return s.GetRestoreTestRO(name)
+	})
+	return obj, nil
+}
+
+// UpdateRestoreTestStatus updates the given Longhorn RestoreTest status in the cluster and verifies update
+func (s *DataStore) UpdateRestoreTestStatus(restoreTest *longhorn.RestoreTest) (*longhorn.RestoreTest, error) {
+	obj, err := s.lhClient.LonghornV1beta2().RestoreTests(s.namespace).UpdateStatus(context.TODO(), restoreTest, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	verifyUpdate(restoreTest.Name, obj, func(name string) (k8sruntime.Object, error) {
+		return s.GetRestoreTestRO(name)
+	})
+	return obj, nil
+}
+
+// ListRestoreTests returns a map of all RestoreTests for the given namespace
+func (s *DataStore) ListRestoreTests() (map[string]*longhorn.RestoreTest, error) {
+	list, err := s.restoreTestLister.RestoreTests(s.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	itemMap := map[string]*longhorn.RestoreTest{}
+	for _, itemRO := range list {
+		// Cannot use cached object from lister
+		itemMap[itemRO.Name] = itemRO.DeepCopy()
+	}
+	return itemMap, nil
+}
+
+// ListRestoreTestsRO returns a list of all RestoreTests for the given namespace,
+// the list contains direct references to the internal cache objects and should not be mutated.
+func (s *DataStore) ListRestoreTestsRO() ([]*longhorn.RestoreTest, error) {
+	return s.restoreTestLister.RestoreTests(s.namespace).List(labels.Everything())
+}
+
+// DeleteRestoreTest deletes the RestoreTest with the given name
+func (s *DataStore) DeleteRestoreTest(name string) error {
+	return s.lhClient.LonghornV1beta2().RestoreTests(s.namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
+// CreateReplicaCountPolicy creates a Longhorn ReplicaCountPolicy resource and verifies creation
+func (s *DataStore) CreateReplicaCountPolicy(replicaCountPolicy *longhorn.ReplicaCountPolicy) (*longhorn.ReplicaCountPolicy, error) {
+	ret, err := s.lhClient.LonghornV1beta2().ReplicaCountPolicies(s.namespace).Create(context.TODO(), replicaCountPolicy, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if SkipListerCheck {
+		return ret, nil
+	}
+
+	obj, err := verifyCreation(ret.Name, "replica count policy", func(name string) (k8sruntime.Object, error) {
+		return s.GetReplicaCountPolicyRO(name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	ret, ok := obj.(*longhorn.ReplicaCountPolicy)
+	if !ok {
+		return nil, fmt.Errorf("BUG: datastore: verifyCreation returned wrong type for replica count policy")
+	}
+
+	return ret.DeepCopy(), nil
+}
+
+// GetReplicaCountPolicyRO returns the ReplicaCountPolicy with the given name in the cluster
+func (s *DataStore) GetReplicaCountPolicyRO(name string) (*longhorn.ReplicaCountPolicy, error) {
+	return s.replicaCountPolicyLister.ReplicaCountPolicies(s.namespace).Get(name)
+}
+
+// GetReplicaCountPolicy returns a copy of ReplicaCountPolicy with the given name in the cluster
+func (s *DataStore) GetReplicaCountPolicy(name string) (*longhorn.ReplicaCountPolicy, error) {
+	resultRO, err := s.GetReplicaCountPolicyRO(name)
+	if err != nil {
+		return nil, err
+	}
+	// Cannot use cached object from lister
+	return resultRO.DeepCopy(), nil
+}
+
+// UpdateReplicaCountPolicy updates the given Longhorn ReplicaCountPolicy in the cluster and verifies update
+func (s *DataStore) UpdateReplicaCountPolicy(replicaCountPolicy *longhorn.ReplicaCountPolicy) (*longhorn.ReplicaCountPolicy, error) {
+	obj, err := s.lhClient.LonghornV1beta2().ReplicaCountPolicies(s.namespace).Update(context.TODO(), replicaCountPolicy, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	verifyUpdate(replicaCountPolicy.Name, obj, func(name string) (k8sruntime.Object, error) {
+		return s.GetReplicaCountPolicyRO(name)
+	})
+	return obj, nil
+}
+
+// UpdateReplicaCountPolicyStatus updates the given Longhorn ReplicaCountPolicy status in the cluster and verifies update
+func (s *DataStore) UpdateReplicaCountPolicyStatus(replicaCountPolicy *longhorn.ReplicaCountPolicy) (*longhorn.ReplicaCountPolicy, error) {
+	obj, err := s.lhClient.LonghornV1beta2().ReplicaCountPolicies(s.namespace).UpdateStatus(context.TODO(), replicaCountPolicy, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	verifyUpdate(replicaCountPolicy.Name, obj, func(name string) (k8sruntime.Object, error) {
+		return s.GetReplicaCountPolicyRO(name)
+	})
+	return obj, nil
+}
+
+// ListReplicaCountPolicies returns a map of all ReplicaCountPolicies for the given namespace
+func (s *DataStore) ListReplicaCountPolicies() (map[string]*longhorn.ReplicaCountPolicy, error) {
+	list, err := s.replicaCountPolicyLister.ReplicaCountPolicies(s.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	itemMap := map[string]*longhorn.ReplicaCountPolicy{}
+	for _, itemRO := range list {
+		// Cannot use cached object from lister
+		itemMap[itemRO.Name] = itemRO.DeepCopy()
+	}
+	return itemMap, nil
+}
+
+// ListReplicaCountPoliciesRO returns a list of all ReplicaCountPolicies for the given namespace,
+// the list contains direct references to the internal cache objects and should not be mutated.
+func (s *DataStore) ListReplicaCountPoliciesRO() ([]*longhorn.ReplicaCountPolicy, error) {
+	return s.replicaCountPolicyLister.ReplicaCountPolicies(s.namespace).List(labels.Everything())
+}
+
+// DeleteReplicaCountPolicy deletes the ReplicaCountPolicy with the given name
+func (s *DataStore) DeleteReplicaCountPolicy(name string) error {
+	return s.lhClient.LonghornV1beta2().ReplicaCountPolicies(s.namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
 // GetOwnerReferencesForSupportBundle returns a list contains single OwnerReference for the
 // given SupportBundle object
 func GetOwnerReferencesForSupportBundle(supportBundle *longhorn.SupportBundle) []metav1.OwnerReference {
@@ -5854,6 +6631,11 @@ func (s *DataStore) ListSystemRestores() (map[string]*longhorn.SystemRestore, er
 	return s.listSystemRestores(labels.Everything())
 }
 
+// ListSystemRestoresRO returns a list of all SystemRestores for the given namespace
+func (s *DataStore) ListSystemRestoresRO() ([]*longhorn.SystemRestore, error) {
+	return s.systemRestoreLister.SystemRestores(s.namespace).List(labels.Everything())
+}
+
 // UpdateLHVolumeAttachment updates the given Longhorn VolumeAttachment in the VolumeAttachment CR and verifies update
 func (s *DataStore) UpdateLHVolumeAttachment(va *longhorn.VolumeAttachment) (*longhorn.VolumeAttachment, error) {
 	obj, err := s.lhClient.LonghornV1beta2().VolumeAttachments(s.namespace).Update(context.TODO(), va, metav1.UpdateOptions{})
@@ -0,0 +1,57 @@
+package datastore
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// PodMemoryUsageCacheTTL is how long the memory usage of a pod is reused before getting it from the metrics
+	// server again. The metrics server does not refresh the usage more often than this anyway.
+	PodMemoryUsageCacheTTL = 30 * time.Second
+)
+
+type podMemoryUsage struct {
+	usage     int64
+	err       error
+	fetchedAt time.Time
+}
+
+// podMemoryUsageCache remembers the memory usage of the pods for a while, so that the checks running for every
+// volume in every sync do not hit the metrics server once per volume. Failures are remembered as well, otherwise
+// the unavailable metrics server would be retried by each check.
+type podMemoryUsageCache struct {
+	lock   sync.Mutex
+	usages map[string]*podMemoryUsage
+	fetch  func(namespace, name string) (int64, error)
+}
+
+func newPodMemoryUsageCache(fetch func(namespace, name string) (int64, error)) *podMemoryUsageCache {
+	return &podMemoryUsageCache{
+		usages: map[string]*podMemoryUsage{},
+		fetch:  fetch,
+	}
+}
+
+func (c *podMemoryUsageCache) get(namespace, name string) (int64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := namespace + "/" + name
+	if u, ok := c.usages[key]; ok && time.Since(u.fetchedAt) < PodMemoryUsageCacheTTL {
+		return u.usage, u.err
+	}
+
+	for k, u := range c.usages {
+		if time.Since(u.fetchedAt) >= PodMemoryUsageCacheTTL {
+			delete(c.usages, k)
+		}
+	}
+	usage, err := c.fetch(namespace, name)
+	c.usages[key] = &podMemoryUsage{
+		usage:     usage,
+		err:       err,
+		fetchedAt: time.Now(),
+	}
+	return usage, err
+}
@@ -0,0 +1,151 @@
+package datastore
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// StatusUpdateResultWritten means the status update was sent to the API server
+	StatusUpdateResultWritten = "written"
+	// StatusUpdateResultUnchanged means the status update was skipped since the status is the same as the cached one
+	StatusUpdateResultUnchanged = "unchanged"
+	// StatusUpdateResultCoalesced means the status update was skipped since the same status was just written
+	// on top of the same object, and the object has not changed since
+	StatusUpdateResultCoalesced = "coalesced"
+)
+
+var (
+	// StatusUpdateCoalesceWindow is how long a written status is remembered for coalescing the successive
+	// status updates of the same object
+	StatusUpdateCoalesceWindow = 2 * time.Second
+
+	statusUpdateMetricsProvider StatusUpdateMetricsProvider = noopStatusUpdateMetricsProvider{}
+)
+
+// StatusUpdateMetricsProvider receives the result of each status update issued through the datastore.
+type StatusUpdateMetricsProvider interface {
+	ObserveStatusUpdate(kind, result string)
+}
+
+type noopStatusUpdateMetricsProvider struct{}
+
+func (noopStatusUpdateMetricsProvider) ObserveStatusUpdate(kind, result string) {}
+
+// SetStatusUpdateMetricsProvider sets the provider observing the status updates. It should be called before
+// the controllers start.
+func SetStatusUpdateMetricsProvider(provider StatusUpdateMetricsProvider) {
+	statusUpdateMetricsProvider = provider
+}
+
+type statusObject interface {
+	k8sruntime.Object
+	metav1.Object
+}
+
+type statusWrite struct {
+	// fromResourceVersion is the resource version the status was written on top of
+	fromResourceVersion string
+	resourceVersion     string
+	obj                 k8sruntime.Object
+	writtenAt           time.Time
+}
+
+// statusUpdateCoalescer remembers the recently written status of each object, so that the successive updates
+// carrying the same status can be answered without another round trip to the API server.
+type statusUpdateCoalescer struct {
+	lock        sync.Mutex
+	writes      map[string]*statusWrite
+	lastCleanup time.Time
+}
+
+func newStatusUpdateCoalescer() *statusUpdateCoalescer {
+	return &statusUpdateCoalescer{
+		writes:      map[string]*statusWrite{},
+		lastCleanup: time.Now(),
+	}
+}
+
+// getCoalesced returns the recently written object if its status is the same as the status to write, the object to
+// update is the one the status was written on top of, and the cached object is still the written one. If the cached
+// object is not the written one, either the cache has not caught up yet or another writer has changed the object
+// since, so the update is sent to the API server to detect the conflict.
+func (c *statusUpdateCoalescer) getCoalesced(key, resourceVersion, cachedResourceVersion string, isSameStatus func(written k8sruntime.Object) bool) k8sruntime.Object {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	write, ok := c.writes[key]
+	if !ok || time.Since(write.writtenAt) > StatusUpdateCoalesceWindow {
+		return nil
+	}
+	if cachedResourceVersion != write.resourceVersion {
+		return nil
+	}
+	if resourceVersion != write.fromResourceVersion {
+		return nil
+	}
+	if !isSameStatus(write.obj) {
+		return nil
+	}
+	return write.obj.DeepCopyObject()
+}
+
+func (c *statusUpdateCoalescer) record(key, fromResourceVersion string, obj statusObject) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	c.writes[key] = &statusWrite{
+		fromResourceVersion: fromResourceVersion,
+		resourceVersion:     obj.GetResourceVersion(),
+		obj:                 obj.DeepCopyObject(),
+		writtenAt:           now,
+	}
+
+	if now.Sub(c.lastCleanup) < StatusUpdateCoalesceWindow {
+		return
+	}
+	for k, write := range c.writes {
+		if now.Sub(write.writtenAt) > StatusUpdateCoalesceWindow {
+			delete(c.writes, k)
+		}
+	}
+	c.lastCleanup = now
+}
+
+// updateStatus skips the status update if the status is the same as the cached one or the one just written on
+// top of the same object, otherwise it calls the update function. Since the update can be skipped, the caller
+// must not assume the returned object is newer than the cached one.
+func updateStatus[T statusObject](s *DataStore, kind string, obj T, getStatus func(T) interface{},
+	getCached func(name string) (T, error), update func(T) (T, error)) (T, error) {
+	cached, err := getCached(obj.GetName())
+	if err == nil {
+		if cached.GetResourceVersion() == obj.GetResourceVersion() &&
+			equality.Semantic.DeepEqual(getStatus(cached), getStatus(obj)) {
+			statusUpdateMetricsProvider.ObserveStatusUpdate(kind, StatusUpdateResultUnchanged)
+			return cached.DeepCopyObject().(T), nil
+		}
+
+		key := kind + "/" + obj.GetName()
+		if written := s.statusUpdateCoalescer.getCoalesced(key, obj.GetResourceVersion(), cached.GetResourceVersion(), func(written k8sruntime.Object) bool {
+			return equality.Semantic.DeepEqual(getStatus(written.(T)), getStatus(obj))
+		}); written != nil {
+			statusUpdateMetricsProvider.ObserveStatusUpdate(kind, StatusUpdateResultCoalesced)
+			return written.(T), nil
+		}
+	}
+
+	fromResourceVersion := obj.GetResourceVersion()
+	ret, err := update(obj)
+	if err != nil {
+		return ret, err
+	}
+	statusUpdateMetricsProvider.ObserveStatusUpdate(kind, StatusUpdateResultWritten)
+	s.statusUpdateCoalescer.record(kind+"/"+obj.GetName(), fromResourceVersion, ret)
+	return ret, nil
+}
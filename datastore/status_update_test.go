@@ -0,0 +1,214 @@
+package datastore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+type fakeStatusUpdateMetricsProvider struct {
+	results []string
+}
+
+func (p *fakeStatusUpdateMetricsProvider) ObserveStatusUpdate(kind, result string) {
+	p.results = append(p.results, result)
+}
+
+func newStatusUpdateTestVolume(resourceVersion string, state longhorn.VolumeState) *longhorn.Volume {
+	return &longhorn.Volume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-volume",
+			ResourceVersion: resourceVersion,
+		},
+		Status: longhorn.VolumeStatus{
+			State: state,
+		},
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	type statusUpdate struct {
+		obj *longhorn.Volume
+		// writtenAgo moves the previous write back in time before this update
+		writtenAgo time.Duration
+		// concurrentWrite is written by another writer right before this update
+		concurrentWrite *longhorn.Volume
+		// cacheBehind keeps the cache from catching up with this update
+		cacheBehind bool
+
+		expectedResult          string
+		expectedResourceVersion string
+		expectedConflict        bool
+	}
+
+	tests := map[string]struct {
+		cached  *longhorn.Volume
+		updates []statusUpdate
+	}{
+		"unchanged": {
+			cached: newStatusUpdateTestVolume("1", longhorn.VolumeStateAttached),
+			updates: []statusUpdate{
+				{
+					obj:                     newStatusUpdateTestVolume("1", longhorn.VolumeStateAttached),
+					expectedResult:          StatusUpdateResultUnchanged,
+					expectedResourceVersion: "1",
+				},
+			},
+		},
+		"changed": {
+			cached: newStatusUpdateTestVolume("1", longhorn.VolumeStateAttached),
+			updates: []statusUpdate{
+				{
+					obj:                     newStatusUpdateTestVolume("1", longhorn.VolumeStateDetaching),
+					expectedResult:          StatusUpdateResultWritten,
+					expectedResourceVersion: "2",
+				},
+			},
+		},
+		"coalescedOnStaleObject": {
+			cached: newStatusUpdateTestVolume("1", longhorn.VolumeStateAttached),
+			updates: []statusUpdate{
+				{
+					obj:                     newStatusUpdateTestVolume("1", longhorn.VolumeStateDetaching),
+					expectedResult:          StatusUpdateResultWritten,
+					expectedResourceVersion: "2",
+				},
+				{
+					obj:                     newStatusUpdateTestVolume("1", longhorn.VolumeStateDetaching),
+					expectedResult:          StatusUpdateResultCoalesced,
+					expectedResourceVersion: "2",
+				},
+			},
+		},
+		"differentStatusWritten": {
+			cached: newStatusUpdateTestVolume("1", longhorn.VolumeStateAttached),
+			updates: []statusUpdate{
+				{
+					obj:                     newStatusUpdateTestVolume("1", longhorn.VolumeStateDetaching),
+					expectedResult:          StatusUpdateResultWritten,
+					expectedResourceVersion: "2",
+				},
+				{
+					obj:                     newStatusUpdateTestVolume("2", longhorn.VolumeStateDetached),
+					expectedResult:          StatusUpdateResultWritten,
+					expectedResourceVersion: "3",
+				},
+			},
+		},
+		"cacheBehindWritten": {
+			cached: newStatusUpdateTestVolume("1", longhorn.VolumeStateAttached),
+			updates: []statusUpdate{
+				{
+					obj:                     newStatusUpdateTestVolume("1", longhorn.VolumeStateDetaching),
+					cacheBehind:             true,
+					expectedResult:          StatusUpdateResultWritten,
+					expectedResourceVersion: "2",
+				},
+				{
+					// The object may have been changed by another writer since, let the API server decide
+					obj:              newStatusUpdateTestVolume("1", longhorn.VolumeStateDetaching),
+					expectedConflict: true,
+				},
+			},
+		},
+		"conflictAfterConcurrentWrite": {
+			cached: newStatusUpdateTestVolume("1", longhorn.VolumeStateAttached),
+			updates: []statusUpdate{
+				{
+					obj:                     newStatusUpdateTestVolume("1", longhorn.VolumeStateDetaching),
+					expectedResult:          StatusUpdateResultWritten,
+					expectedResourceVersion: "2",
+				},
+				{
+					// The object was updated by another writer after the write
+					concurrentWrite:  newStatusUpdateTestVolume("3", longhorn.VolumeStateAttached),
+					obj:              newStatusUpdateTestVolume("1", longhorn.VolumeStateDetaching),
+					expectedConflict: true,
+				},
+			},
+		},
+		"expiredWindowWritten": {
+			cached: newStatusUpdateTestVolume("1", longhorn.VolumeStateAttached),
+			updates: []statusUpdate{
+				{
+					obj:                     newStatusUpdateTestVolume("1", longhorn.VolumeStateDetaching),
+					expectedResult:          StatusUpdateResultWritten,
+					expectedResourceVersion: "2",
+				},
+				{
+					obj:              newStatusUpdateTestVolume("1", longhorn.VolumeStateDetaching),
+					writtenAgo:       2 * StatusUpdateCoalesceWindow,
+					expectedConflict: true,
+				},
+			},
+		},
+	}
+
+	defer SetStatusUpdateMetricsProvider(noopStatusUpdateMetricsProvider{})
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			metrics := &fakeStatusUpdateMetricsProvider{}
+			SetStatusUpdateMetricsProvider(metrics)
+
+			s := &DataStore{statusUpdateCoalescer: newStatusUpdateCoalescer()}
+			// stored is the object in the API server, and cached is the one in the informer cache
+			stored := tt.cached.DeepCopy()
+			cached := tt.cached.DeepCopy()
+			getCached := func(name string) (*longhorn.Volume, error) {
+				return cached, nil
+			}
+			update := func(v *longhorn.Volume) (*longhorn.Volume, error) {
+				if v.ResourceVersion != stored.ResourceVersion {
+					return nil, apierrors.NewConflict(schema.GroupResource{Resource: "volumes"}, v.Name, fmt.Errorf("stale resource version"))
+				}
+				ret := v.DeepCopy()
+				var resourceVersion int
+				_, err := fmt.Sscanf(v.ResourceVersion, "%d", &resourceVersion)
+				assert.NoError(err)
+				ret.ResourceVersion = fmt.Sprintf("%d", resourceVersion+1)
+				stored = ret.DeepCopy()
+				return ret, nil
+			}
+			getStatus := func(v *longhorn.Volume) interface{} {
+				return v.Status
+			}
+
+			for i, u := range tt.updates {
+				if u.writtenAgo != 0 {
+					for _, write := range s.statusUpdateCoalescer.writes {
+						write.writtenAt = write.writtenAt.Add(-u.writtenAgo)
+					}
+				}
+				if u.concurrentWrite != nil {
+					stored = u.concurrentWrite.DeepCopy()
+					cached = u.concurrentWrite.DeepCopy()
+				}
+
+				ret, err := updateStatus(s, "volume", u.obj, getStatus, getCached, update)
+				if u.expectedConflict {
+					assert.True(apierrors.IsConflict(err), "update %v", i)
+					assert.Len(metrics.results, i, "update %v", i)
+					continue
+				}
+				assert.NoError(err)
+				assert.Equal(u.expectedResourceVersion, ret.ResourceVersion, "update %v", i)
+				assert.Equal(u.obj.Status, ret.Status, "update %v", i)
+				assert.Equal(u.expectedResult, metrics.results[i], "update %v", i)
+				if !u.cacheBehind {
+					cached = stored.DeepCopy()
+				}
+			}
+		})
+	}
+}
@@ -158,6 +158,10 @@ type BackupTarget struct {
 	PollInterval     string `json:"pollInterval"`
 	Available        bool   `json:"available"`
 	Message          string `json:"message"`
+
+	Zone                  string `json:"zone"`
+	Region                string `json:"region"`
+	ReplicationTargetName string `json:"replicationTargetName"`
 }
 
 type BackupVolume struct {
@@ -26,6 +26,15 @@ const (
 	BackupCompressionMethodGzip = BackupCompressionMethod("gzip")
 )
 
+type BackupReplicationState string
+
+const (
+	BackupReplicationStateNone       = BackupReplicationState("")
+	BackupReplicationStateInProgress = BackupReplicationState("InProgress")
+	BackupReplicationStateCompleted  = BackupReplicationState("Completed")
+	BackupReplicationStateError      = BackupReplicationState("Error")
+)
+
 // +kubebuilder:validation:Enum=full;incremental;""
 type BackupMode string
 
@@ -122,6 +131,16 @@ type BackupStatus struct {
 	// The backup target name.
 	// +optional
 	BackupTargetName string `json:"backupTargetName"`
+	// The secondary backup target the completed backup is replicated to.
+	// +optional
+	ReplicationTargetName string `json:"replicationTargetName"`
+	// The backup on the secondary backup target replicating this backup.
+	// +optional
+	ReplicationBackupName string `json:"replicationBackupName"`
+	// The replication state.
+	// Can be "", "InProgress", "Completed", "Error".
+	// +optional
+	ReplicationState BackupReplicationState `json:"replicationState"`
 }
 
 // +genclient
@@ -23,6 +23,17 @@ type BackupTargetSpec struct {
 	// +optional
 	// +nullable
 	SyncRequestedAt metav1.Time `json:"syncRequestedAt"`
+	// The zone where the backup target is located. Backups of the volumes in the same zone prefer this backup target
+	// if the setting backup-target-zone-aware-selection is enabled.
+	// +optional
+	Zone string `json:"zone"`
+	// The region where the backup target is located. Backups of the volumes in the same region prefer this backup target
+	// if the setting backup-target-zone-aware-selection is enabled and there is no backup target in the same zone.
+	// +optional
+	Region string `json:"region"`
+	// The secondary backup target the completed backups are replicated to asynchronously.
+	// +optional
+	ReplicationTargetName string `json:"replicationTargetName"`
 }
 
 // BackupTargetStatus defines the observed state of the Longhorn backup target
@@ -0,0 +1,103 @@
+package v1beta2
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// +kubebuilder:validation:Enum=snapshot;backup
+type CloneScheduleSourceType string
+
+const (
+	CloneScheduleSourceTypeSnapshot = CloneScheduleSourceType("snapshot")
+	CloneScheduleSourceTypeBackup   = CloneScheduleSourceType("backup")
+)
+
+type CloneScheduleState string
+
+const (
+	CloneScheduleStateIdle       = CloneScheduleState("idle")
+	CloneScheduleStateDetaching  = CloneScheduleState("detaching")
+	CloneScheduleStateRefreshing = CloneScheduleState("refreshing")
+	CloneScheduleStateAttaching  = CloneScheduleState("attaching")
+	CloneScheduleStateError      = CloneScheduleState("error")
+)
+
+// CloneScheduleSpec defines the desired state of the Longhorn clone schedule
+type CloneScheduleSpec struct {
+	// The volume whose data is cloned.
+	// +optional
+	SourceVolume string `json:"sourceVolume"`
+	// The standby clone volume which is refreshed with the data of the source volume.
+	// +optional
+	TargetVolume string `json:"targetVolume"`
+	// The data of the source volume used for refreshing the target volume.
+	// Can be "snapshot" for the latest snapshot or "backup" for the latest completed backup of the source volume.
+	// +optional
+	SourceType CloneScheduleSourceType `json:"sourceType"`
+	// The cron setting.
+	// +optional
+	Cron string `json:"cron"`
+	// Suspend the subsequent refreshes. A refresh in progress is not affected.
+	// +optional
+	Suspend bool `json:"suspend"`
+}
+
+// CloneScheduleStatus defines the observed state of the Longhorn clone schedule
+type CloneScheduleStatus struct {
+	// The owner ID which is responsible to reconcile this clone schedule CR.
+	// +optional
+	OwnerID string `json:"ownerID"`
+	// +optional
+	State CloneScheduleState `json:"state"`
+	// The last time a refresh was triggered.
+	// +optional
+	LastScheduleTime string `json:"lastScheduleTime"`
+	// The last time the target volume was refreshed and reattached.
+	// +optional
+	LastRefreshTime string `json:"lastRefreshTime"`
+	// The snapshot or backup used by the last triggered refresh.
+	// +optional
+	LastRefreshSource string `json:"lastRefreshSource"`
+	// +optional
+	Message string `json:"message"`
+	// The target volume spec recorded before the target volume is recreated.
+	// +optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TargetVolumeSpec *VolumeSpec `json:"targetVolumeSpec,omitempty"`
+	// The target volume labels recorded before the target volume is recreated.
+	// +optional
+	TargetVolumeLabels map[string]string `json:"targetVolumeLabels,omitempty"`
+	// The attachment tickets removed from the target volume for the refresh, which are restored once the refresh is done.
+	// +optional
+	AttachmentTickets map[string]*AttachmentTicket `json:"attachmentTickets,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=lhcs
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.sourceVolume`,description="The volume whose data is cloned"
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.targetVolume`,description="The volume which is refreshed"
+// +kubebuilder:printcolumn:name="SourceType",type=string,JSONPath=`.spec.sourceType`,description="The data used for the refresh"
+// +kubebuilder:printcolumn:name="Cron",type=string,JSONPath=`.spec.cron`,description="The cron expression represents the refresh scheduling"
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`,description="The state of the clone schedule"
+// +kubebuilder:printcolumn:name="LastRefresh",type=string,JSONPath=`.status.lastRefreshTime`,description="The last time the target volume was refreshed"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// CloneSchedule is where Longhorn stores clone schedule object.
+type CloneSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloneScheduleSpec   `json:"spec,omitempty"`
+	Status CloneScheduleStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloneScheduleList is a list of CloneSchedules.
+type CloneScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloneSchedule `json:"items"`
+}
@@ -158,6 +158,11 @@ type EngineStatus struct {
 	// +nullable
 	ReplicaModeMap map[string]ReplicaMode `json:"replicaModeMap"`
 	// +optional
+	// ReplicaModeMapCheckedAt is the last time the engine monitor got the replica modes in ReplicaModeMap from the
+	// engine. It is refreshed regularly even if the replica modes do not change, so other controllers can tell
+	// whether ReplicaModeMap is still up to date.
+	ReplicaModeMapCheckedAt string `json:"replicaModeMapCheckedAt"`
+	// +optional
 	// ReplicaTransitionTimeMap records the time a replica in ReplicaModeMap transitions from one mode to another (or
 	// from not being in the ReplicaModeMap to being in it). This information is sometimes required by other controllers
 	// (e.g. the volume controller uses it to determine the correct value for replica.Spec.lastHealthyAt).
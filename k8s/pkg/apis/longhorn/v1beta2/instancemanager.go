@@ -20,6 +20,14 @@ const (
 	InstanceManagerStateUnknown  = InstanceManagerState("unknown")
 )
 
+type InstanceManagerDrainState string
+
+const (
+	InstanceManagerDrainStateNone     = InstanceManagerDrainState("")
+	InstanceManagerDrainStateDraining = InstanceManagerDrainState("draining")
+	InstanceManagerDrainStateTimedOut = InstanceManagerDrainState("timedOut")
+)
+
 // +kubebuilder:validation:Enum=aio;engine;replica
 type InstanceManagerType string
 
@@ -207,6 +215,12 @@ type InstanceManagerStatus struct {
 	ProxyAPIVersion int `json:"proxyApiVersion"`
 	// +optional
 	DataEngineStatus DataEngineStatus `json:"dataEngineStatus"`
+	// DrainState indicates whether the instance manager is moving its instances away before its pod is replaced.
+	// +optional
+	DrainState InstanceManagerDrainState `json:"drainState"`
+	// DrainStartedAt is the time when the current drain started.
+	// +optional
+	DrainStartedAt string `json:"drainStartedAt"`
 
 	// Deprecated: Replaced by InstanceEngines and InstanceReplicas
 	// +optional
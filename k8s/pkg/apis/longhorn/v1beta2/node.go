@@ -19,6 +19,7 @@ const (
 	NodeConditionReasonKubernetesNodeGone        = "KubernetesNodeGone"
 	NodeConditionReasonKubernetesNodeNotReady    = "KubernetesNodeNotReady"
 	NodeConditionReasonKubernetesNodePressure    = "KubernetesNodePressure"
+	NodeConditionReasonNetworkPartitioned        = "NetworkPartitioned"
 	NodeConditionReasonUnknownNodeConditionTrue  = "UnknownNodeConditionTrue"
 	NodeConditionReasonNoMountPropagationSupport = "NoMountPropagationSupport"
 	NodeConditionReasonMultipathdIsRunning       = "MultipathdIsRunning"
@@ -46,6 +47,7 @@ const (
 	DiskConditionReasonNoDiskInfo             = "NoDiskInfo"
 	DiskConditionReasonDiskNotReady           = "DiskNotReady"
 	DiskConditionReasonDiskServiceUnreachable = "DiskServiceUnreachable"
+	DiskConditionReasonDiskEncryptionFailed   = "DiskEncryptionFailed"
 )
 
 const (
@@ -102,6 +104,14 @@ type DiskSpec struct {
 	StorageReserved int64 `json:"storageReserved"`
 	// +optional
 	Tags []string `json:"tags"`
+	// Encrypt the whole disk with dm-crypt when it is registered, so all replicas on the disk are encrypted at rest.
+	// Only block-type disks are supported. A filesystem-type disk is a directory on a filesystem mounted by the host,
+	// and Longhorn does not own the device underneath it. Encrypt that device on the host instead.
+	// +optional
+	Encrypted bool `json:"encrypted"`
+	// The name of the secret in the Longhorn namespace containing the passphrase and the optional cipher parameters for the disk encryption.
+	// +optional
+	EncryptionSecret string `json:"encryptionSecret"`
 }
 
 type DiskStatus struct {
@@ -134,6 +144,9 @@ type DiskStatus struct {
 	FSType string `json:"filesystemType"`
 	// +optional
 	InstanceManagerName string `json:"instanceManagerName"`
+	// The path of the opened dm-crypt device backing the disk if the disk is encrypted.
+	// +optional
+	EncryptedDevicePath string `json:"encryptedDevicePath"`
 }
 
 // NodeSpec defines the desired state of the Longhorn node
@@ -168,6 +181,15 @@ type NodeStatus struct {
 	SnapshotCheckStatus SnapshotCheckStatus `json:"snapshotCheckStatus"`
 	// +optional
 	AutoEvicting bool `json:"autoEvicting"`
+	// The UID of the Kubernetes node the Longhorn node was last running on.
+	// +optional
+	KubernetesNodeUID string `json:"kubernetesNodeUID"`
+	// The machine ID of the Kubernetes node the Longhorn node was last running on.
+	// +optional
+	MachineID string `json:"machineID"`
+	// The node ID registered by the Longhorn CSI driver in the CSINode of the Kubernetes node.
+	// +optional
+	CSIDriverNodeID string `json:"csiDriverNodeID"`
 }
 
 // +genclient
@@ -35,6 +35,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&BackupTargetList{},
 		&BackupVolume{},
 		&BackupVolumeList{},
+		&CloneSchedule{},
+		&CloneScheduleList{},
 		&Engine{},
 		&EngineList{},
 		&EngineImage{},
@@ -48,7 +50,11 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&RecurringJob{},
 		&RecurringJobList{},
 		&Replica{},
+		&ReplicaCountPolicy{},
+		&ReplicaCountPolicyList{},
 		&ReplicaList{},
+		&RestoreTest{},
+		&RestoreTestList{},
 		&Setting{},
 		&SettingList{},
 		&ShareManager{},
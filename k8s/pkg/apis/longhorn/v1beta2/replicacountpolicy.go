@@ -0,0 +1,84 @@
+package v1beta2
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// ReplicaCountPolicySpec defines the desired state of the Longhorn replica count policy
+type ReplicaCountPolicySpec struct {
+	// The volumes whose labels match all the given labels are managed by this policy.
+	// +optional
+	Selector map[string]string `json:"selector"`
+	// The number of replicas of the selected volumes when the cluster has enough capacity.
+	// +optional
+	NumberOfReplicas int `json:"numberOfReplicas"`
+	// The number of replicas the selected volumes are reduced to when the cluster lacks capacity.
+	// +optional
+	MinNumberOfReplicas int `json:"minNumberOfReplicas"`
+	// The policy with the highest priority applies to a volume selected by multiple policies.
+	// +optional
+	Priority int `json:"priority"`
+	// The time in seconds a new number of replicas must be continuously desired before it is applied.
+	// It is also the minimum interval between two changes of the same volume.
+	// +optional
+	StabilizationWindowSeconds int64 `json:"stabilizationWindowSeconds"`
+	// Only report the decisions in the status without changing the volumes.
+	// +optional
+	DryRun bool `json:"dryRun"`
+}
+
+// ReplicaCountPolicyDecision is the number of replicas the policy decides for a volume
+type ReplicaCountPolicyDecision struct {
+	// +optional
+	VolumeName string `json:"volumeName"`
+	// +optional
+	CurrentNumberOfReplicas int `json:"currentNumberOfReplicas"`
+	// +optional
+	DesiredNumberOfReplicas int `json:"desiredNumberOfReplicas"`
+	// +optional
+	Reason string `json:"reason"`
+	// The time since the desired number of replicas differs from the current one.
+	// +optional
+	PendingSince string `json:"pendingSince"`
+	// The last time the policy changed the number of replicas of the volume.
+	// +optional
+	LastAppliedTime string `json:"lastAppliedTime"`
+}
+
+// ReplicaCountPolicyStatus defines the observed state of the Longhorn replica count policy
+type ReplicaCountPolicyStatus struct {
+	// The owner ID which is responsible to reconcile this replica count policy CR.
+	// +optional
+	OwnerID string `json:"ownerID"`
+	// The decisions for the volumes managed by this policy.
+	// +optional
+	// +nullable
+	Decisions []ReplicaCountPolicyDecision `json:"decisions"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=lhrcp
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.spec.numberOfReplicas`,description="The number of replicas of the selected volumes"
+// +kubebuilder:printcolumn:name="MinReplicas",type=integer,JSONPath=`.spec.minNumberOfReplicas`,description="The number of replicas when the cluster lacks capacity"
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=`.spec.priority`,description="The priority of the policy"
+// +kubebuilder:printcolumn:name="DryRun",type=boolean,JSONPath=`.spec.dryRun`,description="Only report the decisions"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ReplicaCountPolicy is where Longhorn stores replica count policy object.
+type ReplicaCountPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReplicaCountPolicySpec   `json:"spec,omitempty"`
+	Status ReplicaCountPolicyStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ReplicaCountPolicyList is a list of ReplicaCountPolicies.
+type ReplicaCountPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReplicaCountPolicy `json:"items"`
+}
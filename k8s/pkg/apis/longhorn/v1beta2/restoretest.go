@@ -0,0 +1,123 @@
+package v1beta2
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// +kubebuilder:validation:Enum=random;latest
+type RestoreTestSelectionPolicy string
+
+const (
+	RestoreTestSelectionPolicyRandom = RestoreTestSelectionPolicy("random")
+	RestoreTestSelectionPolicyLatest = RestoreTestSelectionPolicy("latest")
+)
+
+type RestoreTestState string
+
+const (
+	RestoreTestStateIdle       = RestoreTestState("idle")
+	RestoreTestStateRestoring  = RestoreTestState("restoring")
+	RestoreTestStateValidating = RestoreTestState("validating")
+	RestoreTestStateCleaningUp = RestoreTestState("cleaning-up")
+)
+
+type RestoreTestResult string
+
+const (
+	RestoreTestResultPassed = RestoreTestResult("passed")
+	RestoreTestResultFailed = RestoreTestResult("failed")
+)
+
+// RestoreTestSpec defines the desired state of the Longhorn restore test
+type RestoreTestSpec struct {
+	// The backup target whose backups are tested.
+	// +optional
+	BackupTargetName string `json:"backupTargetName"`
+	// Only test the backups of this volume. Backups of all volumes are candidates if empty.
+	// +optional
+	VolumeName string `json:"volumeName"`
+	// How the tested backup is picked among the completed backups.
+	// Can be "random" for a randomly selected backup or "latest" for the latest completed backup.
+	// +optional
+	SelectionPolicy RestoreTestSelectionPolicy `json:"selectionPolicy"`
+	// The cron setting.
+	// +optional
+	Cron string `json:"cron"`
+	// Suspend the subsequent tests. A test in progress is not affected.
+	// +optional
+	Suspend bool `json:"suspend"`
+	// The image of the checker pod which validates the restored data.
+	// +optional
+	Image string `json:"image"`
+	// The validation command run by the checker pod. The restored filesystem is mounted at /data.
+	// The entrypoint of the image is used if empty.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// The filesystem of the restored volume, used if the PV of the backup source volume is not found.
+	// The restored volume is mounted with the filesystem found on it and never formatted.
+	// +optional
+	FSType string `json:"fsType"`
+	// The time in seconds a test may take before it is considered failed.
+	// +optional
+	TimeoutSeconds int64 `json:"timeoutSeconds"`
+}
+
+// RestoreTestStatus defines the observed state of the Longhorn restore test
+type RestoreTestStatus struct {
+	// The owner ID which is responsible to reconcile this restore test CR.
+	// +optional
+	OwnerID string `json:"ownerID"`
+	// +optional
+	State RestoreTestState `json:"state"`
+	// The last time a test was triggered.
+	// +optional
+	LastScheduleTime string `json:"lastScheduleTime"`
+	// The backup being tested.
+	// +optional
+	CurrentBackup string `json:"currentBackup"`
+	// The last time a test finished.
+	// +optional
+	LastTestTime string `json:"lastTestTime"`
+	// The backup tested by the last finished test.
+	// +optional
+	LastTestedBackup string `json:"lastTestedBackup"`
+	// +optional
+	LastResult RestoreTestResult `json:"lastResult"`
+	// +optional
+	Message string `json:"message"`
+	// The number of passed tests.
+	// +optional
+	PassedCount int64 `json:"passedCount"`
+	// The number of failed tests.
+	// +optional
+	FailedCount int64 `json:"failedCount"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=lhrt
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="BackupTarget",type=string,JSONPath=`.spec.backupTargetName`,description="The backup target whose backups are tested"
+// +kubebuilder:printcolumn:name="Volume",type=string,JSONPath=`.spec.volumeName`,description="The volume whose backups are tested"
+// +kubebuilder:printcolumn:name="Cron",type=string,JSONPath=`.spec.cron`,description="The cron expression represents the test scheduling"
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`,description="The state of the restore test"
+// +kubebuilder:printcolumn:name="LastResult",type=string,JSONPath=`.status.lastResult`,description="The result of the last test"
+// +kubebuilder:printcolumn:name="LastTest",type=string,JSONPath=`.status.lastTestTime`,description="The last time a test finished"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RestoreTest is where Longhorn stores restore test object.
+type RestoreTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RestoreTestSpec   `json:"spec,omitempty"`
+	Status RestoreTestStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RestoreTestList is a list of RestoreTests.
+type RestoreTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RestoreTest `json:"items"`
+}
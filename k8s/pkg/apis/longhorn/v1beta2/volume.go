@@ -13,6 +13,7 @@ const (
 	VolumeStateAttaching = VolumeState("attaching")
 	VolumeStateDetaching = VolumeState("detaching")
 	VolumeStateDeleting  = VolumeState("deleting")
+	VolumeStateTrashed   = VolumeState("trashed")
 )
 
 type VolumeRobustness string
@@ -116,6 +117,7 @@ const (
 	VolumeConditionTypeRestore             = "Restore"
 	VolumeConditionTypeTooManySnapshots    = "TooManySnapshots"
 	VolumeConditionTypeWaitForBackingImage = "WaitForBackingImage"
+	VolumeConditionTypeEngineUpgradeReady  = "EngineUpgradeReady"
 )
 
 const (
@@ -126,6 +128,10 @@ const (
 	VolumeConditionReasonTooManySnapshots              = "TooManySnapshots"
 	VolumeConditionReasonWaitForBackingImageFailed     = "GetBackingImageFailed"
 	VolumeConditionReasonWaitForBackingImageWaiting    = "Waiting"
+	VolumeConditionReasonReplicaUnhealthy              = "ReplicaUnhealthy"
+	VolumeConditionReasonRebuildInProgress             = "RebuildInProgress"
+	VolumeConditionReasonInsufficientMemory            = "InsufficientInstanceManagerMemory"
+	VolumeConditionReasonUpgradeFrozen                 = "UpgradeFrozen"
 )
 
 type SnapshotDataIntegrity string
@@ -282,6 +288,9 @@ type VolumeSpec struct {
 	// +kubebuilder:validation:Enum=ignored;disabled;enabled;fast-check
 	// +optional
 	SnapshotDataIntegrity SnapshotDataIntegrity `json:"snapshotDataIntegrity"`
+	// The cron schedule of the periodic snapshot data integrity check for the volume. Empty means following the global setting snapshot-data-integrity-cronjob.
+	// +optional
+	SnapshotDataIntegrityCronJob string `json:"snapshotDataIntegrityCronJob"`
 	// +kubebuilder:validation:Enum=none;lz4;gzip
 	// +optional
 	BackupCompressionMethod BackupCompressionMethod `json:"backupCompressionMethod"`
@@ -306,6 +315,12 @@ type VolumeSpec struct {
 	// - disabled: Disable offline rebuilding for this volume, regardless of the global setting
 	// +optional
 	OfflineRebuilding VolumeOfflineRebuilding `json:"offlineRebuilding"`
+	// The time the volume was moved to the recycle bin. A non-empty value means the volume is trashed and will be purged once the recycle bin retention period has passed.
+	// +optional
+	TrashedAt string `json:"trashedAt"`
+	// Relocate the existing replicas placed on nodes or disks that don't match the node selector or the disk selector, one replica at a time.
+	// +optional
+	ReplicaSelectorRelocation bool `json:"replicaSelectorRelocation"`
 }
 
 // VolumeStatus defines the observed state of the Longhorn volume
@@ -354,6 +369,10 @@ type VolumeStatus struct {
 	ShareEndpoint string `json:"shareEndpoint"`
 	// +optional
 	ShareState ShareManagerState `json:"shareState"`
+	// The replicas placed on nodes or disks that don't match the node selector or the disk selector of the volume.
+	// +optional
+	// +nullable
+	SelectorViolatingReplicas []string `json:"selectorViolatingReplicas"`
 }
 
 // +genclient
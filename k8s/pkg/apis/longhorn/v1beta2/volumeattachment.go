@@ -10,6 +10,10 @@ type AttachmentTicket struct {
 	ID string `json:"id"`
 	// +optional
 	Type AttacherType `json:"type"`
+	// The identity of the entity requesting this attachment, for example the user of a UI request,
+	// the recurring job or the object that a Longhorn controller is working on. Empty if unknown.
+	// +optional
+	AttacherID string `json:"attacherID"`
 	// The node that this attachment is requesting
 	// +optional
 	NodeID string `json:"nodeID"`
@@ -28,6 +32,12 @@ type AttachmentTicketStatus struct {
 	ID string `json:"id"`
 	// Indicate whether this attachment ticket has been satisfied
 	Satisfied bool `json:"satisfied"`
+	// The type of the attacher requesting this attachment
+	// +optional
+	AttacherType AttacherType `json:"attacherType"`
+	// The identity of the entity requesting this attachment
+	// +optional
+	AttacherID string `json:"attacherID"`
 	// Record any error when trying to fulfill this attachment
 	// +nullable
 	Conditions []Condition `json:"conditions"`
@@ -76,6 +86,10 @@ const (
 
 	AttachmentParameterDisableFrontend = "disableFrontend"
 	AttachmentParameterLastAttachedBy  = "lastAttachedBy"
+	// AttachmentParameterMaintenance marks a ticket attaching a volume without frontend in the background
+	// for an internal operation (e.g. backup). Such a ticket is hidden from the user-facing attachment
+	// accounting and gives way to workload attachments.
+	AttachmentParameterMaintenance = "maintenance"
 )
 
 const (
@@ -123,6 +137,13 @@ func GetAttachmentTicketID(attacherType AttacherType, id string) string {
 	return retID
 }
 
+func IsMaintenanceAttachmentTicket(attachmentTicket *AttachmentTicket) bool {
+	if attachmentTicket == nil || attachmentTicket.Parameters == nil {
+		return false
+	}
+	return attachmentTicket.Parameters[AttachmentParameterMaintenance] == TrueValue
+}
+
 func GetNodeIdOfAttachmentTicket(attachmentID string, va *VolumeAttachment) string {
 	if va == nil {
 		return ""
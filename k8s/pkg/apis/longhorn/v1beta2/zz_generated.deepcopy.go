@@ -944,6 +944,126 @@ func (in *BackupVolumeStatus) DeepCopy() *BackupVolumeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneSchedule) DeepCopyInto(out *CloneSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSchedule.
+func (in *CloneSchedule) DeepCopy() *CloneSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloneSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneScheduleList) DeepCopyInto(out *CloneScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloneSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneScheduleList.
+func (in *CloneScheduleList) DeepCopy() *CloneScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloneScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneScheduleSpec) DeepCopyInto(out *CloneScheduleSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneScheduleSpec.
+func (in *CloneScheduleSpec) DeepCopy() *CloneScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneScheduleStatus) DeepCopyInto(out *CloneScheduleStatus) {
+	*out = *in
+	if in.TargetVolumeSpec != nil {
+		in, out := &in.TargetVolumeSpec, &out.TargetVolumeSpec
+		*out = new(VolumeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetVolumeLabels != nil {
+		in, out := &in.TargetVolumeLabels, &out.TargetVolumeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AttachmentTickets != nil {
+		in, out := &in.AttachmentTickets, &out.AttachmentTickets
+		*out = make(map[string]*AttachmentTicket, len(*in))
+		for key, val := range *in {
+			var outVal *AttachmentTicket
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(AttachmentTicket)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneScheduleStatus.
+func (in *CloneScheduleStatus) DeepCopy() *CloneScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -2065,6 +2185,127 @@ func (in *Replica) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaCountPolicy) DeepCopyInto(out *ReplicaCountPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaCountPolicy.
+func (in *ReplicaCountPolicy) DeepCopy() *ReplicaCountPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaCountPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplicaCountPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaCountPolicyDecision) DeepCopyInto(out *ReplicaCountPolicyDecision) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaCountPolicyDecision.
+func (in *ReplicaCountPolicyDecision) DeepCopy() *ReplicaCountPolicyDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaCountPolicyDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaCountPolicyList) DeepCopyInto(out *ReplicaCountPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReplicaCountPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaCountPolicyList.
+func (in *ReplicaCountPolicyList) DeepCopy() *ReplicaCountPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaCountPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplicaCountPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaCountPolicySpec) DeepCopyInto(out *ReplicaCountPolicySpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaCountPolicySpec.
+func (in *ReplicaCountPolicySpec) DeepCopy() *ReplicaCountPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaCountPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaCountPolicyStatus) DeepCopyInto(out *ReplicaCountPolicyStatus) {
+	*out = *in
+	if in.Decisions != nil {
+		in, out := &in.Decisions, &out.Decisions
+		*out = make([]ReplicaCountPolicyDecision, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaCountPolicyStatus.
+func (in *ReplicaCountPolicyStatus) DeepCopy() *ReplicaCountPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaCountPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicaList) DeepCopyInto(out *ReplicaList) {
 	*out = *in
@@ -2148,6 +2389,104 @@ func (in *RestoreStatus) DeepCopy() *RestoreStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreTest) DeepCopyInto(out *RestoreTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTest.
+func (in *RestoreTest) DeepCopy() *RestoreTest {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestoreTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreTestList) DeepCopyInto(out *RestoreTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RestoreTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTestList.
+func (in *RestoreTestList) DeepCopy() *RestoreTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestoreTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreTestSpec) DeepCopyInto(out *RestoreTestSpec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTestSpec.
+func (in *RestoreTestSpec) DeepCopy() *RestoreTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreTestStatus) DeepCopyInto(out *RestoreTestStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTestStatus.
+func (in *RestoreTestStatus) DeepCopy() *RestoreTestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreTestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Setting) DeepCopyInto(out *Setting) {
 	*out = *in
@@ -3096,6 +3435,11 @@ func (in *VolumeStatus) DeepCopyInto(out *VolumeStatus) {
 		copy(*out, *in)
 	}
 	out.CloneStatus = in.CloneStatus
+	if in.SelectorViolatingReplicas != nil {
+		in, out := &in.SelectorViolatingReplicas, &out.SelectorViolatingReplicas
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
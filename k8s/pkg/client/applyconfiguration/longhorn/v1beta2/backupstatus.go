@@ -47,6 +47,9 @@ type BackupStatusApplyConfiguration struct {
 	NewlyUploadedDataSize  *string                                  `json:"newlyUploadDataSize,omitempty"`
 	ReUploadedDataSize     *string                                  `json:"reUploadedDataSize,omitempty"`
 	BackupTargetName       *string                                  `json:"backupTargetName,omitempty"`
+	ReplicationTargetName  *string                                  `json:"replicationTargetName,omitempty"`
+	ReplicationBackupName  *string                                  `json:"replicationBackupName,omitempty"`
+	ReplicationState       *longhornv1beta2.BackupReplicationState  `json:"replicationState,omitempty"`
 }
 
 // BackupStatusApplyConfiguration constructs a declarative configuration of the BackupStatus type for use with
@@ -234,3 +237,27 @@ func (b *BackupStatusApplyConfiguration) WithBackupTargetName(value string) *Bac
 	b.BackupTargetName = &value
 	return b
 }
+
+// WithReplicationTargetName sets the ReplicationTargetName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReplicationTargetName field is set to the value of the last call.
+func (b *BackupStatusApplyConfiguration) WithReplicationTargetName(value string) *BackupStatusApplyConfiguration {
+	b.ReplicationTargetName = &value
+	return b
+}
+
+// WithReplicationBackupName sets the ReplicationBackupName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReplicationBackupName field is set to the value of the last call.
+func (b *BackupStatusApplyConfiguration) WithReplicationBackupName(value string) *BackupStatusApplyConfiguration {
+	b.ReplicationBackupName = &value
+	return b
+}
+
+// WithReplicationState sets the ReplicationState field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReplicationState field is set to the value of the last call.
+func (b *BackupStatusApplyConfiguration) WithReplicationState(value longhornv1beta2.BackupReplicationState) *BackupStatusApplyConfiguration {
+	b.ReplicationState = &value
+	return b
+}
@@ -25,10 +25,13 @@ import (
 // BackupTargetSpecApplyConfiguration represents a declarative configuration of the BackupTargetSpec type for use
 // with apply.
 type BackupTargetSpecApplyConfiguration struct {
-	BackupTargetURL  *string      `json:"backupTargetURL,omitempty"`
-	CredentialSecret *string      `json:"credentialSecret,omitempty"`
-	PollInterval     *v1.Duration `json:"pollInterval,omitempty"`
-	SyncRequestedAt  *v1.Time     `json:"syncRequestedAt,omitempty"`
+	BackupTargetURL       *string      `json:"backupTargetURL,omitempty"`
+	CredentialSecret      *string      `json:"credentialSecret,omitempty"`
+	PollInterval          *v1.Duration `json:"pollInterval,omitempty"`
+	SyncRequestedAt       *v1.Time     `json:"syncRequestedAt,omitempty"`
+	Zone                  *string      `json:"zone,omitempty"`
+	Region                *string      `json:"region,omitempty"`
+	ReplicationTargetName *string      `json:"replicationTargetName,omitempty"`
 }
 
 // BackupTargetSpecApplyConfiguration constructs a declarative configuration of the BackupTargetSpec type for use with
@@ -68,3 +71,27 @@ func (b *BackupTargetSpecApplyConfiguration) WithSyncRequestedAt(value v1.Time)
 	b.SyncRequestedAt = &value
 	return b
 }
+
+// WithZone sets the Zone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Zone field is set to the value of the last call.
+func (b *BackupTargetSpecApplyConfiguration) WithZone(value string) *BackupTargetSpecApplyConfiguration {
+	b.Zone = &value
+	return b
+}
+
+// WithRegion sets the Region field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Region field is set to the value of the last call.
+func (b *BackupTargetSpecApplyConfiguration) WithRegion(value string) *BackupTargetSpecApplyConfiguration {
+	b.Region = &value
+	return b
+}
+
+// WithReplicationTargetName sets the ReplicationTargetName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReplicationTargetName field is set to the value of the last call.
+func (b *BackupTargetSpecApplyConfiguration) WithReplicationTargetName(value string) *BackupTargetSpecApplyConfiguration {
+	b.ReplicationTargetName = &value
+	return b
+}
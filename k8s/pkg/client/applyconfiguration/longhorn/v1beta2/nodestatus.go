@@ -31,6 +31,9 @@ type NodeStatusApplyConfiguration struct {
 	Zone                *string                                `json:"zone,omitempty"`
 	SnapshotCheckStatus *SnapshotCheckStatusApplyConfiguration `json:"snapshotCheckStatus,omitempty"`
 	AutoEvicting        *bool                                  `json:"autoEvicting,omitempty"`
+	KubernetesNodeUID   *string                                `json:"kubernetesNodeUID,omitempty"`
+	MachineID           *string                                `json:"machineID,omitempty"`
+	CSIDriverNodeID     *string                                `json:"csiDriverNodeID,omitempty"`
 }
 
 // NodeStatusApplyConfiguration constructs a declarative configuration of the NodeStatus type for use with
@@ -97,3 +100,27 @@ func (b *NodeStatusApplyConfiguration) WithAutoEvicting(value bool) *NodeStatusA
 	b.AutoEvicting = &value
 	return b
 }
+
+// WithKubernetesNodeUID sets the KubernetesNodeUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KubernetesNodeUID field is set to the value of the last call.
+func (b *NodeStatusApplyConfiguration) WithKubernetesNodeUID(value string) *NodeStatusApplyConfiguration {
+	b.KubernetesNodeUID = &value
+	return b
+}
+
+// WithMachineID sets the MachineID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MachineID field is set to the value of the last call.
+func (b *NodeStatusApplyConfiguration) WithMachineID(value string) *NodeStatusApplyConfiguration {
+	b.MachineID = &value
+	return b
+}
+
+// WithCSIDriverNodeID sets the CSIDriverNodeID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CSIDriverNodeID field is set to the value of the last call.
+func (b *NodeStatusApplyConfiguration) WithCSIDriverNodeID(value string) *NodeStatusApplyConfiguration {
+	b.CSIDriverNodeID = &value
+	return b
+}
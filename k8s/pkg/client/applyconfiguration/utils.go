@@ -86,6 +86,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &longhornv1beta2.BackupVolumeSpecApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("BackupVolumeStatus"):
 		return &longhornv1beta2.BackupVolumeStatusApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("CloneSchedule"):
+		return &longhornv1beta2.CloneScheduleApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("CloneScheduleSpec"):
+		return &longhornv1beta2.CloneScheduleSpecApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("CloneScheduleStatus"):
+		return &longhornv1beta2.CloneScheduleStatusApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("Condition"):
 		return &longhornv1beta2.ConditionApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("DataEngineSpec"):
@@ -154,10 +160,24 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &longhornv1beta2.RecurringJobStatusApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("Replica"):
 		return &longhornv1beta2.ReplicaApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("ReplicaCountPolicy"):
+		return &longhornv1beta2.ReplicaCountPolicyApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("ReplicaCountPolicyDecision"):
+		return &longhornv1beta2.ReplicaCountPolicyDecisionApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("ReplicaCountPolicySpec"):
+		return &longhornv1beta2.ReplicaCountPolicySpecApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("ReplicaCountPolicyStatus"):
+		return &longhornv1beta2.ReplicaCountPolicyStatusApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("ReplicaSpec"):
 		return &longhornv1beta2.ReplicaSpecApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("RestoreStatus"):
 		return &longhornv1beta2.RestoreStatusApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("RestoreTest"):
+		return &longhornv1beta2.RestoreTestApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("RestoreTestSpec"):
+		return &longhornv1beta2.RestoreTestSpecApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("RestoreTestStatus"):
+		return &longhornv1beta2.RestoreTestStatusApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("Setting"):
 		return &longhornv1beta2.SettingApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("SettingStatus"):
@@ -56,6 +56,10 @@ func (c *FakeLonghornV1beta2) BackupVolumes(namespace string) v1beta2.BackupVolu
 	return newFakeBackupVolumes(c, namespace)
 }
 
+func (c *FakeLonghornV1beta2) CloneSchedules(namespace string) v1beta2.CloneScheduleInterface {
+	return newFakeCloneSchedules(c, namespace)
+}
+
 func (c *FakeLonghornV1beta2) Engines(namespace string) v1beta2.EngineInterface {
 	return newFakeEngines(c, namespace)
 }
@@ -84,6 +88,14 @@ func (c *FakeLonghornV1beta2) Replicas(namespace string) v1beta2.ReplicaInterfac
 	return newFakeReplicas(c, namespace)
 }
 
+func (c *FakeLonghornV1beta2) ReplicaCountPolicies(namespace string) v1beta2.ReplicaCountPolicyInterface {
+	return newFakeReplicaCountPolicies(c, namespace)
+}
+
+func (c *FakeLonghornV1beta2) RestoreTests(namespace string) v1beta2.RestoreTestInterface {
+	return newFakeRestoreTests(c, namespace)
+}
+
 func (c *FakeLonghornV1beta2) Settings(namespace string) v1beta2.SettingInterface {
 	return newFakeSettings(c, namespace)
 }
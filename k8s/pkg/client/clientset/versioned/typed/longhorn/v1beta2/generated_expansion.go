@@ -32,6 +32,8 @@ type BackupTargetExpansion interface{}
 
 type BackupVolumeExpansion interface{}
 
+type CloneScheduleExpansion interface{}
+
 type EngineExpansion interface{}
 
 type EngineImageExpansion interface{}
@@ -46,6 +48,10 @@ type RecurringJobExpansion interface{}
 
 type ReplicaExpansion interface{}
 
+type ReplicaCountPolicyExpansion interface{}
+
+type RestoreTestExpansion interface{}
+
 type SettingExpansion interface{}
 
 type ShareManagerExpansion interface{}
@@ -35,6 +35,7 @@ type LonghornV1beta2Interface interface {
 	BackupBackingImagesGetter
 	BackupTargetsGetter
 	BackupVolumesGetter
+	CloneSchedulesGetter
 	EnginesGetter
 	EngineImagesGetter
 	InstanceManagersGetter
@@ -42,6 +43,8 @@ type LonghornV1beta2Interface interface {
 	OrphansGetter
 	RecurringJobsGetter
 	ReplicasGetter
+	ReplicaCountPoliciesGetter
+	RestoreTestsGetter
 	SettingsGetter
 	ShareManagersGetter
 	SnapshotsGetter
@@ -85,6 +88,10 @@ func (c *LonghornV1beta2Client) BackupVolumes(namespace string) BackupVolumeInte
 	return newBackupVolumes(c, namespace)
 }
 
+func (c *LonghornV1beta2Client) CloneSchedules(namespace string) CloneScheduleInterface {
+	return newCloneSchedules(c, namespace)
+}
+
 func (c *LonghornV1beta2Client) Engines(namespace string) EngineInterface {
 	return newEngines(c, namespace)
 }
@@ -113,6 +120,14 @@ func (c *LonghornV1beta2Client) Replicas(namespace string) ReplicaInterface {
 	return newReplicas(c, namespace)
 }
 
+func (c *LonghornV1beta2Client) ReplicaCountPolicies(namespace string) ReplicaCountPolicyInterface {
+	return newReplicaCountPolicies(c, namespace)
+}
+
+func (c *LonghornV1beta2Client) RestoreTests(namespace string) RestoreTestInterface {
+	return newRestoreTests(c, namespace)
+}
+
 func (c *LonghornV1beta2Client) Settings(namespace string) SettingInterface {
 	return newSettings(c, namespace)
 }
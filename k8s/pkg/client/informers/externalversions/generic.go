@@ -67,6 +67,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().BackupTargets().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("backupvolumes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().BackupVolumes().Informer()}, nil
+	case v1beta2.SchemeGroupVersion.WithResource("cloneschedules"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().CloneSchedules().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("engines"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().Engines().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("engineimages"):
@@ -79,8 +81,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().Orphans().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("recurringjobs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().RecurringJobs().Informer()}, nil
+	case v1beta2.SchemeGroupVersion.WithResource("replicacountpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().ReplicaCountPolicies().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("replicas"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().Replicas().Informer()}, nil
+	case v1beta2.SchemeGroupVersion.WithResource("restoretests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().RestoreTests().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("settings"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().Settings().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("sharemanagers"):
@@ -38,6 +38,8 @@ type Interface interface {
 	BackupTargets() BackupTargetInformer
 	// BackupVolumes returns a BackupVolumeInformer.
 	BackupVolumes() BackupVolumeInformer
+	// CloneSchedules returns a CloneScheduleInformer.
+	CloneSchedules() CloneScheduleInformer
 	// Engines returns a EngineInformer.
 	Engines() EngineInformer
 	// EngineImages returns a EngineImageInformer.
@@ -52,6 +54,10 @@ type Interface interface {
 	RecurringJobs() RecurringJobInformer
 	// Replicas returns a ReplicaInformer.
 	Replicas() ReplicaInformer
+	// ReplicaCountPolicies returns a ReplicaCountPolicyInformer.
+	ReplicaCountPolicies() ReplicaCountPolicyInformer
+	// RestoreTests returns a RestoreTestInformer.
+	RestoreTests() RestoreTestInformer
 	// Settings returns a SettingInformer.
 	Settings() SettingInformer
 	// ShareManagers returns a ShareManagerInformer.
@@ -116,6 +122,11 @@ func (v *version) BackupVolumes() BackupVolumeInformer {
 	return &backupVolumeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CloneSchedules returns a CloneScheduleInformer.
+func (v *version) CloneSchedules() CloneScheduleInformer {
+	return &cloneScheduleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Engines returns a EngineInformer.
 func (v *version) Engines() EngineInformer {
 	return &engineInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -151,6 +162,16 @@ func (v *version) Replicas() ReplicaInformer {
 	return &replicaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ReplicaCountPolicies returns a ReplicaCountPolicyInformer.
+func (v *version) ReplicaCountPolicies() ReplicaCountPolicyInformer {
+	return &replicaCountPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// RestoreTests returns a RestoreTestInformer.
+func (v *version) RestoreTests() RestoreTestInformer {
+	return &restoreTestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Settings returns a SettingInformer.
 func (v *version) Settings() SettingInformer {
 	return &settingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
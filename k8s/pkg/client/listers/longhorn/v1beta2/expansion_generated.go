@@ -74,6 +74,14 @@ type BackupVolumeListerExpansion interface{}
 // BackupVolumeNamespaceLister.
 type BackupVolumeNamespaceListerExpansion interface{}
 
+// CloneScheduleListerExpansion allows custom methods to be added to
+// CloneScheduleLister.
+type CloneScheduleListerExpansion interface{}
+
+// CloneScheduleNamespaceListerExpansion allows custom methods to be added to
+// CloneScheduleNamespaceLister.
+type CloneScheduleNamespaceListerExpansion interface{}
+
 // EngineListerExpansion allows custom methods to be added to
 // EngineLister.
 type EngineListerExpansion interface{}
@@ -126,10 +134,26 @@ type RecurringJobNamespaceListerExpansion interface{}
 // ReplicaLister.
 type ReplicaListerExpansion interface{}
 
+// ReplicaCountPolicyListerExpansion allows custom methods to be added to
+// ReplicaCountPolicyLister.
+type ReplicaCountPolicyListerExpansion interface{}
+
+// ReplicaCountPolicyNamespaceListerExpansion allows custom methods to be added to
+// ReplicaCountPolicyNamespaceLister.
+type ReplicaCountPolicyNamespaceListerExpansion interface{}
+
 // ReplicaNamespaceListerExpansion allows custom methods to be added to
 // ReplicaNamespaceLister.
 type ReplicaNamespaceListerExpansion interface{}
 
+// RestoreTestListerExpansion allows custom methods to be added to
+// RestoreTestLister.
+type RestoreTestListerExpansion interface{}
+
+// RestoreTestNamespaceListerExpansion allows custom methods to be added to
+// RestoreTestNamespaceLister.
+type RestoreTestNamespaceListerExpansion interface{}
+
 // SettingListerExpansion allows custom methods to be added to
 // SettingLister.
 type SettingListerExpansion interface{}
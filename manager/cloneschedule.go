@@ -0,0 +1,72 @@
+package manager
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+func (m *VolumeManager) GetCloneSchedule(name string) (*longhorn.CloneSchedule, error) {
+	return m.ds.GetCloneSchedule(name)
+}
+
+func (m *VolumeManager) ListCloneSchedulesSorted() ([]*longhorn.CloneSchedule, error) {
+	cloneScheduleMap, err := m.ds.ListCloneSchedules()
+	if err != nil {
+		return []*longhorn.CloneSchedule{}, err
+	}
+
+	cloneSchedules := make([]*longhorn.CloneSchedule, len(cloneScheduleMap))
+	cloneScheduleNames, err := util.SortKeys(cloneScheduleMap)
+	if err != nil {
+		return []*longhorn.CloneSchedule{}, err
+	}
+	for i, name := range cloneScheduleNames {
+		cloneSchedules[i] = cloneScheduleMap[name]
+	}
+	return cloneSchedules, nil
+}
+
+func (m *VolumeManager) CreateCloneSchedule(name string, spec *longhorn.CloneScheduleSpec) (*longhorn.CloneSchedule, error) {
+	cloneSchedule := &longhorn.CloneSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: *spec,
+	}
+
+	cloneSchedule, err := m.ds.CreateCloneSchedule(cloneSchedule)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Created clone schedule %v", name)
+	return cloneSchedule, nil
+}
+
+func (m *VolumeManager) UpdateCloneSchedule(name string, spec *longhorn.CloneScheduleSpec) (cloneSchedule *longhorn.CloneSchedule, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to update clone schedule %v", name)
+	}()
+
+	cloneSchedule, err = m.ds.GetCloneSchedule(name)
+	if err != nil {
+		return nil, err
+	}
+	cloneSchedule.Spec.SourceType = spec.SourceType
+	cloneSchedule.Spec.Cron = spec.Cron
+	cloneSchedule.Spec.Suspend = spec.Suspend
+	return m.ds.UpdateCloneSchedule(cloneSchedule)
+}
+
+func (m *VolumeManager) DeleteCloneSchedule(name string) error {
+	if err := m.ds.DeleteCloneSchedule(name); err != nil {
+		return err
+	}
+	logrus.Infof("Deleted clone schedule %v", name)
+	return nil
+}
@@ -10,6 +10,8 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	bsutil "github.com/longhorn/backupstore/util"
+
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/types"
@@ -254,15 +256,45 @@ func (m *VolumeManager) PurgeSnapshot(volumeName string) error {
 	return nil
 }
 
-func (m *VolumeManager) BackupSnapshot(backupName, backupTargetName, volumeName, snapshotName string, labels map[string]string, backupMode string) error {
-	if volumeName == "" || snapshotName == "" {
-		return fmt.Errorf("volume and snapshot name required")
+// BackupSnapshot creates the backup of the snapshot. If the snapshot name is not given, or takeSnapshot is set for a
+// snapshot that does not exist yet, the backup controller takes the snapshot between the backup hooks of the pods
+// using the volume.
+func (m *VolumeManager) BackupSnapshot(backupName, backupTargetName, volumeName, snapshotName string, takeSnapshot bool, labels map[string]string, backupMode string) error {
+	if volumeName == "" {
+		return fmt.Errorf("volume name required")
+	}
+
+	if snapshotName == "" {
+		snapshotName = bsutil.GenerateName("snap")
+		takeSnapshot = true
+	} else if _, err := m.ds.GetSnapshotRO(snapshotName); err != nil {
+		if !apierrors.IsNotFound(err) || !takeSnapshot {
+			return errors.Wrapf(err, "failed to get snapshot %v", snapshotName)
+		}
+	} else {
+		takeSnapshot = false
 	}
 
 	if err := m.checkVolumeNotInMigration(volumeName); err != nil {
 		return err
 	}
 
+	volume, err := m.ds.GetVolumeRO(volumeName)
+	if err != nil {
+		return err
+	}
+	// Only select the nearest backup target if the volume does not ask for a specific one
+	if backupTargetName == "" || backupTargetName == types.DefaultBackupTargetName {
+		nearestBackupTargetName, err := m.ds.GetNearestBackupTargetName(volume, types.DefaultBackupTargetName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to select the backup target for volume %v", volumeName)
+		}
+		if nearestBackupTargetName != types.DefaultBackupTargetName {
+			logrus.Infof("Backing up snapshot %v of volume %v to the nearest backup target %v instead of %v", snapshotName, volumeName, nearestBackupTargetName, types.DefaultBackupTargetName)
+		}
+		backupTargetName = nearestBackupTargetName
+	}
+
 	backupCR := &longhorn.Backup{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: backupName,
@@ -276,7 +308,10 @@ func (m *VolumeManager) BackupSnapshot(backupName, backupTargetName, volumeName,
 			BackupMode:   longhorn.BackupMode(backupMode),
 		},
 	}
-	_, err := m.ds.CreateBackup(backupCR, volumeName)
+	if takeSnapshot {
+		backupCR.Annotations = map[string]string{types.BackupAnnotationTakeSnapshot: ""}
+	}
+	_, err = m.ds.CreateBackup(backupCR, volumeName)
 	return err
 }
 
@@ -390,6 +425,15 @@ func (m *VolumeManager) UpdateBackupTarget(backupTargetName string, backupTarget
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to update backup target spec")
 		}
+	} else if isBackupTargetPlacementChanged(backupTargetSpec, &existingBackupTarget.Spec) {
+		// The placement does not affect the remote backup target, hence there is no need to resync
+		existingBackupTarget.Spec.Zone = backupTargetSpec.Zone
+		existingBackupTarget.Spec.Region = backupTargetSpec.Region
+		existingBackupTarget.Spec.ReplicationTargetName = backupTargetSpec.ReplicationTargetName
+		existingBackupTarget, err = m.ds.UpdateBackupTarget(existingBackupTarget)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to update backup target spec")
+		}
 	}
 
 	return existingBackupTarget, nil
@@ -401,6 +445,12 @@ func isBackupTargetSpecChanged(newSpec, existingSpec *longhorn.BackupTargetSpec)
 		newSpec.PollInterval != existingSpec.PollInterval
 }
 
+func isBackupTargetPlacementChanged(newSpec, existingSpec *longhorn.BackupTargetSpec) bool {
+	return newSpec.Zone != existingSpec.Zone ||
+		newSpec.Region != existingSpec.Region ||
+		newSpec.ReplicationTargetName != existingSpec.ReplicationTargetName
+}
+
 func (m *VolumeManager) DeleteBackupTarget(backupTargetName string) error {
 	return m.ds.DeleteBackupTarget(backupTargetName)
 }
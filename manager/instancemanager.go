@@ -0,0 +1,199 @@
+package manager
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/engineapi"
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+// instanceManagerPodMetricsTimeout bounds the metrics API call, which may hang if the metrics server is unreachable.
+const instanceManagerPodMetricsTimeout = 5 * time.Second
+
+// InstanceManagerDetail is the process inventory of an instance manager and the resource usage of its pod. The
+// instance manager gRPC API does not report the resource usage or the start time per process, so only the pod
+// totals and the pod uptime are available.
+type InstanceManagerDetail struct {
+	InstanceManager *longhorn.InstanceManager
+
+	// PodStartTime is used as the uptime of the instance manager. The processes may have started later.
+	PodStartTime *metav1.Time
+
+	// PodUsageAvailable is false if the pod metrics cannot be retrieved, e.g. metrics-server is not deployed.
+	PodUsageAvailable bool
+	// PodCPUUsage is the CPU usage of the whole instance manager pod in millicores. The instance manager
+	// does not report the usage per process, so this is the total of all processes listed below.
+	PodCPUUsage int64
+	// PodMemoryUsage is the memory usage of the whole instance manager pod in bytes.
+	PodMemoryUsage int64
+
+	Processes []*InstanceManagerProcessDetail
+}
+
+type InstanceManagerProcessDetail struct {
+	Name       string
+	Type       longhorn.InstanceType
+	VolumeName string
+	DataEngine longhorn.DataEngineType
+	State      longhorn.InstanceState
+	ErrorMsg   string
+	PortStart  int32
+	PortEnd    int32
+}
+
+// GetInstanceManagerDetailsByNode returns the details of the instance managers on the given node.
+// The process inventory is fetched from the instance manager and falls back to the last status
+// recorded in the instance manager CR if the instance manager is unreachable.
+func (m *VolumeManager) GetInstanceManagerDetailsByNode(nodeName string) ([]*InstanceManagerDetail, error) {
+	if _, err := m.ds.GetNodeRO(nodeName); err != nil {
+		return nil, err
+	}
+
+	ims, err := m.ds.ListInstanceManagersByNodeRO(nodeName, longhorn.InstanceManagerTypeAllInOne, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list instance managers on node %v", nodeName)
+	}
+
+	var podUsages map[string]podUsage
+	details := []*InstanceManagerDetail{}
+	for _, im := range ims {
+		// All the instance managers are in the Longhorn namespace, so the pod metrics are listed once
+		if podUsages == nil {
+			if podUsages, err = m.listInstanceManagerPodUsages(im.Namespace, nodeName); err != nil {
+				logrus.WithError(err).Debugf("Failed to get pod metrics of instance managers on node %v", nodeName)
+				podUsages = map[string]podUsage{}
+			}
+		}
+
+		detail := &InstanceManagerDetail{
+			InstanceManager: im,
+			Processes:       m.getInstanceManagerProcesses(im),
+		}
+
+		pod, err := m.ds.GetPodRO(im.Namespace, im.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get pod of instance manager %v", im.Name)
+		}
+		if pod != nil {
+			detail.PodStartTime = pod.Status.StartTime
+		}
+
+		if usage, ok := podUsages[im.Name]; ok {
+			detail.PodUsageAvailable = true
+			detail.PodCPUUsage = usage.cpu
+			detail.PodMemoryUsage = usage.memory
+		}
+
+		details = append(details, detail)
+	}
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].InstanceManager.Name < details[j].InstanceManager.Name
+	})
+
+	return details, nil
+}
+
+type podUsage struct {
+	cpu    int64
+	memory int64
+}
+
+// listInstanceManagerPodUsages returns the CPU millicores and the memory bytes used by the instance manager pods
+// on the node, by pod name. The metrics of all the pods are listed at once, so a slow metrics API only delays the
+// request once.
+func (m *VolumeManager) listInstanceManagerPodUsages(namespace, nodeName string) (map[string]podUsage, error) {
+	if m.kubeMetricsClient == nil {
+		return nil, errors.New("metrics client is not available")
+	}
+
+	selector := types.GetInstanceManagerComponentLabel()
+	selector[types.GetLonghornLabelKey(types.LonghornLabelNode)] = nodeName
+
+	ctx, cancel := context.WithTimeout(context.Background(), instanceManagerPodMetricsTimeout)
+	defer cancel()
+	podMetricsList, err := m.kubeMetricsClient.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set(selector).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usages := map[string]podUsage{}
+	for _, podMetrics := range podMetricsList.Items {
+		usage := podUsage{}
+		for _, c := range podMetrics.Containers {
+			usage.cpu += c.Usage.Cpu().MilliValue()
+			usage.memory += c.Usage.Memory().Value()
+		}
+		usages[podMetrics.Name] = usage
+	}
+	return usages, nil
+}
+
+func (m *VolumeManager) getInstanceManagerProcesses(im *longhorn.InstanceManager) []*InstanceManagerProcessDetail {
+	instances, err := m.listInstanceManagerProcesses(im)
+	if err != nil {
+		logrus.WithError(err).Warnf("Failed to list processes of instance manager %v, falling back to its recorded status", im.Name)
+		instances = map[string]longhorn.InstanceProcess{}
+		for name, instance := range im.Status.InstanceEngines {
+			instances[name] = instance
+		}
+		for name, instance := range im.Status.InstanceReplicas {
+			instances[name] = instance
+		}
+	}
+
+	processes := []*InstanceManagerProcessDetail{}
+	for name, instance := range instances {
+		process := &InstanceManagerProcessDetail{
+			Name:       name,
+			Type:       instance.Status.Type,
+			DataEngine: instance.Spec.DataEngine,
+			State:      instance.Status.State,
+			ErrorMsg:   instance.Status.ErrorMsg,
+			PortStart:  instance.Status.PortStart,
+			PortEnd:    instance.Status.PortEnd,
+		}
+		switch instance.Status.Type {
+		case longhorn.InstanceTypeEngine:
+			if e, err := m.ds.GetEngineRO(name); err == nil {
+				process.VolumeName = e.Spec.VolumeName
+			}
+		case longhorn.InstanceTypeReplica:
+			if r, err := m.ds.GetReplicaRO(name); err == nil {
+				process.VolumeName = r.Spec.VolumeName
+			}
+		}
+		processes = append(processes, process)
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].Name < processes[j].Name
+	})
+
+	return processes
+}
+
+func (m *VolumeManager) listInstanceManagerProcesses(im *longhorn.InstanceManager) (map[string]longhorn.InstanceProcess, error) {
+	if im.Status.CurrentState != longhorn.InstanceManagerStateRunning {
+		return nil, errors.Errorf("instance manager is in state %v", im.Status.CurrentState)
+	}
+
+	client, err := engineapi.NewInstanceManagerClient(im, false)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.InstanceList()
+}
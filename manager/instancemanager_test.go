@@ -0,0 +1,161 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/pkg/controller"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+)
+
+const (
+	testNamespace       = "longhorn-system"
+	testNodeName        = "test-node"
+	testVolumeName      = "test-volume"
+	testInstanceImage   = "longhornio/longhorn-instance-manager:test"
+	testInstanceManager = "instance-manager-test"
+)
+
+func newTestInstanceManager(name string, instanceEngines, instanceReplicas map[string]longhorn.InstanceProcess) *longhorn.InstanceManager {
+	return &longhorn.InstanceManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    types.GetInstanceManagerLabels(testNodeName, testInstanceImage, longhorn.InstanceManagerTypeAllInOne, longhorn.DataEngineTypeV1),
+		},
+		Spec: longhorn.InstanceManagerSpec{
+			Image:      testInstanceImage,
+			NodeID:     testNodeName,
+			Type:       longhorn.InstanceManagerTypeAllInOne,
+			DataEngine: longhorn.DataEngineTypeV1,
+		},
+		Status: longhorn.InstanceManagerStatus{
+			// The instance manager is not reachable, so the processes are taken from the recorded status
+			CurrentState:     longhorn.InstanceManagerStateUnknown,
+			InstanceEngines:  instanceEngines,
+			InstanceReplicas: instanceReplicas,
+		},
+	}
+}
+
+func newTestInstanceProcess(instanceType longhorn.InstanceType, portStart int32) longhorn.InstanceProcess {
+	return longhorn.InstanceProcess{
+		Spec: longhorn.InstanceProcessSpec{
+			DataEngine: longhorn.DataEngineTypeV1,
+		},
+		Status: longhorn.InstanceProcessStatus{
+			Type:      instanceType,
+			State:     longhorn.InstanceStateRunning,
+			PortStart: portStart,
+			PortEnd:   portStart + 10,
+		},
+	}
+}
+
+func TestGetInstanceManagerDetailsByNode(t *testing.T) {
+	assert := assert.New(t)
+
+	kubeClient := fake.NewSimpleClientset()
+	lhClient := lhfake.NewSimpleClientset()
+	extensionsClient := apiextensionsfake.NewSimpleClientset()
+	informerFactories := util.NewInformerFactories(testNamespace, kubeClient, lhClient, controller.NoResyncPeriodFunc())
+	lhInformerFactory := informerFactories.LhInformerFactory.Longhorn().V1beta2()
+	kubeInformerFactory := informerFactories.KubeInformerFactory.Core().V1()
+
+	ds := datastore.NewDataStore(testNamespace, lhClient, kubeClient, extensionsClient, informerFactories)
+
+	engineName := testVolumeName + "-e-0"
+	replicaName := testVolumeName + "-r-0"
+	startTime := metav1.NewTime(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	objs := []interface{}{
+		&longhorn.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: testNodeName, Namespace: testNamespace},
+		},
+		newTestInstanceManager(testInstanceManager,
+			map[string]longhorn.InstanceProcess{engineName: newTestInstanceProcess(longhorn.InstanceTypeEngine, 10000)},
+			map[string]longhorn.InstanceProcess{replicaName: newTestInstanceProcess(longhorn.InstanceTypeReplica, 10010)}),
+		// The pod of this instance manager is gone, so there is no start time
+		newTestInstanceManager(testInstanceManager+"-old", nil, nil),
+		&longhorn.Engine{
+			ObjectMeta: metav1.ObjectMeta{Name: engineName, Namespace: testNamespace},
+			Spec:       longhorn.EngineSpec{InstanceSpec: longhorn.InstanceSpec{VolumeName: testVolumeName}},
+		},
+		&longhorn.Replica{
+			ObjectMeta: metav1.ObjectMeta{Name: replicaName, Namespace: testNamespace},
+			Spec:       longhorn.ReplicaSpec{InstanceSpec: longhorn.InstanceSpec{VolumeName: testVolumeName}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: testInstanceManager, Namespace: testNamespace},
+			Status:     corev1.PodStatus{StartTime: &startTime},
+		},
+	}
+	for _, obj := range objs {
+		var err error
+		switch o := obj.(type) {
+		case *longhorn.Node:
+			err = lhInformerFactory.Nodes().Informer().GetIndexer().Add(o)
+		case *longhorn.InstanceManager:
+			err = lhInformerFactory.InstanceManagers().Informer().GetIndexer().Add(o)
+		case *longhorn.Engine:
+			err = lhInformerFactory.Engines().Informer().GetIndexer().Add(o)
+		case *longhorn.Replica:
+			err = lhInformerFactory.Replicas().Informer().GetIndexer().Add(o)
+		case *corev1.Pod:
+			err = kubeInformerFactory.Pods().Informer().GetIndexer().Add(o)
+		}
+		assert.NoError(err)
+	}
+
+	// Without a metrics client the pod usage is reported as unavailable
+	m := NewVolumeManager(testNodeName, ds, nil, nil, nil)
+
+	details, err := m.GetInstanceManagerDetailsByNode(testNodeName)
+	assert.NoError(err)
+	assert.Len(details, 2)
+
+	detail := details[0]
+	assert.Equal(testInstanceManager, detail.InstanceManager.Name)
+	assert.Equal(&startTime, detail.PodStartTime)
+	assert.False(detail.PodUsageAvailable)
+	assert.Equal([]*InstanceManagerProcessDetail{
+		{
+			Name:       engineName,
+			Type:       longhorn.InstanceTypeEngine,
+			VolumeName: testVolumeName,
+			DataEngine: longhorn.DataEngineTypeV1,
+			State:      longhorn.InstanceStateRunning,
+			PortStart:  10000,
+			PortEnd:    10010,
+		},
+		{
+			Name:       replicaName,
+			Type:       longhorn.InstanceTypeReplica,
+			VolumeName: testVolumeName,
+			DataEngine: longhorn.DataEngineTypeV1,
+			State:      longhorn.InstanceStateRunning,
+			PortStart:  10010,
+			PortEnd:    10020,
+		},
+	}, detail.Processes)
+
+	detail = details[1]
+	assert.Equal(testInstanceManager+"-old", detail.InstanceManager.Name)
+	assert.Nil(detail.PodStartTime)
+	assert.Empty(detail.Processes)
+
+	_, err = m.GetInstanceManagerDetailsByNode("unknown-node")
+	assert.True(datastore.ErrorIsNotFound(err))
+}
@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+func (m *VolumeManager) GetReplicaCountPolicy(name string) (*longhorn.ReplicaCountPolicy, error) {
+	return m.ds.GetReplicaCountPolicy(name)
+}
+
+func (m *VolumeManager) ListReplicaCountPoliciesSorted() ([]*longhorn.ReplicaCountPolicy, error) {
+	policyMap, err := m.ds.ListReplicaCountPolicies()
+	if err != nil {
+		return []*longhorn.ReplicaCountPolicy{}, err
+	}
+
+	policies := make([]*longhorn.ReplicaCountPolicy, len(policyMap))
+	policyNames, err := util.SortKeys(policyMap)
+	if err != nil {
+		return []*longhorn.ReplicaCountPolicy{}, err
+	}
+	for i, name := range policyNames {
+		policies[i] = policyMap[name]
+	}
+	return policies, nil
+}
+
+func (m *VolumeManager) CreateReplicaCountPolicy(name string, spec *longhorn.ReplicaCountPolicySpec) (*longhorn.ReplicaCountPolicy, error) {
+	policy := &longhorn.ReplicaCountPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: *spec,
+	}
+
+	policy, err := m.ds.CreateReplicaCountPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Created replica count policy %v", name)
+	return policy, nil
+}
+
+func (m *VolumeManager) UpdateReplicaCountPolicy(name string, spec *longhorn.ReplicaCountPolicySpec) (policy *longhorn.ReplicaCountPolicy, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to update replica count policy %v", name)
+	}()
+
+	policy, err = m.ds.GetReplicaCountPolicy(name)
+	if err != nil {
+		return nil, err
+	}
+	policy.Spec = *spec
+	return m.ds.UpdateReplicaCountPolicy(policy)
+}
+
+func (m *VolumeManager) DeleteReplicaCountPolicy(name string) error {
+	if err := m.ds.DeleteReplicaCountPolicy(name); err != nil {
+		return err
+	}
+	logrus.Infof("Deleted replica count policy %v", name)
+	return nil
+}
@@ -0,0 +1,77 @@
+package manager
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+func (m *VolumeManager) GetRestoreTest(name string) (*longhorn.RestoreTest, error) {
+	return m.ds.GetRestoreTest(name)
+}
+
+func (m *VolumeManager) ListRestoreTestsSorted() ([]*longhorn.RestoreTest, error) {
+	restoreTestMap, err := m.ds.ListRestoreTests()
+	if err != nil {
+		return []*longhorn.RestoreTest{}, err
+	}
+
+	restoreTests := make([]*longhorn.RestoreTest, len(restoreTestMap))
+	restoreTestNames, err := util.SortKeys(restoreTestMap)
+	if err != nil {
+		return []*longhorn.RestoreTest{}, err
+	}
+	for i, name := range restoreTestNames {
+		restoreTests[i] = restoreTestMap[name]
+	}
+	return restoreTests, nil
+}
+
+func (m *VolumeManager) CreateRestoreTest(name string, spec *longhorn.RestoreTestSpec) (*longhorn.RestoreTest, error) {
+	restoreTest := &longhorn.RestoreTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: *spec,
+	}
+
+	restoreTest, err := m.ds.CreateRestoreTest(restoreTest)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Created restore test %v", name)
+	return restoreTest, nil
+}
+
+func (m *VolumeManager) UpdateRestoreTest(name string, spec *longhorn.RestoreTestSpec) (restoreTest *longhorn.RestoreTest, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to update restore test %v", name)
+	}()
+
+	restoreTest, err = m.ds.GetRestoreTest(name)
+	if err != nil {
+		return nil, err
+	}
+	restoreTest.Spec.VolumeName = spec.VolumeName
+	restoreTest.Spec.SelectionPolicy = spec.SelectionPolicy
+	restoreTest.Spec.Cron = spec.Cron
+	restoreTest.Spec.Suspend = spec.Suspend
+	restoreTest.Spec.Image = spec.Image
+	restoreTest.Spec.Command = spec.Command
+	restoreTest.Spec.FSType = spec.FSType
+	restoreTest.Spec.TimeoutSeconds = spec.TimeoutSeconds
+	return m.ds.UpdateRestoreTest(restoreTest)
+}
+
+func (m *VolumeManager) DeleteRestoreTest(name string) error {
+	if err := m.ds.DeleteRestoreTest(name); err != nil {
+		return err
+	}
+	logrus.Infof("Deleted restore test %v", name)
+	return nil
+}
@@ -1,10 +1,13 @@
 package manager
 
 import (
+	"sort"
+
 	"github.com/sirupsen/logrus"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/longhorn/longhorn-manager/controller"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
@@ -55,3 +58,122 @@ func (m *VolumeManager) CreateOrUpdateSetting(s *longhorn.Setting) (*longhorn.Se
 	logrus.Infof("Updated setting %v to %v", s.Name, setting.Value)
 	return setting, nil
 }
+
+// UpgradeFreezeReport lists the upgrade actions which are held back while the upgrade freeze is enabled.
+type UpgradeFreezeReport struct {
+	Enabled                            bool
+	PendingEngineImageUpgrades         []string
+	PendingLiveEngineUpgrades          []string
+	PendingInstanceManagerReplacements []string
+}
+
+func (m *VolumeManager) GetUpgradeFreezeReport() (*UpgradeFreezeReport, error) {
+	enabled, err := m.ds.GetSettingAsBool(types.SettingNameUpgradeFreeze)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingEngineImageUpgrades, err := m.getPendingEngineImageUpgrades()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UpgradeFreezeReport{
+		Enabled:                            enabled,
+		PendingEngineImageUpgrades:         pendingEngineImageUpgrades,
+		PendingLiveEngineUpgrades:          []string{},
+		PendingInstanceManagerReplacements: []string{},
+	}
+
+	volumes, err := m.ds.ListVolumesRO()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range volumes {
+		if !types.IsDataEngineV1(v.Spec.DataEngine) || v.Spec.Image == v.Status.CurrentImage {
+			continue
+		}
+		if v.Status.State != longhorn.VolumeStateAttached {
+			continue
+		}
+		replicas, err := m.ds.ListVolumeReplicasRO(v.Name)
+		if err != nil {
+			return nil, err
+		}
+		liveUpgradeStarted := false
+		for _, r := range replicas {
+			if r.Spec.Image == v.Spec.Image {
+				liveUpgradeStarted = true
+				break
+			}
+		}
+		if !liveUpgradeStarted {
+			report.PendingLiveEngineUpgrades = append(report.PendingLiveEngineUpgrades, v.Name)
+		}
+	}
+
+	// The instance managers using an outdated image are not replaced but removed once they are empty, only the
+	// in-place pod replacements are held back.
+	ims, err := m.ds.ListInstanceManagersRO()
+	if err != nil {
+		return nil, err
+	}
+	for _, im := range ims {
+		if im.DeletionTimestamp != nil || im.Status.CurrentState != longhorn.InstanceManagerStateRunning {
+			continue
+		}
+		required, _, err := controller.IsInstanceManagerPodReplacementRequired(m.ds, im)
+		if err != nil {
+			return nil, err
+		}
+		if required {
+			report.PendingInstanceManagerReplacements = append(report.PendingInstanceManagerReplacements, im.Name)
+		}
+	}
+
+	sort.Strings(report.PendingEngineImageUpgrades)
+	sort.Strings(report.PendingLiveEngineUpgrades)
+	sort.Strings(report.PendingInstanceManagerReplacements)
+
+	return report, nil
+}
+
+// getPendingEngineImageUpgrades returns the volumes the automatic engine upgrade would move to the default engine
+// image.
+func (m *VolumeManager) getPendingEngineImageUpgrades() ([]string, error) {
+	pending := []string{}
+
+	concurrentAutomaticEngineUpgradePerNodeLimit, err := m.ds.GetSettingAsInt(types.SettingNameConcurrentAutomaticEngineUpgradePerNodeLimit)
+	if err != nil {
+		return nil, err
+	}
+	if concurrentAutomaticEngineUpgradePerNodeLimit <= 0 {
+		return pending, nil
+	}
+
+	defaultEngineImage, err := m.ds.GetSettingValueExisted(types.SettingNameDefaultEngineImage)
+	if err != nil {
+		return nil, err
+	}
+	defaultEngineImageResource, err := m.ds.GetEngineImageRO(types.GetEngineImageChecksumName(defaultEngineImage))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return pending, nil
+		}
+		return nil, err
+	}
+
+	volumes, err := m.ds.ListVolumes()
+	if err != nil {
+		return nil, err
+	}
+	candidates, _ := controller.GetVolumesForEngineImageUpgrading(m.ds, volumes, defaultEngineImageResource)
+	for _, vs := range candidates {
+		for _, v := range vs {
+			if types.IsDataEngineV1(v.Spec.DataEngine) {
+				pending = append(pending, v.Name)
+			}
+		}
+	}
+	return pending, nil
+}
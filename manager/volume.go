@@ -10,11 +10,14 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/tools/record"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 
+	"github.com/longhorn/longhorn-manager/constant"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/scheduler"
@@ -31,9 +34,14 @@ type VolumeManager struct {
 	currentNodeID string
 
 	proxyConnCounter util.Counter
+
+	kubeMetricsClient *metricsclientset.Clientset
+
+	eventRecorder record.EventRecorder
 }
 
-func NewVolumeManager(currentNodeID string, ds *datastore.DataStore, proxyConnCounter util.Counter) *VolumeManager {
+func NewVolumeManager(currentNodeID string, ds *datastore.DataStore, proxyConnCounter util.Counter, kubeMetricsClient *metricsclientset.Clientset,
+	eventRecorder record.EventRecorder) *VolumeManager {
 	return &VolumeManager{
 		ds:        ds,
 		scheduler: scheduler.NewReplicaScheduler(ds),
@@ -41,6 +49,10 @@ func NewVolumeManager(currentNodeID string, ds *datastore.DataStore, proxyConnCo
 		currentNodeID: currentNodeID,
 
 		proxyConnCounter: proxyConnCounter,
+
+		kubeMetricsClient: kubeMetricsClient,
+
+		eventRecorder: eventRecorder,
 	}
 }
 
@@ -64,6 +76,31 @@ func (m *VolumeManager) List() (map[string]*longhorn.Volume, error) {
 	return m.ds.ListVolumes()
 }
 
+// checkBackupBindings verifies the backup target and the recurring jobs requested for a new volume, e.g. by the
+// parameters of a StorageClass, so that a volume is not provisioned with backups that can never succeed. The
+// backup target must be configured if a backup job is selected or a target other than the default one is requested,
+// and it is returned then so that the caller can warn about it being unavailable.
+func (m *VolumeManager) checkBackupBindings(backupTargetName string, recurringJobSelector []longhorn.VolumeRecurringJob) (*longhorn.BackupTarget, error) {
+	hasBackupJob, err := m.ds.CheckVolumeRecurringJobs(recurringJobSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	if backupTargetName == "" {
+		backupTargetName = types.DefaultBackupTargetName
+	}
+	if !hasBackupJob && backupTargetName == types.DefaultBackupTargetName {
+		return nil, nil
+	}
+	return m.ds.GetConfiguredBackupTargetRO(backupTargetName)
+}
+
+// ListRebuildQueue returns the volumes rebuilding replicas followed by the degraded volumes waiting for a
+// rebuild, in the order they are allowed to rebuild.
+func (m *VolumeManager) ListRebuildQueue() ([]*datastore.RebuildQueueEntry, error) {
+	return m.ds.ListRebuildQueueRO()
+}
+
 func (m *VolumeManager) ListSorted() ([]*longhorn.Volume, error) {
 	volumeMap, err := m.List()
 	if err != nil {
@@ -151,6 +188,11 @@ func (m *VolumeManager) Create(name string, spec *longhorn.VolumeSpec, recurring
 		}
 	}
 
+	boundBackupTarget, err := m.checkBackupBindings(spec.BackupTargetName, recurringJobSelector)
+	if err != nil {
+		return nil, err
+	}
+
 	backupTargetName := spec.BackupTargetName
 	if spec.BackupTargetName == "" {
 		backupTargetName = types.DefaultBackupTargetName
@@ -169,36 +211,38 @@ func (m *VolumeManager) Create(name string, spec *longhorn.VolumeSpec, recurring
 			Labels: labels,
 		},
 		Spec: longhorn.VolumeSpec{
-			Size:                        spec.Size,
-			AccessMode:                  spec.AccessMode,
-			Migratable:                  spec.Migratable,
-			Encrypted:                   spec.Encrypted,
-			Frontend:                    spec.Frontend,
-			Image:                       "",
-			FromBackup:                  spec.FromBackup,
-			RestoreVolumeRecurringJob:   spec.RestoreVolumeRecurringJob,
-			DataSource:                  spec.DataSource,
-			NumberOfReplicas:            spec.NumberOfReplicas,
-			ReplicaAutoBalance:          spec.ReplicaAutoBalance,
-			DataLocality:                spec.DataLocality,
-			StaleReplicaTimeout:         spec.StaleReplicaTimeout,
-			BackingImage:                spec.BackingImage,
-			Standby:                     spec.Standby,
-			DiskSelector:                spec.DiskSelector,
-			NodeSelector:                spec.NodeSelector,
-			RevisionCounterDisabled:     spec.RevisionCounterDisabled,
-			SnapshotDataIntegrity:       spec.SnapshotDataIntegrity,
-			SnapshotMaxCount:            spec.SnapshotMaxCount,
-			SnapshotMaxSize:             spec.SnapshotMaxSize,
-			BackupCompressionMethod:     spec.BackupCompressionMethod,
-			UnmapMarkSnapChainRemoved:   spec.UnmapMarkSnapChainRemoved,
-			ReplicaSoftAntiAffinity:     spec.ReplicaSoftAntiAffinity,
-			ReplicaZoneSoftAntiAffinity: spec.ReplicaZoneSoftAntiAffinity,
-			ReplicaDiskSoftAntiAffinity: spec.ReplicaDiskSoftAntiAffinity,
-			DataEngine:                  spec.DataEngine,
-			FreezeFilesystemForSnapshot: spec.FreezeFilesystemForSnapshot,
-			BackupTargetName:            backupTargetName,
-			OfflineRebuilding:           spec.OfflineRebuilding,
+			Size:                         spec.Size,
+			AccessMode:                   spec.AccessMode,
+			Migratable:                   spec.Migratable,
+			Encrypted:                    spec.Encrypted,
+			Frontend:                     spec.Frontend,
+			Image:                        "",
+			FromBackup:                   spec.FromBackup,
+			RestoreVolumeRecurringJob:    spec.RestoreVolumeRecurringJob,
+			DataSource:                   spec.DataSource,
+			NumberOfReplicas:             spec.NumberOfReplicas,
+			ReplicaAutoBalance:           spec.ReplicaAutoBalance,
+			DataLocality:                 spec.DataLocality,
+			StaleReplicaTimeout:          spec.StaleReplicaTimeout,
+			BackingImage:                 spec.BackingImage,
+			Standby:                      spec.Standby,
+			DiskSelector:                 spec.DiskSelector,
+			NodeSelector:                 spec.NodeSelector,
+			RevisionCounterDisabled:      spec.RevisionCounterDisabled,
+			SnapshotDataIntegrity:        spec.SnapshotDataIntegrity,
+			SnapshotDataIntegrityCronJob: spec.SnapshotDataIntegrityCronJob,
+			SnapshotMaxCount:             spec.SnapshotMaxCount,
+			SnapshotMaxSize:              spec.SnapshotMaxSize,
+			BackupCompressionMethod:      spec.BackupCompressionMethod,
+			UnmapMarkSnapChainRemoved:    spec.UnmapMarkSnapChainRemoved,
+			ReplicaSoftAntiAffinity:      spec.ReplicaSoftAntiAffinity,
+			ReplicaZoneSoftAntiAffinity:  spec.ReplicaZoneSoftAntiAffinity,
+			ReplicaDiskSoftAntiAffinity:  spec.ReplicaDiskSoftAntiAffinity,
+			DataEngine:                   spec.DataEngine,
+			FreezeFilesystemForSnapshot:  spec.FreezeFilesystemForSnapshot,
+			BackupTargetName:             backupTargetName,
+			OfflineRebuilding:            spec.OfflineRebuilding,
+			ReplicaSelectorRelocation:    spec.ReplicaSelectorRelocation,
 		},
 	}
 
@@ -207,10 +251,30 @@ func (m *VolumeManager) Create(name string, spec *longhorn.VolumeSpec, recurring
 		return nil, err
 	}
 	logrus.Infof("Created volume %v: %+v", v.Name, v.Spec)
+
+	if boundBackupTarget != nil && !boundBackupTarget.Status.Available {
+		m.eventRecorder.Eventf(v, corev1.EventTypeWarning, constant.EventReasonBackupTargetUnavailable,
+			"Backup target %v is not available, backups of the volume fail until it becomes available", boundBackupTarget.Name)
+	}
 	return v, nil
 }
 
 func (m *VolumeManager) Delete(name string) error {
+	retentionPeriod, err := m.ds.GetSettingAsInt(types.SettingNameVolumeRecycleBinRetentionPeriod)
+	if err != nil {
+		return err
+	}
+	if retentionPeriod > 0 {
+		v, err := m.ds.GetVolumeRO(name)
+		if err != nil {
+			return err
+		}
+		// A volume still being created has no data worth keeping
+		if v.Status.State != longhorn.VolumeStateCreating {
+			return m.moveToRecycleBin(name)
+		}
+	}
+
 	if err := m.ds.DeleteVolume(name); err != nil {
 		return err
 	}
@@ -218,7 +282,71 @@ func (m *VolumeManager) Delete(name string) error {
 	return nil
 }
 
-func (m *VolumeManager) Attach(name, nodeID string, disableFrontend bool, attachedBy, attacherType, attachmentID string) (v *longhorn.Volume, err error) {
+func (m *VolumeManager) moveToRecycleBin(name string) (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to move volume %v to the recycle bin", name)
+	}()
+
+	v, err := m.ds.GetVolume(name)
+	if err != nil {
+		return err
+	}
+	if v.Spec.TrashedAt != "" {
+		return nil
+	}
+
+	// The volume attachment controller detaches the volume once it is in the recycle bin
+	v.Spec.TrashedAt = util.Now()
+	if _, err := m.ds.UpdateVolume(v); err != nil {
+		return err
+	}
+	logrus.Infof("Moved volume %v to the recycle bin", name)
+	return nil
+}
+
+func (m *VolumeManager) RestoreFromRecycleBin(name string) (v *longhorn.Volume, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to restore volume %v from the recycle bin", name)
+	}()
+
+	v, err = m.ds.GetVolume(name)
+	if err != nil {
+		return nil, err
+	}
+	if v.Spec.TrashedAt == "" {
+		return nil, fmt.Errorf("volume is not in the recycle bin")
+	}
+
+	v.Spec.TrashedAt = ""
+	v, err = m.ds.UpdateVolume(v)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Restored volume %v from the recycle bin", name)
+	return v, nil
+}
+
+func (m *VolumeManager) Purge(name string) (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to purge volume %v", name)
+	}()
+
+	v, err := m.ds.GetVolumeRO(name)
+	if err != nil {
+		return err
+	}
+	if v.Spec.TrashedAt == "" {
+		return fmt.Errorf("volume is not in the recycle bin")
+	}
+
+	if err := m.ds.DeleteVolume(name); err != nil {
+		return err
+	}
+	logrus.Infof("Purged volume %v from the recycle bin", name)
+	return nil
+}
+
+func (m *VolumeManager) Attach(name, nodeID string, disableFrontend bool, attachedBy, attacherType, attacherID, attachmentID string) (v *longhorn.Volume, err error) {
 	defer func() {
 		err = errors.Wrapf(err, "unable to attach volume %v to %v", name, nodeID)
 	}()
@@ -249,6 +377,10 @@ func (m *VolumeManager) Attach(name, nodeID string, disableFrontend bool, attach
 		return nil, fmt.Errorf("volume %v is restoring data", name)
 	}
 
+	if v.Spec.TrashedAt != "" {
+		return nil, fmt.Errorf("volume %v is in the recycle bin", name)
+	}
+
 	if v.Status.RestoreRequired {
 		return nil, fmt.Errorf("volume %v is pending restoring", name)
 	}
@@ -271,8 +403,9 @@ func (m *VolumeManager) Attach(name, nodeID string, disableFrontend bool, attach
 	va.Spec.AttachmentTickets[attachmentID] = &longhorn.AttachmentTicket{
 		ID: attachmentID,
 		// TODO: validate attacher type
-		Type:   longhorn.AttacherType(attacherType),
-		NodeID: node.Name,
+		Type:       longhorn.AttacherType(attacherType),
+		AttacherID: attacherID,
+		NodeID:     node.Name,
 		Parameters: map[string]string{
 			longhorn.AttachmentParameterDisableFrontend: strconv.FormatBool(disableFrontend),
 			longhorn.AttachmentParameterLastAttachedBy:  attachedBy,
@@ -836,6 +969,16 @@ func (m *VolumeManager) EngineUpgrade(volumeName, image string) (v *longhorn.Vol
 		return nil, fmt.Errorf("cannot do live upgrade for an attached strict-local volume %v", v.Name)
 	}
 
+	if v.Status.State == longhorn.VolumeStateAttached && image != v.Status.CurrentImage {
+		failures, err := m.ds.CheckEngineUpgradePreconditions(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(failures) > 0 {
+			return nil, fmt.Errorf("cannot do live upgrade for volume %v: %v", v.Name, datastore.EngineUpgradePreCheckMessage(failures))
+		}
+	}
+
 	oldImage := v.Spec.Image
 	v.Spec.Image = image
 
@@ -852,6 +995,15 @@ func (m *VolumeManager) EngineUpgrade(volumeName, image string) (v *longhorn.Vol
 	return v, nil
 }
 
+// CheckEngineUpgrade returns the failed live engine upgrade pre-checks of the volume
+func (m *VolumeManager) CheckEngineUpgrade(name string) ([]datastore.EngineUpgradePreCheckFailure, error) {
+	v, err := m.ds.GetVolumeRO(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.ds.CheckEngineUpgradePreconditions(v)
+}
+
 func (m *VolumeManager) UpdateReplicaCount(name string, count int) (v *longhorn.Volume, err error) {
 	defer func() {
 		err = errors.Wrapf(err, "unable to update replica count for volume %v", name)
@@ -904,6 +1056,27 @@ func (m *VolumeManager) UpdateSnapshotDataIntegrity(name string, value string) (
 	return v, nil
 }
 
+func (m *VolumeManager) UpdateSnapshotDataIntegrityCronJob(name string, value string) (v *longhorn.Volume, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to update snapshot data integrity cron job for volume %v", name)
+	}()
+
+	v, err = m.ds.GetVolume(name)
+	if err != nil {
+		return nil, err
+	}
+
+	oldValue := v.Spec.SnapshotDataIntegrityCronJob
+	v.Spec.SnapshotDataIntegrityCronJob = value
+
+	v, err = m.ds.UpdateVolume(v)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Updated volume %v snapshot data integrity cron job from %v to %v", v.Name, oldValue, v.Spec.SnapshotDataIntegrityCronJob)
+	return v, nil
+}
+
 func (m *VolumeManager) UpdateBackupCompressionMethod(name string, value string) (v *longhorn.Volume, err error) {
 	defer func() {
 		err = errors.Wrapf(err, "unable to update backup compression method for volume %v", name)
@@ -1292,6 +1465,33 @@ func (m *VolumeManager) UpdateFreezeFilesystemForSnapshot(name string,
 	return v, nil
 }
 
+func (m *VolumeManager) UpdateReplicaSelectorRelocation(name string, replicaSelectorRelocation bool) (v *longhorn.Volume, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to update field ReplicaSelectorRelocation for volume %v", name)
+	}()
+
+	v, err = m.ds.GetVolume(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Spec.ReplicaSelectorRelocation == replicaSelectorRelocation {
+		logrus.Debugf("Volume %v already set field ReplicaSelectorRelocation to %v", v.Name, replicaSelectorRelocation)
+		return v, nil
+	}
+
+	oldReplicaSelectorRelocation := v.Spec.ReplicaSelectorRelocation
+	v.Spec.ReplicaSelectorRelocation = replicaSelectorRelocation
+	v, err = m.ds.UpdateVolume(v)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Updated volume %v field ReplicaSelectorRelocation from %v to %v", v.Name,
+		oldReplicaSelectorRelocation, replicaSelectorRelocation)
+	return v, nil
+}
+
 func (m *VolumeManager) UpdateVolumeBackupTarget(name string, backupTargetName string) (v *longhorn.Volume, err error) {
 	defer func() {
 		err = errors.Wrapf(err, "unable to update field BackupTargetName for volume %v", name)
@@ -0,0 +1,229 @@
+package metricscollector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+// Disks are not custom resources, their conditions are reported with the name <node>/<disk>.
+const conditionKindDisk = "Disk"
+
+// Attachment tickets are not custom resources either, their conditions are reported with the name
+// <volume attachment>/<ticket>.
+const conditionKindAttachmentTicket = "AttachmentTicket"
+
+// Instance managers have no conditions of their own. The conditions of their instance processes are reported
+// with the name <instance manager>/<instance>.
+const conditionKindInstanceProcess = "InstanceProcess"
+
+var conditionStatuses = []longhorn.ConditionStatus{
+	longhorn.ConditionStatusTrue,
+	longhorn.ConditionStatusFalse,
+	longhorn.ConditionStatusUnknown,
+}
+
+// ConditionCollector exposes the conditions of the Longhorn custom resources in the kube-state-metrics style.
+// Each condition results in one series per possible status, and the series matching the current status is set to 1.
+type ConditionCollector struct {
+	*baseCollector
+
+	conditionMetric metricInfo
+}
+
+func NewConditionCollector(
+	logger logrus.FieldLogger,
+	nodeID string,
+	ds *datastore.DataStore) *ConditionCollector {
+
+	cc := &ConditionCollector{
+		baseCollector: newBaseCollector(subsystemCondition, logger, nodeID, ds),
+	}
+
+	cc.conditionMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemCondition, "status"),
+			"Condition of this Longhorn resource",
+			[]string{kindLabel, nameLabel, conditionLabel, conditionStatusLabel, conditionReasonLabel},
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	return cc
+}
+
+func (cc *ConditionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cc.conditionMetric.Desc
+}
+
+func (cc *ConditionCollector) Collect(ch chan<- prometheus.Metric) {
+	defer func() {
+		if err := recover(); err != nil {
+			cc.logger.WithField("error", err).Warn("Panic during collecting metrics")
+		}
+	}()
+
+	// Each resource is reported by the manager owning it to avoid duplicate series.
+	ownedVolumes := map[string]struct{}{}
+	if volumes, err := cc.ds.ListVolumesRO(); err != nil {
+		cc.logger.WithError(err).Warn("Error during scrape volumes")
+	} else {
+		for _, v := range volumes {
+			if v.Status.OwnerID == cc.currentNodeID {
+				ownedVolumes[v.Name] = struct{}{}
+				cc.collectConditions(ch, types.LonghornKindVolume, v.Name, v.Status.Conditions)
+			}
+		}
+	}
+
+	// Volume attachments have no owner, they are handled by the owner of the volume.
+	if volumeAttachments, err := cc.ds.ListLHVolumeAttachmentsRO(); err != nil {
+		cc.logger.WithError(err).Warn("Error during scrape volume attachments")
+	} else {
+		for _, va := range volumeAttachments {
+			if _, ok := ownedVolumes[va.Spec.Volume]; !ok {
+				continue
+			}
+			for ticketID, ticketStatus := range va.Status.AttachmentTicketStatuses {
+				if ticketStatus != nil {
+					cc.collectConditions(ch, conditionKindAttachmentTicket, va.Name+"/"+ticketID, ticketStatus.Conditions)
+				}
+			}
+		}
+	}
+
+	if nodes, err := cc.ds.ListNodesRO(); err != nil {
+		cc.logger.WithError(err).Warn("Error during scrape nodes")
+	} else {
+		for _, node := range nodes {
+			if node.Name != cc.currentNodeID {
+				continue
+			}
+			cc.collectConditions(ch, types.LonghornKindNode, node.Name, node.Status.Conditions)
+			for diskName, diskStatus := range node.Status.DiskStatus {
+				if diskStatus != nil {
+					cc.collectConditions(ch, conditionKindDisk, node.Name+"/"+diskName, diskStatus.Conditions)
+				}
+			}
+		}
+	}
+
+	if engineImages, err := cc.ds.ListEngineImages(); err != nil {
+		cc.logger.WithError(err).Warn("Error during scrape engine images")
+	} else {
+		for _, ei := range engineImages {
+			if ei.Status.OwnerID == cc.currentNodeID {
+				cc.collectConditions(ch, types.LonghornKindEngineImage, ei.Name, ei.Status.Conditions)
+			}
+		}
+	}
+
+	if engines, err := cc.ds.ListEnginesRO(); err != nil {
+		cc.logger.WithError(err).Warn("Error during scrape engines")
+	} else {
+		for _, e := range engines {
+			if e.Status.OwnerID == cc.currentNodeID {
+				cc.collectConditions(ch, types.LonghornKindEngine, e.Name, e.Status.Conditions)
+			}
+		}
+	}
+
+	if replicas, err := cc.ds.ListReplicasRO(); err != nil {
+		cc.logger.WithError(err).Warn("Error during scrape replicas")
+	} else {
+		for _, r := range replicas {
+			if r.Status.OwnerID == cc.currentNodeID {
+				cc.collectConditions(ch, types.LonghornKindReplica, r.Name, r.Status.Conditions)
+			}
+		}
+	}
+
+	if backupTargets, err := cc.ds.ListBackupTargetsRO(); err != nil {
+		cc.logger.WithError(err).Warn("Error during scrape backup targets")
+	} else {
+		for _, bt := range backupTargets {
+			if bt.Status.OwnerID == cc.currentNodeID {
+				cc.collectConditions(ch, types.LonghornKindBackupTarget, bt.Name, bt.Status.Conditions)
+			}
+		}
+	}
+
+	if instanceManagers, err := cc.ds.ListInstanceManagersRO(); err != nil {
+		cc.logger.WithError(err).Warn("Error during scrape instance managers")
+	} else {
+		for _, im := range instanceManagers {
+			if im.Status.OwnerID != cc.currentNodeID {
+				continue
+			}
+			for _, processes := range []map[string]longhorn.InstanceProcess{im.Status.InstanceEngines, im.Status.InstanceReplicas} {
+				for processName, process := range processes {
+					cc.collectProcessConditions(ch, im.Name+"/"+processName, process.Status.Conditions)
+				}
+			}
+		}
+	}
+
+	if systemBackups, err := cc.ds.ListSystemBackupsRO(); err != nil {
+		cc.logger.WithError(err).Warn("Error during scrape system backups")
+	} else {
+		for _, sb := range systemBackups {
+			if sb.Status.OwnerID == cc.currentNodeID {
+				cc.collectConditions(ch, types.LonghornKindSystemBackup, sb.Name, sb.Status.Conditions)
+			}
+		}
+	}
+
+	if systemRestores, err := cc.ds.ListSystemRestoresRO(); err != nil {
+		cc.logger.WithError(err).Warn("Error during scrape system restores")
+	} else {
+		for _, sr := range systemRestores {
+			if sr.Status.OwnerID == cc.currentNodeID {
+				cc.collectConditions(ch, types.LonghornKindSystemRestore, sr.Name, sr.Status.Conditions)
+			}
+		}
+	}
+
+	if orphans, err := cc.ds.ListOrphansRO(); err != nil {
+		cc.logger.WithError(err).Warn("Error during scrape orphans")
+	} else {
+		for _, orphan := range orphans {
+			if orphan.Status.OwnerID == cc.currentNodeID {
+				cc.collectConditions(ch, types.LonghornKindOrphan, orphan.Name, orphan.Status.Conditions)
+			}
+		}
+	}
+}
+
+func (cc *ConditionCollector) collectConditions(ch chan<- prometheus.Metric, kind, name string, conditions []longhorn.Condition) {
+	for _, condition := range conditions {
+		for _, status := range conditionStatuses {
+			value := float64(0)
+			if condition.Status == status {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(cc.conditionMetric.Desc, cc.conditionMetric.Type, value,
+				kind, name, condition.Type, string(status), condition.Reason)
+		}
+	}
+}
+
+// collectProcessConditions reports the instance process conditions, which only have a true or false status.
+func (cc *ConditionCollector) collectProcessConditions(ch chan<- prometheus.Metric, name string, conditions map[string]bool) {
+	for conditionType, isTrue := range conditions {
+		conditionStatus := longhorn.ConditionStatusFalse
+		if isTrue {
+			conditionStatus = longhorn.ConditionStatusTrue
+		}
+		cc.collectConditions(ch, conditionKindInstanceProcess, name, []longhorn.Condition{
+			{
+				Type:   conditionType,
+				Status: conditionStatus,
+			},
+		})
+	}
+}
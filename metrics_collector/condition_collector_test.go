@@ -0,0 +1,170 @@
+package metricscollector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/pkg/controller"
+
+	dto "github.com/prometheus/client_model/go"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+)
+
+const (
+	testNamespace = "longhorn-system"
+	testNode1     = "test-node-1"
+	testNode2     = "test-node-2"
+)
+
+func newTestCondition(conditionType string, status longhorn.ConditionStatus, reason string) []longhorn.Condition {
+	return []longhorn.Condition{
+		{
+			Type:   conditionType,
+			Status: status,
+			Reason: reason,
+		},
+	}
+}
+
+func TestConditionCollector(t *testing.T) {
+	assert := require.New(t)
+
+	kubeClient := fake.NewSimpleClientset()
+	lhClient := lhfake.NewSimpleClientset()
+	extensionsClient := apiextensionsfake.NewSimpleClientset()
+	informerFactories := util.NewInformerFactories(testNamespace, kubeClient, lhClient, controller.NoResyncPeriodFunc())
+	lhInformerFactory := informerFactories.LhInformerFactory.Longhorn().V1beta2()
+
+	ds := datastore.NewDataStore(testNamespace, lhClient, kubeClient, extensionsClient, informerFactories)
+
+	objs := []interface{}{
+		&longhorn.Volume{
+			ObjectMeta: metav1.ObjectMeta{Name: "vol-1", Namespace: testNamespace},
+			Status: longhorn.VolumeStatus{
+				OwnerID:    testNode1,
+				Conditions: newTestCondition(longhorn.VolumeConditionTypeScheduled, longhorn.ConditionStatusTrue, ""),
+			},
+		},
+		// Owned by another node, not reported
+		&longhorn.Volume{
+			ObjectMeta: metav1.ObjectMeta{Name: "vol-2", Namespace: testNamespace},
+			Status: longhorn.VolumeStatus{
+				OwnerID:    testNode2,
+				Conditions: newTestCondition(longhorn.VolumeConditionTypeScheduled, longhorn.ConditionStatusFalse, ""),
+			},
+		},
+		&longhorn.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "vol-1", Namespace: testNamespace},
+			Spec:       longhorn.VolumeAttachmentSpec{Volume: "vol-1"},
+			Status: longhorn.VolumeAttachmentStatus{
+				AttachmentTicketStatuses: map[string]*longhorn.AttachmentTicketStatus{
+					"csi-ticket": {
+						ID:         "csi-ticket",
+						Conditions: newTestCondition(longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusFalse, "Waiting"),
+					},
+				},
+			},
+		},
+		// Owned by another node through its volume, not reported
+		&longhorn.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "vol-2", Namespace: testNamespace},
+			Spec:       longhorn.VolumeAttachmentSpec{Volume: "vol-2"},
+			Status: longhorn.VolumeAttachmentStatus{
+				AttachmentTicketStatuses: map[string]*longhorn.AttachmentTicketStatus{
+					"csi-ticket": {
+						ID:         "csi-ticket",
+						Conditions: newTestCondition(longhorn.AttachmentStatusConditionTypeSatisfied, longhorn.ConditionStatusTrue, ""),
+					},
+				},
+			},
+		},
+		&longhorn.InstanceManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "im-1", Namespace: testNamespace},
+			Status: longhorn.InstanceManagerStatus{
+				OwnerID: testNode1,
+				InstanceReplicas: map[string]longhorn.InstanceProcess{
+					"vol-1-r-1": {
+						Status: longhorn.InstanceProcessStatus{
+							Conditions: map[string]bool{"FilesystemReadOnly": true},
+						},
+					},
+				},
+			},
+		},
+		&longhorn.SystemBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: "system-backup-1", Namespace: testNamespace},
+			Status: longhorn.SystemBackupStatus{
+				OwnerID:    testNode1,
+				Conditions: newTestCondition(longhorn.SystemBackupConditionTypeError, longhorn.ConditionStatusTrue, "Failed"),
+			},
+		},
+		&longhorn.SystemRestore{
+			ObjectMeta: metav1.ObjectMeta{Name: "system-restore-1", Namespace: testNamespace},
+			Status: longhorn.SystemRestoreStatus{
+				OwnerID:    testNode1,
+				Conditions: newTestCondition(longhorn.SystemRestoreConditionTypeError, longhorn.ConditionStatusFalse, ""),
+			},
+		},
+	}
+	for _, obj := range objs {
+		var err error
+		switch o := obj.(type) {
+		case *longhorn.Volume:
+			err = lhInformerFactory.Volumes().Informer().GetIndexer().Add(o)
+		case *longhorn.VolumeAttachment:
+			err = lhInformerFactory.VolumeAttachments().Informer().GetIndexer().Add(o)
+		case *longhorn.InstanceManager:
+			err = lhInformerFactory.InstanceManagers().Informer().GetIndexer().Add(o)
+		case *longhorn.SystemBackup:
+			err = lhInformerFactory.SystemBackups().Informer().GetIndexer().Add(o)
+		case *longhorn.SystemRestore:
+			err = lhInformerFactory.SystemRestores().Informer().GetIndexer().Add(o)
+		}
+		assert.NoError(err)
+	}
+
+	cc := NewConditionCollector(logrus.StandardLogger(), testNode1, ds)
+
+	ch := make(chan prometheus.Metric, 100)
+	cc.Collect(ch)
+	close(ch)
+
+	// Maps <kind> <name> <condition> to the status set to 1
+	reported := map[string]string{}
+	for metric := range ch {
+		m := &dto.Metric{}
+		assert.NoError(metric.Write(m))
+
+		labels := map[string]string{}
+		for _, label := range m.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		key := labels[kindLabel] + " " + labels[nameLabel] + " " + labels[conditionLabel]
+		if _, ok := reported[key]; !ok {
+			reported[key] = ""
+		}
+		if m.GetGauge().GetValue() == 1 {
+			reported[key] = labels[conditionStatusLabel]
+		}
+	}
+
+	assert.Equal(map[string]string{
+		types.LonghornKindVolume + " vol-1 " + longhorn.VolumeConditionTypeScheduled:                           string(longhorn.ConditionStatusTrue),
+		conditionKindAttachmentTicket + " vol-1/csi-ticket " + longhorn.AttachmentStatusConditionTypeSatisfied: string(longhorn.ConditionStatusFalse),
+		conditionKindInstanceProcess + " im-1/vol-1-r-1 FilesystemReadOnly":                                    string(longhorn.ConditionStatusTrue),
+		types.LonghornKindSystemBackup + " system-backup-1 " + longhorn.SystemBackupConditionTypeError:         string(longhorn.ConditionStatusTrue),
+		types.LonghornKindSystemRestore + " system-restore-1 " + longhorn.SystemRestoreConditionTypeError:      string(longhorn.ConditionStatusFalse),
+	}, reported)
+}
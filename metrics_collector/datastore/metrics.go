@@ -0,0 +1,59 @@
+package datastore
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	lhdatastore "github.com/longhorn/longhorn-manager/datastore"
+
+	"github.com/longhorn/longhorn-manager/metrics_collector/registry"
+)
+
+// Package datastore sets the datastore status update metrics provider to produce
+// prometheus metrics. To use this package, you just have to import it.
+
+const (
+	LonghornName        = "longhorn"
+	DatastoreSubsystem  = "datastore"
+	StatusUpdatesKey    = "status_updates_total"
+	SavedStatusWriteKey = "saved_status_writes_total"
+)
+
+var (
+	statusUpdates = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: LonghornName,
+		Subsystem: DatastoreSubsystem,
+		Name:      StatusUpdatesKey,
+		Help:      "Total number of status updates issued through the datastore, partitioned by kind and result.",
+	}, []string{"kind", "result"})
+
+	savedStatusWrites = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: LonghornName,
+		Subsystem: DatastoreSubsystem,
+		Name:      SavedStatusWriteKey,
+		Help:      "Total number of status writes to the API server saved by skipping unchanged or coalesced status updates.",
+	}, []string{"kind"})
+
+	metrics = []prometheus.Collector{
+		statusUpdates, savedStatusWrites,
+	}
+)
+
+func init() {
+	for _, m := range metrics {
+		if err := registry.Register(m); err != nil {
+			logrus.WithError(err).WithField("metric", m).Error("Failed to register datastore metrics")
+		}
+	}
+
+	lhdatastore.SetStatusUpdateMetricsProvider(statusUpdateMetricsProvider{})
+}
+
+type statusUpdateMetricsProvider struct{}
+
+func (statusUpdateMetricsProvider) ObserveStatusUpdate(kind, result string) {
+	statusUpdates.WithLabelValues(kind, result).Inc()
+	if result != lhdatastore.StatusUpdateResultWritten {
+		savedStatusWrites.WithLabelValues(kind).Inc()
+	}
+}
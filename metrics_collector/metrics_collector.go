@@ -17,6 +17,7 @@ import (
 	"github.com/longhorn/longhorn-manager/util"
 
 	_ "github.com/longhorn/longhorn-manager/metrics_collector/client_go_adaper" // load the client-go metrics
+	_ "github.com/longhorn/longhorn-manager/metrics_collector/datastore"        // load the datastore metrics
 	_ "github.com/longhorn/longhorn-manager/metrics_collector/workqueue"        // load the workqueue metrics
 )
 
@@ -31,6 +32,7 @@ func InitMetricsCollectorSystem(logger logrus.FieldLogger, currentNodeID string,
 	backupBackingImageCollector := NewBackupBackingImageCollector(logger, currentNodeID, ds)
 	engineCollector := NewEngineCollector(logger, currentNodeID, ds)
 	ReplicaCollector := NewReplicaCollector(logger, currentNodeID, ds)
+	conditionCollector := NewConditionCollector(logger, currentNodeID, ds)
 
 	if err := registry.Register(volumeCollector); err != nil {
 		logger.WithField("collector", subsystemVolume).WithError(err).Warn("Failed to register collector")
@@ -64,6 +66,10 @@ func InitMetricsCollectorSystem(logger logrus.FieldLogger, currentNodeID string,
 		logger.WithField("collector", subsystemReplica).WithError(err).Warn("Failed to register collector")
 	}
 
+	if err := registry.Register(conditionCollector); err != nil {
+		logger.WithField("collector", subsystemCondition).WithError(err).Warn("Failed to register collector")
+	}
+
 	namespace := os.Getenv(types.EnvPodNamespace)
 	if namespace == "" {
 		logger.Warnf("Cannot detect pod namespace, environment variable %v is missing, "+
@@ -20,6 +20,7 @@ const (
 	subsystemSnapshot           = "snapshot"
 	subsystemBackingImage       = "backing_image"
 	subsystemBackupBackingImage = "backup_backing_image"
+	subsystemCondition          = "condition"
 
 	nodeLabel               = "node"
 	diskLabel               = "disk"
@@ -47,6 +48,9 @@ const (
 	frontendLabel           = "frontend"
 	imageLabel              = "image"
 	modeLabel               = "mode"
+	kindLabel               = "kind"
+	nameLabel               = "name"
+	conditionStatusLabel    = "condition_status"
 )
 
 type metricInfo struct {
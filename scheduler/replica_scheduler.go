@@ -8,6 +8,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
@@ -16,6 +17,10 @@ import (
 
 const (
 	FailedReplicaMaxRetryCount = 5
+
+	activeRebuildLoadWeight       = 1.0
+	recentRebuildLoadWeight       = 0.5
+	recentRebuildIOPressureWindow = 10 * time.Minute
 )
 
 type ReplicaScheduler struct {
@@ -530,7 +535,7 @@ func (rcs *ReplicaScheduler) getNodeInfo() (map[string]*longhorn.Node, error) {
 }
 
 func (rcs *ReplicaScheduler) scheduleReplicaToDisk(replica *longhorn.Replica, diskCandidates map[string]*Disk) {
-	disk := rcs.getDiskWithMostUsableStorage(diskCandidates)
+	disk := getDiskWithHighestScore(diskCandidates, rcs.getNodeRebuildLoads())
 	replica.Spec.NodeID = disk.NodeID
 	replica.Spec.DiskID = disk.DiskUUID
 	replica.Spec.DiskPath = disk.Path
@@ -544,26 +549,103 @@ func (rcs *ReplicaScheduler) scheduleReplicaToDisk(replica *longhorn.Replica, di
 	}).Infof("Schedule replica to node %v", replica.Spec.NodeID)
 }
 
-// Investigate
-func (rcs *ReplicaScheduler) getDiskWithMostUsableStorage(disks map[string]*Disk) *Disk {
-	diskWithMostUsableStorage := &Disk{}
-	for _, disk := range disks {
-		diskWithMostUsableStorage = disk
-		break
+// getNodeRebuildLoads returns the rebuild load of each node when rebuild load aware scheduling is enabled. An empty
+// map is returned otherwise, in which case disks are scored by their usable storage only.
+func (rcs *ReplicaScheduler) getNodeRebuildLoads() map[string]float64 {
+	rebuildLoadAware, err := rcs.ds.GetSettingAsBool(types.SettingNameRebuildLoadAwareReplicaScheduling)
+	if err != nil {
+		logrus.WithError(err).Warnf("Failed to get %v setting, ignoring node rebuild load", types.SettingNameRebuildLoadAwareReplicaScheduling)
+		return map[string]float64{}
+	}
+	if !rebuildLoadAware {
+		return map[string]float64{}
+	}
+
+	engines, err := rcs.ds.ListEnginesRO()
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to list engines, ignoring node rebuild load")
+		return map[string]float64{}
+	}
+	replicas, err := rcs.ds.ListReplicasRO()
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to list replicas, ignoring node rebuild load")
+		return map[string]float64{}
+	}
+
+	return getNodeRebuildLoads(engines, replicas, rcs.nowHandler())
+}
+
+// getNodeRebuildLoads scores the reconstruction traffic of each node. Every ongoing rebuild a replica on the node
+// serves as source or destination and every in progress backup a replica on the node serves as source adds
+// activeRebuildLoadWeight. Every replica on the node that finished rebuilding within recentRebuildIOPressureWindow
+// adds recentRebuildLoadWeight, since the node is likely still flushing and compacting the rebuilt data.
+func getNodeRebuildLoads(engines []*longhorn.Engine, replicas []*longhorn.Replica, now time.Time) map[string]float64 {
+	loads := map[string]float64{}
+
+	replicaNodes := map[string]string{}
+	for _, r := range replicas {
+		replicaNodes[r.Name] = r.Spec.NodeID
+		if r.Spec.NodeID == "" || r.Spec.HealthyAt == "" {
+			continue
+		}
+		healthyAt, err := util.ParseTime(r.Spec.HealthyAt)
+		if err != nil {
+			continue
+		}
+		if now.Sub(healthyAt) < recentRebuildIOPressureWindow {
+			loads[r.Spec.NodeID] += recentRebuildLoadWeight
+		}
+	}
+
+	for _, e := range engines {
+		addressNodes := map[string]string{}
+		for replicaName, address := range e.Status.CurrentReplicaAddressMap {
+			addressNodes[address] = replicaNodes[replicaName]
+		}
+		addLoad := func(address string) {
+			if nodeID := addressNodes[address]; nodeID != "" {
+				loads[nodeID] += activeRebuildLoadWeight
+			}
+		}
+
+		for address, rebuildStatus := range e.Status.RebuildStatus {
+			if rebuildStatus == nil || !rebuildStatus.IsRebuilding {
+				continue
+			}
+			addLoad(address)
+			addLoad(rebuildStatus.FromReplicaAddress)
+		}
+		for _, backupStatus := range e.Status.BackupStatus {
+			if backupStatus == nil || backupStatus.State != engineapi.ProcessStateInProgress {
+				continue
+			}
+			addLoad(backupStatus.ReplicaAddress)
+		}
 	}
+	return loads
+}
 
+// getDiskWithHighestScore picks the disk with the highest usable storage discounted by the rebuild load of its node.
+// Without any load, this is the disk with the most usable storage.
+func getDiskWithHighestScore(disks map[string]*Disk, nodeLoads map[string]float64) *Disk {
+	var diskWithHighestScore *Disk
+	highestScore := 0.0
 	for _, disk := range disks {
-		diskWithMostStorageSize := diskWithMostUsableStorage.StorageAvailable - diskWithMostUsableStorage.StorageReserved
-		diskSize := disk.StorageAvailable - disk.StorageReserved
-		if diskWithMostStorageSize > diskSize {
+		score := float64(disk.StorageAvailable-disk.StorageReserved) / (1 + nodeLoads[disk.NodeID])
+		if diskWithHighestScore != nil && highestScore > score {
 			continue
 		}
 
-		diskWithMostUsableStorage = disk
+		diskWithHighestScore = disk
+		highestScore = score
+	}
+	if diskWithHighestScore == nil {
+		return &Disk{}
 	}
 
-	return diskWithMostUsableStorage
+	return diskWithHighestScore
 }
+
 func filterActiveReplicas(replicas map[string]*longhorn.Replica) map[string]*longhorn.Replica {
 	result := map[string]*longhorn.Replica{}
 	for _, r := range replicas {
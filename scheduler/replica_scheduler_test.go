@@ -16,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
@@ -1685,6 +1686,89 @@ func (s *TestSuite) TestIsSchedulableToDiskConsiderDiskPressure(c *C) {
 	}
 }
 
+func (s *TestSuite) TestGetDiskWithHighestScore(c *C) {
+	now := getTestNow()
+	v := newVolume(TestVolumeName, 3)
+	replicaAddresses := map[string]string{}
+	replicas := []*longhorn.Replica{}
+	for i, nodeID := range []string{TestNode1, TestNode2, TestNode3} {
+		r := newReplicaForVolume(v)
+		r.Spec.NodeID = nodeID
+		replicas = append(replicas, r)
+		replicaAddresses[r.Name] = fmt.Sprintf("tcp://%v:%v", TestIP1, 10000+i)
+	}
+	// The replica on node 3 finished rebuilding a minute ago, the one on node 2 long ago
+	replicas[1].Spec.HealthyAt = now.Add(-time.Hour).Format(time.RFC3339)
+	replicas[2].Spec.HealthyAt = now.Add(-time.Minute).Format(time.RFC3339)
+	engine := &longhorn.Engine{
+		Status: longhorn.EngineStatus{
+			CurrentReplicaAddressMap: replicaAddresses,
+			RebuildStatus: map[string]*longhorn.RebuildStatus{
+				replicaAddresses[replicas[0].Name]: {
+					IsRebuilding:       true,
+					FromReplicaAddress: replicaAddresses[replicas[1].Name],
+				},
+			},
+			BackupStatus: map[string]*longhorn.EngineBackupStatus{
+				"backup-1": {
+					State:          engineapi.ProcessStateInProgress,
+					ReplicaAddress: replicaAddresses[replicas[1].Name],
+				},
+			},
+		},
+	}
+
+	loads := getNodeRebuildLoads([]*longhorn.Engine{engine}, replicas, now)
+	c.Assert(loads[TestNode1], Equals, activeRebuildLoadWeight)
+	c.Assert(loads[TestNode2], Equals, 2*activeRebuildLoadWeight)
+	c.Assert(loads[TestNode3], Equals, recentRebuildLoadWeight)
+
+	newDisk := func(nodeID string, usableStorage int64) *Disk {
+		return &Disk{
+			NodeID:     nodeID,
+			DiskStatus: &longhorn.DiskStatus{StorageAvailable: usableStorage},
+		}
+	}
+
+	testCases := map[string]struct {
+		disks          map[string]*Disk
+		loads          map[string]float64
+		expectedNodeID string
+	}{
+		"no load picks the disk with the most usable storage": {
+			disks: map[string]*Disk{
+				"disk-1": newDisk(TestNode1, 300),
+				"disk-2": newDisk(TestNode2, 200),
+				"disk-3": newDisk(TestNode3, 100),
+			},
+			loads:          map[string]float64{},
+			expectedNodeID: TestNode1,
+		},
+		"load outweighs a small storage difference": {
+			disks: map[string]*Disk{
+				"disk-1": newDisk(TestNode1, 300),
+				"disk-2": newDisk(TestNode2, 300),
+				"disk-3": newDisk(TestNode3, 250),
+			},
+			loads:          loads,
+			expectedNodeID: TestNode3,
+		},
+		"a loaded node still wins with enough usable storage": {
+			disks: map[string]*Disk{
+				"disk-1": newDisk(TestNode1, 1000),
+				"disk-2": newDisk(TestNode2, 200),
+				"disk-3": newDisk(TestNode3, 100),
+			},
+			loads:          loads,
+			expectedNodeID: TestNode1,
+		},
+	}
+	for name, tc := range testCases {
+		fmt.Printf("testing %v\n", name)
+		c.Assert(getDiskWithHighestScore(tc.disks, tc.loads).NodeID, Equals, tc.expectedNodeID)
+	}
+}
+
 func getTestNow() time.Time {
 	now, _ := time.Parse(time.RFC3339, TestTimeNow)
 	return now
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 	"sync"
@@ -145,6 +146,16 @@ const (
 	SettingNameBackupExecutionTimeout                                   = SettingName("backup-execution-timeout")
 	SettingNameRWXVolumeFastFailover                                    = SettingName("rwx-volume-fast-failover")
 	SettingNameOfflineReplicaRebuilding                                 = SettingName("offline-replica-rebuilding")
+	SettingNameInstanceManagerPodDrainTimeout                           = SettingName("instance-manager-pod-drain-timeout")
+	SettingNameSnapshotDataIntegrityCheckStaggerWindow                  = SettingName("snapshot-data-integrity-check-stagger-window")
+	SettingNameVolumeRecycleBinRetentionPeriod                          = SettingName("volume-recycle-bin-retention-period")
+	SettingNameRebuildLoadAwareReplicaScheduling                        = SettingName("rebuild-load-aware-replica-scheduling")
+	SettingNameUpgradeFreeze                                            = SettingName("upgrade-freeze")
+	SettingNameBackupTargetZoneAwareSelection                           = SettingName("backup-target-zone-aware-selection")
+	SettingNameEngineUpgradeSnapshotCountLimit                          = SettingName("engine-upgrade-snapshot-count-limit")
+	SettingNameConcurrentVolumeRebuildLimit                             = SettingName("concurrent-volume-rebuild-limit")
+	SettingNameNodeNetworkPartitionGracePeriod                          = SettingName("node-network-partition-grace-period")
+	SettingNameAPITrustedProxies                                        = SettingName("api-trusted-proxies")
 	// These three backup target parameters are used in the "longhorn-default-resource" ConfigMap
 	// to update the default BackupTarget resource.
 	// Longhorn won't create the Setting resources for these three parameters.
@@ -248,6 +259,16 @@ var (
 		SettingNameBackupExecutionTimeout,
 		SettingNameRWXVolumeFastFailover,
 		SettingNameOfflineReplicaRebuilding,
+		SettingNameInstanceManagerPodDrainTimeout,
+		SettingNameSnapshotDataIntegrityCheckStaggerWindow,
+		SettingNameVolumeRecycleBinRetentionPeriod,
+		SettingNameRebuildLoadAwareReplicaScheduling,
+		SettingNameUpgradeFreeze,
+		SettingNameBackupTargetZoneAwareSelection,
+		SettingNameEngineUpgradeSnapshotCountLimit,
+		SettingNameConcurrentVolumeRebuildLimit,
+		SettingNameNodeNetworkPartitionGracePeriod,
+		SettingNameAPITrustedProxies,
 	}
 )
 
@@ -377,6 +398,16 @@ var (
 		SettingNameBackupExecutionTimeout:                                   SettingDefinitionBackupExecutionTimeout,
 		SettingNameRWXVolumeFastFailover:                                    SettingDefinitionRWXVolumeFastFailover,
 		SettingNameOfflineReplicaRebuilding:                                 SettingDefinitionOfflineReplicaRebuilding,
+		SettingNameInstanceManagerPodDrainTimeout:                           SettingDefinitionInstanceManagerPodDrainTimeout,
+		SettingNameSnapshotDataIntegrityCheckStaggerWindow:                  SettingDefinitionSnapshotDataIntegrityCheckStaggerWindow,
+		SettingNameVolumeRecycleBinRetentionPeriod:                          SettingDefinitionVolumeRecycleBinRetentionPeriod,
+		SettingNameRebuildLoadAwareReplicaScheduling:                        SettingDefinitionRebuildLoadAwareReplicaScheduling,
+		SettingNameUpgradeFreeze:                                            SettingDefinitionUpgradeFreeze,
+		SettingNameBackupTargetZoneAwareSelection:                           SettingDefinitionBackupTargetZoneAwareSelection,
+		SettingNameEngineUpgradeSnapshotCountLimit:                          SettingDefinitionEngineUpgradeSnapshotCountLimit,
+		SettingNameConcurrentVolumeRebuildLimit:                             SettingDefinitionConcurrentVolumeRebuildLimit,
+		SettingNameNodeNetworkPartitionGracePeriod:                          SettingDefinitionNodeNetworkPartitionGracePeriod,
+		SettingNameAPITrustedProxies:                                        SettingDefinitionAPITrustedProxies,
 	}
 
 	SettingDefinitionAllowRecurringJobWhileVolumeDetached = SettingDefinition{
@@ -1577,6 +1608,161 @@ var (
 		ReadOnly: false,
 		Default:  "false",
 	}
+
+	SettingDefinitionInstanceManagerPodDrainTimeout = SettingDefinition{
+		DisplayName: "Instance Manager Pod Drain Timeout",
+		Description: "In seconds. When an instance manager pod needs to be recreated (for example, a danger zone setting or the data engine CPU mask is changed) while engines or replicas are still running in it, " +
+			"Longhorn drains the instance manager first: replicas are evicted and rebuilt elsewhere, and no new instance is started in the draining instance manager. " +
+			"Engines leave the instance manager once their volumes are detached. Only one instance manager in the cluster is drained at a time. \n\n" +
+			"When the timeout expires, the pod is deleted only if no engine is running in it and every remaining replica has a healthy replica on another instance manager. Otherwise Longhorn keeps waiting. \n\n" +
+			"Set to 0 to disable draining. Longhorn then keeps the pod until all instances are stopped by other means.",
+		Category: SettingCategoryGeneral,
+		Type:     SettingTypeInt,
+		Required: true,
+		ReadOnly: false,
+		Default:  "0",
+		ValueIntRange: map[string]int{
+			ValueIntRangeMinimum: 0,
+		},
+	}
+
+	SettingDefinitionSnapshotDataIntegrityCheckStaggerWindow = SettingDefinition{
+		DisplayName: "Snapshot Data Integrity Check Stagger Window",
+		Description: "In minutes. When a periodic snapshot data integrity check is triggered by the cron job, Longhorn spreads the start time of each volume's check over this window. " +
+			"The delay of a volume is derived from its name, so it is stable across runs and nodes. This avoids synchronized IO storms when many volumes share the same cron job. \n\n" +
+			"Set to 0 to start the checks of all volumes immediately.",
+		Category: SettingCategorySnapshot,
+		Type:     SettingTypeInt,
+		Required: true,
+		ReadOnly: false,
+		Default:  "0",
+		ValueIntRange: map[string]int{
+			ValueIntRangeMinimum: 0,
+		},
+	}
+
+	SettingDefinitionVolumeRecycleBinRetentionPeriod = SettingDefinition{
+		DisplayName: "Volume Recycle Bin Retention Period",
+		Description: "In hours. When set to a positive value, deleting a volume moves it to the recycle bin instead of deleting its data immediately. " +
+			"An attached volume is detached first, and a volume still being created is deleted immediately. " +
+			"A volume in the recycle bin is in the trashed state: it stays detached even for Longhorn operations like backups, is hidden from the volume list and can be restored or purged. " +
+			"Longhorn purges the volume once it has been in the recycle bin longer than this period. \n\n" +
+			"Set to 0 to disable the recycle bin and delete volumes immediately. Volumes already in the recycle bin are then purged as well.",
+		Category: SettingCategoryGeneral,
+		Type:     SettingTypeInt,
+		Required: true,
+		ReadOnly: false,
+		Default:  "0",
+		ValueIntRange: map[string]int{
+			ValueIntRangeMinimum: 0,
+		},
+	}
+
+	SettingDefinitionRebuildLoadAwareReplicaScheduling = SettingDefinition{
+		DisplayName: "Rebuild Load Aware Replica Scheduling",
+		Description: "When enabled, Longhorn factors the rebuild load of each node into disk scoring when scheduling a new replica. " +
+			"Every ongoing rebuild a replica on the node serves as source or destination, and every in progress backup a replica on the node serves as source, counts as load. " +
+			"Replicas on the node that finished rebuilding in the last 10 minutes count as half the load, as recent IO pressure. " +
+			"The usable storage of each disk is divided by one plus the load of its node, and the disk with the highest score is selected. \n\n" +
+			"When disabled, Longhorn only considers the usable storage of the disks.",
+		Category: SettingCategoryScheduling,
+		Type:     SettingTypeBool,
+		Required: true,
+		ReadOnly: false,
+		Default:  "false",
+	}
+
+	SettingDefinitionUpgradeFreeze = SettingDefinition{
+		DisplayName: "Upgrade Freeze",
+		Description: "When enabled, Longhorn stops all automatic upgrades in the cluster, which is useful during change freezes. While the freeze is on: \n\n" +
+			"- Volumes are not automatically upgraded to the default engine image. \n" +
+			"- Engines are not live upgraded. Live upgrades that are already in progress are finished. \n" +
+			"- Instance managers using a non-default image are not cleaned up and replaced. \n" +
+			"- Instance manager pods are not drained and recreated to apply setting changes. Drains that are already in progress are finished. \n\n" +
+			"Each skipped action is logged and recorded as an event once, and the pending actions are listed in the upgrade freeze report.",
+		Category: SettingCategoryGeneral,
+		Type:     SettingTypeBool,
+		Required: true,
+		ReadOnly: false,
+		Default:  "false",
+	}
+
+	SettingDefinitionBackupTargetZoneAwareSelection = SettingDefinition{
+		DisplayName: "Backup Target Zone Aware Selection",
+		Description: "When enabled, Longhorn uploads a backup of a volume using the default backup target to the available backup target in the same zone as the node the volume is attached to, " +
+			"or in the same region if there is none in the same zone. " +
+			"Volumes set to another backup target always back up to that backup target. " +
+			"The zone and region of a backup target are specified in its spec.",
+		Category: SettingCategoryBackup,
+		Type:     SettingTypeBool,
+		Required: true,
+		ReadOnly: false,
+		Default:  "false",
+	}
+
+	SettingDefinitionEngineUpgradeSnapshotCountLimit = SettingDefinition{
+		DisplayName: "Engine Upgrade Snapshot Count Limit",
+		Description: "The maximum number of snapshots a volume can have to start a live engine upgrade. " +
+			"A volume with a longer snapshot chain is not live upgraded until some snapshots are removed. " +
+			"If the value is 0, the snapshot count is not checked before a live engine upgrade.",
+		Category: SettingCategoryGeneral,
+		Type:     SettingTypeInt,
+		Required: true,
+		ReadOnly: false,
+		Default:  "0",
+		ValueIntRange: map[string]int{
+			ValueIntRangeMinimum: 0,
+		},
+	}
+
+	SettingDefinitionConcurrentVolumeRebuildLimit = SettingDefinition{
+		DisplayName: "Concurrent Volume Rebuild Limit",
+		Description: "This setting controls how many volumes in the cluster can rebuild replicas simultaneously. \n\n" +
+			"Once the limit is reached, the volumes waiting for a rebuild are queued and started by priority: " +
+			"the volumes with the fewest healthy replicas first, then the volumes with the highest redundancy deficit, " +
+			"then the volumes with the highest \"longhorn.io/rebuild-priority\" label value, and then the smallest volumes. \n\n" +
+			"The queue is available in the rebuild queue API. When the value is 0, the number of volumes rebuilding simultaneously is not limited " +
+			"and only the setting \"Concurrent Replica Rebuild Per Node Limit\" applies.",
+		Category: SettingCategoryDangerZone,
+		Type:     SettingTypeInt,
+		Required: true,
+		ReadOnly: false,
+		Default:  "0",
+		ValueIntRange: map[string]int{
+			ValueIntRangeMinimum: 0,
+		},
+	}
+
+	SettingDefinitionNodeNetworkPartitionGracePeriod = SettingDefinition{
+		DisplayName: "Node Network Partition Grace Period",
+		Description: "In seconds. When the kubelet of a node stops posting its status but the instance manager on the node is still reachable from the other nodes, " +
+			"or the engines on the other nodes are still connected to the replicas on the node, the node is considered network partitioned instead of down. \n\n" +
+			"A network partitioned node is reported as not ready with the reason \"NetworkPartitioned\", but its replicas are not failed, " +
+			"its instance managers are not marked unknown and its workload pods are not deleted until the grace period since the kubelet stopped posting its status elapses. " +
+			"After that, the node is handled as a down node. \n\n" +
+			"When the value is 0, the network partition detection is disabled and a node whose kubelet stopped posting its status is always handled as a down node.",
+		Category: SettingCategoryGeneral,
+		Type:     SettingTypeInt,
+		Required: true,
+		ReadOnly: false,
+		Default:  "300",
+		ValueIntRange: map[string]int{
+			ValueIntRangeMinimum: 0,
+		},
+	}
+
+	SettingDefinitionAPITrustedProxies = SettingDefinition{
+		DisplayName: "API Trusted Proxies",
+		Description: "The IP addresses or CIDRs of the authenticating proxies in front of the Longhorn UI and API, separated by semicolons, e.g. \"10.42.0.15;10.43.0.0/16\". \n\n" +
+			"Longhorn records the user in the X-Forwarded-User header as the attacher identity of an attachment requested through the API " +
+			"only if the request comes from one of these addresses. Otherwise the header is ignored, since any API client can set it. \n\n" +
+			"When the value is empty, no proxy is trusted.",
+		Category: SettingCategoryGeneral,
+		Type:     SettingTypeString,
+		Required: false,
+		ReadOnly: false,
+		Default:  "",
+	}
 )
 
 type NodeDownPodDeletionPolicy string
@@ -1829,6 +2015,42 @@ func UnmarshalOrphanResourceTypes(resourceTypesSetting string) (map[OrphanResour
 	return resourceTypes, nil
 }
 
+// UnmarshalTrustedProxies parses the semicolon separated IP addresses and
+// CIDRs of the setting api-trusted-proxies. An IP address is returned as a
+// single address network.
+func UnmarshalTrustedProxies(trustedProxiesSetting string) ([]*net.IPNet, error) {
+	trustedProxies := []*net.IPNet{}
+
+	trustedProxiesSetting = strings.Trim(trustedProxiesSetting, " ")
+	if trustedProxiesSetting == "" {
+		return trustedProxies, nil
+	}
+
+	invalidItems := []string{}
+	for _, item := range strings.Split(trustedProxiesSetting, ";") {
+		item = strings.Trim(item, " ")
+		if _, ipNet, err := net.ParseCIDR(item); err == nil {
+			trustedProxies = append(trustedProxies, ipNet)
+			continue
+		}
+		ip := net.ParseIP(item)
+		if ip == nil {
+			invalidItems = append(invalidItems, item)
+			continue
+		}
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 8 * net.IPv4len
+		}
+		trustedProxies = append(trustedProxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	if len(invalidItems) > 0 {
+		return nil, fmt.Errorf("invalid trusted proxies: %s", strings.Join(invalidItems, ", "))
+	}
+	return trustedProxies, nil
+}
+
 func IsSettingReplaced(name SettingName) bool {
 	return replacedSettingNames[name]
 }
@@ -1943,6 +2165,11 @@ func validateString(sName SettingName, definition SettingDefinition, value strin
 		if _, err := UnmarshalOrphanResourceTypes(value); err != nil {
 			return errors.Wrapf(err, "the value of %v is invalid", sName)
 		}
+
+	case SettingNameAPITrustedProxies:
+		if _, err := UnmarshalTrustedProxies(value); err != nil {
+			return errors.Wrapf(err, "the value of %v is invalid", sName)
+		}
 	}
 
 	return nil
@@ -46,6 +46,8 @@ const (
 	LonghornKindSystemBackup        = "SystemBackup"
 	LonghornKindSystemRestore       = "SystemRestore"
 	LonghornKindOrphan              = "Orphan"
+	LonghornKindCloneSchedule       = "CloneSchedule"
+	LonghornKindRestoreTest         = "RestoreTest"
 
 	LonghornKindBackingImageDataSource = "BackingImageDataSource"
 
@@ -175,11 +177,14 @@ const (
 	LonghornLabelBackingImageDataSource     = "backing-image-data-source"
 	LonghornLabelBackupTarget               = "backup-target"
 	LonghornLabelBackupVolume               = "backup-volume"
+	LonghornLabelBackupReplicationSource    = "backup-replication-source"
 	LonghornLabelRecurringJob               = "job"
 	LonghornLabelRecurringJobGroup          = "job-group"
 	LonghornLabelRecurringJobSource         = "source"
 	LonghornLabelOrphan                     = "orphan"
 	LonghornLabelOrphanType                 = "orphan-type"
+	LonghornLabelCloneSchedule              = "clone-schedule"
+	LonghornLabelRestoreTest                = "restore-test"
 	LonghornLabelRecoveryBackend            = "recovery-backend"
 	LonghornLabelCRDAPIVersion              = "crd-api-version"
 	LonghornLabelVolumeAccessMode           = "volume-access-mode"
@@ -192,6 +197,7 @@ const (
 	LonghornLabelLastSystemRestoreBackup    = "last-system-restored-backup"
 	LonghornLabelDataEngine                 = "data-engine"
 	LonghornLabelVersion                    = "version"
+	LonghornLabelRebuildPriority            = "rebuild-priority"
 	LonghornLabelAdmissionWebhook           = "admission-webhook"
 	LonghornLabelConversionWebhook          = "conversion-webhook"
 
@@ -226,6 +232,27 @@ const (
 
 	PVAnnotationLonghornVolumeSchedulingError = "longhorn.io/volume-scheduling-error"
 
+	// The backup hooks are executed in the pods using a volume right before and after the snapshot of a backup is taken,
+	// by the recurring job or by the backup controller for the backups requested with BackupAnnotationTakeSnapshot.
+	// A command is either a JSON array of arguments or a string run by /bin/sh -c.
+	PodAnnotationPreBackupCommand    = "backup.longhorn.io/pre-command"
+	PodAnnotationPostBackupCommand   = "backup.longhorn.io/post-command"
+	PodAnnotationBackupHookContainer = "backup.longhorn.io/container"
+	PodAnnotationBackupHookTimeout   = "backup.longhorn.io/timeout"
+	PodAnnotationBackupHookOnError   = "backup.longhorn.io/on-error"
+
+	BackupHookOnErrorFail     = "fail"
+	BackupHookOnErrorContinue = "continue"
+
+	DefaultBackupHookTimeout = 30 * time.Second
+	// MaxBackupHookTimeout bounds the time a backup hook command can block the backup controller worker or the
+	// recurring job running it.
+	MaxBackupHookTimeout = 5 * time.Minute
+
+	// BackupAnnotationTakeSnapshot is set on a backup whose snapshot does not exist yet. The backup controller takes
+	// the snapshot between the backup hooks before backing it up.
+	BackupAnnotationTakeSnapshot = "longhorn.io/backup-take-snapshot"
+
 	CniNetworkNone          = ""
 	StorageNetworkInterface = "lhnet1"
 
@@ -289,6 +316,13 @@ const (
 	OptionDiskSelector        = "diskSelector"
 	OptionNodeSelector        = "nodeSelector"
 
+	// OptionRequireExistingFilesystem makes the CSI node server mount the filesystem found on the volume rather than
+	// formatting it, and fail the staging if there is none
+	OptionRequireExistingFilesystem = "requireExistingFilesystem"
+	// NoExistingFilesystemMessage is reported by the CSI node server when a volume requiring an existing filesystem
+	// has none
+	NoExistingFilesystemMessage = "has no existing filesystem"
+
 	// DefaultStaleReplicaTimeout in minutes. 48h by default
 	DefaultStaleReplicaTimeout = "2880"
 
@@ -1302,6 +1336,17 @@ func GetLHVolumeAttachmentNameFromVolumeName(volName string) string {
 	return volName
 }
 
+// GetRestoreTestResourceName returns the name of the temporary volume, PV, PVC and checker pod of a restore test
+func GetRestoreTestResourceName(restoreTestName string) string {
+	return "restore-test-" + restoreTestName
+}
+
+func GetRestoreTestLabels(restoreTestName string) map[string]string {
+	return map[string]string{
+		GetLonghornLabelKey(LonghornLabelRestoreTest): restoreTestName,
+	}
+}
+
 // IsSelectorsInTags checks if all the selectors are present in the tags slice.
 // It returns true if all selectors are found, false otherwise.
 func IsSelectorsInTags(tags, selectors []string, allowEmptySelector bool) bool {
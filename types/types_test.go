@@ -121,6 +121,46 @@ func (s *TestSuite) TestParseToleration(c *C) {
 	}
 }
 
+func (s *TestSuite) TestUnmarshalTrustedProxies(c *C) {
+	type testCase struct {
+		input string
+
+		expectedProxies []string
+		expectError     bool
+	}
+	testCases := map[string]testCase{
+		"valid empty setting": {
+			input:           "",
+			expectedProxies: []string{},
+		},
+		"valid addresses and CIDRs": {
+			input:           "10.42.0.15; 10.43.0.0/16;fd00::1",
+			expectedProxies: []string{"10.42.0.15/32", "10.43.0.0/16", "fd00::1/128"},
+		},
+		"invalid address": {
+			input:       "10.42.0.15;proxy",
+			expectError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		proxies, err := UnmarshalTrustedProxies(testCase.input)
+		if testCase.expectError {
+			c.Assert(err, NotNil)
+			continue
+		}
+		c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+
+		proxyStrings := []string{}
+		for _, proxy := range proxies {
+			proxyStrings = append(proxyStrings, proxy.String())
+		}
+		c.Assert(reflect.DeepEqual(proxyStrings, testCase.expectedProxies), Equals, true, Commentf(TestErrResultFmt, testName))
+	}
+}
+
 func (s *TestSuite) TestIsSelectorsInTags(c *C) {
 	type testCase struct {
 		inputTags          []string
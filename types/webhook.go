@@ -11,4 +11,14 @@ const (
 
 	CaName   = "longhorn-webhook-ca"
 	CertName = "longhorn-webhook-tls"
+
+	// StaticCertName is the user-provided kubernetes.io/tls secret served by the webhook and API servers
+	// instead of the self-signed certificates. It must be annotated with listener.cattle.io/static=true, contain
+	// the CA bundle verifying the certificate in ca.crt, and the certificate must be valid for the webhook and API
+	// service names and the --tls-sans.
+	StaticCertName  = "longhorn-manager-tls"
+	StaticCertCAKey = "ca.crt"
+
+	APIServiceName = "longhorn-backend"
+	APICertName    = "longhorn-api-tls"
 )
@@ -0,0 +1,187 @@
+package backuphook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+// Hook is the pre-backup and post-backup commands requested by the annotations of a pod using a volume.
+type Hook struct {
+	Namespace string
+	PodName   string
+	Container string
+
+	PreCommand  []string
+	PostCommand []string
+
+	Timeout         time.Duration
+	ContinueOnError bool
+}
+
+// PodGetter returns the pod, or nil if the pod no longer exists.
+type PodGetter func(namespace, name string) (*corev1.Pod, error)
+
+// GetHooks returns the backup hooks of the running pods using the volume with the Kubernetes status.
+func GetHooks(kubeStatus longhorn.KubernetesStatus, getPod PodGetter) ([]*Hook, error) {
+	if kubeStatus.Namespace == "" || kubeStatus.LastPodRefAt != "" {
+		return nil, nil
+	}
+
+	hooks := []*Hook{}
+	for _, workload := range kubeStatus.WorkloadsStatus {
+		if workload.PodStatus != string(corev1.PodRunning) {
+			continue
+		}
+		pod, err := getPod(kubeStatus.Namespace, workload.PodName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get pod %v/%v", kubeStatus.Namespace, workload.PodName)
+		}
+		if pod == nil {
+			continue
+		}
+		hook, err := ParseHook(pod)
+		if err != nil {
+			return nil, err
+		}
+		if hook != nil {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks, nil
+}
+
+// ParseHook returns the backup hook requested by the annotations of the pod, or nil if the pod has no
+// pre-backup or post-backup command.
+func ParseHook(pod *corev1.Pod) (*Hook, error) {
+	annotations := pod.Annotations
+	preValue, preExists := annotations[types.PodAnnotationPreBackupCommand]
+	postValue, postExists := annotations[types.PodAnnotationPostBackupCommand]
+	if !preExists && !postExists {
+		return nil, nil
+	}
+
+	hook := &Hook{
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+		Container: annotations[types.PodAnnotationBackupHookContainer],
+		Timeout:   types.DefaultBackupHookTimeout,
+	}
+	if hook.Container == "" && len(pod.Spec.Containers) > 0 {
+		hook.Container = pod.Spec.Containers[0].Name
+	}
+
+	var err error
+	if preExists {
+		if hook.PreCommand, err = parseCommand(preValue); err != nil {
+			return nil, errors.Wrapf(err, "invalid annotation %v of pod %v/%v", types.PodAnnotationPreBackupCommand, pod.Namespace, pod.Name)
+		}
+	}
+	if postExists {
+		if hook.PostCommand, err = parseCommand(postValue); err != nil {
+			return nil, errors.Wrapf(err, "invalid annotation %v of pod %v/%v", types.PodAnnotationPostBackupCommand, pod.Namespace, pod.Name)
+		}
+	}
+
+	if value, exists := annotations[types.PodAnnotationBackupHookTimeout]; exists {
+		if hook.Timeout, err = time.ParseDuration(value); err != nil || hook.Timeout <= 0 {
+			return nil, fmt.Errorf("invalid annotation %v of pod %v/%v: %v is not a positive duration", types.PodAnnotationBackupHookTimeout, pod.Namespace, pod.Name, value)
+		}
+		if hook.Timeout > types.MaxBackupHookTimeout {
+			return nil, fmt.Errorf("invalid annotation %v of pod %v/%v: %v exceeds the maximum of %v", types.PodAnnotationBackupHookTimeout, pod.Namespace, pod.Name,
+				value, types.MaxBackupHookTimeout)
+		}
+	}
+
+	switch onError := annotations[types.PodAnnotationBackupHookOnError]; onError {
+	case "", types.BackupHookOnErrorFail:
+	case types.BackupHookOnErrorContinue:
+		hook.ContinueOnError = true
+	default:
+		return nil, fmt.Errorf("invalid annotation %v of pod %v/%v: %v is not one of %v and %v", types.PodAnnotationBackupHookOnError, pod.Namespace, pod.Name,
+			onError, types.BackupHookOnErrorFail, types.BackupHookOnErrorContinue)
+	}
+
+	return hook, nil
+}
+
+// parseCommand accepts either a JSON array of arguments or a shell command line.
+func parseCommand(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("empty command")
+	}
+	if !strings.HasPrefix(value, "[") {
+		return []string{"/bin/sh", "-c", value}, nil
+	}
+
+	command := []string{}
+	if err := json.Unmarshal([]byte(value), &command); err != nil {
+		return nil, err
+	}
+	if len(command) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+	return command, nil
+}
+
+// RunPreHooks runs the pre-backup commands and returns the hooks whose post-backup commands should run
+// once the snapshot is taken. Every failure is passed to onFailure. The error is returned if a hook failed
+// and does not allow the backup to continue.
+func RunPreHooks(config *rest.Config, kubeClient kubernetes.Interface, log logrus.FieldLogger, hooks []*Hook, onFailure func(error)) ([]*Hook, error) {
+	executed := []*Hook{}
+	for _, hook := range hooks {
+		if len(hook.PreCommand) != 0 {
+			if err := runCommand(config, kubeClient, log, hook, hook.PreCommand); err != nil {
+				err = errors.Wrapf(err, "failed to run the pre-backup command in pod %v/%v", hook.Namespace, hook.PodName)
+				onFailure(err)
+				if !hook.ContinueOnError {
+					return executed, err
+				}
+				continue
+			}
+		}
+		executed = append(executed, hook)
+	}
+	return executed, nil
+}
+
+// RunPostHooks runs the post-backup commands of all the hooks. A failure doesn't affect the snapshot
+// already taken, so it is only passed to onFailure.
+func RunPostHooks(config *rest.Config, kubeClient kubernetes.Interface, log logrus.FieldLogger, hooks []*Hook, onFailure func(error)) {
+	for _, hook := range hooks {
+		if len(hook.PostCommand) == 0 {
+			continue
+		}
+		if err := runCommand(config, kubeClient, log, hook, hook.PostCommand); err != nil {
+			onFailure(errors.Wrapf(err, "failed to run the post-backup command in pod %v/%v", hook.Namespace, hook.PodName))
+		}
+	}
+}
+
+func runCommand(config *rest.Config, kubeClient kubernetes.Interface, log logrus.FieldLogger, hook *Hook, command []string) error {
+	log = log.WithField("pod", hook.Namespace+"/"+hook.PodName).WithField("container", hook.Container)
+	log.Infof("Running backup hook command %v", command)
+
+	stdout, stderr, err := util.ExecInPod(config, kubeClient, hook.Namespace, hook.PodName, hook.Container, command, hook.Timeout)
+	if err != nil {
+		return errors.Wrapf(err, "stderr: %v", strings.TrimSpace(stderr))
+	}
+
+	log.WithField("stdout", strings.TrimSpace(stdout)).Info("Finished backup hook command")
+	return nil
+}
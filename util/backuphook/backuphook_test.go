@@ -0,0 +1,257 @@
+package backuphook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+func newTestPod(annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app"},
+				{Name: "sidecar"},
+			},
+		},
+	}
+}
+
+func TestParseHook(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[string]struct {
+		annotations map[string]string
+		expected    *Hook
+		wantErr     bool
+	}{
+		"noHookAnnotations": {
+			annotations: map[string]string{types.PodAnnotationBackupHookContainer: "sidecar"},
+			expected:    nil,
+		},
+		"preAndPostCommands": {
+			annotations: map[string]string{
+				types.PodAnnotationPreBackupCommand:  "fsfreeze -f /data",
+				types.PodAnnotationPostBackupCommand: `["fsfreeze", "-u", "/data"]`,
+			},
+			expected: &Hook{
+				Namespace:   "default",
+				PodName:     "test-pod",
+				Container:   "app",
+				PreCommand:  []string{"/bin/sh", "-c", "fsfreeze -f /data"},
+				PostCommand: []string{"fsfreeze", "-u", "/data"},
+				Timeout:     types.DefaultBackupHookTimeout,
+			},
+		},
+		"postCommandOnly": {
+			annotations: map[string]string{
+				types.PodAnnotationPostBackupCommand: "sync",
+			},
+			expected: &Hook{
+				Namespace:   "default",
+				PodName:     "test-pod",
+				Container:   "app",
+				PostCommand: []string{"/bin/sh", "-c", "sync"},
+				Timeout:     types.DefaultBackupHookTimeout,
+			},
+		},
+		"customContainerTimeoutAndOnError": {
+			annotations: map[string]string{
+				types.PodAnnotationPreBackupCommand:    "sync",
+				types.PodAnnotationBackupHookContainer: "sidecar",
+				types.PodAnnotationBackupHookTimeout:   "2m",
+				types.PodAnnotationBackupHookOnError:   types.BackupHookOnErrorContinue,
+			},
+			expected: &Hook{
+				Namespace:       "default",
+				PodName:         "test-pod",
+				Container:       "sidecar",
+				PreCommand:      []string{"/bin/sh", "-c", "sync"},
+				Timeout:         2 * time.Minute,
+				ContinueOnError: true,
+			},
+		},
+		"onErrorFail": {
+			annotations: map[string]string{
+				types.PodAnnotationPreBackupCommand:  "sync",
+				types.PodAnnotationBackupHookOnError: types.BackupHookOnErrorFail,
+			},
+			expected: &Hook{
+				Namespace:  "default",
+				PodName:    "test-pod",
+				Container:  "app",
+				PreCommand: []string{"/bin/sh", "-c", "sync"},
+				Timeout:    types.DefaultBackupHookTimeout,
+			},
+		},
+		"emptyPreCommand": {
+			annotations: map[string]string{types.PodAnnotationPreBackupCommand: " "},
+			wantErr:     true,
+		},
+		"invalidPostCommand": {
+			annotations: map[string]string{types.PodAnnotationPostBackupCommand: `["sync"`},
+			wantErr:     true,
+		},
+		"invalidTimeout": {
+			annotations: map[string]string{
+				types.PodAnnotationPreBackupCommand:  "sync",
+				types.PodAnnotationBackupHookTimeout: "30",
+			},
+			wantErr: true,
+		},
+		"nonPositiveTimeout": {
+			annotations: map[string]string{
+				types.PodAnnotationPreBackupCommand:  "sync",
+				types.PodAnnotationBackupHookTimeout: "0s",
+			},
+			wantErr: true,
+		},
+		"timeoutAboveMaximum": {
+			annotations: map[string]string{
+				types.PodAnnotationPreBackupCommand:  "sync",
+				types.PodAnnotationBackupHookTimeout: "24h",
+			},
+			wantErr: true,
+		},
+		"invalidOnError": {
+			annotations: map[string]string{
+				types.PodAnnotationPreBackupCommand:  "sync",
+				types.PodAnnotationBackupHookOnError: "ignore",
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			hook, err := ParseHook(newTestPod(tt.annotations))
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.expected, hook)
+		})
+	}
+}
+
+func TestGetHooks(t *testing.T) {
+	assert := assert.New(t)
+
+	pods := map[string]*corev1.Pod{
+		"default/test-pod": newTestPod(map[string]string{types.PodAnnotationPreBackupCommand: "sync"}),
+		"default/no-hook":  {ObjectMeta: metav1.ObjectMeta{Name: "no-hook", Namespace: "default"}},
+	}
+	getPod := func(namespace, name string) (*corev1.Pod, error) {
+		return pods[namespace+"/"+name], nil
+	}
+	hook := &Hook{
+		Namespace:  "default",
+		PodName:    "test-pod",
+		Container:  "app",
+		PreCommand: []string{"/bin/sh", "-c", "sync"},
+		Timeout:    types.DefaultBackupHookTimeout,
+	}
+
+	tests := map[string]struct {
+		kubeStatus longhorn.KubernetesStatus
+		expected   []*Hook
+	}{
+		"noNamespace": {
+			kubeStatus: longhorn.KubernetesStatus{},
+			expected:   nil,
+		},
+		"noPodReferringToVolume": {
+			kubeStatus: longhorn.KubernetesStatus{
+				Namespace:       "default",
+				LastPodRefAt:    "2024-01-01T00:00:00Z",
+				WorkloadsStatus: []longhorn.WorkloadStatus{{PodName: "test-pod", PodStatus: string(corev1.PodRunning)}},
+			},
+			expected: nil,
+		},
+		"runningPodsOnly": {
+			kubeStatus: longhorn.KubernetesStatus{
+				Namespace: "default",
+				WorkloadsStatus: []longhorn.WorkloadStatus{
+					{PodName: "test-pod", PodStatus: string(corev1.PodRunning)},
+					{PodName: "test-pod", PodStatus: string(corev1.PodPending)},
+					{PodName: "no-hook", PodStatus: string(corev1.PodRunning)},
+					{PodName: "deleted-pod", PodStatus: string(corev1.PodRunning)},
+				},
+			},
+			expected: []*Hook{hook},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			hooks, err := GetHooks(tt.kubeStatus, getPod)
+			assert.NoError(err)
+			assert.Equal(tt.expected, hooks)
+		})
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[string]struct {
+		value    string
+		expected []string
+		wantErr  bool
+	}{
+		"shellCommand": {
+			value:    "pg_ctl stop && sync",
+			expected: []string{"/bin/sh", "-c", "pg_ctl stop && sync"},
+		},
+		"shellCommandWithSpaces": {
+			value:    "  sync \n",
+			expected: []string{"/bin/sh", "-c", "sync"},
+		},
+		"jsonArray": {
+			value:    `["mysql", "-e", "FLUSH TABLES WITH READ LOCK"]`,
+			expected: []string{"mysql", "-e", "FLUSH TABLES WITH READ LOCK"},
+		},
+		"empty": {
+			value:   "",
+			wantErr: true,
+		},
+		"emptyJSONArray": {
+			value:   "[]",
+			wantErr: true,
+		},
+		"invalidJSONArray": {
+			value:   `["sync",`,
+			wantErr: true,
+		},
+		"jsonArrayOfNonStrings": {
+			value:   `[1, 2]`,
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			command, err := parseCommand(tt.value)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.expected, command)
+		})
+	}
+}
@@ -91,6 +91,7 @@ func NewClients(kubeconfigPath string, needDataStore bool, stopCh <-chan struct{
 		//  some refs to look at: https://github.com/kubernetes-sigs/controller-runtime/issues/521
 		informerFactories := util.NewInformerFactories(namespace, clients.K8s, lhClient, 30*time.Second)
 		ds = datastore.NewDataStore(namespace, lhClient, clients.K8s, extensionsClient, informerFactories)
+		ds.SetKubeMetricsClient(metricsClient)
 
 		informerFactories.Start(stopCh)
 		if !ds.Sync(stopCh) {
@@ -0,0 +1,130 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	podExecProtocol = "v4.channel.k8s.io"
+
+	podExecChannelStdout = 1
+	podExecChannelStderr = 2
+	podExecChannelError  = 3
+)
+
+// ExecInPod runs the command in the container of the pod through the exec subresource of the Kubernetes API
+// and returns the stdout and the stderr of the command. An error is returned if the command cannot be started,
+// exits with a non-zero code or does not complete before the timeout.
+func ExecInPod(config *rest.Config, kubeClient kubernetes.Interface, namespace, podName, container string, command []string, timeout time.Duration) (string, string, error) {
+	if len(command) == 0 {
+		return "", "", fmt.Errorf("empty command")
+	}
+
+	execURL := kubeClient.CoreV1().RESTClient().Get().
+		Namespace(namespace).
+		Resource("pods").
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec).
+		URL()
+	switch execURL.Scheme {
+	case "https":
+		execURL.Scheme = "wss"
+	case "http":
+		execURL.Scheme = "ws"
+	}
+
+	tlsConfig, err := rest.TLSConfigFor(config)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to get TLS config")
+	}
+	header := http.Header{}
+	token := config.BearerToken
+	if config.BearerTokenFile != "" {
+		// The token file is rotated by the kubelet, so always use the latest one
+		if data, err := os.ReadFile(config.BearerTokenFile); err == nil {
+			token = strings.TrimSpace(string(data))
+		}
+	}
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	dialer := &websocket.Dialer{
+		TLSClientConfig:  tlsConfig,
+		Subprotocols:     []string{podExecProtocol},
+		HandshakeTimeout: timeout,
+		Proxy:            http.ProxyFromEnvironment,
+	}
+	conn, resp, err := dialer.DialContext(ctx, execURL.String(), header)
+	if err != nil {
+		if resp != nil {
+			return "", "", errors.Wrapf(err, "failed to exec in pod %v/%v with status %v", namespace, podName, resp.Status)
+		}
+		return "", "", errors.Wrapf(err, "failed to exec in pod %v/%v", namespace, podName)
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return "", "", err
+	}
+
+	var stdout, stderr, status bytes.Buffer
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			// The v4 protocol always sends the exit status on the error channel before the connection is closed.
+			// Without it, the command may have been cut off, so the connection must not be closed before it.
+			if status.Len() > 0 {
+				break
+			}
+			return stdout.String(), stderr.String(), errors.Wrapf(err, "failed to read the output of the command in pod %v/%v", namespace, podName)
+		}
+		if len(data) == 0 {
+			continue
+		}
+		switch data[0] {
+		case podExecChannelStdout:
+			stdout.Write(data[1:])
+		case podExecChannelStderr:
+			stderr.Write(data[1:])
+		case podExecChannelError:
+			status.Write(data[1:])
+		}
+	}
+
+	execStatus := &metav1.Status{}
+	if err := json.Unmarshal(status.Bytes(), execStatus); err != nil {
+		return stdout.String(), stderr.String(), errors.Wrapf(err, "failed to parse the exec status %v", status.String())
+	}
+	if execStatus.Status != metav1.StatusSuccess {
+		return stdout.String(), stderr.String(), fmt.Errorf("command failed in pod %v/%v: %v", namespace, podName, execStatus.Message)
+	}
+	return stdout.String(), stderr.String(), nil
+}
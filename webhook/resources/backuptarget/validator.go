@@ -65,6 +65,10 @@ func (b *backupTargetValidator) Create(request *admission.Request, newObj runtim
 		return werror.NewInvalidError(err.Error(), "")
 	}
 
+	if err := b.validateReplicationTarget(backupTarget); err != nil {
+		return werror.NewInvalidError(err.Error(), "")
+	}
+
 	return nil
 }
 
@@ -117,6 +121,27 @@ func (b *backupTargetValidator) Update(request *admission.Request, oldObj runtim
 		}
 	}
 
+	if oldBackupTarget.Spec.ReplicationTargetName != newBackupTarget.Spec.ReplicationTargetName {
+		if err := b.validateReplicationTarget(newBackupTarget); err != nil {
+			return werror.NewInvalidError(err.Error(), "")
+		}
+	}
+
+	return nil
+}
+
+// validateReplicationTarget makes sure the completed backups are replicated to another existing backup target.
+func (b *backupTargetValidator) validateReplicationTarget(backupTarget *longhorn.BackupTarget) error {
+	replicationTargetName := backupTarget.Spec.ReplicationTargetName
+	if replicationTargetName == "" {
+		return nil
+	}
+	if replicationTargetName == backupTarget.Name {
+		return fmt.Errorf("backup target %v cannot replicate backups to itself", backupTarget.Name)
+	}
+	if _, err := b.ds.GetBackupTargetRO(replicationTargetName); err != nil {
+		return errors.Wrapf(err, "failed to get replication target %v of backup target %v", replicationTargetName, backupTarget.Name)
+	}
 	return nil
 }
 
@@ -216,5 +241,27 @@ func (b *backupTargetValidator) Delete(request *admission.Request, oldObj runtim
 		return werror.NewInvalidError(err.Error(), "")
 	}
 
+	if err := b.validateReplicationSource(backupTarget); err != nil {
+		return werror.NewInvalidError(err.Error(), "")
+	}
+
+	return nil
+}
+
+func (b *backupTargetValidator) validateReplicationSource(backupTarget *longhorn.BackupTarget) error {
+	// All backup targets are deleted during uninstalling
+	if _, ok := backupTarget.Annotations[types.GetLonghornLabelKey(types.DeleteBackupTargetFromLonghorn)]; ok {
+		return nil
+	}
+
+	backupTargets, err := b.ds.ListBackupTargetsRO()
+	if err != nil {
+		return errors.Wrap(err, "failed to list backup targets")
+	}
+	for _, bt := range backupTargets {
+		if bt.Spec.ReplicationTargetName == backupTarget.Name {
+			return fmt.Errorf("cannot delete backup target %v since backup target %v replicates backups to it", backupTarget.Name, bt.Name)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,119 @@
+package cloneschedule
+
+import (
+	"fmt"
+
+	"github.com/robfig/cron"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/util"
+	"github.com/longhorn/longhorn-manager/webhook/admission"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	werror "github.com/longhorn/longhorn-manager/webhook/error"
+)
+
+type cloneScheduleValidator struct {
+	admission.DefaultValidator
+	ds *datastore.DataStore
+}
+
+func NewValidator(ds *datastore.DataStore) admission.Validator {
+	return &cloneScheduleValidator{ds: ds}
+}
+
+func (c *cloneScheduleValidator) Resource() admission.Resource {
+	return admission.Resource{
+		Name:       "cloneschedules",
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   longhorn.SchemeGroupVersion.Group,
+		APIVersion: longhorn.SchemeGroupVersion.Version,
+		ObjectType: &longhorn.CloneSchedule{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
+
+func (c *cloneScheduleValidator) Create(request *admission.Request, newObj runtime.Object) error {
+	cloneSchedule, ok := newObj.(*longhorn.CloneSchedule)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.CloneSchedule", newObj), "")
+	}
+
+	if !util.ValidateName(cloneSchedule.Name) {
+		return werror.NewInvalidError(fmt.Sprintf("invalid name %v", cloneSchedule.Name), "")
+	}
+
+	if err := validateSpec(&cloneSchedule.Spec); err != nil {
+		return err
+	}
+
+	if _, err := c.ds.GetVolumeRO(cloneSchedule.Spec.SourceVolume); err != nil {
+		return werror.NewInvalidError(fmt.Sprintf("failed to get source volume %v: %v", cloneSchedule.Spec.SourceVolume, err), "spec.sourceVolume")
+	}
+	if _, err := c.ds.GetVolumeRO(cloneSchedule.Spec.TargetVolume); err != nil {
+		return werror.NewInvalidError(fmt.Sprintf("failed to get target volume %v: %v", cloneSchedule.Spec.TargetVolume, err), "spec.targetVolume")
+	}
+
+	cloneSchedules, err := c.ds.ListCloneSchedulesRO()
+	if err != nil {
+		return werror.NewInternalError(err.Error())
+	}
+	for _, existing := range cloneSchedules {
+		if existing.Spec.TargetVolume == cloneSchedule.Spec.TargetVolume {
+			return werror.NewInvalidError(fmt.Sprintf("target volume %v is already refreshed by clone schedule %v",
+				cloneSchedule.Spec.TargetVolume, existing.Name), "spec.targetVolume")
+		}
+	}
+
+	return nil
+}
+
+func (c *cloneScheduleValidator) Update(request *admission.Request, oldObj runtime.Object, newObj runtime.Object) error {
+	oldCloneSchedule, ok := oldObj.(*longhorn.CloneSchedule)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.CloneSchedule", oldObj), "")
+	}
+	newCloneSchedule, ok := newObj.(*longhorn.CloneSchedule)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.CloneSchedule", newObj), "")
+	}
+
+	if oldCloneSchedule.Spec.SourceVolume != newCloneSchedule.Spec.SourceVolume {
+		return werror.NewInvalidError("spec.sourceVolume field is immutable", "spec.sourceVolume")
+	}
+	if oldCloneSchedule.Spec.TargetVolume != newCloneSchedule.Spec.TargetVolume {
+		return werror.NewInvalidError("spec.targetVolume field is immutable", "spec.targetVolume")
+	}
+
+	return validateSpec(&newCloneSchedule.Spec)
+}
+
+func validateSpec(spec *longhorn.CloneScheduleSpec) error {
+	if spec.SourceVolume == "" {
+		return werror.NewInvalidError("source volume is required", "spec.sourceVolume")
+	}
+	if spec.TargetVolume == "" {
+		return werror.NewInvalidError("target volume is required", "spec.targetVolume")
+	}
+	if spec.SourceVolume == spec.TargetVolume {
+		return werror.NewInvalidError("target volume cannot be the same as source volume", "spec.targetVolume")
+	}
+
+	switch spec.SourceType {
+	case longhorn.CloneScheduleSourceTypeSnapshot, longhorn.CloneScheduleSourceTypeBackup:
+	default:
+		return werror.NewInvalidError(fmt.Sprintf("invalid source type %v", spec.SourceType), "spec.sourceType")
+	}
+
+	if _, err := cron.ParseStandard(spec.Cron); err != nil {
+		return werror.NewInvalidError(fmt.Sprintf("invalid cron %v: %v", spec.Cron, err), "spec.cron")
+	}
+	return nil
+}
@@ -73,6 +73,10 @@ func (n *nodeValidator) Create(request *admission.Request, newObj runtime.Object
 				return werror.NewInvalidError(fmt.Sprintf("disk %v type %v is not supported to specify disk driver", name, disk.Type), "")
 			}
 		}
+
+		if err := validateDiskEncryption(name, disk); err != nil {
+			return werror.NewInvalidError(err.Error(), "")
+		}
 	}
 
 	return nil
@@ -184,6 +188,10 @@ func (n *nodeValidator) Update(request *admission.Request, oldObj runtime.Object
 				return werror.NewInvalidError(fmt.Sprintf("disk %v type %v is not supported to specify disk driver", name, disk.Type), "")
 			}
 		}
+
+		if err := validateDiskEncryption(name, disk); err != nil {
+			return werror.NewInvalidError(err.Error(), "")
+		}
 	}
 
 	// Validate delete disks
@@ -202,12 +210,28 @@ func (n *nodeValidator) Update(request *admission.Request, oldObj runtime.Object
 			if disk.Type != "" && disk.Type != newDisk.Type {
 				return werror.NewInvalidError(fmt.Sprintf("update disk on node %v error: The disk %v(%v) type is not allow to change", newNode.Name, name, disk.Path), "")
 			}
+			if disk.Encrypted != newDisk.Encrypted || disk.EncryptionSecret != newDisk.EncryptionSecret {
+				return werror.NewInvalidError(fmt.Sprintf("update disk on node %v error: The disk %v(%v) encryption is not allow to change", newNode.Name, name, disk.Path), "")
+			}
 		}
 	}
 
 	return nil
 }
 
+func validateDiskEncryption(name string, disk longhorn.DiskSpec) error {
+	if !disk.Encrypted {
+		return nil
+	}
+	if disk.Type != longhorn.DiskTypeBlock {
+		return fmt.Errorf("disk %v type %v is not supported to be encrypted, only %v disks can be encrypted by Longhorn", name, disk.Type, longhorn.DiskTypeBlock)
+	}
+	if disk.EncryptionSecret == "" {
+		return fmt.Errorf("encryption secret is required for encrypted disk %v", name)
+	}
+	return nil
+}
+
 func isNodeDiskSpecAndStatusSynced(node *longhorn.Node) bool {
 	if len(node.Spec.Disks) != len(node.Status.DiskStatus) {
 		return false
@@ -0,0 +1,59 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+func TestValidateDiskEncryption(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[string]struct {
+		disk    longhorn.DiskSpec
+		wantErr bool
+	}{
+		"notEncrypted": {
+			disk: longhorn.DiskSpec{
+				Type: longhorn.DiskTypeFilesystem,
+			},
+			wantErr: false,
+		},
+		"encryptedBlockDisk": {
+			disk: longhorn.DiskSpec{
+				Type:             longhorn.DiskTypeBlock,
+				Encrypted:        true,
+				EncryptionSecret: "disk-encryption",
+			},
+			wantErr: false,
+		},
+		"encryptedFilesystemDisk": {
+			disk: longhorn.DiskSpec{
+				Type:             longhorn.DiskTypeFilesystem,
+				Encrypted:        true,
+				EncryptionSecret: "disk-encryption",
+			},
+			wantErr: true,
+		},
+		"encryptedDiskWithoutSecret": {
+			disk: longhorn.DiskSpec{
+				Type:      longhorn.DiskTypeBlock,
+				Encrypted: true,
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateDiskEncryption("disk", tt.disk)
+			if tt.wantErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
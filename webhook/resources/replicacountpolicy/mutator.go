@@ -0,0 +1,70 @@
+package replicacountpolicy
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/webhook/admission"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	werror "github.com/longhorn/longhorn-manager/webhook/error"
+)
+
+const (
+	defaultMinNumberOfReplicas        = 1
+	defaultStabilizationWindowSeconds = 300
+)
+
+type replicaCountPolicyMutator struct {
+	admission.DefaultMutator
+	ds *datastore.DataStore
+}
+
+func NewMutator(ds *datastore.DataStore) admission.Mutator {
+	return &replicaCountPolicyMutator{ds: ds}
+}
+
+func (r *replicaCountPolicyMutator) Resource() admission.Resource {
+	return admission.Resource{
+		Name:       "replicacountpolicies",
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   longhorn.SchemeGroupVersion.Group,
+		APIVersion: longhorn.SchemeGroupVersion.Version,
+		ObjectType: &longhorn.ReplicaCountPolicy{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
+
+func (r *replicaCountPolicyMutator) Create(request *admission.Request, newObj runtime.Object) (admission.PatchOps, error) {
+	return mutate(newObj)
+}
+
+func (r *replicaCountPolicyMutator) Update(request *admission.Request, oldObj runtime.Object, newObj runtime.Object) (admission.PatchOps, error) {
+	return mutate(newObj)
+}
+
+// mutate contains functionality shared by Create and Update.
+func mutate(newObj runtime.Object) (admission.PatchOps, error) {
+	policy, ok := newObj.(*longhorn.ReplicaCountPolicy)
+	if !ok {
+		return nil, werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.ReplicaCountPolicy", newObj), "")
+	}
+
+	var patchOps admission.PatchOps
+
+	if policy.Spec.MinNumberOfReplicas == 0 {
+		patchOps = append(patchOps, fmt.Sprintf(`{"op": "replace", "path": "/spec/minNumberOfReplicas", "value": %d}`, defaultMinNumberOfReplicas))
+	}
+	if policy.Spec.StabilizationWindowSeconds == 0 {
+		patchOps = append(patchOps, fmt.Sprintf(`{"op": "replace", "path": "/spec/stabilizationWindowSeconds", "value": %d}`, defaultStabilizationWindowSeconds))
+	}
+
+	return patchOps, nil
+}
@@ -0,0 +1,95 @@
+package replicacountpolicy
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+	"github.com/longhorn/longhorn-manager/webhook/admission"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	werror "github.com/longhorn/longhorn-manager/webhook/error"
+)
+
+type replicaCountPolicyValidator struct {
+	admission.DefaultValidator
+	ds *datastore.DataStore
+}
+
+func NewValidator(ds *datastore.DataStore) admission.Validator {
+	return &replicaCountPolicyValidator{ds: ds}
+}
+
+func (r *replicaCountPolicyValidator) Resource() admission.Resource {
+	return admission.Resource{
+		Name:       "replicacountpolicies",
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   longhorn.SchemeGroupVersion.Group,
+		APIVersion: longhorn.SchemeGroupVersion.Version,
+		ObjectType: &longhorn.ReplicaCountPolicy{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
+
+func (r *replicaCountPolicyValidator) Create(request *admission.Request, newObj runtime.Object) error {
+	policy, ok := newObj.(*longhorn.ReplicaCountPolicy)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.ReplicaCountPolicy", newObj), "")
+	}
+
+	if !util.ValidateName(policy.Name) {
+		return werror.NewInvalidError(fmt.Sprintf("invalid name %v", policy.Name), "")
+	}
+
+	return validateSpec(&policy.Spec)
+}
+
+func (r *replicaCountPolicyValidator) Update(request *admission.Request, oldObj runtime.Object, newObj runtime.Object) error {
+	policy, ok := newObj.(*longhorn.ReplicaCountPolicy)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.ReplicaCountPolicy", newObj), "")
+	}
+
+	return validateSpec(&policy.Spec)
+}
+
+func validateSpec(spec *longhorn.ReplicaCountPolicySpec) error {
+	// An empty selector would silently take over the replica count of every volume
+	if len(spec.Selector) == 0 {
+		return werror.NewInvalidError("selector is required", "spec.selector")
+	}
+	for key, value := range spec.Selector {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return werror.NewInvalidError(fmt.Sprintf("invalid selector key %v: %v", key, strings.Join(errs, "; ")), "spec.selector")
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return werror.NewInvalidError(fmt.Sprintf("invalid selector value %v: %v", value, strings.Join(errs, "; ")), "spec.selector")
+		}
+	}
+
+	if err := types.ValidateReplicaCount(spec.NumberOfReplicas); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.numberOfReplicas")
+	}
+	if err := types.ValidateReplicaCount(spec.MinNumberOfReplicas); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.minNumberOfReplicas")
+	}
+	if spec.MinNumberOfReplicas > spec.NumberOfReplicas {
+		return werror.NewInvalidError(fmt.Sprintf("minimum number of replicas %v is greater than the number of replicas %v",
+			spec.MinNumberOfReplicas, spec.NumberOfReplicas), "spec.minNumberOfReplicas")
+	}
+
+	if spec.StabilizationWindowSeconds < 0 {
+		return werror.NewInvalidError(fmt.Sprintf("invalid stabilization window %v", spec.StabilizationWindowSeconds), "spec.stabilizationWindowSeconds")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package restoretest
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/webhook/admission"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	werror "github.com/longhorn/longhorn-manager/webhook/error"
+)
+
+const (
+	defaultFSType         = "ext4"
+	defaultTimeoutSeconds = 3600
+)
+
+type restoreTestMutator struct {
+	admission.DefaultMutator
+	ds *datastore.DataStore
+}
+
+func NewMutator(ds *datastore.DataStore) admission.Mutator {
+	return &restoreTestMutator{ds: ds}
+}
+
+func (r *restoreTestMutator) Resource() admission.Resource {
+	return admission.Resource{
+		Name:       "restoretests",
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   longhorn.SchemeGroupVersion.Group,
+		APIVersion: longhorn.SchemeGroupVersion.Version,
+		ObjectType: &longhorn.RestoreTest{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
+
+func (r *restoreTestMutator) Create(request *admission.Request, newObj runtime.Object) (admission.PatchOps, error) {
+	return mutate(newObj)
+}
+
+func (r *restoreTestMutator) Update(request *admission.Request, oldObj runtime.Object, newObj runtime.Object) (admission.PatchOps, error) {
+	return mutate(newObj)
+}
+
+// mutate contains functionality shared by Create and Update.
+func mutate(newObj runtime.Object) (admission.PatchOps, error) {
+	restoreTest, ok := newObj.(*longhorn.RestoreTest)
+	if !ok {
+		return nil, werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.RestoreTest", newObj), "")
+	}
+
+	var patchOps admission.PatchOps
+
+	if restoreTest.Spec.SelectionPolicy == "" {
+		patchOps = append(patchOps, fmt.Sprintf(`{"op": "replace", "path": "/spec/selectionPolicy", "value": "%s"}`, longhorn.RestoreTestSelectionPolicyRandom))
+	}
+	if restoreTest.Spec.FSType == "" {
+		patchOps = append(patchOps, fmt.Sprintf(`{"op": "replace", "path": "/spec/fsType", "value": "%s"}`, defaultFSType))
+	}
+	if restoreTest.Spec.TimeoutSeconds == 0 {
+		patchOps = append(patchOps, fmt.Sprintf(`{"op": "replace", "path": "/spec/timeoutSeconds", "value": %d}`, defaultTimeoutSeconds))
+	}
+
+	return patchOps, nil
+}
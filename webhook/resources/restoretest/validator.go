@@ -0,0 +1,115 @@
+package restoretest
+
+import (
+	"fmt"
+
+	"github.com/robfig/cron"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+	"github.com/longhorn/longhorn-manager/webhook/admission"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	werror "github.com/longhorn/longhorn-manager/webhook/error"
+)
+
+type restoreTestValidator struct {
+	admission.DefaultValidator
+	ds *datastore.DataStore
+}
+
+func NewValidator(ds *datastore.DataStore) admission.Validator {
+	return &restoreTestValidator{ds: ds}
+}
+
+func (r *restoreTestValidator) Resource() admission.Resource {
+	return admission.Resource{
+		Name:       "restoretests",
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   longhorn.SchemeGroupVersion.Group,
+		APIVersion: longhorn.SchemeGroupVersion.Version,
+		ObjectType: &longhorn.RestoreTest{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
+
+func (r *restoreTestValidator) Create(request *admission.Request, newObj runtime.Object) error {
+	restoreTest, ok := newObj.(*longhorn.RestoreTest)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.RestoreTest", newObj), "")
+	}
+
+	if !util.ValidateName(restoreTest.Name) {
+		return werror.NewInvalidError(fmt.Sprintf("invalid name %v", restoreTest.Name), "")
+	}
+	// The temporary volume, PV, PVC and checker pod are named after the restore test
+	if len(types.GetRestoreTestResourceName(restoreTest.Name)) > validation.DNS1035LabelMaxLength {
+		return werror.NewInvalidError(fmt.Sprintf("name %v is too long", restoreTest.Name), "")
+	}
+
+	if err := validateSpec(&restoreTest.Spec); err != nil {
+		return err
+	}
+
+	if _, err := r.ds.GetBackupTargetRO(restoreTest.Spec.BackupTargetName); err != nil {
+		return werror.NewInvalidError(fmt.Sprintf("failed to get backup target %v: %v", restoreTest.Spec.BackupTargetName, err), "spec.backupTargetName")
+	}
+
+	return nil
+}
+
+func (r *restoreTestValidator) Update(request *admission.Request, oldObj runtime.Object, newObj runtime.Object) error {
+	oldRestoreTest, ok := oldObj.(*longhorn.RestoreTest)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.RestoreTest", oldObj), "")
+	}
+	newRestoreTest, ok := newObj.(*longhorn.RestoreTest)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.RestoreTest", newObj), "")
+	}
+
+	if oldRestoreTest.Spec.BackupTargetName != newRestoreTest.Spec.BackupTargetName {
+		return werror.NewInvalidError("spec.backupTargetName field is immutable", "spec.backupTargetName")
+	}
+
+	return validateSpec(&newRestoreTest.Spec)
+}
+
+func validateSpec(spec *longhorn.RestoreTestSpec) error {
+	if spec.BackupTargetName == "" {
+		return werror.NewInvalidError("backup target is required", "spec.backupTargetName")
+	}
+	if spec.Image == "" {
+		return werror.NewInvalidError("checker image is required", "spec.image")
+	}
+
+	switch spec.SelectionPolicy {
+	case longhorn.RestoreTestSelectionPolicyRandom, longhorn.RestoreTestSelectionPolicyLatest:
+	default:
+		return werror.NewInvalidError(fmt.Sprintf("invalid selection policy %v", spec.SelectionPolicy), "spec.selectionPolicy")
+	}
+
+	switch spec.FSType {
+	case "ext4", "xfs":
+	default:
+		return werror.NewInvalidError(fmt.Sprintf("unsupported filesystem %v", spec.FSType), "spec.fsType")
+	}
+
+	if spec.TimeoutSeconds < 0 {
+		return werror.NewInvalidError(fmt.Sprintf("invalid timeout %v", spec.TimeoutSeconds), "spec.timeoutSeconds")
+	}
+
+	if _, err := cron.ParseStandard(spec.Cron); err != nil {
+		return werror.NewInvalidError(fmt.Sprintf("invalid cron %v: %v", spec.Cron, err), "spec.cron")
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/pkg/errors"
+	"github.com/robfig/cron"
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -167,6 +168,14 @@ func (v *volumeValidator) Create(request *admission.Request, newObj runtime.Obje
 		return werror.NewInvalidError(err.Error(), "spec.snapshotMaxSize")
 	}
 
+	if err := validateSnapshotDataIntegrityCronJob(volume.Spec.SnapshotDataIntegrityCronJob); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.snapshotDataIntegrityCronJob")
+	}
+
+	if volume.Spec.TrashedAt != "" {
+		return werror.NewInvalidError("cannot create a volume in the recycle bin", "spec.trashedAt")
+	}
+
 	if err := v.ds.CheckDataEngineImageCompatiblityByImage(volume.Spec.Image, volume.Spec.DataEngine); err != nil {
 		return werror.NewInvalidError(err.Error(), "volume.spec.image")
 	}
@@ -174,6 +183,13 @@ func (v *volumeValidator) Create(request *admission.Request, newObj runtime.Obje
 	if err := v.validateBackupTarget("", volume.Spec.BackupTargetName); err != nil {
 		return werror.NewInvalidError(err.Error(), "spec.backupTargetName")
 	}
+	// A backup target other than the default one is only used when it is explicitly requested, so it must be
+	// configured. It is not required to be available, since it may be briefly unavailable while being polled.
+	if volume.Spec.BackupTargetName != types.DefaultBackupTargetName {
+		if _, err := v.ds.GetConfiguredBackupTargetRO(volume.Spec.BackupTargetName); err != nil {
+			return werror.NewInvalidError(err.Error(), "spec.backupTargetName")
+		}
+	}
 
 	// TODO: remove this check when we support the following features for SPDK volumes
 	if types.IsDataEngineV2(volume.Spec.DataEngine) {
@@ -341,6 +357,14 @@ func (v *volumeValidator) Update(request *admission.Request, oldObj runtime.Obje
 		return werror.NewInvalidError(err.Error(), "spec.snapshotMaxSize")
 	}
 
+	if err := validateSnapshotDataIntegrityCronJob(newVolume.Spec.SnapshotDataIntegrityCronJob); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.snapshotDataIntegrityCronJob")
+	}
+
+	if err := validateTrashedAt(oldVolume, newVolume); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.trashedAt")
+	}
+
 	if err := v.validateBackupTarget(oldVolume.Spec.BackupTargetName, newVolume.Spec.BackupTargetName); err != nil {
 		return werror.NewInvalidError(err.Error(), "spec.backupTargetName")
 	}
@@ -503,6 +527,26 @@ func validateSnapshotMaxSize(size, snapshotMaxSize int64) error {
 	return nil
 }
 
+func validateSnapshotDataIntegrityCronJob(cronJob string) error {
+	if cronJob == "" {
+		return nil
+	}
+	if _, err := cron.ParseStandard(cronJob); err != nil {
+		return errors.Wrapf(err, "invalid snapshot data integrity cron job format: %v", cronJob)
+	}
+	return nil
+}
+
+func validateTrashedAt(oldVolume, newVolume *longhorn.Volume) error {
+	if newVolume.Spec.TrashedAt == "" || newVolume.Spec.TrashedAt == oldVolume.Spec.TrashedAt {
+		return nil
+	}
+	if _, err := util.ParseTime(newVolume.Spec.TrashedAt); err != nil {
+		return errors.Wrapf(err, "invalid recycle bin time %v", newVolume.Spec.TrashedAt)
+	}
+	return nil
+}
+
 func (v *volumeValidator) validateBackupTarget(oldBackupTarget, newBackupTarget string) error {
 	if newBackupTarget == "" {
 		return fmt.Errorf("backup target name cannot be empty when creating a volume or updating from an existing backup target")
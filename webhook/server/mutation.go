@@ -14,6 +14,7 @@ import (
 	"github.com/longhorn/longhorn-manager/webhook/resources/backupbackingimage"
 	"github.com/longhorn/longhorn-manager/webhook/resources/backuptarget"
 	"github.com/longhorn/longhorn-manager/webhook/resources/backupvolume"
+	"github.com/longhorn/longhorn-manager/webhook/resources/cloneschedule"
 	"github.com/longhorn/longhorn-manager/webhook/resources/engine"
 	"github.com/longhorn/longhorn-manager/webhook/resources/engineimage"
 	"github.com/longhorn/longhorn-manager/webhook/resources/instancemanager"
@@ -21,6 +22,8 @@ import (
 	"github.com/longhorn/longhorn-manager/webhook/resources/orphan"
 	"github.com/longhorn/longhorn-manager/webhook/resources/recurringjob"
 	"github.com/longhorn/longhorn-manager/webhook/resources/replica"
+	"github.com/longhorn/longhorn-manager/webhook/resources/replicacountpolicy"
+	"github.com/longhorn/longhorn-manager/webhook/resources/restoretest"
 	"github.com/longhorn/longhorn-manager/webhook/resources/sharemanager"
 	"github.com/longhorn/longhorn-manager/webhook/resources/snapshot"
 	"github.com/longhorn/longhorn-manager/webhook/resources/supportbundle"
@@ -42,6 +45,9 @@ func Mutation(ds *datastore.DataStore) (http.Handler, []admission.Resource, erro
 		recurringjob.NewMutator(ds),
 		engineimage.NewMutator(ds),
 		orphan.NewMutator(ds),
+		cloneschedule.NewMutator(ds),
+		restoretest.NewMutator(ds),
+		replicacountpolicy.NewMutator(ds),
 		sharemanager.NewMutator(ds),
 		backuptarget.NewMutator(ds),
 		backupvolume.NewMutator(ds),
@@ -8,7 +8,6 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/rancher/dynamiclistener"
-	"github.com/rancher/dynamiclistener/server"
 	"github.com/sirupsen/logrus"
 
 	admissionregv1 "k8s.io/api/admissionregistration/v1"
@@ -38,14 +37,17 @@ type WebhookServer struct {
 	namespace   string
 	webhookType string
 	clients     *client.Clients
+	// extraSANs are the additional DNS names, such as external ones, added to the self-signed certificate
+	extraSANs []string
 }
 
-func New(ctx context.Context, namespace, webhookType string, clients *client.Clients) *WebhookServer {
+func New(ctx context.Context, namespace, webhookType string, clients *client.Clients, extraSANs []string) *WebhookServer {
 	return &WebhookServer{
 		context:     ctx,
 		namespace:   namespace,
 		webhookType: webhookType,
 		clients:     clients,
+		extraSANs:   extraSANs,
 	}
 }
 
@@ -102,7 +104,8 @@ func (s *WebhookServer) ListenAndServe() error {
 func (s *WebhookServer) runAdmissionWebhookListenAndServe(handler http.Handler, validationResources []admission.Resource, mutationResources []admission.Resource) error {
 	apply := s.clients.Apply.WithDynamicLookup()
 	s.clients.Core.Secret().OnChange(s.context, "secrets", func(key string, secret *corev1.Secret) (*corev1.Secret, error) {
-		if secret == nil || secret.Name != types.CaName || secret.Namespace != s.namespace || len(secret.Data[corev1.TLSCertKey]) == 0 {
+		caSecret, caBundle := s.getCABundle(key)
+		if caSecret == nil {
 			return nil, nil
 		}
 
@@ -127,7 +130,7 @@ func (s *WebhookServer) runAdmissionWebhookListenAndServe(handler http.Handler,
 							Path:      &validationPath,
 							Port:      &port,
 						},
-						CABundle: secret.Data[corev1.TLSCertKey],
+						CABundle: caBundle,
 					},
 					Rules:                   validationRules,
 					FailurePolicy:           &failPolicyFail,
@@ -152,7 +155,7 @@ func (s *WebhookServer) runAdmissionWebhookListenAndServe(handler http.Handler,
 							Path:      &mutationPath,
 							Port:      &port,
 						},
-						CABundle: secret.Data[corev1.TLSCertKey],
+						CABundle: caBundle,
 					},
 					Rules:                   mutationRules,
 					FailurePolicy:           &failPolicyFail,
@@ -163,28 +166,20 @@ func (s *WebhookServer) runAdmissionWebhookListenAndServe(handler http.Handler,
 			},
 		}
 
-		return secret, apply.WithOwner(secret).ApplyObjects(validatingWebhookConfiguration, mutatingWebhookConfiguration)
+		return secret, apply.WithOwner(caSecret).ApplyObjects(validatingWebhookConfiguration, mutatingWebhookConfiguration)
 	})
 
 	tlsName := fmt.Sprintf("%s.%s.svc", types.AdmissionWebhookServiceName, s.namespace)
+	sans := append([]string{tlsName}, s.extraSANs...)
 
-	return server.ListenAndServe(s.context, types.DefaultAdmissionWebhookPort, 0, handler, &server.ListenOpts{
-		Secrets:       s.clients.Core.Secret(),
-		CertNamespace: s.namespace,
-		CertName:      types.CertName,
-		CAName:        types.CaName,
-		TLSListenerConfig: dynamiclistener.Config{
-			SANs: []string{
-				tlsName,
-			},
-			FilterCN: dynamiclistener.OnlyAllow(tlsName),
-		},
-	})
+	return ListenAndServeTLS(s.context, types.DefaultAdmissionWebhookPort, handler, s.clients.Core.Secret(), s.namespace, types.CertName,
+		sans, dynamiclistener.OnlyAllow(tlsName))
 }
 
 func (s *WebhookServer) runConversionWebhookListenAndServe(handler http.Handler, conversionResources []string) error {
 	s.clients.Core.Secret().OnChange(s.context, "secrets", func(key string, secret *corev1.Secret) (*corev1.Secret, error) {
-		if secret == nil || secret.Name != types.CaName || secret.Namespace != s.namespace || len(secret.Data[corev1.TLSCertKey]) == 0 {
+		caSecret, caBundle := s.getCABundle(key)
+		if caSecret == nil {
 			return nil, nil
 		}
 
@@ -208,7 +203,7 @@ func (s *WebhookServer) runConversionWebhookListenAndServe(handler http.Handler,
 							Path:      &conversionPath,
 							Port:      &port,
 						},
-						CABundle: secret.Data[corev1.TLSCertKey],
+						CABundle: caBundle,
 					},
 					ConversionReviewVersions: []string{"v1beta2", "v1beta1"},
 				},
@@ -226,19 +221,35 @@ func (s *WebhookServer) runConversionWebhookListenAndServe(handler http.Handler,
 	})
 
 	tlsName := fmt.Sprintf("%s.%s.svc", types.ConversionWebhookServiceName, s.namespace)
+	sans := append([]string{tlsName}, s.extraSANs...)
 
-	return server.ListenAndServe(s.context, types.DefaultConversionWebhookPort, 0, handler, &server.ListenOpts{
-		Secrets:       s.clients.Core.Secret(),
-		CertNamespace: s.namespace,
-		CertName:      types.CertName,
-		CAName:        types.CaName,
-		TLSListenerConfig: dynamiclistener.Config{
-			SANs: []string{
-				tlsName,
-			},
-			FilterCN: dynamiclistener.OnlyAllow(tlsName),
-		},
-	})
+	return ListenAndServeTLS(s.context, types.DefaultConversionWebhookPort, handler, s.clients.Core.Secret(), s.namespace, types.CertName,
+		sans, dynamiclistener.OnlyAllow(tlsName))
+}
+
+// getCABundle returns the CA secret owning the webhook configurations and the CA bundle to verify the webhook
+// server certificate, if the changed secret is either the webhook CA secret or the user-provided static TLS secret.
+// The secret is identified by its key rather than the object, since the object is nil once the secret is removed.
+func (s *WebhookServer) getCABundle(key string) (*corev1.Secret, []byte) {
+	if !isCABundleSecretKey(s.namespace, key) {
+		return nil, nil
+	}
+
+	// The webhook configurations are owned by the CA secret, so that they are not garbage collected once the static TLS secret is removed
+	caSecret, err := s.clients.Core.Secret().Cache().Get(s.namespace, types.CaName)
+	if err != nil || len(caSecret.Data[corev1.TLSCertKey]) == 0 {
+		return nil, nil
+	}
+
+	if caBundle, ok := getStaticCABundle(s.clients.Core.Secret().Cache(), s.namespace, getStaticCertSANs(s.namespace, s.extraSANs)); ok {
+		return caSecret, caBundle
+	}
+	return caSecret, caSecret.Data[corev1.TLSCertKey]
+}
+
+// isCABundleSecretKey checks if the key refers to the webhook CA secret or the user-provided static TLS secret.
+func isCABundleSecretKey(namespace, key string) bool {
+	return key == namespace+"/"+types.CaName || key == namespace+"/"+types.StaticCertName
 }
 
 func (s *WebhookServer) buildRules(resources []admission.Resource) []admissionregv1.RuleWithOperations {
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rancher/dynamiclistener"
+	"github.com/rancher/dynamiclistener/factory"
+	"github.com/rancher/dynamiclistener/server"
+	"github.com/rancher/dynamiclistener/storage/kubernetes"
+	"github.com/rancher/dynamiclistener/storage/memory"
+	"github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	corecontrollers "github.com/rancher/wrangler/v3/pkg/generated/controllers/core/v1"
+
+	"github.com/longhorn/longhorn-manager/types"
+)
+
+// staticCertStorage serves the user-provided certificate stored in the static TLS secret when it is valid.
+// Otherwise, it falls back to the self-signed certificate generated and stored by dynamiclistener.
+// The static secret is watched, so that certificate rotations and removals take effect without a restart.
+type staticCertStorage struct {
+	dynamiclistener.TLSStorage
+
+	namespace string
+	secrets   corecontrollers.SecretCache
+	// sans are the names the static certificate must be valid for
+	sans []string
+
+	lock                sync.Mutex
+	validatedVersion    string
+	validatedStaticCert *corev1.Secret
+}
+
+func newStaticCertStorage(ctx context.Context, secrets corecontrollers.SecretController, namespace, certName string, sans []string) *staticCertStorage {
+	return &staticCertStorage{
+		TLSStorage: kubernetes.Load(ctx, secrets, namespace, certName, memory.New()),
+		namespace:  namespace,
+		secrets:    secrets.Cache(),
+		sans:       sans,
+	}
+}
+
+func (s *staticCertStorage) Get() (*corev1.Secret, error) {
+	if secret := s.getStaticCert(); secret != nil {
+		return secret, nil
+	}
+	return s.TLSStorage.Get()
+}
+
+func (s *staticCertStorage) Update(secret *corev1.Secret) error {
+	// The static secret is managed by the user
+	if factory.IsStatic(secret) {
+		return nil
+	}
+	return s.TLSStorage.Update(secret)
+}
+
+func (s *staticCertStorage) SetFactory(tls dynamiclistener.TLSFactory) {
+	if setter, ok := s.TLSStorage.(dynamiclistener.SetFactory); ok {
+		setter.SetFactory(tls)
+	}
+}
+
+// getStaticCert returns the static TLS secret if it exists, is marked as static and contains a valid key pair
+// signed by its CA bundle for the SANs.
+func (s *staticCertStorage) getStaticCert() *corev1.Secret {
+	secret, err := s.secrets.Get(s.namespace, types.StaticCertName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logrus.WithError(err).Warnf("Failed to get static TLS secret %v", types.StaticCertName)
+		}
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// The key pair is only parsed once per secret version since this is called for every TLS handshake
+	if secret.ResourceVersion == s.validatedVersion {
+		return s.validatedStaticCert
+	}
+	s.validatedVersion = secret.ResourceVersion
+	s.validatedStaticCert = nil
+
+	if err := validateStaticCert(secret, s.sans); err != nil {
+		logrus.WithError(err).Warnf("Ignoring TLS secret %v, falling back to the self-signed certificate", secret.Name)
+		return nil
+	}
+	logrus.Infof("Loaded the user-provided certificate from TLS secret %v", secret.Name)
+	s.validatedStaticCert = secret
+	return secret
+}
+
+// getStaticCertSANs returns the names the user-provided certificate must be valid for. The same certificate is served
+// by the admission webhook, the conversion webhook and the API server, so it must be valid for all of them.
+func getStaticCertSANs(namespace string, extraSANs []string) []string {
+	sans := []string{
+		fmt.Sprintf("%s.%s.svc", types.AdmissionWebhookServiceName, namespace),
+		fmt.Sprintf("%s.%s.svc", types.ConversionWebhookServiceName, namespace),
+		types.APIServiceName,
+		fmt.Sprintf("%s.%s.svc", types.APIServiceName, namespace),
+	}
+	for _, san := range extraSANs {
+		if !slices.Contains(sans, san) {
+			sans = append(sans, san)
+		}
+	}
+	return sans
+}
+
+func validateStaticCert(secret *corev1.Secret, sans []string) error {
+	if !factory.IsStatic(secret) {
+		return fmt.Errorf("missing annotation %v=true", factory.Static)
+	}
+	keyPair, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return errors.Wrap(err, "invalid key pair")
+	}
+
+	// The CA bundle is required since it is injected into the webhook configurations to verify the certificate
+	caBundle := secret.Data[types.StaticCertCAKey]
+	if len(caBundle) == 0 {
+		return fmt.Errorf("missing CA bundle %v", types.StaticCertCAKey)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caBundle) {
+		return fmt.Errorf("invalid CA bundle %v", types.StaticCertCAKey)
+	}
+	intermediates := x509.NewCertPool()
+	for _, der := range keyPair.Certificate[1:] {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return errors.Wrap(err, "invalid intermediate certificate")
+		}
+		intermediates.AddCert(cert)
+	}
+	leaf, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return errors.Wrap(err, "invalid certificate")
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates}); err != nil {
+		return errors.Wrapf(err, "certificate is not verified by CA bundle %v", types.StaticCertCAKey)
+	}
+
+	// A certificate missing the name of a service would break every call to it, e.g. the admission webhook calls
+	// with failurePolicy Fail
+	missingSANs := []string{}
+	for _, san := range sans {
+		if err := leaf.VerifyHostname(san); err != nil {
+			missingSANs = append(missingSANs, san)
+		}
+	}
+	if len(missingSANs) > 0 {
+		return fmt.Errorf("certificate is not valid for %v", strings.Join(missingSANs, ", "))
+	}
+	return nil
+}
+
+// getStaticCABundle returns the CA bundle of the user-provided certificate and whether a valid static TLS secret
+// exists for the SANs.
+func getStaticCABundle(secrets corecontrollers.SecretCache, namespace string, sans []string) ([]byte, bool) {
+	secret, err := secrets.Get(namespace, types.StaticCertName)
+	if err != nil || validateStaticCert(secret, sans) != nil {
+		return nil, false
+	}
+	return secret.Data[types.StaticCertCAKey], true
+}
+
+// ListenAndServeTLS serves the handler on the port using the user-provided certificate in the static TLS secret,
+// or a self-signed certificate for the SANs if the static secret is absent or not valid for the SANs of all the
+// servers sharing it.
+func ListenAndServeTLS(ctx context.Context, port int, handler http.Handler, secrets corecontrollers.SecretController,
+	namespace, certName string, sans []string, filterCN func(...string) []string) error {
+	return server.ListenAndServe(ctx, port, 0, handler, &server.ListenOpts{
+		Storage:       newStaticCertStorage(ctx, secrets, namespace, certName, getStaticCertSANs(namespace, sans)),
+		Secrets:       secrets,
+		CertNamespace: namespace,
+		CertName:      certName,
+		CAName:        types.CaName,
+		TLSListenerConfig: dynamiclistener.Config{
+			SANs:     sans,
+			FilterCN: filterCN,
+		},
+	})
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/rancher/dynamiclistener/factory"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/types"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(t *testing.T, commonName string, parent *testCert, dnsNames ...string) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	signerCert, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		template.DNSNames = append([]string{commonName}, dnsNames...)
+		signerCert, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func TestValidateStaticCert(t *testing.T) {
+	assert := assert.New(t)
+
+	ca := newTestCert(t, "test-ca", nil)
+	otherCA := newTestCert(t, "other-ca", nil)
+	sans := getStaticCertSANs("longhorn-system", []string{"longhorn.example.com"})
+	serverCert := newTestCert(t, sans[0], ca, sans[1:]...)
+	webhookOnlyCert := newTestCert(t, "longhorn-admission-webhook.longhorn-system.svc", ca)
+	missingExtraSANCert := newTestCert(t, sans[0], ca, sans[1:len(sans)-1]...)
+
+	newSecret := func(static bool, certPEM, keyPEM, caPEM []byte) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        types.StaticCertName,
+				Annotations: map[string]string{},
+			},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: keyPEM,
+				types.StaticCertCAKey:   caPEM,
+			},
+		}
+		if static {
+			secret.Annotations[factory.Static] = "true"
+		}
+		return secret
+	}
+
+	tests := map[string]struct {
+		secret  *corev1.Secret
+		wantErr bool
+	}{
+		"validStaticCert": {
+			secret:  newSecret(true, serverCert.certPEM, serverCert.keyPEM, ca.certPEM),
+			wantErr: false,
+		},
+		"missingStaticAnnotation": {
+			secret:  newSecret(false, serverCert.certPEM, serverCert.keyPEM, ca.certPEM),
+			wantErr: true,
+		},
+		"mismatchedKeyPair": {
+			secret:  newSecret(true, serverCert.certPEM, otherCA.keyPEM, ca.certPEM),
+			wantErr: true,
+		},
+		"missingCABundle": {
+			secret:  newSecret(true, serverCert.certPEM, serverCert.keyPEM, nil),
+			wantErr: true,
+		},
+		"invalidCABundle": {
+			secret:  newSecret(true, serverCert.certPEM, serverCert.keyPEM, []byte("invalid")),
+			wantErr: true,
+		},
+		"certNotSignedByCABundle": {
+			secret:  newSecret(true, serverCert.certPEM, serverCert.keyPEM, otherCA.certPEM),
+			wantErr: true,
+		},
+		"certMissingConversionWebhookAndAPISANs": {
+			secret:  newSecret(true, webhookOnlyCert.certPEM, webhookOnlyCert.keyPEM, ca.certPEM),
+			wantErr: true,
+		},
+		"certMissingExtraSAN": {
+			secret:  newSecret(true, missingExtraSANCert.certPEM, missingExtraSANCert.keyPEM, ca.certPEM),
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateStaticCert(tt.secret, sans)
+			if tt.wantErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
+func TestIsCABundleSecretKey(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[string]struct {
+		key  string
+		want bool
+	}{
+		"webhookCASecret": {
+			key:  "longhorn-system/" + types.CaName,
+			want: true,
+		},
+		"staticTLSSecret": {
+			key:  "longhorn-system/" + types.StaticCertName,
+			want: true,
+		},
+		"webhookTLSSecret": {
+			key:  "longhorn-system/" + types.CertName,
+			want: false,
+		},
+		"staticTLSSecretInOtherNamespace": {
+			key:  "default/" + types.StaticCertName,
+			want: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(tt.want, isCABundleSecretKey("longhorn-system", tt.key))
+		})
+	}
+}
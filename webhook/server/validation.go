@@ -13,6 +13,7 @@ import (
 	"github.com/longhorn/longhorn-manager/webhook/resources/backup"
 	"github.com/longhorn/longhorn-manager/webhook/resources/backupbackingimage"
 	"github.com/longhorn/longhorn-manager/webhook/resources/backuptarget"
+	"github.com/longhorn/longhorn-manager/webhook/resources/cloneschedule"
 	"github.com/longhorn/longhorn-manager/webhook/resources/engine"
 	"github.com/longhorn/longhorn-manager/webhook/resources/engineimage"
 	"github.com/longhorn/longhorn-manager/webhook/resources/instancemanager"
@@ -21,6 +22,8 @@ import (
 	"github.com/longhorn/longhorn-manager/webhook/resources/persistentvolumeclaim"
 	"github.com/longhorn/longhorn-manager/webhook/resources/recurringjob"
 	"github.com/longhorn/longhorn-manager/webhook/resources/replica"
+	"github.com/longhorn/longhorn-manager/webhook/resources/replicacountpolicy"
+	"github.com/longhorn/longhorn-manager/webhook/resources/restoretest"
 	"github.com/longhorn/longhorn-manager/webhook/resources/setting"
 	"github.com/longhorn/longhorn-manager/webhook/resources/snapshot"
 	"github.com/longhorn/longhorn-manager/webhook/resources/supportbundle"
@@ -47,6 +50,9 @@ func Validation(ds *datastore.DataStore) (http.Handler, []admission.Resource, er
 		backuptarget.NewValidator(ds),
 		volume.NewValidator(ds, currentNodeID),
 		orphan.NewValidator(ds),
+		cloneschedule.NewValidator(ds),
+		restoretest.NewValidator(ds),
+		replicacountpolicy.NewValidator(ds),
 		snapshot.NewValidator(ds),
 		supportbundle.NewValidator(ds),
 		systembackup.NewValidator(ds),
@@ -20,7 +20,9 @@ var (
 	defaultStartTimeout = 60 * time.Second
 )
 
-func StartWebhook(ctx context.Context, webhookType string, clients *client.Clients) error {
+// StartWebhook starts the webhook server. The extraSANs are the additional DNS names, such as external ones,
+// of the webhook server certificate.
+func StartWebhook(ctx context.Context, webhookType string, clients *client.Clients, extraSANs []string) error {
 	logrus.Infof("Starting longhorn %s webhook server", webhookType)
 
 	var webhookLocalEndpoint string
@@ -33,7 +35,7 @@ func StartWebhook(ctx context.Context, webhookType string, clients *client.Clien
 		return fmt.Errorf("unexpected webhook server type %v", webhookType)
 	}
 
-	s := server.New(ctx, clients.Namespace, webhookType, clients)
+	s := server.New(ctx, clients.Namespace, webhookType, clients, extraSANs)
 	go func() {
 		if err := s.ListenAndServe(); err != nil {
 			logrus.Fatalf("Error %v webhook server failed: %v", webhookType, err)